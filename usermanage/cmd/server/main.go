@@ -3,12 +3,16 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
 	"os"
 	"time"
 	"usermanage/gen/proto/conf"
+	"usermanage/internal/data"
 	"usermanage/internal/pkg/auth"
+	"usermanage/internal/pkg/db"
 	"usermanage/internal/pkg/jwt"
 	"usermanage/internal/pkg/log/zap"
+	"usermanage/internal/server"
 
 	"github.com/go-kratos/kratos/v2"
 	"github.com/go-kratos/kratos/v2/config"
@@ -67,7 +71,7 @@ func loadConfig() {
 	bc.Server.Metadata.Version = Version
 }
 
-func newApp(logger log.Logger, hs *http.Server, gs *grpc.Server) *kratos.App {
+func newApp(logger log.Logger, hs *http.Server, gs *grpc.Server, relay *data.OutboxRelay, dispatcher *data.WebhookDispatcher, purger *server.UserPurger, replicaChecker *db.ReplicaHealthChecker, sweeper *data.SessionSweeper) *kratos.App {
 	return kratos.New(
 		kratos.ID(id),
 		kratos.Name(Name),
@@ -77,6 +81,11 @@ func newApp(logger log.Logger, hs *http.Server, gs *grpc.Server) *kratos.App {
 		kratos.Server(
 			hs,
 			gs,
+			relay,
+			dispatcher,
+			purger,
+			replicaChecker,
+			sweeper,
 		),
 	)
 }
@@ -148,8 +157,31 @@ func main() {
 	}
 
 	defer data.Cleanup()
-	if err := data.Migrate(); err != nil {
-		panic(err)
+
+	// `server migrate ...` manages the database schema, `server rotate-keys ...` re-encrypts
+	// the personal data under the current key, then they exit
+	if args := flag.Args(); len(args) > 0 {
+		var err error
+		switch args[0] {
+		case "migrate":
+			err = runMigrate(ctx, data, args[1:])
+		case "rotate-keys":
+			err = runRotateKeys(ctx, data, args[1:])
+		default:
+			fmt.Fprintf(os.Stderr, "unknown command: %s\n", args[0])
+			os.Exit(2)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if bc.Data.Database.MigrateOnStart {
+		if err := data.Migrate(ctx, bc.Data); err != nil {
+			panic(err)
+		}
 	}
 
 	if err := data.InitializeAdminAccount(ctx); err != nil {
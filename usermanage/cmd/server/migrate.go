@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+	"usermanage/internal/data"
+)
+
+const migrateUsage = `usage: server [-conf path] migrate <command>
+
+commands:
+  up [N]      apply the N next pending migrations, all of them by default
+  down [N]    revert the N latest applied migrations, 1 by default
+  status      print the state of every migration`
+
+// Run the `migrate` subcommand.
+func runMigrate(ctx context.Context, d *data.Data, args []string) error {
+	if len(args) == 0 || len(args) > 2 {
+		return fmt.Errorf("%s", migrateUsage)
+	}
+
+	m, err := d.Migrator(bc.Data)
+	if err != nil {
+		return err
+	}
+
+	steps := 0
+	if len(args) == 2 {
+		if steps, err = strconv.Atoi(args[1]); err != nil || steps <= 0 {
+			return fmt.Errorf("invalid number of migrations: %s", args[1])
+		}
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := m.Up(ctx, steps)
+		for _, migration := range applied {
+			fmt.Printf("applied %d_%s\n", migration.Version, migration.Name)
+		}
+		if err == nil && len(applied) == 0 {
+			fmt.Println("no pending migrations")
+		}
+		return err
+	case "down":
+		if steps == 0 {
+			steps = 1
+		}
+		reverted, err := m.Down(ctx, steps)
+		for _, migration := range reverted {
+			fmt.Printf("reverted %d_%s\n", migration.Version, migration.Name)
+		}
+		return err
+	case "status":
+		if len(args) != 1 {
+			return fmt.Errorf("%s", migrateUsage)
+		}
+		statuses, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+		for _, status := range statuses {
+			state, appliedAt := "pending", ""
+			if status.Applied {
+				state, appliedAt = "applied", status.AppliedAt.Format(time.RFC3339)
+				if status.Modified {
+					state = "modified"
+				}
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", status.Migration.Version, status.Migration.Name, state, appliedAt)
+		}
+		return w.Flush()
+	default:
+		return fmt.Errorf("%s", migrateUsage)
+	}
+}
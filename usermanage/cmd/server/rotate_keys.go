@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"usermanage/internal/data"
+)
+
+const defaultRotateKeysBatchSize = 500
+
+const rotateKeysUsage = `usage: server [-conf path] rotate-keys [batch size]
+
+Re-encrypts the personal data under the current key of data.encryption.key_file,
+500 rows at a time by default. The previous keys can be removed once it completes.`
+
+// Run the `rotate-keys` subcommand.
+func runRotateKeys(ctx context.Context, d *data.Data, args []string) error {
+	if len(args) > 1 {
+		return fmt.Errorf("%s", rotateKeysUsage)
+	}
+
+	batchSize := defaultRotateKeysBatchSize
+	if len(args) == 1 {
+		var err error
+		if batchSize, err = strconv.Atoi(args[0]); err != nil || batchSize <= 0 {
+			return fmt.Errorf("invalid batch size: %s", args[0])
+		}
+	}
+
+	updated, err := d.RotateKeys(ctx, batchSize)
+	fmt.Printf("re-encrypted %d users\n", updated)
+	return err
+}
@@ -34,7 +34,11 @@ func wireData(confData *conf.Data, logger log.Logger) (*data.Data, error) {
 	if err != nil {
 		return nil, err
 	}
-	dataData, err := data.NewData(database, universalClient, logger)
+	envelope, err := db.NewEnvelope(confData)
+	if err != nil {
+		return nil, err
+	}
+	dataData, err := data.NewData(database, universalClient, envelope, logger)
 	if err != nil {
 		return nil, err
 	}
@@ -53,14 +57,58 @@ func wireApp(contextContext context.Context, confServer *conf.Server, confData *
 	}
 	healthUseCase := biz.NewHealthUseCase(database, universalClient)
 	healthService := service.NewHealthService(healthUseCase, logger)
-	userRepo := data.NewUserRepo(database, logger)
-	tokenRepo := data.NewRedisTokenRepo(universalClient, logger)
-	userUseCase := biz.NewUserUseCase(userRepo, tokenRepo)
+	transaction := data.NewTransaction(database)
+	envelope, err := db.NewEnvelope(confData)
+	if err != nil {
+		return nil, err
+	}
+	userRepo := data.NewCachedUserRepo(confData, database, universalClient, envelope, logger)
+	tokenRepo, err := data.NewTokenRepo(confData, database, universalClient, logger)
+	if err != nil {
+		return nil, err
+	}
+	eventRepo := data.NewEventRepo(database, logger)
+	userUseCase := biz.NewUserUseCase(transaction, userRepo, tokenRepo, eventRepo)
 	userService := service.NewUserService(userUseCase, logger)
-	authUseCase := biz.NewAuthUseCase(userRepo, tokenRepo)
+	authOptions, err := service.NewAuthOptions(confServer, confData)
+	if err != nil {
+		return nil, err
+	}
+	authUseCase := biz.NewAuthUseCase(transaction, userRepo, tokenRepo, eventRepo, authOptions)
 	authService := service.NewAuthService(authUseCase, logger)
-	httpServer := server.NewHTTPServer(contextContext, confServer, healthService, userService, authService, authUseCase, logger)
-	grpcServer := server.NewGRPCServer(contextContext, confServer, healthService, userService, authService, authUseCase, logger)
-	app := newApp(logger, httpServer, grpcServer)
+	webhookRepo := data.NewWebhookRepo(database, logger)
+	webhookUseCase := biz.NewWebhookUseCase(webhookRepo)
+	webhookService := service.NewWebhookService(webhookUseCase, logger)
+	scimService, err := service.NewScimService(confServer, userUseCase, logger)
+	if err != nil {
+		return nil, err
+	}
+	oAuthRepo := data.NewOAuthRepo(database, logger)
+	oAuthOptions, err := service.NewOAuthOptions(confServer, logger)
+	if err != nil {
+		return nil, err
+	}
+	oAuthUseCase := biz.NewOAuthUseCase(transaction, oAuthRepo, authUseCase, oAuthOptions)
+	oAuthClientService := service.NewOAuthClientService(oAuthUseCase, logger)
+	oidcService := service.NewOidcService(confServer, oAuthUseCase, authUseCase, logger)
+	federationRepo := data.NewFederationRepo(database, logger)
+	federationOptions, err := service.NewFederationOptions(confServer)
+	if err != nil {
+		return nil, err
+	}
+	federationUseCase := biz.NewFederationUseCase(transaction, federationRepo, userRepo, eventRepo, authUseCase, federationOptions)
+	federationService := service.NewFederationService(confServer, federationUseCase, logger)
+	httpServer := server.NewHTTPServer(contextContext, confServer, confData, healthService, userService, authService, webhookService, scimService, oAuthClientService, oidcService, federationService, authUseCase, universalClient, logger)
+	grpcServer := server.NewGRPCServer(contextContext, confServer, confData, healthService, userService, authService, webhookService, oAuthClientService, authUseCase, universalClient, logger)
+	broker, err := data.NewEventBroker(confData, database, universalClient)
+	if err != nil {
+		return nil, err
+	}
+	outboxRelay := data.NewOutboxRelay(confData, database, broker, logger)
+	webhookDispatcher := data.NewWebhookDispatcher(confData, database, logger)
+	userPurger := server.NewUserPurger(confData, userUseCase, logger)
+	replicaHealthChecker := db.NewReplicaHealthChecker(confData, database, logger)
+	sessionSweeper := data.NewSessionSweeper(confData, database, logger)
+	app := newApp(logger, httpServer, grpcServer, outboxRelay, webhookDispatcher, userPurger, replicaHealthChecker, sessionSweeper)
 	return app, nil
 }
@@ -127,6 +127,41 @@ func (m *UserPublic) validate(all bool) error {
 		}
 	}
 
+	if all {
+		switch v := interface{}(m.GetDeletedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UserPublicValidationError{
+					field:  "DeletedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UserPublicValidationError{
+					field:  "DeletedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetDeletedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UserPublicValidationError{
+				field:  "DeletedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Email
+
+	// no validation rules for Phone
+
+	// no validation rules for Etag
+
 	if len(errors) > 0 {
 		return UserPublicMultiError(errors)
 	}
@@ -273,6 +308,22 @@ func (m *UserListRequest) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
+	if m.GetEmail() != "" {
+
+		if err := m._validateEmail(m.GetEmail()); err != nil {
+			err = UserListRequestValidationError{
+				field:  "Email",
+				reason: "value must be a valid email address",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
 	if len(errors) > 0 {
 		return UserListRequestMultiError(errors)
 	}
@@ -280,6 +331,56 @@ func (m *UserListRequest) validate(all bool) error {
 	return nil
 }
 
+func (m *UserListRequest) _validateHostname(host string) error {
+	s := strings.ToLower(strings.TrimSuffix(host, "."))
+
+	if len(host) > 253 {
+		return errors.New("hostname cannot exceed 253 characters")
+	}
+
+	for _, part := range strings.Split(s, ".") {
+		if l := len(part); l == 0 || l > 63 {
+			return errors.New("hostname part must be non-empty and cannot exceed 63 characters")
+		}
+
+		if part[0] == '-' {
+			return errors.New("hostname parts cannot begin with hyphens")
+		}
+
+		if part[len(part)-1] == '-' {
+			return errors.New("hostname parts cannot end with hyphens")
+		}
+
+		for _, r := range part {
+			if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+				return fmt.Errorf("hostname parts can only contain alphanumeric characters or hyphens, got %q", string(r))
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *UserListRequest) _validateEmail(addr string) error {
+	a, err := mail.ParseAddress(addr)
+	if err != nil {
+		return err
+	}
+	addr = a.Address
+
+	if len(addr) > 254 {
+		return errors.New("email addresses cannot exceed 254 characters")
+	}
+
+	parts := strings.SplitN(addr, "@", 2)
+
+	if len(parts[0]) > 64 {
+		return errors.New("email address local phrase cannot exceed 64 characters")
+	}
+
+	return m._validateHostname(parts[1])
+}
+
 // UserListRequestMultiError is an error wrapping multiple validation errors
 // returned by UserListRequest.ValidateAll() if the designated constraints
 // aren't met.
@@ -436,6 +537,8 @@ func (m *UserListResponse) validate(all bool) error {
 
 	}
 
+	// no validation rules for ResourceVersion
+
 	if len(errors) > 0 {
 		return UserListResponseMultiError(errors)
 	}
@@ -829,6 +932,37 @@ func (m *UserCreateRequest) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
+	if m.GetEmail() != "" {
+
+		if err := m._validateEmail(m.GetEmail()); err != nil {
+			err = UserCreateRequestValidationError{
+				field:  "Email",
+				reason: "value must be a valid email address",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if m.GetPhone() != "" {
+
+		if !_UserCreateRequest_Phone_Pattern.MatchString(m.GetPhone()) {
+			err := UserCreateRequestValidationError{
+				field:  "Phone",
+				reason: "value does not match regex pattern \"^\\\\+[1-9][0-9]{6,14}$\"",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
 	if len(errors) > 0 {
 		return UserCreateRequestMultiError(errors)
 	}
@@ -836,6 +970,56 @@ func (m *UserCreateRequest) validate(all bool) error {
 	return nil
 }
 
+func (m *UserCreateRequest) _validateHostname(host string) error {
+	s := strings.ToLower(strings.TrimSuffix(host, "."))
+
+	if len(host) > 253 {
+		return errors.New("hostname cannot exceed 253 characters")
+	}
+
+	for _, part := range strings.Split(s, ".") {
+		if l := len(part); l == 0 || l > 63 {
+			return errors.New("hostname part must be non-empty and cannot exceed 63 characters")
+		}
+
+		if part[0] == '-' {
+			return errors.New("hostname parts cannot begin with hyphens")
+		}
+
+		if part[len(part)-1] == '-' {
+			return errors.New("hostname parts cannot end with hyphens")
+		}
+
+		for _, r := range part {
+			if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+				return fmt.Errorf("hostname parts can only contain alphanumeric characters or hyphens, got %q", string(r))
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *UserCreateRequest) _validateEmail(addr string) error {
+	a, err := mail.ParseAddress(addr)
+	if err != nil {
+		return err
+	}
+	addr = a.Address
+
+	if len(addr) > 254 {
+		return errors.New("email addresses cannot exceed 254 characters")
+	}
+
+	parts := strings.SplitN(addr, "@", 2)
+
+	if len(parts[0]) > 64 {
+		return errors.New("email address local phrase cannot exceed 64 characters")
+	}
+
+	return m._validateHostname(parts[1])
+}
+
 // UserCreateRequestMultiError is an error wrapping multiple validation errors
 // returned by UserCreateRequest.ValidateAll() if the designated constraints
 // aren't met.
@@ -917,6 +1101,8 @@ var _UserCreateRequest_Status_NotInLookup = map[UserStatus]struct{}{
 	0: {},
 }
 
+var _UserCreateRequest_Phone_Pattern = regexp.MustCompile("^\\+[1-9][0-9]{6,14}$")
+
 // Validate checks the field values on UserUpdateRequest with the rules defined
 // in the proto definition for this message. If any rules are violated, the
 // first error encountered is returned, or nil if there are no violations.
@@ -1034,6 +1220,39 @@ func (m *UserUpdateRequest) validate(all bool) error {
 		}
 	}
 
+	if m.GetEmail() != "" {
+
+		if err := m._validateEmail(m.GetEmail()); err != nil {
+			err = UserUpdateRequestValidationError{
+				field:  "Email",
+				reason: "value must be a valid email address",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if m.GetPhone() != "" {
+
+		if !_UserUpdateRequest_Phone_Pattern.MatchString(m.GetPhone()) {
+			err := UserUpdateRequestValidationError{
+				field:  "Phone",
+				reason: "value does not match regex pattern \"^\\\\+[1-9][0-9]{6,14}$\"",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	// no validation rules for Etag
+
 	if len(errors) > 0 {
 		return UserUpdateRequestMultiError(errors)
 	}
@@ -1041,6 +1260,56 @@ func (m *UserUpdateRequest) validate(all bool) error {
 	return nil
 }
 
+func (m *UserUpdateRequest) _validateHostname(host string) error {
+	s := strings.ToLower(strings.TrimSuffix(host, "."))
+
+	if len(host) > 253 {
+		return errors.New("hostname cannot exceed 253 characters")
+	}
+
+	for _, part := range strings.Split(s, ".") {
+		if l := len(part); l == 0 || l > 63 {
+			return errors.New("hostname part must be non-empty and cannot exceed 63 characters")
+		}
+
+		if part[0] == '-' {
+			return errors.New("hostname parts cannot begin with hyphens")
+		}
+
+		if part[len(part)-1] == '-' {
+			return errors.New("hostname parts cannot end with hyphens")
+		}
+
+		for _, r := range part {
+			if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+				return fmt.Errorf("hostname parts can only contain alphanumeric characters or hyphens, got %q", string(r))
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *UserUpdateRequest) _validateEmail(addr string) error {
+	a, err := mail.ParseAddress(addr)
+	if err != nil {
+		return err
+	}
+	addr = a.Address
+
+	if len(addr) > 254 {
+		return errors.New("email addresses cannot exceed 254 characters")
+	}
+
+	parts := strings.SplitN(addr, "@", 2)
+
+	if len(parts[0]) > 64 {
+		return errors.New("email address local phrase cannot exceed 64 characters")
+	}
+
+	return m._validateHostname(parts[1])
+}
+
 // UserUpdateRequestMultiError is an error wrapping multiple validation errors
 // returned by UserUpdateRequest.ValidateAll() if the designated constraints
 // aren't met.
@@ -1122,6 +1391,8 @@ var _UserUpdateRequest_Status_NotInLookup = map[UserStatus]struct{}{
 	0: {},
 }
 
+var _UserUpdateRequest_Phone_Pattern = regexp.MustCompile("^\\+[1-9][0-9]{6,14}$")
+
 // Validate checks the field values on UserReplaceRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
@@ -1210,6 +1481,39 @@ func (m *UserReplaceRequest) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
+	if m.GetEmail() != "" {
+
+		if err := m._validateEmail(m.GetEmail()); err != nil {
+			err = UserReplaceRequestValidationError{
+				field:  "Email",
+				reason: "value must be a valid email address",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if m.GetPhone() != "" {
+
+		if !_UserReplaceRequest_Phone_Pattern.MatchString(m.GetPhone()) {
+			err := UserReplaceRequestValidationError{
+				field:  "Phone",
+				reason: "value does not match regex pattern \"^\\\\+[1-9][0-9]{6,14}$\"",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	// no validation rules for Etag
+
 	if len(errors) > 0 {
 		return UserReplaceRequestMultiError(errors)
 	}
@@ -1217,6 +1521,56 @@ func (m *UserReplaceRequest) validate(all bool) error {
 	return nil
 }
 
+func (m *UserReplaceRequest) _validateHostname(host string) error {
+	s := strings.ToLower(strings.TrimSuffix(host, "."))
+
+	if len(host) > 253 {
+		return errors.New("hostname cannot exceed 253 characters")
+	}
+
+	for _, part := range strings.Split(s, ".") {
+		if l := len(part); l == 0 || l > 63 {
+			return errors.New("hostname part must be non-empty and cannot exceed 63 characters")
+		}
+
+		if part[0] == '-' {
+			return errors.New("hostname parts cannot begin with hyphens")
+		}
+
+		if part[len(part)-1] == '-' {
+			return errors.New("hostname parts cannot end with hyphens")
+		}
+
+		for _, r := range part {
+			if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+				return fmt.Errorf("hostname parts can only contain alphanumeric characters or hyphens, got %q", string(r))
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *UserReplaceRequest) _validateEmail(addr string) error {
+	a, err := mail.ParseAddress(addr)
+	if err != nil {
+		return err
+	}
+	addr = a.Address
+
+	if len(addr) > 254 {
+		return errors.New("email addresses cannot exceed 254 characters")
+	}
+
+	parts := strings.SplitN(addr, "@", 2)
+
+	if len(parts[0]) > 64 {
+		return errors.New("email address local phrase cannot exceed 64 characters")
+	}
+
+	return m._validateHostname(parts[1])
+}
+
 // UserReplaceRequestMultiError is an error wrapping multiple validation errors
 // returned by UserReplaceRequest.ValidateAll() if the designated constraints
 // aren't met.
@@ -1298,6 +1652,8 @@ var _UserReplaceRequest_Status_NotInLookup = map[UserStatus]struct{}{
 	0: {},
 }
 
+var _UserReplaceRequest_Phone_Pattern = regexp.MustCompile("^\\+[1-9][0-9]{6,14}$")
+
 // Validate checks the field values on UserDeleteRequest with the rules defined
 // in the proto definition for this message. If any rules are violated, the
 // first error encountered is returned, or nil if there are no violations.
@@ -1331,6 +1687,8 @@ func (m *UserDeleteRequest) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
+	// no validation rules for Etag
+
 	if len(errors) > 0 {
 		return UserDeleteRequestMultiError(errors)
 	}
@@ -1534,3 +1892,3158 @@ var _ interface {
 	Cause() error
 	ErrorName() string
 } = UserPasswordResetRequestValidationError{}
+
+// Validate checks the field values on UserFieldChange with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *UserFieldChange) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UserFieldChange with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UserFieldChangeMultiError, or nil if none found.
+func (m *UserFieldChange) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UserFieldChange) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Field
+
+	// no validation rules for OldValue
+
+	// no validation rules for NewValue
+
+	if len(errors) > 0 {
+		return UserFieldChangeMultiError(errors)
+	}
+
+	return nil
+}
+
+// UserFieldChangeMultiError is an error wrapping multiple validation errors
+// returned by UserFieldChange.ValidateAll() if the designated constraints
+// aren't met.
+type UserFieldChangeMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UserFieldChangeMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UserFieldChangeMultiError) AllErrors() []error { return m }
+
+// UserFieldChangeValidationError is the validation error returned by
+// UserFieldChange.Validate if the designated constraints aren't met.
+type UserFieldChangeValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UserFieldChangeValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UserFieldChangeValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UserFieldChangeValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UserFieldChangeValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UserFieldChangeValidationError) ErrorName() string { return "UserFieldChangeValidationError" }
+
+// Error satisfies the builtin error interface
+func (e UserFieldChangeValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUserFieldChange.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UserFieldChangeValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UserFieldChangeValidationError{}
+
+// Validate checks the field values on UserRevision with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *UserRevision) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UserRevision with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in UserRevisionMultiError, or
+// nil if none found.
+func (m *UserRevision) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UserRevision) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for UserId
+
+	// no validation rules for Revision
+
+	// no validation rules for Action
+
+	if all {
+		switch v := interface{}(m.GetSnapshot()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UserRevisionValidationError{
+					field:  "Snapshot",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UserRevisionValidationError{
+					field:  "Snapshot",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetSnapshot()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UserRevisionValidationError{
+				field:  "Snapshot",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	for idx, item := range m.GetChanges() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, UserRevisionValidationError{
+						field:  fmt.Sprintf("Changes[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, UserRevisionValidationError{
+						field:  fmt.Sprintf("Changes[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return UserRevisionValidationError{
+					field:  fmt.Sprintf("Changes[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for Operator
+
+	if all {
+		switch v := interface{}(m.GetCreatedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UserRevisionValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UserRevisionValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCreatedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UserRevisionValidationError{
+				field:  "CreatedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return UserRevisionMultiError(errors)
+	}
+
+	return nil
+}
+
+// UserRevisionMultiError is an error wrapping multiple validation errors
+// returned by UserRevision.ValidateAll() if the designated constraints aren't met.
+type UserRevisionMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UserRevisionMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UserRevisionMultiError) AllErrors() []error { return m }
+
+// UserRevisionValidationError is the validation error returned by
+// UserRevision.Validate if the designated constraints aren't met.
+type UserRevisionValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UserRevisionValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UserRevisionValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UserRevisionValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UserRevisionValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UserRevisionValidationError) ErrorName() string { return "UserRevisionValidationError" }
+
+// Error satisfies the builtin error interface
+func (e UserRevisionValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUserRevision.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UserRevisionValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UserRevisionValidationError{}
+
+// Validate checks the field values on UserRevisionListRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UserRevisionListRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UserRevisionListRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UserRevisionListRequestMultiError, or nil if none found.
+func (m *UserRevisionListRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UserRevisionListRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetId()) < 1 {
+		err := UserRevisionListRequestValidationError{
+			field:  "Id",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetPage() != 0 {
+
+		if m.GetPage() <= 0 {
+			err := UserRevisionListRequestValidationError{
+				field:  "Page",
+				reason: "value must be greater than 0",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if m.GetPageSize() != 0 {
+
+		if m.GetPageSize() <= 0 {
+			err := UserRevisionListRequestValidationError{
+				field:  "PageSize",
+				reason: "value must be greater than 0",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return UserRevisionListRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// UserRevisionListRequestMultiError is an error wrapping multiple validation
+// errors returned by UserRevisionListRequest.ValidateAll() if the designated
+// constraints aren't met.
+type UserRevisionListRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UserRevisionListRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UserRevisionListRequestMultiError) AllErrors() []error { return m }
+
+// UserRevisionListRequestValidationError is the validation error returned by
+// UserRevisionListRequest.Validate if the designated constraints aren't met.
+type UserRevisionListRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UserRevisionListRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UserRevisionListRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UserRevisionListRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UserRevisionListRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UserRevisionListRequestValidationError) ErrorName() string {
+	return "UserRevisionListRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UserRevisionListRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUserRevisionListRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UserRevisionListRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UserRevisionListRequestValidationError{}
+
+// Validate checks the field values on UserRevisionListResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UserRevisionListResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UserRevisionListResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UserRevisionListResponseMultiError, or nil if none found.
+func (m *UserRevisionListResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UserRevisionListResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetPagination()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UserRevisionListResponseValidationError{
+					field:  "Pagination",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UserRevisionListResponseValidationError{
+					field:  "Pagination",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetPagination()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UserRevisionListResponseValidationError{
+				field:  "Pagination",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	for idx, item := range m.GetData() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, UserRevisionListResponseValidationError{
+						field:  fmt.Sprintf("Data[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, UserRevisionListResponseValidationError{
+						field:  fmt.Sprintf("Data[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return UserRevisionListResponseValidationError{
+					field:  fmt.Sprintf("Data[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return UserRevisionListResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// UserRevisionListResponseMultiError is an error wrapping multiple validation
+// errors returned by UserRevisionListResponse.ValidateAll() if the designated
+// constraints aren't met.
+type UserRevisionListResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UserRevisionListResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UserRevisionListResponseMultiError) AllErrors() []error { return m }
+
+// UserRevisionListResponseValidationError is the validation error returned by
+// UserRevisionListResponse.Validate if the designated constraints aren't met.
+type UserRevisionListResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UserRevisionListResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UserRevisionListResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UserRevisionListResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UserRevisionListResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UserRevisionListResponseValidationError) ErrorName() string {
+	return "UserRevisionListResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UserRevisionListResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUserRevisionListResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UserRevisionListResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UserRevisionListResponseValidationError{}
+
+// Validate checks the field values on UserAtTimeRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *UserAtTimeRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UserAtTimeRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UserAtTimeRequestMultiError, or nil if none found.
+func (m *UserAtTimeRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UserAtTimeRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetId()) < 1 {
+		err := UserAtTimeRequestValidationError{
+			field:  "Id",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetTime() == nil {
+		err := UserAtTimeRequestValidationError{
+			field:  "Time",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return UserAtTimeRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// UserAtTimeRequestMultiError is an error wrapping multiple validation errors
+// returned by UserAtTimeRequest.ValidateAll() if the designated constraints
+// aren't met.
+type UserAtTimeRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UserAtTimeRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UserAtTimeRequestMultiError) AllErrors() []error { return m }
+
+// UserAtTimeRequestValidationError is the validation error returned by
+// UserAtTimeRequest.Validate if the designated constraints aren't met.
+type UserAtTimeRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UserAtTimeRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UserAtTimeRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UserAtTimeRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UserAtTimeRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UserAtTimeRequestValidationError) ErrorName() string {
+	return "UserAtTimeRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UserAtTimeRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUserAtTimeRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UserAtTimeRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UserAtTimeRequestValidationError{}
+
+// Validate checks the field values on UserRevisionRestoreRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UserRevisionRestoreRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UserRevisionRestoreRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UserRevisionRestoreRequestMultiError, or nil if none found.
+func (m *UserRevisionRestoreRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UserRevisionRestoreRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetId()) < 1 {
+		err := UserRevisionRestoreRequestValidationError{
+			field:  "Id",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetRevision() <= 0 {
+		err := UserRevisionRestoreRequestValidationError{
+			field:  "Revision",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return UserRevisionRestoreRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// UserRevisionRestoreRequestMultiError is an error wrapping multiple
+// validation errors returned by UserRevisionRestoreRequest.ValidateAll() if
+// the designated constraints aren't met.
+type UserRevisionRestoreRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UserRevisionRestoreRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UserRevisionRestoreRequestMultiError) AllErrors() []error { return m }
+
+// UserRevisionRestoreRequestValidationError is the validation error returned
+// by UserRevisionRestoreRequest.Validate if the designated constraints aren't met.
+type UserRevisionRestoreRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UserRevisionRestoreRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UserRevisionRestoreRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UserRevisionRestoreRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UserRevisionRestoreRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UserRevisionRestoreRequestValidationError) ErrorName() string {
+	return "UserRevisionRestoreRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UserRevisionRestoreRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUserRevisionRestoreRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UserRevisionRestoreRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UserRevisionRestoreRequestValidationError{}
+
+// Validate checks the field values on UserWatchRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *UserWatchRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UserWatchRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UserWatchRequestMultiError, or nil if none found.
+func (m *UserWatchRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UserWatchRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for ResourceVersion
+
+	if len(errors) > 0 {
+		return UserWatchRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// UserWatchRequestMultiError is an error wrapping multiple validation errors
+// returned by UserWatchRequest.ValidateAll() if the designated constraints
+// aren't met.
+type UserWatchRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UserWatchRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UserWatchRequestMultiError) AllErrors() []error { return m }
+
+// UserWatchRequestValidationError is the validation error returned by
+// UserWatchRequest.Validate if the designated constraints aren't met.
+type UserWatchRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UserWatchRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UserWatchRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UserWatchRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UserWatchRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UserWatchRequestValidationError) ErrorName() string { return "UserWatchRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e UserWatchRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUserWatchRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UserWatchRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UserWatchRequestValidationError{}
+
+// Validate checks the field values on UserWatchEvent with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *UserWatchEvent) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UserWatchEvent with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in UserWatchEventMultiError,
+// or nil if none found.
+func (m *UserWatchEvent) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UserWatchEvent) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Type
+
+	// no validation rules for ResourceVersion
+
+	if all {
+		switch v := interface{}(m.GetUser()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UserWatchEventValidationError{
+					field:  "User",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UserWatchEventValidationError{
+					field:  "User",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetUser()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UserWatchEventValidationError{
+				field:  "User",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	for idx, item := range m.GetChanges() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, UserWatchEventValidationError{
+						field:  fmt.Sprintf("Changes[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, UserWatchEventValidationError{
+						field:  fmt.Sprintf("Changes[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return UserWatchEventValidationError{
+					field:  fmt.Sprintf("Changes[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for Actor
+
+	if all {
+		switch v := interface{}(m.GetOccurredAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UserWatchEventValidationError{
+					field:  "OccurredAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UserWatchEventValidationError{
+					field:  "OccurredAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetOccurredAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UserWatchEventValidationError{
+				field:  "OccurredAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return UserWatchEventMultiError(errors)
+	}
+
+	return nil
+}
+
+// UserWatchEventMultiError is an error wrapping multiple validation errors
+// returned by UserWatchEvent.ValidateAll() if the designated constraints
+// aren't met.
+type UserWatchEventMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UserWatchEventMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UserWatchEventMultiError) AllErrors() []error { return m }
+
+// UserWatchEventValidationError is the validation error returned by
+// UserWatchEvent.Validate if the designated constraints aren't met.
+type UserWatchEventValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UserWatchEventValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UserWatchEventValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UserWatchEventValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UserWatchEventValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UserWatchEventValidationError) ErrorName() string { return "UserWatchEventValidationError" }
+
+// Error satisfies the builtin error interface
+func (e UserWatchEventValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUserWatchEvent.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UserWatchEventValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UserWatchEventValidationError{}
+
+// Validate checks the field values on DeletedUserListRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *DeletedUserListRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DeletedUserListRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DeletedUserListRequestMultiError, or nil if none found.
+func (m *DeletedUserListRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DeletedUserListRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetPage() != 0 {
+
+		if m.GetPage() <= 0 {
+			err := DeletedUserListRequestValidationError{
+				field:  "Page",
+				reason: "value must be greater than 0",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if m.GetPageSize() != 0 {
+
+		if m.GetPageSize() <= 0 {
+			err := DeletedUserListRequestValidationError{
+				field:  "PageSize",
+				reason: "value must be greater than 0",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	// no validation rules for Username
+
+	if len(errors) > 0 {
+		return DeletedUserListRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// DeletedUserListRequestMultiError is an error wrapping multiple validation
+// errors returned by DeletedUserListRequest.ValidateAll() if the designated
+// constraints aren't met.
+type DeletedUserListRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DeletedUserListRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DeletedUserListRequestMultiError) AllErrors() []error { return m }
+
+// DeletedUserListRequestValidationError is the validation error returned by
+// DeletedUserListRequest.Validate if the designated constraints aren't met.
+type DeletedUserListRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DeletedUserListRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DeletedUserListRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DeletedUserListRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DeletedUserListRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DeletedUserListRequestValidationError) ErrorName() string {
+	return "DeletedUserListRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DeletedUserListRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDeletedUserListRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DeletedUserListRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DeletedUserListRequestValidationError{}
+
+// Validate checks the field values on UserUndeleteRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UserUndeleteRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UserUndeleteRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UserUndeleteRequestMultiError, or nil if none found.
+func (m *UserUndeleteRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UserUndeleteRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetId()) < 1 {
+		err := UserUndeleteRequestValidationError{
+			field:  "Id",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return UserUndeleteRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// UserUndeleteRequestMultiError is an error wrapping multiple validation
+// errors returned by UserUndeleteRequest.ValidateAll() if the designated
+// constraints aren't met.
+type UserUndeleteRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UserUndeleteRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UserUndeleteRequestMultiError) AllErrors() []error { return m }
+
+// UserUndeleteRequestValidationError is the validation error returned by
+// UserUndeleteRequest.Validate if the designated constraints aren't met.
+type UserUndeleteRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UserUndeleteRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UserUndeleteRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UserUndeleteRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UserUndeleteRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UserUndeleteRequestValidationError) ErrorName() string {
+	return "UserUndeleteRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UserUndeleteRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUserUndeleteRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UserUndeleteRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UserUndeleteRequestValidationError{}
+
+// Validate checks the field values on UserPurgeRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *UserPurgeRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UserPurgeRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UserPurgeRequestMultiError, or nil if none found.
+func (m *UserPurgeRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UserPurgeRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetId()) < 1 {
+		err := UserPurgeRequestValidationError{
+			field:  "Id",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return UserPurgeRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// UserPurgeRequestMultiError is an error wrapping multiple validation errors
+// returned by UserPurgeRequest.ValidateAll() if the designated constraints
+// aren't met.
+type UserPurgeRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UserPurgeRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UserPurgeRequestMultiError) AllErrors() []error { return m }
+
+// UserPurgeRequestValidationError is the validation error returned by
+// UserPurgeRequest.Validate if the designated constraints aren't met.
+type UserPurgeRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UserPurgeRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UserPurgeRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UserPurgeRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UserPurgeRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UserPurgeRequestValidationError) ErrorName() string { return "UserPurgeRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e UserPurgeRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUserPurgeRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UserPurgeRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UserPurgeRequestValidationError{}
+
+// Validate checks the field values on UserImportOptions with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *UserImportOptions) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UserImportOptions with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UserImportOptionsMultiError, or nil if none found.
+func (m *UserImportOptions) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UserImportOptions) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if _, ok := _UserImportOptions_Format_NotInLookup[m.GetFormat()]; ok {
+		err := UserImportOptionsValidationError{
+			field:  "Format",
+			reason: "value must not be in list [USER_IMPORT_FORMAT_UNSPECIFIED]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if _, ok := UserImportFormat_name[int32(m.GetFormat())]; !ok {
+		err := UserImportOptionsValidationError{
+			field:  "Format",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if _, ok := UserImportMode_name[int32(m.GetMode())]; !ok {
+		err := UserImportOptionsValidationError{
+			field:  "Mode",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for DryRun
+
+	if len(errors) > 0 {
+		return UserImportOptionsMultiError(errors)
+	}
+
+	return nil
+}
+
+// UserImportOptionsMultiError is an error wrapping multiple validation errors
+// returned by UserImportOptions.ValidateAll() if the designated constraints
+// aren't met.
+type UserImportOptionsMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UserImportOptionsMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UserImportOptionsMultiError) AllErrors() []error { return m }
+
+// UserImportOptionsValidationError is the validation error returned by
+// UserImportOptions.Validate if the designated constraints aren't met.
+type UserImportOptionsValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UserImportOptionsValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UserImportOptionsValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UserImportOptionsValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UserImportOptionsValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UserImportOptionsValidationError) ErrorName() string {
+	return "UserImportOptionsValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UserImportOptionsValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUserImportOptions.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UserImportOptionsValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UserImportOptionsValidationError{}
+
+var _UserImportOptions_Format_NotInLookup = map[UserImportFormat]struct{}{
+	0: {},
+}
+
+// Validate checks the field values on UserImportRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *UserImportRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UserImportRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UserImportRequestMultiError, or nil if none found.
+func (m *UserImportRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UserImportRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetOptions()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UserImportRequestValidationError{
+					field:  "Options",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UserImportRequestValidationError{
+					field:  "Options",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetOptions()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UserImportRequestValidationError{
+				field:  "Options",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Chunk
+
+	if len(errors) > 0 {
+		return UserImportRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// UserImportRequestMultiError is an error wrapping multiple validation errors
+// returned by UserImportRequest.ValidateAll() if the designated constraints
+// aren't met.
+type UserImportRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UserImportRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UserImportRequestMultiError) AllErrors() []error { return m }
+
+// UserImportRequestValidationError is the validation error returned by
+// UserImportRequest.Validate if the designated constraints aren't met.
+type UserImportRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UserImportRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UserImportRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UserImportRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UserImportRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UserImportRequestValidationError) ErrorName() string {
+	return "UserImportRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UserImportRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUserImportRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UserImportRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UserImportRequestValidationError{}
+
+// Validate checks the field values on UserImportRowResult with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UserImportRowResult) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UserImportRowResult with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UserImportRowResultMultiError, or nil if none found.
+func (m *UserImportRowResult) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UserImportRowResult) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Row
+
+	// no validation rules for Username
+
+	// no validation rules for Status
+
+	// no validation rules for UserId
+
+	// no validation rules for Error
+
+	if len(errors) > 0 {
+		return UserImportRowResultMultiError(errors)
+	}
+
+	return nil
+}
+
+// UserImportRowResultMultiError is an error wrapping multiple validation
+// errors returned by UserImportRowResult.ValidateAll() if the designated
+// constraints aren't met.
+type UserImportRowResultMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UserImportRowResultMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UserImportRowResultMultiError) AllErrors() []error { return m }
+
+// UserImportRowResultValidationError is the validation error returned by
+// UserImportRowResult.Validate if the designated constraints aren't met.
+type UserImportRowResultValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UserImportRowResultValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UserImportRowResultValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UserImportRowResultValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UserImportRowResultValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UserImportRowResultValidationError) ErrorName() string {
+	return "UserImportRowResultValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UserImportRowResultValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUserImportRowResult.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UserImportRowResultValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UserImportRowResultValidationError{}
+
+// Validate checks the field values on UserImportResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UserImportResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UserImportResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UserImportResponseMultiError, or nil if none found.
+func (m *UserImportResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UserImportResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Total
+
+	// no validation rules for Created
+
+	// no validation rules for Updated
+
+	// no validation rules for Unchanged
+
+	// no validation rules for Failed
+
+	// no validation rules for DryRun
+
+	for idx, item := range m.GetRows() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, UserImportResponseValidationError{
+						field:  fmt.Sprintf("Rows[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, UserImportResponseValidationError{
+						field:  fmt.Sprintf("Rows[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return UserImportResponseValidationError{
+					field:  fmt.Sprintf("Rows[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return UserImportResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// UserImportResponseMultiError is an error wrapping multiple validation errors
+// returned by UserImportResponse.ValidateAll() if the designated constraints
+// aren't met.
+type UserImportResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UserImportResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UserImportResponseMultiError) AllErrors() []error { return m }
+
+// UserImportResponseValidationError is the validation error returned by
+// UserImportResponse.Validate if the designated constraints aren't met.
+type UserImportResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UserImportResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UserImportResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UserImportResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UserImportResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UserImportResponseValidationError) ErrorName() string {
+	return "UserImportResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UserImportResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUserImportResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UserImportResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UserImportResponseValidationError{}
+
+// Validate checks the field values on UserExportRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *UserExportRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UserExportRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UserExportRequestMultiError, or nil if none found.
+func (m *UserExportRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UserExportRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if _, ok := _UserExportRequest_Format_NotInLookup[m.GetFormat()]; ok {
+		err := UserExportRequestValidationError{
+			field:  "Format",
+			reason: "value must not be in list [USER_EXPORT_FORMAT_UNSPECIFIED]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if _, ok := UserExportFormat_name[int32(m.GetFormat())]; !ok {
+		err := UserExportRequestValidationError{
+			field:  "Format",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Username
+
+	if _, ok := UserStatus_name[int32(m.GetStatus())]; !ok {
+		err := UserExportRequestValidationError{
+			field:  "Status",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetEmail() != "" {
+
+		if err := m._validateEmail(m.GetEmail()); err != nil {
+			err = UserExportRequestValidationError{
+				field:  "Email",
+				reason: "value must be a valid email address",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return UserExportRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *UserExportRequest) _validateHostname(host string) error {
+	s := strings.ToLower(strings.TrimSuffix(host, "."))
+
+	if len(host) > 253 {
+		return errors.New("hostname cannot exceed 253 characters")
+	}
+
+	for _, part := range strings.Split(s, ".") {
+		if l := len(part); l == 0 || l > 63 {
+			return errors.New("hostname part must be non-empty and cannot exceed 63 characters")
+		}
+
+		if part[0] == '-' {
+			return errors.New("hostname parts cannot begin with hyphens")
+		}
+
+		if part[len(part)-1] == '-' {
+			return errors.New("hostname parts cannot end with hyphens")
+		}
+
+		for _, r := range part {
+			if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+				return fmt.Errorf("hostname parts can only contain alphanumeric characters or hyphens, got %q", string(r))
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *UserExportRequest) _validateEmail(addr string) error {
+	a, err := mail.ParseAddress(addr)
+	if err != nil {
+		return err
+	}
+	addr = a.Address
+
+	if len(addr) > 254 {
+		return errors.New("email addresses cannot exceed 254 characters")
+	}
+
+	parts := strings.SplitN(addr, "@", 2)
+
+	if len(parts[0]) > 64 {
+		return errors.New("email address local phrase cannot exceed 64 characters")
+	}
+
+	return m._validateHostname(parts[1])
+}
+
+// UserExportRequestMultiError is an error wrapping multiple validation errors
+// returned by UserExportRequest.ValidateAll() if the designated constraints
+// aren't met.
+type UserExportRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UserExportRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UserExportRequestMultiError) AllErrors() []error { return m }
+
+// UserExportRequestValidationError is the validation error returned by
+// UserExportRequest.Validate if the designated constraints aren't met.
+type UserExportRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UserExportRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UserExportRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UserExportRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UserExportRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UserExportRequestValidationError) ErrorName() string {
+	return "UserExportRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UserExportRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUserExportRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UserExportRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UserExportRequestValidationError{}
+
+var _UserExportRequest_Format_NotInLookup = map[UserExportFormat]struct{}{
+	0: {},
+}
+
+// Validate checks the field values on UserExportChunk with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *UserExportChunk) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UserExportChunk with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UserExportChunkMultiError, or nil if none found.
+func (m *UserExportChunk) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UserExportChunk) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Data
+
+	if len(errors) > 0 {
+		return UserExportChunkMultiError(errors)
+	}
+
+	return nil
+}
+
+// UserExportChunkMultiError is an error wrapping multiple validation errors
+// returned by UserExportChunk.ValidateAll() if the designated constraints
+// aren't met.
+type UserExportChunkMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UserExportChunkMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UserExportChunkMultiError) AllErrors() []error { return m }
+
+// UserExportChunkValidationError is the validation error returned by
+// UserExportChunk.Validate if the designated constraints aren't met.
+type UserExportChunkValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UserExportChunkValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UserExportChunkValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UserExportChunkValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UserExportChunkValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UserExportChunkValidationError) ErrorName() string { return "UserExportChunkValidationError" }
+
+// Error satisfies the builtin error interface
+func (e UserExportChunkValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUserExportChunk.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UserExportChunkValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UserExportChunkValidationError{}
+
+// Validate checks the field values on UserBatchFilter with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *UserBatchFilter) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UserBatchFilter with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UserBatchFilterMultiError, or nil if none found.
+func (m *UserBatchFilter) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UserBatchFilter) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Username
+
+	if _, ok := UserStatus_name[int32(m.GetStatus())]; !ok {
+		err := UserBatchFilterValidationError{
+			field:  "Status",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetEmail() != "" {
+
+		if err := m._validateEmail(m.GetEmail()); err != nil {
+			err = UserBatchFilterValidationError{
+				field:  "Email",
+				reason: "value must be a valid email address",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return UserBatchFilterMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *UserBatchFilter) _validateHostname(host string) error {
+	s := strings.ToLower(strings.TrimSuffix(host, "."))
+
+	if len(host) > 253 {
+		return errors.New("hostname cannot exceed 253 characters")
+	}
+
+	for _, part := range strings.Split(s, ".") {
+		if l := len(part); l == 0 || l > 63 {
+			return errors.New("hostname part must be non-empty and cannot exceed 63 characters")
+		}
+
+		if part[0] == '-' {
+			return errors.New("hostname parts cannot begin with hyphens")
+		}
+
+		if part[len(part)-1] == '-' {
+			return errors.New("hostname parts cannot end with hyphens")
+		}
+
+		for _, r := range part {
+			if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+				return fmt.Errorf("hostname parts can only contain alphanumeric characters or hyphens, got %q", string(r))
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *UserBatchFilter) _validateEmail(addr string) error {
+	a, err := mail.ParseAddress(addr)
+	if err != nil {
+		return err
+	}
+	addr = a.Address
+
+	if len(addr) > 254 {
+		return errors.New("email addresses cannot exceed 254 characters")
+	}
+
+	parts := strings.SplitN(addr, "@", 2)
+
+	if len(parts[0]) > 64 {
+		return errors.New("email address local phrase cannot exceed 64 characters")
+	}
+
+	return m._validateHostname(parts[1])
+}
+
+// UserBatchFilterMultiError is an error wrapping multiple validation errors
+// returned by UserBatchFilter.ValidateAll() if the designated constraints
+// aren't met.
+type UserBatchFilterMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UserBatchFilterMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UserBatchFilterMultiError) AllErrors() []error { return m }
+
+// UserBatchFilterValidationError is the validation error returned by
+// UserBatchFilter.Validate if the designated constraints aren't met.
+type UserBatchFilterValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UserBatchFilterValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UserBatchFilterValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UserBatchFilterValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UserBatchFilterValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UserBatchFilterValidationError) ErrorName() string { return "UserBatchFilterValidationError" }
+
+// Error satisfies the builtin error interface
+func (e UserBatchFilterValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUserBatchFilter.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UserBatchFilterValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UserBatchFilterValidationError{}
+
+// Validate checks the field values on UserBatchUpdateRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UserBatchUpdateRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UserBatchUpdateRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UserBatchUpdateRequestMultiError, or nil if none found.
+func (m *UserBatchUpdateRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UserBatchUpdateRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetIds()) > 1000 {
+		err := UserBatchUpdateRequestValidationError{
+			field:  "Ids",
+			reason: "value must contain no more than 1000 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetIds() {
+		_, _ = idx, item
+
+		if utf8.RuneCountInString(item) < 1 {
+			err := UserBatchUpdateRequestValidationError{
+				field:  fmt.Sprintf("Ids[%v]", idx),
+				reason: "value length must be at least 1 runes",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if all {
+		switch v := interface{}(m.GetFilter()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UserBatchUpdateRequestValidationError{
+					field:  "Filter",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UserBatchUpdateRequestValidationError{
+					field:  "Filter",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetFilter()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UserBatchUpdateRequestValidationError{
+				field:  "Filter",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if _, ok := UserRole_name[int32(m.GetRole())]; !ok {
+		err := UserBatchUpdateRequestValidationError{
+			field:  "Role",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if _, ok := UserStatus_name[int32(m.GetStatus())]; !ok {
+		err := UserBatchUpdateRequestValidationError{
+			field:  "Status",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if _, ok := UserBatchMode_name[int32(m.GetMode())]; !ok {
+		err := UserBatchUpdateRequestValidationError{
+			field:  "Mode",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return UserBatchUpdateRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// UserBatchUpdateRequestMultiError is an error wrapping multiple validation
+// errors returned by UserBatchUpdateRequest.ValidateAll() if the designated
+// constraints aren't met.
+type UserBatchUpdateRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UserBatchUpdateRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UserBatchUpdateRequestMultiError) AllErrors() []error { return m }
+
+// UserBatchUpdateRequestValidationError is the validation error returned by
+// UserBatchUpdateRequest.Validate if the designated constraints aren't met.
+type UserBatchUpdateRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UserBatchUpdateRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UserBatchUpdateRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UserBatchUpdateRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UserBatchUpdateRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UserBatchUpdateRequestValidationError) ErrorName() string {
+	return "UserBatchUpdateRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UserBatchUpdateRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUserBatchUpdateRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UserBatchUpdateRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UserBatchUpdateRequestValidationError{}
+
+// Validate checks the field values on UserBatchDeleteRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UserBatchDeleteRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UserBatchDeleteRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UserBatchDeleteRequestMultiError, or nil if none found.
+func (m *UserBatchDeleteRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UserBatchDeleteRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetIds()) > 1000 {
+		err := UserBatchDeleteRequestValidationError{
+			field:  "Ids",
+			reason: "value must contain no more than 1000 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetIds() {
+		_, _ = idx, item
+
+		if utf8.RuneCountInString(item) < 1 {
+			err := UserBatchDeleteRequestValidationError{
+				field:  fmt.Sprintf("Ids[%v]", idx),
+				reason: "value length must be at least 1 runes",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if all {
+		switch v := interface{}(m.GetFilter()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UserBatchDeleteRequestValidationError{
+					field:  "Filter",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UserBatchDeleteRequestValidationError{
+					field:  "Filter",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetFilter()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UserBatchDeleteRequestValidationError{
+				field:  "Filter",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if _, ok := UserBatchMode_name[int32(m.GetMode())]; !ok {
+		err := UserBatchDeleteRequestValidationError{
+			field:  "Mode",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return UserBatchDeleteRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// UserBatchDeleteRequestMultiError is an error wrapping multiple validation
+// errors returned by UserBatchDeleteRequest.ValidateAll() if the designated
+// constraints aren't met.
+type UserBatchDeleteRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UserBatchDeleteRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UserBatchDeleteRequestMultiError) AllErrors() []error { return m }
+
+// UserBatchDeleteRequestValidationError is the validation error returned by
+// UserBatchDeleteRequest.Validate if the designated constraints aren't met.
+type UserBatchDeleteRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UserBatchDeleteRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UserBatchDeleteRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UserBatchDeleteRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UserBatchDeleteRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UserBatchDeleteRequestValidationError) ErrorName() string {
+	return "UserBatchDeleteRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UserBatchDeleteRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUserBatchDeleteRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UserBatchDeleteRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UserBatchDeleteRequestValidationError{}
+
+// Validate checks the field values on UserBatchItemResult with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UserBatchItemResult) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UserBatchItemResult with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UserBatchItemResultMultiError, or nil if none found.
+func (m *UserBatchItemResult) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UserBatchItemResult) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for Username
+
+	// no validation rules for Success
+
+	// no validation rules for Error
+
+	if len(errors) > 0 {
+		return UserBatchItemResultMultiError(errors)
+	}
+
+	return nil
+}
+
+// UserBatchItemResultMultiError is an error wrapping multiple validation
+// errors returned by UserBatchItemResult.ValidateAll() if the designated
+// constraints aren't met.
+type UserBatchItemResultMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UserBatchItemResultMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UserBatchItemResultMultiError) AllErrors() []error { return m }
+
+// UserBatchItemResultValidationError is the validation error returned by
+// UserBatchItemResult.Validate if the designated constraints aren't met.
+type UserBatchItemResultValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UserBatchItemResultValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UserBatchItemResultValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UserBatchItemResultValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UserBatchItemResultValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UserBatchItemResultValidationError) ErrorName() string {
+	return "UserBatchItemResultValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UserBatchItemResultValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUserBatchItemResult.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UserBatchItemResultValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UserBatchItemResultValidationError{}
+
+// Validate checks the field values on UserBatchResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *UserBatchResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UserBatchResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UserBatchResponseMultiError, or nil if none found.
+func (m *UserBatchResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UserBatchResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Total
+
+	// no validation rules for Succeeded
+
+	// no validation rules for Failed
+
+	// no validation rules for RolledBack
+
+	for idx, item := range m.GetResults() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, UserBatchResponseValidationError{
+						field:  fmt.Sprintf("Results[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, UserBatchResponseValidationError{
+						field:  fmt.Sprintf("Results[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return UserBatchResponseValidationError{
+					field:  fmt.Sprintf("Results[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return UserBatchResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// UserBatchResponseMultiError is an error wrapping multiple validation errors
+// returned by UserBatchResponse.ValidateAll() if the designated constraints
+// aren't met.
+type UserBatchResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UserBatchResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UserBatchResponseMultiError) AllErrors() []error { return m }
+
+// UserBatchResponseValidationError is the validation error returned by
+// UserBatchResponse.Validate if the designated constraints aren't met.
+type UserBatchResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UserBatchResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UserBatchResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UserBatchResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UserBatchResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UserBatchResponseValidationError) ErrorName() string {
+	return "UserBatchResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UserBatchResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUserBatchResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UserBatchResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UserBatchResponseValidationError{}
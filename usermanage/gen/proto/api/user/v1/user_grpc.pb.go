@@ -20,13 +20,24 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	UserService_ListUsers_FullMethodName         = "/user.v1.UserService/ListUsers"
-	UserService_GetUser_FullMethodName           = "/user.v1.UserService/GetUser"
-	UserService_CreateUser_FullMethodName        = "/user.v1.UserService/CreateUser"
-	UserService_UpdateUser_FullMethodName        = "/user.v1.UserService/UpdateUser"
-	UserService_ReplaceUser_FullMethodName       = "/user.v1.UserService/ReplaceUser"
-	UserService_DeleteUser_FullMethodName        = "/user.v1.UserService/DeleteUser"
-	UserService_ResetUserPassword_FullMethodName = "/user.v1.UserService/ResetUserPassword"
+	UserService_ListUsers_FullMethodName           = "/user.v1.UserService/ListUsers"
+	UserService_GetUser_FullMethodName             = "/user.v1.UserService/GetUser"
+	UserService_CreateUser_FullMethodName          = "/user.v1.UserService/CreateUser"
+	UserService_UpdateUser_FullMethodName          = "/user.v1.UserService/UpdateUser"
+	UserService_ReplaceUser_FullMethodName         = "/user.v1.UserService/ReplaceUser"
+	UserService_DeleteUser_FullMethodName          = "/user.v1.UserService/DeleteUser"
+	UserService_ResetUserPassword_FullMethodName   = "/user.v1.UserService/ResetUserPassword"
+	UserService_ListUserRevisions_FullMethodName   = "/user.v1.UserService/ListUserRevisions"
+	UserService_GetUserAtTime_FullMethodName       = "/user.v1.UserService/GetUserAtTime"
+	UserService_RestoreUserRevision_FullMethodName = "/user.v1.UserService/RestoreUserRevision"
+	UserService_WatchUsers_FullMethodName          = "/user.v1.UserService/WatchUsers"
+	UserService_ListDeletedUsers_FullMethodName    = "/user.v1.UserService/ListDeletedUsers"
+	UserService_UndeleteUser_FullMethodName        = "/user.v1.UserService/UndeleteUser"
+	UserService_PurgeUser_FullMethodName           = "/user.v1.UserService/PurgeUser"
+	UserService_ImportUsers_FullMethodName         = "/user.v1.UserService/ImportUsers"
+	UserService_ExportUsers_FullMethodName         = "/user.v1.UserService/ExportUsers"
+	UserService_BatchUpdateUsers_FullMethodName    = "/user.v1.UserService/BatchUpdateUsers"
+	UserService_BatchDeleteUsers_FullMethodName    = "/user.v1.UserService/BatchDeleteUsers"
 )
 
 // UserServiceClient is the client API for UserService service.
@@ -42,6 +53,40 @@ type UserServiceClient interface {
 	ReplaceUser(ctx context.Context, in *UserReplaceRequest, opts ...grpc.CallOption) (*UserResponse, error)
 	DeleteUser(ctx context.Context, in *UserDeleteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	ResetUserPassword(ctx context.Context, in *UserPasswordResetRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// ListUserRevisions lists the change history of a user, newest first.
+	ListUserRevisions(ctx context.Context, in *UserRevisionListRequest, opts ...grpc.CallOption) (*UserRevisionListResponse, error)
+	// GetUserAtTime returns the state of a user as it was at the given point in time.
+	GetUserAtTime(ctx context.Context, in *UserAtTimeRequest, opts ...grpc.CallOption) (*UserResponse, error)
+	// RestoreUserRevision rolls a user back to the state recorded by a previous revision.
+	RestoreUserRevision(ctx context.Context, in *UserRevisionRestoreRequest, opts ...grpc.CallOption) (*UserResponse, error)
+	// WatchUsers streams the changes of users as they happen.
+	//
+	// Admins receive the changes of all users, other users only their own.
+	// Over HTTP the same feed is served as Server-Sent Events at `GET /v1/users/watch`.
+	WatchUsers(ctx context.Context, in *UserWatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[UserWatchEvent], error)
+	// ListDeletedUsers lists the soft-deleted users which have not been purged yet.
+	ListDeletedUsers(ctx context.Context, in *DeletedUserListRequest, opts ...grpc.CallOption) (*UserListResponse, error)
+	// UndeleteUser brings a soft-deleted user back, unless a live user took its username meanwhile.
+	UndeleteUser(ctx context.Context, in *UserUndeleteRequest, opts ...grpc.CallOption) (*UserResponse, error)
+	// PurgeUser permanently removes a soft-deleted user along with its change history.
+	PurgeUser(ctx context.Context, in *UserPurgeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// ImportUsers creates, or upserts by username, users from a CSV or JSON Lines file.
+	//
+	// The first message carries the options, the file is sent in chunks. Every row is
+	// reported, rows are written in chunks of 100 per transaction.
+	// Over HTTP the file is uploaded as `multipart/form-data` to `POST /v1/admin/users/import`.
+	ImportUsers(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UserImportRequest, UserImportResponse], error)
+	// ExportUsers streams the users matching the filters of `ListUsers` as a CSV, JSON Lines
+	// or XLSX file, in chunks. Over HTTP the file is downloaded from `GET /v1/admin/users/export`.
+	// CSV and XLSX values starting with `=`, `+`, `-` or `@` are prefixed with a quote, so
+	// spreadsheet applications do not run them as formulas; the import removes it.
+	ExportUsers(ctx context.Context, in *UserExportRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[UserExportChunk], error)
+	// BatchUpdateUsers changes the role or the status of up to 1000 users, given by ID or a filter.
+	//
+	// Users no longer in the normal status have their sessions revoked.
+	BatchUpdateUsers(ctx context.Context, in *UserBatchUpdateRequest, opts ...grpc.CallOption) (*UserBatchResponse, error)
+	// BatchDeleteUsers deletes up to 1000 users, given by ID or a filter, and revokes their sessions.
+	BatchDeleteUsers(ctx context.Context, in *UserBatchDeleteRequest, opts ...grpc.CallOption) (*UserBatchResponse, error)
 }
 
 type userServiceClient struct {
@@ -122,6 +167,137 @@ func (c *userServiceClient) ResetUserPassword(ctx context.Context, in *UserPassw
 	return out, nil
 }
 
+func (c *userServiceClient) ListUserRevisions(ctx context.Context, in *UserRevisionListRequest, opts ...grpc.CallOption) (*UserRevisionListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserRevisionListResponse)
+	err := c.cc.Invoke(ctx, UserService_ListUserRevisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetUserAtTime(ctx context.Context, in *UserAtTimeRequest, opts ...grpc.CallOption) (*UserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserResponse)
+	err := c.cc.Invoke(ctx, UserService_GetUserAtTime_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RestoreUserRevision(ctx context.Context, in *UserRevisionRestoreRequest, opts ...grpc.CallOption) (*UserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserResponse)
+	err := c.cc.Invoke(ctx, UserService_RestoreUserRevision_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) WatchUsers(ctx context.Context, in *UserWatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[UserWatchEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &UserService_ServiceDesc.Streams[0], UserService_WatchUsers_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[UserWatchRequest, UserWatchEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UserService_WatchUsersClient = grpc.ServerStreamingClient[UserWatchEvent]
+
+func (c *userServiceClient) ListDeletedUsers(ctx context.Context, in *DeletedUserListRequest, opts ...grpc.CallOption) (*UserListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserListResponse)
+	err := c.cc.Invoke(ctx, UserService_ListDeletedUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UndeleteUser(ctx context.Context, in *UserUndeleteRequest, opts ...grpc.CallOption) (*UserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserResponse)
+	err := c.cc.Invoke(ctx, UserService_UndeleteUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) PurgeUser(ctx context.Context, in *UserPurgeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, UserService_PurgeUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ImportUsers(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UserImportRequest, UserImportResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &UserService_ServiceDesc.Streams[1], UserService_ImportUsers_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[UserImportRequest, UserImportResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UserService_ImportUsersClient = grpc.ClientStreamingClient[UserImportRequest, UserImportResponse]
+
+func (c *userServiceClient) ExportUsers(ctx context.Context, in *UserExportRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[UserExportChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &UserService_ServiceDesc.Streams[2], UserService_ExportUsers_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[UserExportRequest, UserExportChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UserService_ExportUsersClient = grpc.ServerStreamingClient[UserExportChunk]
+
+func (c *userServiceClient) BatchUpdateUsers(ctx context.Context, in *UserBatchUpdateRequest, opts ...grpc.CallOption) (*UserBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserBatchResponse)
+	err := c.cc.Invoke(ctx, UserService_BatchUpdateUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) BatchDeleteUsers(ctx context.Context, in *UserBatchDeleteRequest, opts ...grpc.CallOption) (*UserBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserBatchResponse)
+	err := c.cc.Invoke(ctx, UserService_BatchDeleteUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserServiceServer is the server API for UserService service.
 // All implementations must embed UnimplementedUserServiceServer
 // for forward compatibility.
@@ -135,6 +311,40 @@ type UserServiceServer interface {
 	ReplaceUser(context.Context, *UserReplaceRequest) (*UserResponse, error)
 	DeleteUser(context.Context, *UserDeleteRequest) (*emptypb.Empty, error)
 	ResetUserPassword(context.Context, *UserPasswordResetRequest) (*emptypb.Empty, error)
+	// ListUserRevisions lists the change history of a user, newest first.
+	ListUserRevisions(context.Context, *UserRevisionListRequest) (*UserRevisionListResponse, error)
+	// GetUserAtTime returns the state of a user as it was at the given point in time.
+	GetUserAtTime(context.Context, *UserAtTimeRequest) (*UserResponse, error)
+	// RestoreUserRevision rolls a user back to the state recorded by a previous revision.
+	RestoreUserRevision(context.Context, *UserRevisionRestoreRequest) (*UserResponse, error)
+	// WatchUsers streams the changes of users as they happen.
+	//
+	// Admins receive the changes of all users, other users only their own.
+	// Over HTTP the same feed is served as Server-Sent Events at `GET /v1/users/watch`.
+	WatchUsers(*UserWatchRequest, grpc.ServerStreamingServer[UserWatchEvent]) error
+	// ListDeletedUsers lists the soft-deleted users which have not been purged yet.
+	ListDeletedUsers(context.Context, *DeletedUserListRequest) (*UserListResponse, error)
+	// UndeleteUser brings a soft-deleted user back, unless a live user took its username meanwhile.
+	UndeleteUser(context.Context, *UserUndeleteRequest) (*UserResponse, error)
+	// PurgeUser permanently removes a soft-deleted user along with its change history.
+	PurgeUser(context.Context, *UserPurgeRequest) (*emptypb.Empty, error)
+	// ImportUsers creates, or upserts by username, users from a CSV or JSON Lines file.
+	//
+	// The first message carries the options, the file is sent in chunks. Every row is
+	// reported, rows are written in chunks of 100 per transaction.
+	// Over HTTP the file is uploaded as `multipart/form-data` to `POST /v1/admin/users/import`.
+	ImportUsers(grpc.ClientStreamingServer[UserImportRequest, UserImportResponse]) error
+	// ExportUsers streams the users matching the filters of `ListUsers` as a CSV, JSON Lines
+	// or XLSX file, in chunks. Over HTTP the file is downloaded from `GET /v1/admin/users/export`.
+	// CSV and XLSX values starting with `=`, `+`, `-` or `@` are prefixed with a quote, so
+	// spreadsheet applications do not run them as formulas; the import removes it.
+	ExportUsers(*UserExportRequest, grpc.ServerStreamingServer[UserExportChunk]) error
+	// BatchUpdateUsers changes the role or the status of up to 1000 users, given by ID or a filter.
+	//
+	// Users no longer in the normal status have their sessions revoked.
+	BatchUpdateUsers(context.Context, *UserBatchUpdateRequest) (*UserBatchResponse, error)
+	// BatchDeleteUsers deletes up to 1000 users, given by ID or a filter, and revokes their sessions.
+	BatchDeleteUsers(context.Context, *UserBatchDeleteRequest) (*UserBatchResponse, error)
 	mustEmbedUnimplementedUserServiceServer()
 }
 
@@ -166,6 +376,39 @@ func (UnimplementedUserServiceServer) DeleteUser(context.Context, *UserDeleteReq
 func (UnimplementedUserServiceServer) ResetUserPassword(context.Context, *UserPasswordResetRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ResetUserPassword not implemented")
 }
+func (UnimplementedUserServiceServer) ListUserRevisions(context.Context, *UserRevisionListRequest) (*UserRevisionListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUserRevisions not implemented")
+}
+func (UnimplementedUserServiceServer) GetUserAtTime(context.Context, *UserAtTimeRequest) (*UserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserAtTime not implemented")
+}
+func (UnimplementedUserServiceServer) RestoreUserRevision(context.Context, *UserRevisionRestoreRequest) (*UserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreUserRevision not implemented")
+}
+func (UnimplementedUserServiceServer) WatchUsers(*UserWatchRequest, grpc.ServerStreamingServer[UserWatchEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchUsers not implemented")
+}
+func (UnimplementedUserServiceServer) ListDeletedUsers(context.Context, *DeletedUserListRequest) (*UserListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeletedUsers not implemented")
+}
+func (UnimplementedUserServiceServer) UndeleteUser(context.Context, *UserUndeleteRequest) (*UserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UndeleteUser not implemented")
+}
+func (UnimplementedUserServiceServer) PurgeUser(context.Context, *UserPurgeRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeUser not implemented")
+}
+func (UnimplementedUserServiceServer) ImportUsers(grpc.ClientStreamingServer[UserImportRequest, UserImportResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ImportUsers not implemented")
+}
+func (UnimplementedUserServiceServer) ExportUsers(*UserExportRequest, grpc.ServerStreamingServer[UserExportChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportUsers not implemented")
+}
+func (UnimplementedUserServiceServer) BatchUpdateUsers(context.Context, *UserBatchUpdateRequest) (*UserBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchUpdateUsers not implemented")
+}
+func (UnimplementedUserServiceServer) BatchDeleteUsers(context.Context, *UserBatchDeleteRequest) (*UserBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchDeleteUsers not implemented")
+}
 func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
 func (UnimplementedUserServiceServer) testEmbeddedByValue()                     {}
 
@@ -313,6 +556,179 @@ func _UserService_ResetUserPassword_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_ListUserRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserRevisionListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListUserRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListUserRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListUserRevisions(ctx, req.(*UserRevisionListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetUserAtTime_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserAtTimeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetUserAtTime(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetUserAtTime_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetUserAtTime(ctx, req.(*UserAtTimeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RestoreUserRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserRevisionRestoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RestoreUserRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RestoreUserRevision_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RestoreUserRevision(ctx, req.(*UserRevisionRestoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_WatchUsers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(UserWatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UserServiceServer).WatchUsers(m, &grpc.GenericServerStream[UserWatchRequest, UserWatchEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UserService_WatchUsersServer = grpc.ServerStreamingServer[UserWatchEvent]
+
+func _UserService_ListDeletedUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletedUserListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListDeletedUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListDeletedUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListDeletedUsers(ctx, req.(*DeletedUserListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UndeleteUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserUndeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UndeleteUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UndeleteUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UndeleteUser(ctx, req.(*UserUndeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_PurgeUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserPurgeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).PurgeUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_PurgeUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).PurgeUser(ctx, req.(*UserPurgeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ImportUsers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(UserServiceServer).ImportUsers(&grpc.GenericServerStream[UserImportRequest, UserImportResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UserService_ImportUsersServer = grpc.ClientStreamingServer[UserImportRequest, UserImportResponse]
+
+func _UserService_ExportUsers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(UserExportRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UserServiceServer).ExportUsers(m, &grpc.GenericServerStream[UserExportRequest, UserExportChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UserService_ExportUsersServer = grpc.ServerStreamingServer[UserExportChunk]
+
+func _UserService_BatchUpdateUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserBatchUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).BatchUpdateUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_BatchUpdateUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).BatchUpdateUsers(ctx, req.(*UserBatchUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_BatchDeleteUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserBatchDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).BatchDeleteUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_BatchDeleteUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).BatchDeleteUsers(ctx, req.(*UserBatchDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -348,7 +764,55 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ResetUserPassword",
 			Handler:    _UserService_ResetUserPassword_Handler,
 		},
+		{
+			MethodName: "ListUserRevisions",
+			Handler:    _UserService_ListUserRevisions_Handler,
+		},
+		{
+			MethodName: "GetUserAtTime",
+			Handler:    _UserService_GetUserAtTime_Handler,
+		},
+		{
+			MethodName: "RestoreUserRevision",
+			Handler:    _UserService_RestoreUserRevision_Handler,
+		},
+		{
+			MethodName: "ListDeletedUsers",
+			Handler:    _UserService_ListDeletedUsers_Handler,
+		},
+		{
+			MethodName: "UndeleteUser",
+			Handler:    _UserService_UndeleteUser_Handler,
+		},
+		{
+			MethodName: "PurgeUser",
+			Handler:    _UserService_PurgeUser_Handler,
+		},
+		{
+			MethodName: "BatchUpdateUsers",
+			Handler:    _UserService_BatchUpdateUsers_Handler,
+		},
+		{
+			MethodName: "BatchDeleteUsers",
+			Handler:    _UserService_BatchDeleteUsers_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchUsers",
+			Handler:       _UserService_WatchUsers_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportUsers",
+			Handler:       _UserService_ImportUsers_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportUsers",
+			Handler:       _UserService_ExportUsers_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/api/user/v1/user.proto",
 }
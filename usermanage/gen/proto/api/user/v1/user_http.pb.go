@@ -20,22 +20,48 @@ var _ = binding.EncodeURL
 
 const _ = http.SupportPackageIsVersion1
 
+const OperationUserServiceBatchDeleteUsers = "/user.v1.UserService/BatchDeleteUsers"
+const OperationUserServiceBatchUpdateUsers = "/user.v1.UserService/BatchUpdateUsers"
 const OperationUserServiceCreateUser = "/user.v1.UserService/CreateUser"
 const OperationUserServiceDeleteUser = "/user.v1.UserService/DeleteUser"
 const OperationUserServiceGetUser = "/user.v1.UserService/GetUser"
+const OperationUserServiceGetUserAtTime = "/user.v1.UserService/GetUserAtTime"
+const OperationUserServiceListDeletedUsers = "/user.v1.UserService/ListDeletedUsers"
+const OperationUserServiceListUserRevisions = "/user.v1.UserService/ListUserRevisions"
 const OperationUserServiceListUsers = "/user.v1.UserService/ListUsers"
+const OperationUserServicePurgeUser = "/user.v1.UserService/PurgeUser"
 const OperationUserServiceReplaceUser = "/user.v1.UserService/ReplaceUser"
 const OperationUserServiceResetUserPassword = "/user.v1.UserService/ResetUserPassword"
+const OperationUserServiceRestoreUserRevision = "/user.v1.UserService/RestoreUserRevision"
+const OperationUserServiceUndeleteUser = "/user.v1.UserService/UndeleteUser"
 const OperationUserServiceUpdateUser = "/user.v1.UserService/UpdateUser"
 
 type UserServiceHTTPServer interface {
+	// BatchDeleteUsers BatchDeleteUsers deletes up to 1000 users, given by ID or a filter, and revokes their sessions.
+	BatchDeleteUsers(context.Context, *UserBatchDeleteRequest) (*UserBatchResponse, error)
+	// BatchUpdateUsers BatchUpdateUsers changes the role or the status of up to 1000 users, given by ID or a filter.
+	//
+	// Users no longer in the normal status have their sessions revoked.
+	BatchUpdateUsers(context.Context, *UserBatchUpdateRequest) (*UserBatchResponse, error)
 	CreateUser(context.Context, *UserCreateRequest) (*UserResponse, error)
 	DeleteUser(context.Context, *UserDeleteRequest) (*emptypb.Empty, error)
 	GetUser(context.Context, *UserRequest) (*UserResponse, error)
+	// GetUserAtTime GetUserAtTime returns the state of a user as it was at the given point in time.
+	GetUserAtTime(context.Context, *UserAtTimeRequest) (*UserResponse, error)
+	// ListDeletedUsers ListDeletedUsers lists the soft-deleted users which have not been purged yet.
+	ListDeletedUsers(context.Context, *DeletedUserListRequest) (*UserListResponse, error)
+	// ListUserRevisions ListUserRevisions lists the change history of a user, newest first.
+	ListUserRevisions(context.Context, *UserRevisionListRequest) (*UserRevisionListResponse, error)
 	ListUsers(context.Context, *UserListRequest) (*UserListResponse, error)
+	// PurgeUser PurgeUser permanently removes a soft-deleted user along with its change history.
+	PurgeUser(context.Context, *UserPurgeRequest) (*emptypb.Empty, error)
 	// ReplaceUser ReplaceUser performs a full replacement of a user resource.
 	ReplaceUser(context.Context, *UserReplaceRequest) (*UserResponse, error)
 	ResetUserPassword(context.Context, *UserPasswordResetRequest) (*emptypb.Empty, error)
+	// RestoreUserRevision RestoreUserRevision rolls a user back to the state recorded by a previous revision.
+	RestoreUserRevision(context.Context, *UserRevisionRestoreRequest) (*UserResponse, error)
+	// UndeleteUser UndeleteUser brings a soft-deleted user back, unless a live user took its username meanwhile.
+	UndeleteUser(context.Context, *UserUndeleteRequest) (*UserResponse, error)
 	// UpdateUser UpdateUser performs a partial update on a user resource using the provided field mask.
 	UpdateUser(context.Context, *UserUpdateRequest) (*UserResponse, error)
 }
@@ -49,6 +75,14 @@ func RegisterUserServiceHTTPServer(s *http.Server, srv UserServiceHTTPServer) {
 	r.PUT("/v1/admin/users/{id}", _UserService_ReplaceUser0_HTTP_Handler(srv))
 	r.DELETE("/v1/admin/users/{id}", _UserService_DeleteUser0_HTTP_Handler(srv))
 	r.POST("/v1/admin/users/{id}/reset-password", _UserService_ResetUserPassword0_HTTP_Handler(srv))
+	r.GET("/v1/admin/users/{id}/revisions", _UserService_ListUserRevisions0_HTTP_Handler(srv))
+	r.GET("/v1/admin/users/{id}/at", _UserService_GetUserAtTime0_HTTP_Handler(srv))
+	r.POST("/v1/admin/users/{id}/revisions/{revision}/restore", _UserService_RestoreUserRevision0_HTTP_Handler(srv))
+	r.GET("/v1/admin/deleted-users", _UserService_ListDeletedUsers0_HTTP_Handler(srv))
+	r.POST("/v1/admin/deleted-users/{id}/undelete", _UserService_UndeleteUser0_HTTP_Handler(srv))
+	r.DELETE("/v1/admin/deleted-users/{id}", _UserService_PurgeUser0_HTTP_Handler(srv))
+	r.POST("/v1/admin/users/batch-update", _UserService_BatchUpdateUsers0_HTTP_Handler(srv))
+	r.POST("/v1/admin/users/batch-delete", _UserService_BatchDeleteUsers0_HTTP_Handler(srv))
 }
 
 func _UserService_ListUsers0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
@@ -211,13 +245,200 @@ func _UserService_ResetUserPassword0_HTTP_Handler(srv UserServiceHTTPServer) fun
 	}
 }
 
+func _UserService_ListUserRevisions0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in UserRevisionListRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServiceListUserRevisions)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ListUserRevisions(ctx, req.(*UserRevisionListRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*UserRevisionListResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _UserService_GetUserAtTime0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in UserAtTimeRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServiceGetUserAtTime)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetUserAtTime(ctx, req.(*UserAtTimeRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*UserResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _UserService_RestoreUserRevision0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in UserRevisionRestoreRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServiceRestoreUserRevision)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.RestoreUserRevision(ctx, req.(*UserRevisionRestoreRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*UserResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _UserService_ListDeletedUsers0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in DeletedUserListRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServiceListDeletedUsers)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.ListDeletedUsers(ctx, req.(*DeletedUserListRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*UserListResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _UserService_UndeleteUser0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in UserUndeleteRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServiceUndeleteUser)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.UndeleteUser(ctx, req.(*UserUndeleteRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*UserResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _UserService_PurgeUser0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in UserPurgeRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindVars(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServicePurgeUser)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.PurgeUser(ctx, req.(*UserPurgeRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*emptypb.Empty)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _UserService_BatchUpdateUsers0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in UserBatchUpdateRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServiceBatchUpdateUsers)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.BatchUpdateUsers(ctx, req.(*UserBatchUpdateRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*UserBatchResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
+func _UserService_BatchDeleteUsers0_HTTP_Handler(srv UserServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in UserBatchDeleteRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationUserServiceBatchDeleteUsers)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.BatchDeleteUsers(ctx, req.(*UserBatchDeleteRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*UserBatchResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
 type UserServiceHTTPClient interface {
+	BatchDeleteUsers(ctx context.Context, req *UserBatchDeleteRequest, opts ...http.CallOption) (rsp *UserBatchResponse, err error)
+	BatchUpdateUsers(ctx context.Context, req *UserBatchUpdateRequest, opts ...http.CallOption) (rsp *UserBatchResponse, err error)
 	CreateUser(ctx context.Context, req *UserCreateRequest, opts ...http.CallOption) (rsp *UserResponse, err error)
 	DeleteUser(ctx context.Context, req *UserDeleteRequest, opts ...http.CallOption) (rsp *emptypb.Empty, err error)
 	GetUser(ctx context.Context, req *UserRequest, opts ...http.CallOption) (rsp *UserResponse, err error)
+	GetUserAtTime(ctx context.Context, req *UserAtTimeRequest, opts ...http.CallOption) (rsp *UserResponse, err error)
+	ListDeletedUsers(ctx context.Context, req *DeletedUserListRequest, opts ...http.CallOption) (rsp *UserListResponse, err error)
+	ListUserRevisions(ctx context.Context, req *UserRevisionListRequest, opts ...http.CallOption) (rsp *UserRevisionListResponse, err error)
 	ListUsers(ctx context.Context, req *UserListRequest, opts ...http.CallOption) (rsp *UserListResponse, err error)
+	PurgeUser(ctx context.Context, req *UserPurgeRequest, opts ...http.CallOption) (rsp *emptypb.Empty, err error)
 	ReplaceUser(ctx context.Context, req *UserReplaceRequest, opts ...http.CallOption) (rsp *UserResponse, err error)
 	ResetUserPassword(ctx context.Context, req *UserPasswordResetRequest, opts ...http.CallOption) (rsp *emptypb.Empty, err error)
+	RestoreUserRevision(ctx context.Context, req *UserRevisionRestoreRequest, opts ...http.CallOption) (rsp *UserResponse, err error)
+	UndeleteUser(ctx context.Context, req *UserUndeleteRequest, opts ...http.CallOption) (rsp *UserResponse, err error)
 	UpdateUser(ctx context.Context, req *UserUpdateRequest, opts ...http.CallOption) (rsp *UserResponse, err error)
 }
 
@@ -229,6 +450,32 @@ func NewUserServiceHTTPClient(client *http.Client) UserServiceHTTPClient {
 	return &UserServiceHTTPClientImpl{client}
 }
 
+func (c *UserServiceHTTPClientImpl) BatchDeleteUsers(ctx context.Context, in *UserBatchDeleteRequest, opts ...http.CallOption) (*UserBatchResponse, error) {
+	var out UserBatchResponse
+	pattern := "/v1/admin/users/batch-delete"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationUserServiceBatchDeleteUsers))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *UserServiceHTTPClientImpl) BatchUpdateUsers(ctx context.Context, in *UserBatchUpdateRequest, opts ...http.CallOption) (*UserBatchResponse, error) {
+	var out UserBatchResponse
+	pattern := "/v1/admin/users/batch-update"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationUserServiceBatchUpdateUsers))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 func (c *UserServiceHTTPClientImpl) CreateUser(ctx context.Context, in *UserCreateRequest, opts ...http.CallOption) (*UserResponse, error) {
 	var out UserResponse
 	pattern := "/v1/admin/users"
@@ -268,6 +515,45 @@ func (c *UserServiceHTTPClientImpl) GetUser(ctx context.Context, in *UserRequest
 	return &out, nil
 }
 
+func (c *UserServiceHTTPClientImpl) GetUserAtTime(ctx context.Context, in *UserAtTimeRequest, opts ...http.CallOption) (*UserResponse, error) {
+	var out UserResponse
+	pattern := "/v1/admin/users/{id}/at"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationUserServiceGetUserAtTime))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *UserServiceHTTPClientImpl) ListDeletedUsers(ctx context.Context, in *DeletedUserListRequest, opts ...http.CallOption) (*UserListResponse, error) {
+	var out UserListResponse
+	pattern := "/v1/admin/deleted-users"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationUserServiceListDeletedUsers))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *UserServiceHTTPClientImpl) ListUserRevisions(ctx context.Context, in *UserRevisionListRequest, opts ...http.CallOption) (*UserRevisionListResponse, error) {
+	var out UserRevisionListResponse
+	pattern := "/v1/admin/users/{id}/revisions"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationUserServiceListUserRevisions))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 func (c *UserServiceHTTPClientImpl) ListUsers(ctx context.Context, in *UserListRequest, opts ...http.CallOption) (*UserListResponse, error) {
 	var out UserListResponse
 	pattern := "/v1/admin/users"
@@ -281,6 +567,19 @@ func (c *UserServiceHTTPClientImpl) ListUsers(ctx context.Context, in *UserListR
 	return &out, nil
 }
 
+func (c *UserServiceHTTPClientImpl) PurgeUser(ctx context.Context, in *UserPurgeRequest, opts ...http.CallOption) (*emptypb.Empty, error) {
+	var out emptypb.Empty
+	pattern := "/v1/admin/deleted-users/{id}"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationUserServicePurgeUser))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "DELETE", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 func (c *UserServiceHTTPClientImpl) ReplaceUser(ctx context.Context, in *UserReplaceRequest, opts ...http.CallOption) (*UserResponse, error) {
 	var out UserResponse
 	pattern := "/v1/admin/users/{id}"
@@ -307,6 +606,32 @@ func (c *UserServiceHTTPClientImpl) ResetUserPassword(ctx context.Context, in *U
 	return &out, nil
 }
 
+func (c *UserServiceHTTPClientImpl) RestoreUserRevision(ctx context.Context, in *UserRevisionRestoreRequest, opts ...http.CallOption) (*UserResponse, error) {
+	var out UserResponse
+	pattern := "/v1/admin/users/{id}/revisions/{revision}/restore"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationUserServiceRestoreUserRevision))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *UserServiceHTTPClientImpl) UndeleteUser(ctx context.Context, in *UserUndeleteRequest, opts ...http.CallOption) (*UserResponse, error) {
+	var out UserResponse
+	pattern := "/v1/admin/deleted-users/{id}/undelete"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationUserServiceUndeleteUser))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 func (c *UserServiceHTTPClientImpl) UpdateUser(ctx context.Context, in *UserUpdateRequest, opts ...http.CallOption) (*UserResponse, error) {
 	var out UserResponse
 	pattern := "/v1/admin/users/{id}"
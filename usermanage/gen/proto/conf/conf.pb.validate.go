@@ -656,6 +656,151 @@ func (m *Server) validate(all bool) error {
 		}
 	}
 
+	if all {
+		switch v := interface{}(m.GetIdempotency()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ServerValidationError{
+					field:  "Idempotency",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ServerValidationError{
+					field:  "Idempotency",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetIdempotency()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ServerValidationError{
+				field:  "Idempotency",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetScim()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ServerValidationError{
+					field:  "Scim",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ServerValidationError{
+					field:  "Scim",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetScim()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ServerValidationError{
+				field:  "Scim",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetOidc()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ServerValidationError{
+					field:  "Oidc",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ServerValidationError{
+					field:  "Oidc",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetOidc()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ServerValidationError{
+				field:  "Oidc",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetFederation()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ServerValidationError{
+					field:  "Federation",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ServerValidationError{
+					field:  "Federation",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetFederation()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ServerValidationError{
+				field:  "Federation",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetAuth()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ServerValidationError{
+					field:  "Auth",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ServerValidationError{
+					field:  "Auth",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetAuth()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ServerValidationError{
+				field:  "Auth",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
 	if len(errors) > 0 {
 		return ServerMultiError(errors)
 	}
@@ -812,58 +957,236 @@ func (m *Data) validate(all bool) error {
 		}
 	}
 
-	if len(errors) > 0 {
-		return DataMultiError(errors)
+	if all {
+		switch v := interface{}(m.GetOutbox()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, DataValidationError{
+					field:  "Outbox",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, DataValidationError{
+					field:  "Outbox",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetOutbox()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return DataValidationError{
+				field:  "Outbox",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
 	}
 
-	return nil
-}
-
-// DataMultiError is an error wrapping multiple validation errors returned by
-// Data.ValidateAll() if the designated constraints aren't met.
-type DataMultiError []error
-
-// Error returns a concatenation of all the error messages it wraps.
-func (m DataMultiError) Error() string {
-	msgs := make([]string, 0, len(m))
-	for _, err := range m {
-		msgs = append(msgs, err.Error())
+	if all {
+		switch v := interface{}(m.GetWebhook()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, DataValidationError{
+					field:  "Webhook",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, DataValidationError{
+					field:  "Webhook",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetWebhook()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return DataValidationError{
+				field:  "Webhook",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
 	}
-	return strings.Join(msgs, "; ")
-}
-
-// AllErrors returns a list of validation violation errors.
-func (m DataMultiError) AllErrors() []error { return m }
-
-// DataValidationError is the validation error returned by Data.Validate if the
-// designated constraints aren't met.
-type DataValidationError struct {
-	field  string
-	reason string
-	cause  error
-	key    bool
-}
 
-// Field function returns field value.
-func (e DataValidationError) Field() string { return e.field }
-
-// Reason function returns reason value.
-func (e DataValidationError) Reason() string { return e.reason }
-
-// Cause function returns cause value.
-func (e DataValidationError) Cause() error { return e.cause }
-
-// Key function returns key value.
-func (e DataValidationError) Key() bool { return e.key }
-
-// ErrorName returns error name.
-func (e DataValidationError) ErrorName() string { return "DataValidationError" }
+	if all {
+		switch v := interface{}(m.GetDeletedUser()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, DataValidationError{
+					field:  "DeletedUser",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, DataValidationError{
+					field:  "DeletedUser",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetDeletedUser()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return DataValidationError{
+				field:  "DeletedUser",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
 
-// Error satisfies the builtin error interface
-func (e DataValidationError) Error() string {
-	cause := ""
-	if e.cause != nil {
-		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	if all {
+		switch v := interface{}(m.GetUserCache()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, DataValidationError{
+					field:  "UserCache",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, DataValidationError{
+					field:  "UserCache",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetUserCache()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return DataValidationError{
+				field:  "UserCache",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for SessionStore
+
+	if all {
+		switch v := interface{}(m.GetSessionSweepInterval()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, DataValidationError{
+					field:  "SessionSweepInterval",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, DataValidationError{
+					field:  "SessionSweepInterval",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetSessionSweepInterval()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return DataValidationError{
+				field:  "SessionSweepInterval",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for MaxSessionsPerUser
+
+	if all {
+		switch v := interface{}(m.GetEncryption()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, DataValidationError{
+					field:  "Encryption",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, DataValidationError{
+					field:  "Encryption",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetEncryption()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return DataValidationError{
+				field:  "Encryption",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return DataMultiError(errors)
+	}
+
+	return nil
+}
+
+// DataMultiError is an error wrapping multiple validation errors returned by
+// Data.ValidateAll() if the designated constraints aren't met.
+type DataMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DataMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DataMultiError) AllErrors() []error { return m }
+
+// DataValidationError is the validation error returned by Data.Validate if the
+// designated constraints aren't met.
+type DataValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DataValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DataValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DataValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DataValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DataValidationError) ErrorName() string { return "DataValidationError" }
+
+// Error satisfies the builtin error interface
+func (e DataValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
 	}
 
 	key := ""
@@ -909,28 +1232,1989 @@ func (m *Server_Metadata) validate(all bool) error {
 		return nil
 	}
 
-	var errors []error
-
-	// no validation rules for Name
-
-	// no validation rules for Version
-
-	// no validation rules for Env
+	var errors []error
+
+	// no validation rules for Name
+
+	// no validation rules for Version
+
+	// no validation rules for Env
+
+	if len(errors) > 0 {
+		return Server_MetadataMultiError(errors)
+	}
+
+	return nil
+}
+
+// Server_MetadataMultiError is an error wrapping multiple validation errors
+// returned by Server_Metadata.ValidateAll() if the designated constraints
+// aren't met.
+type Server_MetadataMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m Server_MetadataMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m Server_MetadataMultiError) AllErrors() []error { return m }
+
+// Server_MetadataValidationError is the validation error returned by
+// Server_Metadata.Validate if the designated constraints aren't met.
+type Server_MetadataValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e Server_MetadataValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e Server_MetadataValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e Server_MetadataValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e Server_MetadataValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e Server_MetadataValidationError) ErrorName() string { return "Server_MetadataValidationError" }
+
+// Error satisfies the builtin error interface
+func (e Server_MetadataValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sServer_Metadata.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = Server_MetadataValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = Server_MetadataValidationError{}
+
+// Validate checks the field values on Server_HTTP with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Server_HTTP) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Server_HTTP with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in Server_HTTPMultiError, or
+// nil if none found.
+func (m *Server_HTTP) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Server_HTTP) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Network
+
+	// no validation rules for Addr
+
+	if all {
+		switch v := interface{}(m.GetTimeout()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, Server_HTTPValidationError{
+					field:  "Timeout",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, Server_HTTPValidationError{
+					field:  "Timeout",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetTimeout()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return Server_HTTPValidationError{
+				field:  "Timeout",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return Server_HTTPMultiError(errors)
+	}
+
+	return nil
+}
+
+// Server_HTTPMultiError is an error wrapping multiple validation errors
+// returned by Server_HTTP.ValidateAll() if the designated constraints aren't met.
+type Server_HTTPMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m Server_HTTPMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m Server_HTTPMultiError) AllErrors() []error { return m }
+
+// Server_HTTPValidationError is the validation error returned by
+// Server_HTTP.Validate if the designated constraints aren't met.
+type Server_HTTPValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e Server_HTTPValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e Server_HTTPValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e Server_HTTPValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e Server_HTTPValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e Server_HTTPValidationError) ErrorName() string { return "Server_HTTPValidationError" }
+
+// Error satisfies the builtin error interface
+func (e Server_HTTPValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sServer_HTTP.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = Server_HTTPValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = Server_HTTPValidationError{}
+
+// Validate checks the field values on Server_GRPC with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Server_GRPC) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Server_GRPC with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in Server_GRPCMultiError, or
+// nil if none found.
+func (m *Server_GRPC) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Server_GRPC) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Network
+
+	// no validation rules for Addr
+
+	if all {
+		switch v := interface{}(m.GetTimeout()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, Server_GRPCValidationError{
+					field:  "Timeout",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, Server_GRPCValidationError{
+					field:  "Timeout",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetTimeout()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return Server_GRPCValidationError{
+				field:  "Timeout",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return Server_GRPCMultiError(errors)
+	}
+
+	return nil
+}
+
+// Server_GRPCMultiError is an error wrapping multiple validation errors
+// returned by Server_GRPC.ValidateAll() if the designated constraints aren't met.
+type Server_GRPCMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m Server_GRPCMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m Server_GRPCMultiError) AllErrors() []error { return m }
+
+// Server_GRPCValidationError is the validation error returned by
+// Server_GRPC.Validate if the designated constraints aren't met.
+type Server_GRPCValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e Server_GRPCValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e Server_GRPCValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e Server_GRPCValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e Server_GRPCValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e Server_GRPCValidationError) ErrorName() string { return "Server_GRPCValidationError" }
+
+// Error satisfies the builtin error interface
+func (e Server_GRPCValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sServer_GRPC.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = Server_GRPCValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = Server_GRPCValidationError{}
+
+// Validate checks the field values on Server_OTLP with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Server_OTLP) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Server_OTLP with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in Server_OTLPMultiError, or
+// nil if none found.
+func (m *Server_OTLP) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Server_OTLP) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Insecure
+
+	// no validation rules for GrpcEndpoint
+
+	if len(errors) > 0 {
+		return Server_OTLPMultiError(errors)
+	}
+
+	return nil
+}
+
+// Server_OTLPMultiError is an error wrapping multiple validation errors
+// returned by Server_OTLP.ValidateAll() if the designated constraints aren't met.
+type Server_OTLPMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m Server_OTLPMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m Server_OTLPMultiError) AllErrors() []error { return m }
+
+// Server_OTLPValidationError is the validation error returned by
+// Server_OTLP.Validate if the designated constraints aren't met.
+type Server_OTLPValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e Server_OTLPValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e Server_OTLPValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e Server_OTLPValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e Server_OTLPValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e Server_OTLPValidationError) ErrorName() string { return "Server_OTLPValidationError" }
+
+// Error satisfies the builtin error interface
+func (e Server_OTLPValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sServer_OTLP.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = Server_OTLPValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = Server_OTLPValidationError{}
+
+// Validate checks the field values on Server_Telemetry with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *Server_Telemetry) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Server_Telemetry with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// Server_TelemetryMultiError, or nil if none found.
+func (m *Server_Telemetry) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Server_Telemetry) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for OutputToConsole
+
+	if all {
+		switch v := interface{}(m.GetOtlp()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, Server_TelemetryValidationError{
+					field:  "Otlp",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, Server_TelemetryValidationError{
+					field:  "Otlp",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetOtlp()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return Server_TelemetryValidationError{
+				field:  "Otlp",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return Server_TelemetryMultiError(errors)
+	}
+
+	return nil
+}
+
+// Server_TelemetryMultiError is an error wrapping multiple validation errors
+// returned by Server_Telemetry.ValidateAll() if the designated constraints
+// aren't met.
+type Server_TelemetryMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m Server_TelemetryMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m Server_TelemetryMultiError) AllErrors() []error { return m }
+
+// Server_TelemetryValidationError is the validation error returned by
+// Server_Telemetry.Validate if the designated constraints aren't met.
+type Server_TelemetryValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e Server_TelemetryValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e Server_TelemetryValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e Server_TelemetryValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e Server_TelemetryValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e Server_TelemetryValidationError) ErrorName() string { return "Server_TelemetryValidationError" }
+
+// Error satisfies the builtin error interface
+func (e Server_TelemetryValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sServer_Telemetry.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = Server_TelemetryValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = Server_TelemetryValidationError{}
+
+// Validate checks the field values on Server_Idempotency with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *Server_Idempotency) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Server_Idempotency with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// Server_IdempotencyMultiError, or nil if none found.
+func (m *Server_Idempotency) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Server_Idempotency) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetWindow()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, Server_IdempotencyValidationError{
+					field:  "Window",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, Server_IdempotencyValidationError{
+					field:  "Window",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetWindow()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return Server_IdempotencyValidationError{
+				field:  "Window",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return Server_IdempotencyMultiError(errors)
+	}
+
+	return nil
+}
+
+// Server_IdempotencyMultiError is an error wrapping multiple validation errors
+// returned by Server_Idempotency.ValidateAll() if the designated constraints
+// aren't met.
+type Server_IdempotencyMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m Server_IdempotencyMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m Server_IdempotencyMultiError) AllErrors() []error { return m }
+
+// Server_IdempotencyValidationError is the validation error returned by
+// Server_Idempotency.Validate if the designated constraints aren't met.
+type Server_IdempotencyValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e Server_IdempotencyValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e Server_IdempotencyValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e Server_IdempotencyValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e Server_IdempotencyValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e Server_IdempotencyValidationError) ErrorName() string {
+	return "Server_IdempotencyValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e Server_IdempotencyValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sServer_Idempotency.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = Server_IdempotencyValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = Server_IdempotencyValidationError{}
+
+// Validate checks the field values on Server_Scim with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Server_Scim) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Server_Scim with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in Server_ScimMultiError, or
+// nil if none found.
+func (m *Server_Scim) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Server_Scim) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Enabled
+
+	// no validation rules for BearerToken
+
+	if len(errors) > 0 {
+		return Server_ScimMultiError(errors)
+	}
+
+	return nil
+}
+
+// Server_ScimMultiError is an error wrapping multiple validation errors
+// returned by Server_Scim.ValidateAll() if the designated constraints aren't met.
+type Server_ScimMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m Server_ScimMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m Server_ScimMultiError) AllErrors() []error { return m }
+
+// Server_ScimValidationError is the validation error returned by
+// Server_Scim.Validate if the designated constraints aren't met.
+type Server_ScimValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e Server_ScimValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e Server_ScimValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e Server_ScimValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e Server_ScimValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e Server_ScimValidationError) ErrorName() string { return "Server_ScimValidationError" }
+
+// Error satisfies the builtin error interface
+func (e Server_ScimValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sServer_Scim.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = Server_ScimValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = Server_ScimValidationError{}
+
+// Validate checks the field values on Server_Oidc with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Server_Oidc) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Server_Oidc with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in Server_OidcMultiError, or
+// nil if none found.
+func (m *Server_Oidc) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Server_Oidc) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Enabled
+
+	// no validation rules for Issuer
+
+	// no validation rules for SigningKeyFile
+
+	if all {
+		switch v := interface{}(m.GetCodeTtl()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, Server_OidcValidationError{
+					field:  "CodeTtl",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, Server_OidcValidationError{
+					field:  "CodeTtl",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCodeTtl()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return Server_OidcValidationError{
+				field:  "CodeTtl",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetIdTokenTtl()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, Server_OidcValidationError{
+					field:  "IdTokenTtl",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, Server_OidcValidationError{
+					field:  "IdTokenTtl",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetIdTokenTtl()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return Server_OidcValidationError{
+				field:  "IdTokenTtl",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetRefreshTokenTtl()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, Server_OidcValidationError{
+					field:  "RefreshTokenTtl",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, Server_OidcValidationError{
+					field:  "RefreshTokenTtl",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetRefreshTokenTtl()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return Server_OidcValidationError{
+				field:  "RefreshTokenTtl",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return Server_OidcMultiError(errors)
+	}
+
+	return nil
+}
+
+// Server_OidcMultiError is an error wrapping multiple validation errors
+// returned by Server_Oidc.ValidateAll() if the designated constraints aren't met.
+type Server_OidcMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m Server_OidcMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m Server_OidcMultiError) AllErrors() []error { return m }
+
+// Server_OidcValidationError is the validation error returned by
+// Server_Oidc.Validate if the designated constraints aren't met.
+type Server_OidcValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e Server_OidcValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e Server_OidcValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e Server_OidcValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e Server_OidcValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e Server_OidcValidationError) ErrorName() string { return "Server_OidcValidationError" }
+
+// Error satisfies the builtin error interface
+func (e Server_OidcValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sServer_Oidc.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = Server_OidcValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = Server_OidcValidationError{}
+
+// Validate checks the field values on Server_Federation with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *Server_Federation) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Server_Federation with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// Server_FederationMultiError, or nil if none found.
+func (m *Server_Federation) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Server_Federation) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for BaseUrl
+
+	// no validation rules for SuccessRedirectUrl
+
+	for idx, item := range m.GetProviders() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, Server_FederationValidationError{
+						field:  fmt.Sprintf("Providers[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, Server_FederationValidationError{
+						field:  fmt.Sprintf("Providers[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return Server_FederationValidationError{
+					field:  fmt.Sprintf("Providers[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return Server_FederationMultiError(errors)
+	}
+
+	return nil
+}
+
+// Server_FederationMultiError is an error wrapping multiple validation errors
+// returned by Server_Federation.ValidateAll() if the designated constraints
+// aren't met.
+type Server_FederationMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m Server_FederationMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m Server_FederationMultiError) AllErrors() []error { return m }
+
+// Server_FederationValidationError is the validation error returned by
+// Server_Federation.Validate if the designated constraints aren't met.
+type Server_FederationValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e Server_FederationValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e Server_FederationValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e Server_FederationValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e Server_FederationValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e Server_FederationValidationError) ErrorName() string {
+	return "Server_FederationValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e Server_FederationValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sServer_Federation.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = Server_FederationValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = Server_FederationValidationError{}
+
+// Validate checks the field values on Server_Auth with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Server_Auth) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Server_Auth with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in Server_AuthMultiError, or
+// nil if none found.
+func (m *Server_Auth) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Server_Auth) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetLdap()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, Server_AuthValidationError{
+					field:  "Ldap",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, Server_AuthValidationError{
+					field:  "Ldap",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetLdap()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return Server_AuthValidationError{
+				field:  "Ldap",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return Server_AuthMultiError(errors)
+	}
+
+	return nil
+}
+
+// Server_AuthMultiError is an error wrapping multiple validation errors
+// returned by Server_Auth.ValidateAll() if the designated constraints aren't met.
+type Server_AuthMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m Server_AuthMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m Server_AuthMultiError) AllErrors() []error { return m }
+
+// Server_AuthValidationError is the validation error returned by
+// Server_Auth.Validate if the designated constraints aren't met.
+type Server_AuthValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e Server_AuthValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e Server_AuthValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e Server_AuthValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e Server_AuthValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e Server_AuthValidationError) ErrorName() string { return "Server_AuthValidationError" }
+
+// Error satisfies the builtin error interface
+func (e Server_AuthValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sServer_Auth.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = Server_AuthValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = Server_AuthValidationError{}
+
+// Validate checks the field values on Server_Federation_Provider with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *Server_Federation_Provider) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Server_Federation_Provider with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// Server_Federation_ProviderMultiError, or nil if none found.
+func (m *Server_Federation_Provider) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Server_Federation_Provider) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Name
+
+	// no validation rules for Issuer
+
+	// no validation rules for ClientId
+
+	// no validation rules for ClientSecret
+
+	// no validation rules for UsernameClaim
+
+	// no validation rules for AutoProvision
+
+	// no validation rules for LinkByUsername
+
+	// no validation rules for RoleClaim
+
+	if len(errors) > 0 {
+		return Server_Federation_ProviderMultiError(errors)
+	}
+
+	return nil
+}
+
+// Server_Federation_ProviderMultiError is an error wrapping multiple
+// validation errors returned by Server_Federation_Provider.ValidateAll() if
+// the designated constraints aren't met.
+type Server_Federation_ProviderMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m Server_Federation_ProviderMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m Server_Federation_ProviderMultiError) AllErrors() []error { return m }
+
+// Server_Federation_ProviderValidationError is the validation error returned
+// by Server_Federation_Provider.Validate if the designated constraints aren't met.
+type Server_Federation_ProviderValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e Server_Federation_ProviderValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e Server_Federation_ProviderValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e Server_Federation_ProviderValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e Server_Federation_ProviderValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e Server_Federation_ProviderValidationError) ErrorName() string {
+	return "Server_Federation_ProviderValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e Server_Federation_ProviderValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sServer_Federation_Provider.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = Server_Federation_ProviderValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = Server_Federation_ProviderValidationError{}
+
+// Validate checks the field values on Server_Auth_Ldap with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *Server_Auth_Ldap) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Server_Auth_Ldap with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// Server_Auth_LdapMultiError, or nil if none found.
+func (m *Server_Auth_Ldap) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Server_Auth_Ldap) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Url
+
+	// no validation rules for StartTls
+
+	// no validation rules for InsecureSkipVerify
+
+	// no validation rules for CaFile
+
+	// no validation rules for BindDn
+
+	// no validation rules for BindPassword
+
+	// no validation rules for BaseDn
+
+	// no validation rules for UserFilter
+
+	// no validation rules for EmailAttribute
+
+	// no validation rules for PhoneAttribute
+
+	// no validation rules for GroupAttribute
+
+	// no validation rules for AutoProvision
+
+	if all {
+		switch v := interface{}(m.GetTimeout()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, Server_Auth_LdapValidationError{
+					field:  "Timeout",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, Server_Auth_LdapValidationError{
+					field:  "Timeout",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetTimeout()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return Server_Auth_LdapValidationError{
+				field:  "Timeout",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return Server_Auth_LdapMultiError(errors)
+	}
+
+	return nil
+}
+
+// Server_Auth_LdapMultiError is an error wrapping multiple validation errors
+// returned by Server_Auth_Ldap.ValidateAll() if the designated constraints
+// aren't met.
+type Server_Auth_LdapMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m Server_Auth_LdapMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m Server_Auth_LdapMultiError) AllErrors() []error { return m }
+
+// Server_Auth_LdapValidationError is the validation error returned by
+// Server_Auth_Ldap.Validate if the designated constraints aren't met.
+type Server_Auth_LdapValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e Server_Auth_LdapValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e Server_Auth_LdapValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e Server_Auth_LdapValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e Server_Auth_LdapValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e Server_Auth_LdapValidationError) ErrorName() string { return "Server_Auth_LdapValidationError" }
+
+// Error satisfies the builtin error interface
+func (e Server_Auth_LdapValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sServer_Auth_Ldap.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = Server_Auth_LdapValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = Server_Auth_LdapValidationError{}
+
+// Validate checks the field values on Data_Database with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Data_Database) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Data_Database with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in Data_DatabaseMultiError, or
+// nil if none found.
+func (m *Data_Database) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Data_Database) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if _, ok := _Data_Database_Driver_NotInLookup[m.GetDriver()]; ok {
+		err := Data_DatabaseValidationError{
+			field:  "Driver",
+			reason: "value must not be in list [DATABASE_DRIVER_UNSPECIFIED]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if _, ok := DatabaseDriver_name[int32(m.GetDriver())]; !ok {
+		err := Data_DatabaseValidationError{
+			field:  "Driver",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetName()) < 1 {
+		err := Data_DatabaseValidationError{
+			field:  "Name",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Dsn
+
+	// no validation rules for MigrateOnStart
+
+	if all {
+		switch v := interface{}(m.GetMigrateLockTimeout()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, Data_DatabaseValidationError{
+					field:  "MigrateLockTimeout",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, Data_DatabaseValidationError{
+					field:  "MigrateLockTimeout",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetMigrateLockTimeout()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return Data_DatabaseValidationError{
+				field:  "MigrateLockTimeout",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for ReplicaPolicy
+
+	if all {
+		switch v := interface{}(m.GetReplicaHealthCheckInterval()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, Data_DatabaseValidationError{
+					field:  "ReplicaHealthCheckInterval",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, Data_DatabaseValidationError{
+					field:  "ReplicaHealthCheckInterval",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetReplicaHealthCheckInterval()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return Data_DatabaseValidationError{
+				field:  "ReplicaHealthCheckInterval",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return Data_DatabaseMultiError(errors)
+	}
+
+	return nil
+}
+
+// Data_DatabaseMultiError is an error wrapping multiple validation errors
+// returned by Data_Database.ValidateAll() if the designated constraints
+// aren't met.
+type Data_DatabaseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m Data_DatabaseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m Data_DatabaseMultiError) AllErrors() []error { return m }
+
+// Data_DatabaseValidationError is the validation error returned by
+// Data_Database.Validate if the designated constraints aren't met.
+type Data_DatabaseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e Data_DatabaseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e Data_DatabaseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e Data_DatabaseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e Data_DatabaseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e Data_DatabaseValidationError) ErrorName() string { return "Data_DatabaseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e Data_DatabaseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sData_Database.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = Data_DatabaseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = Data_DatabaseValidationError{}
+
+var _Data_Database_Driver_NotInLookup = map[DatabaseDriver]struct{}{
+	0: {},
+}
+
+// Validate checks the field values on Data_Redis with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Data_Redis) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Data_Redis with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in Data_RedisMultiError, or
+// nil if none found.
+func (m *Data_Redis) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Data_Redis) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Network
+
+	// no validation rules for Password
+
+	// no validation rules for Db
+
+	if all {
+		switch v := interface{}(m.GetDialTimeout()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, Data_RedisValidationError{
+					field:  "DialTimeout",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, Data_RedisValidationError{
+					field:  "DialTimeout",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetDialTimeout()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return Data_RedisValidationError{
+				field:  "DialTimeout",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetReadTimeout()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, Data_RedisValidationError{
+					field:  "ReadTimeout",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, Data_RedisValidationError{
+					field:  "ReadTimeout",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetReadTimeout()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return Data_RedisValidationError{
+				field:  "ReadTimeout",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetWriteTimeout()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, Data_RedisValidationError{
+					field:  "WriteTimeout",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, Data_RedisValidationError{
+					field:  "WriteTimeout",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetWriteTimeout()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return Data_RedisValidationError{
+				field:  "WriteTimeout",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Username
+
+	// no validation rules for MasterName
+
+	// no validation rules for SentinelUsername
+
+	// no validation rules for SentinelPassword
+
+	// no validation rules for Cluster
+
+	if all {
+		switch v := interface{}(m.GetTls()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, Data_RedisValidationError{
+					field:  "Tls",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, Data_RedisValidationError{
+					field:  "Tls",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetTls()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return Data_RedisValidationError{
+				field:  "Tls",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for PoolSize
+
+	// no validation rules for MinIdleConns
+
+	// no validation rules for MaxIdleConns
+
+	if all {
+		switch v := interface{}(m.GetPoolTimeout()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, Data_RedisValidationError{
+					field:  "PoolTimeout",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, Data_RedisValidationError{
+					field:  "PoolTimeout",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetPoolTimeout()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return Data_RedisValidationError{
+				field:  "PoolTimeout",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetConnMaxIdleTime()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, Data_RedisValidationError{
+					field:  "ConnMaxIdleTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, Data_RedisValidationError{
+					field:  "ConnMaxIdleTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetConnMaxIdleTime()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return Data_RedisValidationError{
+				field:  "ConnMaxIdleTime",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for KeyPrefix
 
 	if len(errors) > 0 {
-		return Server_MetadataMultiError(errors)
+		return Data_RedisMultiError(errors)
 	}
 
 	return nil
 }
 
-// Server_MetadataMultiError is an error wrapping multiple validation errors
-// returned by Server_Metadata.ValidateAll() if the designated constraints
-// aren't met.
-type Server_MetadataMultiError []error
+// Data_RedisMultiError is an error wrapping multiple validation errors
+// returned by Data_Redis.ValidateAll() if the designated constraints aren't met.
+type Data_RedisMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m Server_MetadataMultiError) Error() string {
+func (m Data_RedisMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -939,11 +3223,11 @@ func (m Server_MetadataMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m Server_MetadataMultiError) AllErrors() []error { return m }
+func (m Data_RedisMultiError) AllErrors() []error { return m }
 
-// Server_MetadataValidationError is the validation error returned by
-// Server_Metadata.Validate if the designated constraints aren't met.
-type Server_MetadataValidationError struct {
+// Data_RedisValidationError is the validation error returned by
+// Data_Redis.Validate if the designated constraints aren't met.
+type Data_RedisValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -951,22 +3235,22 @@ type Server_MetadataValidationError struct {
 }
 
 // Field function returns field value.
-func (e Server_MetadataValidationError) Field() string { return e.field }
+func (e Data_RedisValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e Server_MetadataValidationError) Reason() string { return e.reason }
+func (e Data_RedisValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e Server_MetadataValidationError) Cause() error { return e.cause }
+func (e Data_RedisValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e Server_MetadataValidationError) Key() bool { return e.key }
+func (e Data_RedisValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e Server_MetadataValidationError) ErrorName() string { return "Server_MetadataValidationError" }
+func (e Data_RedisValidationError) ErrorName() string { return "Data_RedisValidationError" }
 
 // Error satisfies the builtin error interface
-func (e Server_MetadataValidationError) Error() string {
+func (e Data_RedisValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -978,14 +3262,14 @@ func (e Server_MetadataValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sServer_Metadata.%s: %s%s",
+		"invalid %sData_Redis.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = Server_MetadataValidationError{}
+var _ error = Data_RedisValidationError{}
 
 var _ interface {
 	Field() string
@@ -993,76 +3277,80 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = Server_MetadataValidationError{}
+} = Data_RedisValidationError{}
 
-// Validate checks the field values on Server_HTTP with the rules defined in
+// Validate checks the field values on Data_Outbox with the rules defined in
 // the proto definition for this message. If any rules are violated, the first
 // error encountered is returned, or nil if there are no violations.
-func (m *Server_HTTP) Validate() error {
+func (m *Data_Outbox) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on Server_HTTP with the rules defined in
+// ValidateAll checks the field values on Data_Outbox with the rules defined in
 // the proto definition for this message. If any rules are violated, the
-// result is a list of violation errors wrapped in Server_HTTPMultiError, or
+// result is a list of violation errors wrapped in Data_OutboxMultiError, or
 // nil if none found.
-func (m *Server_HTTP) ValidateAll() error {
+func (m *Data_Outbox) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *Server_HTTP) validate(all bool) error {
+func (m *Data_Outbox) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Network
-
-	// no validation rules for Addr
+	// no validation rules for Broker
 
 	if all {
-		switch v := interface{}(m.GetTimeout()).(type) {
+		switch v := interface{}(m.GetPollInterval()).(type) {
 		case interface{ ValidateAll() error }:
 			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, Server_HTTPValidationError{
-					field:  "Timeout",
+				errors = append(errors, Data_OutboxValidationError{
+					field:  "PollInterval",
 					reason: "embedded message failed validation",
 					cause:  err,
 				})
 			}
 		case interface{ Validate() error }:
 			if err := v.Validate(); err != nil {
-				errors = append(errors, Server_HTTPValidationError{
-					field:  "Timeout",
+				errors = append(errors, Data_OutboxValidationError{
+					field:  "PollInterval",
 					reason: "embedded message failed validation",
 					cause:  err,
 				})
 			}
 		}
-	} else if v, ok := interface{}(m.GetTimeout()).(interface{ Validate() error }); ok {
+	} else if v, ok := interface{}(m.GetPollInterval()).(interface{ Validate() error }); ok {
 		if err := v.Validate(); err != nil {
-			return Server_HTTPValidationError{
-				field:  "Timeout",
+			return Data_OutboxValidationError{
+				field:  "PollInterval",
 				reason: "embedded message failed validation",
 				cause:  err,
 			}
 		}
 	}
 
+	// no validation rules for BatchSize
+
+	// no validation rules for StreamPrefix
+
+	// no validation rules for StreamMaxLen
+
 	if len(errors) > 0 {
-		return Server_HTTPMultiError(errors)
+		return Data_OutboxMultiError(errors)
 	}
 
 	return nil
 }
 
-// Server_HTTPMultiError is an error wrapping multiple validation errors
-// returned by Server_HTTP.ValidateAll() if the designated constraints aren't met.
-type Server_HTTPMultiError []error
+// Data_OutboxMultiError is an error wrapping multiple validation errors
+// returned by Data_Outbox.ValidateAll() if the designated constraints aren't met.
+type Data_OutboxMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m Server_HTTPMultiError) Error() string {
+func (m Data_OutboxMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1071,11 +3359,11 @@ func (m Server_HTTPMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m Server_HTTPMultiError) AllErrors() []error { return m }
+func (m Data_OutboxMultiError) AllErrors() []error { return m }
 
-// Server_HTTPValidationError is the validation error returned by
-// Server_HTTP.Validate if the designated constraints aren't met.
-type Server_HTTPValidationError struct {
+// Data_OutboxValidationError is the validation error returned by
+// Data_Outbox.Validate if the designated constraints aren't met.
+type Data_OutboxValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1083,22 +3371,22 @@ type Server_HTTPValidationError struct {
 }
 
 // Field function returns field value.
-func (e Server_HTTPValidationError) Field() string { return e.field }
+func (e Data_OutboxValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e Server_HTTPValidationError) Reason() string { return e.reason }
+func (e Data_OutboxValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e Server_HTTPValidationError) Cause() error { return e.cause }
+func (e Data_OutboxValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e Server_HTTPValidationError) Key() bool { return e.key }
+func (e Data_OutboxValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e Server_HTTPValidationError) ErrorName() string { return "Server_HTTPValidationError" }
+func (e Data_OutboxValidationError) ErrorName() string { return "Data_OutboxValidationError" }
 
 // Error satisfies the builtin error interface
-func (e Server_HTTPValidationError) Error() string {
+func (e Data_OutboxValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1110,14 +3398,14 @@ func (e Server_HTTPValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sServer_HTTP.%s: %s%s",
+		"invalid %sData_Outbox.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = Server_HTTPValidationError{}
+var _ error = Data_OutboxValidationError{}
 
 var _ interface {
 	Field() string
@@ -1125,39 +3413,68 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = Server_HTTPValidationError{}
+} = Data_OutboxValidationError{}
 
-// Validate checks the field values on Server_GRPC with the rules defined in
+// Validate checks the field values on Data_Webhook with the rules defined in
 // the proto definition for this message. If any rules are violated, the first
 // error encountered is returned, or nil if there are no violations.
-func (m *Server_GRPC) Validate() error {
+func (m *Data_Webhook) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on Server_GRPC with the rules defined in
-// the proto definition for this message. If any rules are violated, the
-// result is a list of violation errors wrapped in Server_GRPCMultiError, or
+// ValidateAll checks the field values on Data_Webhook with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in Data_WebhookMultiError, or
 // nil if none found.
-func (m *Server_GRPC) ValidateAll() error {
+func (m *Data_Webhook) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *Server_GRPC) validate(all bool) error {
+func (m *Data_Webhook) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Network
+	if all {
+		switch v := interface{}(m.GetPollInterval()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, Data_WebhookValidationError{
+					field:  "PollInterval",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, Data_WebhookValidationError{
+					field:  "PollInterval",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetPollInterval()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return Data_WebhookValidationError{
+				field:  "PollInterval",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
 
-	// no validation rules for Addr
+	// no validation rules for BatchSize
+
+	// no validation rules for MaxAttempts
 
 	if all {
 		switch v := interface{}(m.GetTimeout()).(type) {
 		case interface{ ValidateAll() error }:
 			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, Server_GRPCValidationError{
+				errors = append(errors, Data_WebhookValidationError{
 					field:  "Timeout",
 					reason: "embedded message failed validation",
 					cause:  err,
@@ -1165,7 +3482,7 @@ func (m *Server_GRPC) validate(all bool) error {
 			}
 		case interface{ Validate() error }:
 			if err := v.Validate(); err != nil {
-				errors = append(errors, Server_GRPCValidationError{
+				errors = append(errors, Data_WebhookValidationError{
 					field:  "Timeout",
 					reason: "embedded message failed validation",
 					cause:  err,
@@ -1174,7 +3491,7 @@ func (m *Server_GRPC) validate(all bool) error {
 		}
 	} else if v, ok := interface{}(m.GetTimeout()).(interface{ Validate() error }); ok {
 		if err := v.Validate(); err != nil {
-			return Server_GRPCValidationError{
+			return Data_WebhookValidationError{
 				field:  "Timeout",
 				reason: "embedded message failed validation",
 				cause:  err,
@@ -1182,19 +3499,77 @@ func (m *Server_GRPC) validate(all bool) error {
 		}
 	}
 
+	if all {
+		switch v := interface{}(m.GetInitialBackoff()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, Data_WebhookValidationError{
+					field:  "InitialBackoff",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, Data_WebhookValidationError{
+					field:  "InitialBackoff",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetInitialBackoff()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return Data_WebhookValidationError{
+				field:  "InitialBackoff",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetMaxBackoff()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, Data_WebhookValidationError{
+					field:  "MaxBackoff",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, Data_WebhookValidationError{
+					field:  "MaxBackoff",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetMaxBackoff()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return Data_WebhookValidationError{
+				field:  "MaxBackoff",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
 	if len(errors) > 0 {
-		return Server_GRPCMultiError(errors)
+		return Data_WebhookMultiError(errors)
 	}
 
 	return nil
 }
 
-// Server_GRPCMultiError is an error wrapping multiple validation errors
-// returned by Server_GRPC.ValidateAll() if the designated constraints aren't met.
-type Server_GRPCMultiError []error
+// Data_WebhookMultiError is an error wrapping multiple validation errors
+// returned by Data_Webhook.ValidateAll() if the designated constraints aren't met.
+type Data_WebhookMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m Server_GRPCMultiError) Error() string {
+func (m Data_WebhookMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1203,11 +3578,11 @@ func (m Server_GRPCMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m Server_GRPCMultiError) AllErrors() []error { return m }
+func (m Data_WebhookMultiError) AllErrors() []error { return m }
 
-// Server_GRPCValidationError is the validation error returned by
-// Server_GRPC.Validate if the designated constraints aren't met.
-type Server_GRPCValidationError struct {
+// Data_WebhookValidationError is the validation error returned by
+// Data_Webhook.Validate if the designated constraints aren't met.
+type Data_WebhookValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1215,22 +3590,22 @@ type Server_GRPCValidationError struct {
 }
 
 // Field function returns field value.
-func (e Server_GRPCValidationError) Field() string { return e.field }
+func (e Data_WebhookValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e Server_GRPCValidationError) Reason() string { return e.reason }
+func (e Data_WebhookValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e Server_GRPCValidationError) Cause() error { return e.cause }
+func (e Data_WebhookValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e Server_GRPCValidationError) Key() bool { return e.key }
+func (e Data_WebhookValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e Server_GRPCValidationError) ErrorName() string { return "Server_GRPCValidationError" }
+func (e Data_WebhookValidationError) ErrorName() string { return "Data_WebhookValidationError" }
 
 // Error satisfies the builtin error interface
-func (e Server_GRPCValidationError) Error() string {
+func (e Data_WebhookValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1242,14 +3617,14 @@ func (e Server_GRPCValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sServer_GRPC.%s: %s%s",
+		"invalid %sData_Webhook.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = Server_GRPCValidationError{}
+var _ error = Data_WebhookValidationError{}
 
 var _ interface {
 	Field() string
@@ -1257,47 +3632,104 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = Server_GRPCValidationError{}
+} = Data_WebhookValidationError{}
 
-// Validate checks the field values on Server_OTLP with the rules defined in
-// the proto definition for this message. If any rules are violated, the first
-// error encountered is returned, or nil if there are no violations.
-func (m *Server_OTLP) Validate() error {
+// Validate checks the field values on Data_DeletedUser with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *Data_DeletedUser) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on Server_OTLP with the rules defined in
-// the proto definition for this message. If any rules are violated, the
-// result is a list of violation errors wrapped in Server_OTLPMultiError, or
-// nil if none found.
-func (m *Server_OTLP) ValidateAll() error {
+// ValidateAll checks the field values on Data_DeletedUser with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// Data_DeletedUserMultiError, or nil if none found.
+func (m *Data_DeletedUser) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *Server_OTLP) validate(all bool) error {
+func (m *Data_DeletedUser) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Insecure
+	if all {
+		switch v := interface{}(m.GetRetention()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, Data_DeletedUserValidationError{
+					field:  "Retention",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, Data_DeletedUserValidationError{
+					field:  "Retention",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetRetention()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return Data_DeletedUserValidationError{
+				field:  "Retention",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
 
-	// no validation rules for GrpcEndpoint
+	if all {
+		switch v := interface{}(m.GetPurgeInterval()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, Data_DeletedUserValidationError{
+					field:  "PurgeInterval",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, Data_DeletedUserValidationError{
+					field:  "PurgeInterval",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetPurgeInterval()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return Data_DeletedUserValidationError{
+				field:  "PurgeInterval",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for PurgeBatchSize
 
 	if len(errors) > 0 {
-		return Server_OTLPMultiError(errors)
+		return Data_DeletedUserMultiError(errors)
 	}
 
 	return nil
 }
 
-// Server_OTLPMultiError is an error wrapping multiple validation errors
-// returned by Server_OTLP.ValidateAll() if the designated constraints aren't met.
-type Server_OTLPMultiError []error
+// Data_DeletedUserMultiError is an error wrapping multiple validation errors
+// returned by Data_DeletedUser.ValidateAll() if the designated constraints
+// aren't met.
+type Data_DeletedUserMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m Server_OTLPMultiError) Error() string {
+func (m Data_DeletedUserMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1306,11 +3738,11 @@ func (m Server_OTLPMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m Server_OTLPMultiError) AllErrors() []error { return m }
+func (m Data_DeletedUserMultiError) AllErrors() []error { return m }
 
-// Server_OTLPValidationError is the validation error returned by
-// Server_OTLP.Validate if the designated constraints aren't met.
-type Server_OTLPValidationError struct {
+// Data_DeletedUserValidationError is the validation error returned by
+// Data_DeletedUser.Validate if the designated constraints aren't met.
+type Data_DeletedUserValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1318,22 +3750,22 @@ type Server_OTLPValidationError struct {
 }
 
 // Field function returns field value.
-func (e Server_OTLPValidationError) Field() string { return e.field }
+func (e Data_DeletedUserValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e Server_OTLPValidationError) Reason() string { return e.reason }
+func (e Data_DeletedUserValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e Server_OTLPValidationError) Cause() error { return e.cause }
+func (e Data_DeletedUserValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e Server_OTLPValidationError) Key() bool { return e.key }
+func (e Data_DeletedUserValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e Server_OTLPValidationError) ErrorName() string { return "Server_OTLPValidationError" }
+func (e Data_DeletedUserValidationError) ErrorName() string { return "Data_DeletedUserValidationError" }
 
 // Error satisfies the builtin error interface
-func (e Server_OTLPValidationError) Error() string {
+func (e Data_DeletedUserValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1345,14 +3777,14 @@ func (e Server_OTLPValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sServer_OTLP.%s: %s%s",
+		"invalid %sData_DeletedUser.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = Server_OTLPValidationError{}
+var _ error = Data_DeletedUserValidationError{}
 
 var _ interface {
 	Field() string
@@ -1360,75 +3792,106 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = Server_OTLPValidationError{}
+} = Data_DeletedUserValidationError{}
 
-// Validate checks the field values on Server_Telemetry with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// first error encountered is returned, or nil if there are no violations.
-func (m *Server_Telemetry) Validate() error {
+// Validate checks the field values on Data_UserCache with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Data_UserCache) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on Server_Telemetry with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, the result is a list of violation errors wrapped in
-// Server_TelemetryMultiError, or nil if none found.
-func (m *Server_Telemetry) ValidateAll() error {
+// ValidateAll checks the field values on Data_UserCache with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in Data_UserCacheMultiError,
+// or nil if none found.
+func (m *Data_UserCache) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *Server_Telemetry) validate(all bool) error {
+func (m *Data_UserCache) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for OutputToConsole
+	// no validation rules for Enabled
 
 	if all {
-		switch v := interface{}(m.GetOtlp()).(type) {
+		switch v := interface{}(m.GetTtl()).(type) {
 		case interface{ ValidateAll() error }:
 			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, Server_TelemetryValidationError{
-					field:  "Otlp",
+				errors = append(errors, Data_UserCacheValidationError{
+					field:  "Ttl",
 					reason: "embedded message failed validation",
 					cause:  err,
 				})
 			}
 		case interface{ Validate() error }:
 			if err := v.Validate(); err != nil {
-				errors = append(errors, Server_TelemetryValidationError{
-					field:  "Otlp",
+				errors = append(errors, Data_UserCacheValidationError{
+					field:  "Ttl",
 					reason: "embedded message failed validation",
 					cause:  err,
 				})
 			}
 		}
-	} else if v, ok := interface{}(m.GetOtlp()).(interface{ Validate() error }); ok {
+	} else if v, ok := interface{}(m.GetTtl()).(interface{ Validate() error }); ok {
 		if err := v.Validate(); err != nil {
-			return Server_TelemetryValidationError{
-				field:  "Otlp",
+			return Data_UserCacheValidationError{
+				field:  "Ttl",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetLocalTtl()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, Data_UserCacheValidationError{
+					field:  "LocalTtl",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, Data_UserCacheValidationError{
+					field:  "LocalTtl",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetLocalTtl()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return Data_UserCacheValidationError{
+				field:  "LocalTtl",
 				reason: "embedded message failed validation",
 				cause:  err,
 			}
 		}
 	}
 
+	// no validation rules for LocalSize
+
 	if len(errors) > 0 {
-		return Server_TelemetryMultiError(errors)
+		return Data_UserCacheMultiError(errors)
 	}
 
 	return nil
 }
 
-// Server_TelemetryMultiError is an error wrapping multiple validation errors
-// returned by Server_Telemetry.ValidateAll() if the designated constraints
+// Data_UserCacheMultiError is an error wrapping multiple validation errors
+// returned by Data_UserCache.ValidateAll() if the designated constraints
 // aren't met.
-type Server_TelemetryMultiError []error
+type Data_UserCacheMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m Server_TelemetryMultiError) Error() string {
+func (m Data_UserCacheMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1437,11 +3900,11 @@ func (m Server_TelemetryMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m Server_TelemetryMultiError) AllErrors() []error { return m }
+func (m Data_UserCacheMultiError) AllErrors() []error { return m }
 
-// Server_TelemetryValidationError is the validation error returned by
-// Server_Telemetry.Validate if the designated constraints aren't met.
-type Server_TelemetryValidationError struct {
+// Data_UserCacheValidationError is the validation error returned by
+// Data_UserCache.Validate if the designated constraints aren't met.
+type Data_UserCacheValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1449,22 +3912,22 @@ type Server_TelemetryValidationError struct {
 }
 
 // Field function returns field value.
-func (e Server_TelemetryValidationError) Field() string { return e.field }
+func (e Data_UserCacheValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e Server_TelemetryValidationError) Reason() string { return e.reason }
+func (e Data_UserCacheValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e Server_TelemetryValidationError) Cause() error { return e.cause }
+func (e Data_UserCacheValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e Server_TelemetryValidationError) Key() bool { return e.key }
+func (e Data_UserCacheValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e Server_TelemetryValidationError) ErrorName() string { return "Server_TelemetryValidationError" }
+func (e Data_UserCacheValidationError) ErrorName() string { return "Data_UserCacheValidationError" }
 
 // Error satisfies the builtin error interface
-func (e Server_TelemetryValidationError) Error() string {
+func (e Data_UserCacheValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1476,14 +3939,14 @@ func (e Server_TelemetryValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sServer_Telemetry.%s: %s%s",
+		"invalid %sData_UserCache.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = Server_TelemetryValidationError{}
+var _ error = Data_UserCacheValidationError{}
 
 var _ interface {
 	Field() string
@@ -1491,79 +3954,46 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = Server_TelemetryValidationError{}
+} = Data_UserCacheValidationError{}
 
-// Validate checks the field values on Data_Database with the rules defined in
-// the proto definition for this message. If any rules are violated, the first
-// error encountered is returned, or nil if there are no violations.
-func (m *Data_Database) Validate() error {
+// Validate checks the field values on Data_Encryption with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *Data_Encryption) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on Data_Database with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// result is a list of violation errors wrapped in Data_DatabaseMultiError, or
-// nil if none found.
-func (m *Data_Database) ValidateAll() error {
+// ValidateAll checks the field values on Data_Encryption with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// Data_EncryptionMultiError, or nil if none found.
+func (m *Data_Encryption) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *Data_Database) validate(all bool) error {
+func (m *Data_Encryption) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if _, ok := _Data_Database_Driver_NotInLookup[m.GetDriver()]; ok {
-		err := Data_DatabaseValidationError{
-			field:  "Driver",
-			reason: "value must not be in list [DATABASE_DRIVER_UNSPECIFIED]",
-		}
-		if !all {
-			return err
-		}
-		errors = append(errors, err)
-	}
-
-	if _, ok := DatabaseDriver_name[int32(m.GetDriver())]; !ok {
-		err := Data_DatabaseValidationError{
-			field:  "Driver",
-			reason: "value must be one of the defined enum values",
-		}
-		if !all {
-			return err
-		}
-		errors = append(errors, err)
-	}
-
-	if utf8.RuneCountInString(m.GetName()) < 1 {
-		err := Data_DatabaseValidationError{
-			field:  "Name",
-			reason: "value length must be at least 1 runes",
-		}
-		if !all {
-			return err
-		}
-		errors = append(errors, err)
-	}
-
-	// no validation rules for Dsn
+	// no validation rules for KeyFile
 
 	if len(errors) > 0 {
-		return Data_DatabaseMultiError(errors)
+		return Data_EncryptionMultiError(errors)
 	}
 
 	return nil
 }
 
-// Data_DatabaseMultiError is an error wrapping multiple validation errors
-// returned by Data_Database.ValidateAll() if the designated constraints
+// Data_EncryptionMultiError is an error wrapping multiple validation errors
+// returned by Data_Encryption.ValidateAll() if the designated constraints
 // aren't met.
-type Data_DatabaseMultiError []error
+type Data_EncryptionMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m Data_DatabaseMultiError) Error() string {
+func (m Data_EncryptionMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1572,11 +4002,11 @@ func (m Data_DatabaseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m Data_DatabaseMultiError) AllErrors() []error { return m }
+func (m Data_EncryptionMultiError) AllErrors() []error { return m }
 
-// Data_DatabaseValidationError is the validation error returned by
-// Data_Database.Validate if the designated constraints aren't met.
-type Data_DatabaseValidationError struct {
+// Data_EncryptionValidationError is the validation error returned by
+// Data_Encryption.Validate if the designated constraints aren't met.
+type Data_EncryptionValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1584,22 +4014,22 @@ type Data_DatabaseValidationError struct {
 }
 
 // Field function returns field value.
-func (e Data_DatabaseValidationError) Field() string { return e.field }
+func (e Data_EncryptionValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e Data_DatabaseValidationError) Reason() string { return e.reason }
+func (e Data_EncryptionValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e Data_DatabaseValidationError) Cause() error { return e.cause }
+func (e Data_EncryptionValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e Data_DatabaseValidationError) Key() bool { return e.key }
+func (e Data_EncryptionValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e Data_DatabaseValidationError) ErrorName() string { return "Data_DatabaseValidationError" }
+func (e Data_EncryptionValidationError) ErrorName() string { return "Data_EncryptionValidationError" }
 
 // Error satisfies the builtin error interface
-func (e Data_DatabaseValidationError) Error() string {
+func (e Data_EncryptionValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1611,14 +4041,14 @@ func (e Data_DatabaseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sData_Database.%s: %s%s",
+		"invalid %sData_Encryption.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = Data_DatabaseValidationError{}
+var _ error = Data_EncryptionValidationError{}
 
 var _ interface {
 	Field() string
@@ -1626,140 +4056,56 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = Data_DatabaseValidationError{}
-
-var _Data_Database_Driver_NotInLookup = map[DatabaseDriver]struct{}{
-	0: {},
-}
+} = Data_EncryptionValidationError{}
 
-// Validate checks the field values on Data_Redis with the rules defined in the
-// proto definition for this message. If any rules are violated, the first
+// Validate checks the field values on Data_Redis_TLS with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
 // error encountered is returned, or nil if there are no violations.
-func (m *Data_Redis) Validate() error {
+func (m *Data_Redis_TLS) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on Data_Redis with the rules defined in
-// the proto definition for this message. If any rules are violated, the
-// result is a list of violation errors wrapped in Data_RedisMultiError, or
-// nil if none found.
-func (m *Data_Redis) ValidateAll() error {
+// ValidateAll checks the field values on Data_Redis_TLS with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in Data_Redis_TLSMultiError,
+// or nil if none found.
+func (m *Data_Redis_TLS) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *Data_Redis) validate(all bool) error {
+func (m *Data_Redis_TLS) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Network
+	// no validation rules for Enabled
 
-	// no validation rules for Password
+	// no validation rules for CaFile
 
-	// no validation rules for Db
+	// no validation rules for CertFile
 
-	if all {
-		switch v := interface{}(m.GetDialTimeout()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, Data_RedisValidationError{
-					field:  "DialTimeout",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		case interface{ Validate() error }:
-			if err := v.Validate(); err != nil {
-				errors = append(errors, Data_RedisValidationError{
-					field:  "DialTimeout",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		}
-	} else if v, ok := interface{}(m.GetDialTimeout()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return Data_RedisValidationError{
-				field:  "DialTimeout",
-				reason: "embedded message failed validation",
-				cause:  err,
-			}
-		}
-	}
+	// no validation rules for KeyFile
 
-	if all {
-		switch v := interface{}(m.GetReadTimeout()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, Data_RedisValidationError{
-					field:  "ReadTimeout",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		case interface{ Validate() error }:
-			if err := v.Validate(); err != nil {
-				errors = append(errors, Data_RedisValidationError{
-					field:  "ReadTimeout",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		}
-	} else if v, ok := interface{}(m.GetReadTimeout()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return Data_RedisValidationError{
-				field:  "ReadTimeout",
-				reason: "embedded message failed validation",
-				cause:  err,
-			}
-		}
-	}
+	// no validation rules for ServerName
 
-	if all {
-		switch v := interface{}(m.GetWriteTimeout()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, Data_RedisValidationError{
-					field:  "WriteTimeout",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		case interface{ Validate() error }:
-			if err := v.Validate(); err != nil {
-				errors = append(errors, Data_RedisValidationError{
-					field:  "WriteTimeout",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		}
-	} else if v, ok := interface{}(m.GetWriteTimeout()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return Data_RedisValidationError{
-				field:  "WriteTimeout",
-				reason: "embedded message failed validation",
-				cause:  err,
-			}
-		}
-	}
+	// no validation rules for InsecureSkipVerify
 
 	if len(errors) > 0 {
-		return Data_RedisMultiError(errors)
+		return Data_Redis_TLSMultiError(errors)
 	}
 
 	return nil
 }
 
-// Data_RedisMultiError is an error wrapping multiple validation errors
-// returned by Data_Redis.ValidateAll() if the designated constraints aren't met.
-type Data_RedisMultiError []error
+// Data_Redis_TLSMultiError is an error wrapping multiple validation errors
+// returned by Data_Redis_TLS.ValidateAll() if the designated constraints
+// aren't met.
+type Data_Redis_TLSMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m Data_RedisMultiError) Error() string {
+func (m Data_Redis_TLSMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1768,11 +4114,11 @@ func (m Data_RedisMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m Data_RedisMultiError) AllErrors() []error { return m }
+func (m Data_Redis_TLSMultiError) AllErrors() []error { return m }
 
-// Data_RedisValidationError is the validation error returned by
-// Data_Redis.Validate if the designated constraints aren't met.
-type Data_RedisValidationError struct {
+// Data_Redis_TLSValidationError is the validation error returned by
+// Data_Redis_TLS.Validate if the designated constraints aren't met.
+type Data_Redis_TLSValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1780,22 +4126,22 @@ type Data_RedisValidationError struct {
 }
 
 // Field function returns field value.
-func (e Data_RedisValidationError) Field() string { return e.field }
+func (e Data_Redis_TLSValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e Data_RedisValidationError) Reason() string { return e.reason }
+func (e Data_Redis_TLSValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e Data_RedisValidationError) Cause() error { return e.cause }
+func (e Data_Redis_TLSValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e Data_RedisValidationError) Key() bool { return e.key }
+func (e Data_Redis_TLSValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e Data_RedisValidationError) ErrorName() string { return "Data_RedisValidationError" }
+func (e Data_Redis_TLSValidationError) ErrorName() string { return "Data_Redis_TLSValidationError" }
 
 // Error satisfies the builtin error interface
-func (e Data_RedisValidationError) Error() string {
+func (e Data_Redis_TLSValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1807,14 +4153,14 @@ func (e Data_RedisValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sData_Redis.%s: %s%s",
+		"invalid %sData_Redis_TLS.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = Data_RedisValidationError{}
+var _ error = Data_Redis_TLSValidationError{}
 
 var _ interface {
 	Field() string
@@ -1822,4 +4168,4 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = Data_RedisValidationError{}
+} = Data_Redis_TLSValidationError{}
@@ -34,13 +34,20 @@ type TokenRepo interface {
 
 // AuthUseCase is the use case for auth.
 type AuthUseCase struct {
-	userRepo  UserRepo
-	tokenRepo TokenRepo
+	userRepo       UserRepo
+	tokenRepo      TokenRepo
+	eventRepo      EventRepo
+	authenticators []Authenticator
 }
 
-// NewAuthUseCase creates a new AuthUseCase.
-func NewAuthUseCase(userRepo UserRepo, tokenRepo TokenRepo) *AuthUseCase {
-	return &AuthUseCase{userRepo: userRepo, tokenRepo: tokenRepo}
+// NewAuthUseCase creates a new AuthUseCase, the passwords are verified by the local backend if opts is nil.
+func NewAuthUseCase(tx Transaction, userRepo UserRepo, tokenRepo TokenRepo, eventRepo EventRepo, opts *AuthOptions) *AuthUseCase {
+	return &AuthUseCase{
+		userRepo:       userRepo,
+		tokenRepo:      tokenRepo,
+		eventRepo:      eventRepo,
+		authenticators: newAuthenticators(tx, userRepo, eventRepo, opts),
+	}
 }
 
 // Login logs in a user.
@@ -56,6 +63,15 @@ func (uc *AuthUseCase) Login(ctx context.Context, username, password string) (us
 		err = fmt.Errorf("failed to generate token: %w", err)
 		return
 	}
+
+	if err = uc.eventRepo.Append(ctx, NewUserEvent(EventTypeUserLoggedIn, user, user.Username)); err != nil {
+		err = fmt.Errorf("failed to append login event: %w", err)
+		// The client gets an error, the session must not outlive it
+		if deleteErr := uc.tokenRepo.DeleteToken(ctx, token); deleteErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to delete token: %w", deleteErr))
+		}
+		return nil, "", time.Time{}, err
+	}
 	return
 }
 
@@ -150,22 +166,31 @@ func (uc *AuthUseCase) GetUserByUsername(ctx context.Context, username string) (
 	return user, nil
 }
 
-// Validate the user's credentials.
+// Validate the user's credentials with the backends in order, the first accepting them wins.
+//
+// A backend failing, e.g. an unreachable directory, does not stop the next ones from being tried.
 //
 // # Note
 //
 // The `password` parameter is plaintext
 func (uc *AuthUseCase) validateCredentials(ctx context.Context, username, rawPassword string) (*User, error) {
-	user, err := uc.userRepo.FindByCredentials(ctx, username, rawPassword)
-	if err != nil {
-		return nil, fmt.Errorf("failed to find user by credentials: %w", err)
-	}
-	return user, nil
+	errs := make([]error, 0, len(uc.authenticators))
+	for _, authenticator := range uc.authenticators {
+		user, err := authenticator.Authenticate(ctx, username, rawPassword)
+		if err == nil {
+			return user, nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", authenticator.Name(), err))
+	}
+	if len(errs) == 0 {
+		return nil, ErrInvalidCredentials
+	}
+	return nil, errors.Join(errs...)
 }
 
 // Generate a token for a user and stores it in Redis.
-func (uc *AuthUseCase) generateToken(ctx context.Context, username string) (token string, expiresAt time.Time, err error) {
-	token, expiresAt, err = jwt.GenerateToken(username)
+func (uc *AuthUseCase) generateToken(ctx context.Context, username string, opts ...jwt.TokenOption) (token string, expiresAt time.Time, err error) {
+	token, expiresAt, err = jwt.GenerateToken(username, opts...)
 	if err != nil {
 		return "", time.Time{}, fmt.Errorf("failed to generate token: %w", err)
 	}
@@ -0,0 +1,268 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"usermanage/internal/pkg/ldap"
+	"usermanage/internal/pkg/oidc"
+)
+
+const (
+	// AuthBackendLocal verifies the passwords stored in the database.
+	AuthBackendLocal = "local"
+	// AuthBackendLDAP verifies the passwords against an LDAP directory or Active Directory.
+	AuthBackendLDAP = "ldap"
+
+	// DefaultLDAPGroupAttribute is the attribute of the user's entry listing its groups.
+	DefaultLDAPGroupAttribute = "memberOf"
+
+	// ldapActor is recorded as the source, creator or updater of the users synced from LDAP.
+	ldapActor = "ldap"
+)
+
+// ErrInvalidCredentials is returned when a backend rejects the credentials of a user.
+var ErrInvalidCredentials = errors.New("invalid credentials")
+
+// Authenticator verifies the credentials of the users against a backend.
+type Authenticator interface {
+	// Name returns the name of the backend, e.g. `local`.
+	Name() string
+
+	// Authenticate returns the local user of the credentials, ErrInvalidCredentials if the
+	// backend rejects them.
+	//
+	// # Note
+	//
+	// The `password` parameter is plaintext
+	Authenticate(ctx context.Context, username, password string) (*User, error)
+}
+
+// Directory authenticates the users against an external directory, returning the user's entry.
+type Directory interface {
+	Authenticate(ctx context.Context, username, password string) (*ldap.Entry, error)
+}
+
+// LDAPOptions are the directory of the LDAP backend and how its entries map to the local users.
+type LDAPOptions struct {
+	Directory      Directory
+	EmailAttribute string   // synced into the email if not empty, e.g. `mail`
+	PhoneAttribute string   // synced into the phone if not empty, e.g. `telephoneNumber`
+	GroupAttribute string   // DefaultLDAPGroupAttribute if empty
+	AdminGroups    []string // DNs of the groups granting the admin role, the role is not synced if empty
+	AutoProvision  bool     // create the users logging in for the first time
+}
+
+// AuthOptions are the backends verifying the passwords at login.
+type AuthOptions struct {
+	Backends []string // tried in order until one accepts the credentials, `local` if empty
+	LDAP     *LDAPOptions
+}
+
+// Create the authenticators of the backends, in order.
+func newAuthenticators(tx Transaction, userRepo UserRepo, eventRepo EventRepo, opts *AuthOptions) []Authenticator {
+	backends := []string{AuthBackendLocal}
+	if opts != nil && len(opts.Backends) > 0 {
+		backends = opts.Backends
+	}
+
+	authenticators := make([]Authenticator, 0, len(backends))
+	for _, backend := range backends {
+		switch {
+		case backend == AuthBackendLocal:
+			authenticators = append(authenticators, &localAuthenticator{userRepo: userRepo})
+		case backend == AuthBackendLDAP && opts.LDAP != nil:
+			authenticators = append(authenticators, &ldapAuthenticator{
+				tx:        tx,
+				userRepo:  userRepo,
+				eventRepo: eventRepo,
+				opts:      opts.LDAP,
+			})
+		}
+	}
+	return authenticators
+}
+
+// localAuthenticator verifies the passwords stored in the database.
+type localAuthenticator struct {
+	userRepo UserRepo
+}
+
+// Name implements Authenticator.
+func (a *localAuthenticator) Name() string {
+	return AuthBackendLocal
+}
+
+// Authenticate implements Authenticator.
+func (a *localAuthenticator) Authenticate(ctx context.Context, username, password string) (*User, error) {
+	user, err := a.userRepo.FindByCredentials(ctx, username, password)
+	if errors.Is(err, ErrUserNotFound) {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCredentials, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user by credentials: %w", err)
+	}
+	return user, nil
+}
+
+// ldapAuthenticator verifies the passwords against a directory, and syncs the user's entry into
+// the local user on every login.
+//
+// Only the users it provisioned are logged in: the other local users keep their own password,
+// whoever holds an entry of the same username in the directory.
+type ldapAuthenticator struct {
+	tx        Transaction
+	userRepo  UserRepo
+	eventRepo EventRepo
+	opts      *LDAPOptions
+}
+
+// Name implements Authenticator.
+func (a *ldapAuthenticator) Name() string {
+	return AuthBackendLDAP
+}
+
+// Authenticate implements Authenticator.
+func (a *ldapAuthenticator) Authenticate(ctx context.Context, username, password string) (*User, error) {
+	entry, err := a.opts.Directory.Authenticate(ctx, username, password)
+	if errors.Is(err, ldap.ErrInvalidCredentials) {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCredentials, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate with directory: %w", err)
+	}
+
+	var user *User
+	err = a.tx.InTx(ctx, func(ctx context.Context) error {
+		existing, err := a.userRepo.GetUserByUsername(ctx, username)
+		switch {
+		// A local user of the same username is not the user of the entry, e.g. the local admin
+		case err == nil && existing.Source != ldapActor:
+			return fmt.Errorf("%w: user[%s] is not provisioned by the directory", ErrInvalidCredentials, username)
+		case err == nil:
+			user, err = a.sync(ctx, existing, entry)
+			return err
+		case !errors.Is(err, ErrUserNotFound):
+			return fmt.Errorf("failed to get user by username[%s]: %w", username, err)
+		case !a.opts.AutoProvision:
+			return fmt.Errorf("%w: user[%s] is not provisioned", ErrInvalidCredentials, username)
+		default:
+			user, err = a.provision(ctx, username, entry)
+			return err
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// Create the user of a first login from the user's entry.
+//
+// The password is random: the user logs in with the directory, unless an admin resets it.
+func (a *ldapAuthenticator) provision(ctx context.Context, username string, entry *ldap.Entry) (*User, error) {
+	password, err := oidc.GenerateToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate password: %w", err)
+	}
+	params := UserCreateParams{
+		Username: username,
+		Password: password,
+		Role:     int32(DefaultUserRole),
+		Status:   int32(DefaultUserStatus),
+		Creator:  ldapActor,
+		UpdateBy: ldapActor,
+		Source:   ldapActor,
+	}
+	if role, ok := a.role(entry); ok {
+		params.Role = int32(role)
+	}
+	if email, ok := a.email(entry); ok {
+		params.Email = email
+	}
+	if phone, ok := a.phone(entry); ok {
+		params.Phone = phone
+	}
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid create user params: %w", err)
+	}
+
+	user, err := a.userRepo.CreateUser(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+	if err := a.eventRepo.Append(ctx, NewUserEvent(EventTypeUserCreated, user, ldapActor)); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// Update the role, email and phone of the user that differ from the user's entry.
+func (a *ldapAuthenticator) sync(ctx context.Context, user *User, entry *ldap.Entry) (*User, error) {
+	params := UserUpdateParams{UpdatedBy: ldapActor}
+	if role, ok := a.role(entry); ok && role != user.Role {
+		r := int32(role)
+		params.Role = &r
+	}
+	if email, ok := a.email(entry); ok && email != user.Email {
+		params.Email = &email
+	}
+	if phone, ok := a.phone(entry); ok && phone != user.Phone {
+		params.Phone = &phone
+	}
+	if params.Role == nil && params.Email == nil && params.Phone == nil {
+		return user, nil
+	}
+
+	before, err := a.userRepo.LockUserByID(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user[id=%s]: %w", user.ID, err)
+	}
+	after, err := a.userRepo.UpdateUser(ctx, user.ID, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sync user[id=%s]: %w", user.ID, err)
+	}
+	if err := a.eventRepo.Append(ctx, userChangedEvents(before, after, ldapActor)...); err != nil {
+		return nil, err
+	}
+	return after, nil
+}
+
+// Return the role granted by the groups of the entry, if the groups are mapped.
+func (a *ldapAuthenticator) role(entry *ldap.Entry) (UserRole, bool) {
+	if len(a.opts.AdminGroups) == 0 {
+		return UserRoleUnknown, false
+	}
+	attribute := a.opts.GroupAttribute
+	if attribute == "" {
+		attribute = DefaultLDAPGroupAttribute
+	}
+	for _, group := range entry.Values(attribute) {
+		// DNs are case-insensitive
+		if slices.ContainsFunc(a.opts.AdminGroups, func(admin string) bool { return strings.EqualFold(admin, group) }) {
+			return UserRoleAdmin, true
+		}
+	}
+	return UserRoleUser, true
+}
+
+// Return the email of the entry, if synced and valid: the directory is not trusted to hold a
+// valid value, an invalid one is left out rather than failing the login.
+func (a *ldapAuthenticator) email(entry *ldap.Entry) (string, bool) {
+	if a.opts.EmailAttribute == "" {
+		return "", false
+	}
+	email := strings.TrimSpace(entry.Get(a.opts.EmailAttribute))
+	return email, validateContacts(email, "") == nil
+}
+
+// Return the phone of the entry, if synced and valid, an E.164 number.
+func (a *ldapAuthenticator) phone(entry *ldap.Entry) (string, bool) {
+	if a.opts.PhoneAttribute == "" {
+		return "", false
+	}
+	phone := strings.Join(strings.Fields(entry.Get(a.opts.PhoneAttribute)), "")
+	return phone, validateContacts("", phone) == nil
+}
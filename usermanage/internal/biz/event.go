@@ -0,0 +1,98 @@
+package biz
+
+import (
+	"context"
+	"time"
+	"usermanage/internal/pkg/id"
+)
+
+// EventType is the type of a domain event.
+type EventType string
+
+const (
+	EventTypeUserCreated   EventType = "user.created"
+	EventTypeUserUpdated   EventType = "user.updated"
+	EventTypeUserDeleted   EventType = "user.deleted"
+	EventTypeUserLocked    EventType = "user.locked"
+	EventTypeUserLoggedIn  EventType = "user.logged_in"
+	EventTypeUserUndeleted EventType = "user.undeleted"
+	EventTypeUserPurged    EventType = "user.purged"
+)
+
+// EventRepo persists domain events to the outbox.
+//
+// Events appended inside a transaction (see `Transaction`) are only published
+// once that transaction commits.
+type EventRepo interface {
+	// Append stores the events in the outbox.
+	Append(ctx context.Context, events ...*Event) error
+
+	// ListEvents returns the stored events matching the query, in version order.
+	ListEvents(ctx context.Context, query EventQuery) ([]*Event, error)
+
+	// LatestVersion returns the version of the latest stored event, 0 if there is none.
+	LatestVersion(ctx context.Context) (uint64, error)
+}
+
+// EventQuery selects stored events.
+type EventQuery struct {
+	AfterVersion uint64      // only events with a greater version
+	AggregateID  string      // filter by user ID (optional)
+	Types        []EventType // filter by event types (optional)
+	Limit        int
+}
+
+// Event is a domain event about a user.
+type Event struct {
+	ID         string            `json:"id"`
+	Version    uint64            `json:"version"` // orders stored events, only set when read back from the store
+	Type       EventType         `json:"type"`
+	Actor      string            `json:"actor"`
+	OccurredAt time.Time         `json:"occurredAt"`
+	User       *User             `json:"user"`
+	Changes    []UserFieldChange `json:"changes,omitempty"`
+}
+
+// NewUserEvent creates a new event about the user.
+func NewUserEvent(eventType EventType, user *User, actor string) *Event {
+	return &Event{
+		ID:         id.GenerateUUID(true),
+		Type:       eventType,
+		Actor:      actor,
+		OccurredAt: time.Now(),
+		User:       user,
+	}
+}
+
+// Return the events describing the change from `before` to `after`.
+//
+// A user.updated event is always emitted, and a user.locked event is added
+// when the user has just become locked.
+func userChangedEvents(before, after *User, actor string) []*Event {
+	updated := NewUserEvent(EventTypeUserUpdated, after, actor)
+	updated.Changes = diffUsers(before, after)
+	events := []*Event{updated}
+	if after.Status == UserStatusLocked && (before == nil || before.Status != UserStatusLocked) {
+		events = append(events, NewUserEvent(EventTypeUserLocked, after, actor))
+	}
+	return events
+}
+
+// Return the field-level changes between two states of a user.
+func diffUsers(before, after *User) []UserFieldChange {
+	if before == nil {
+		before = &User{}
+	}
+
+	changes := make([]UserFieldChange, 0)
+	if before.Username != after.Username {
+		changes = append(changes, UserFieldChange{Field: "username", OldValue: before.Username, NewValue: after.Username})
+	}
+	if before.Role != after.Role {
+		changes = append(changes, UserFieldChange{Field: "role", OldValue: before.Role.String(), NewValue: after.Role.String()})
+	}
+	if before.Status != after.Status {
+		changes = append(changes, UserFieldChange{Field: "status", OldValue: before.Status.String(), NewValue: after.Status.String()})
+	}
+	return changes
+}
@@ -0,0 +1,43 @@
+package biz
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserChangedEvents(t *testing.T) {
+	before := &User{ID: "1", Username: "foo", Role: UserRoleUser, Status: UserStatusNormal}
+
+	t.Run("Updated", func(t *testing.T) {
+		after := *before
+		after.Role = UserRoleAdmin
+
+		events := userChangedEvents(before, &after, "admin")
+		assert.Len(t, events, 1)
+		assert.Equal(t, EventTypeUserUpdated, events[0].Type)
+		assert.Equal(t, "admin", events[0].Actor)
+		assert.Equal(t, []UserFieldChange{{Field: "role", OldValue: "user", NewValue: "admin"}}, events[0].Changes)
+	})
+
+	t.Run("Locked", func(t *testing.T) {
+		after := *before
+		after.Status = UserStatusLocked
+
+		events := userChangedEvents(before, &after, "admin")
+		assert.Len(t, events, 2)
+		assert.Equal(t, EventTypeUserUpdated, events[0].Type)
+		assert.Equal(t, EventTypeUserLocked, events[1].Type)
+	})
+
+	t.Run("Already locked", func(t *testing.T) {
+		locked := *before
+		locked.Status = UserStatusLocked
+		after := locked
+		after.Username = "bar"
+
+		events := userChangedEvents(&locked, &after, "admin")
+		assert.Len(t, events, 1)
+		assert.Equal(t, EventTypeUserUpdated, events[0].Type)
+	})
+}
@@ -0,0 +1,350 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"usermanage/internal/pkg/oidc"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// DefaultFederationUsernameClaim is the claim of the ID tokens the username is read from.
+	DefaultFederationUsernameClaim = "preferred_username"
+
+	// ScimActor is recorded as the source, creator or updater of the users provisioned with SCIM.
+	ScimActor = "scim"
+
+	// maxUsernameLength is the size of the username column.
+	maxUsernameLength = 64
+
+	// federationActorPrefix prefixes the name of a provider in the actor of its changes, and
+	// the source of the users it provisioned.
+	federationActorPrefix = "federation:"
+)
+
+var (
+	// ErrFederationProviderNotFound is returned when no upstream provider has the name.
+	ErrFederationProviderNotFound = errors.New("federation provider not found")
+
+	// ErrExternalIdentityNotFound is returned when no local user is linked to an external identity.
+	ErrExternalIdentityNotFound = errors.New("external identity not found")
+
+	// ErrFederatedLoginDenied is returned when a user signed in with an upstream provider
+	// cannot log in as a local user.
+	ErrFederatedLoginDenied = errors.New("federated login denied")
+)
+
+// FederationRepo defines operations for managing the external identities linked to the users.
+type FederationRepo interface {
+	// GetIdentity retrieves the identity of an upstream provider by its subject.
+	GetIdentity(ctx context.Context, provider, subject string) (*ExternalIdentity, error)
+
+	// GetIdentityByUserID retrieves the identity of an upstream provider linked to a user.
+	GetIdentityByUserID(ctx context.Context, provider, userID string) (*ExternalIdentity, error)
+
+	// CreateIdentity links an identity of an upstream provider to a user.
+	CreateIdentity(ctx context.Context, identity *ExternalIdentity) (*ExternalIdentity, error)
+
+	// TouchIdentity records a login with an identity.
+	TouchIdentity(ctx context.Context, id string) error
+}
+
+// ExternalIdentity is a user of an upstream provider, linked to a local user.
+type ExternalIdentity struct {
+	ID          string
+	Provider    string // the name of the provider
+	Subject     string // the `sub` claim, stable and unique within the provider
+	UserID      string
+	CreatedAt   time.Time
+	LastLoginAt time.Time
+}
+
+// FederatedProvider is an upstream OpenID Connect provider the users log in with.
+type FederatedProvider struct {
+	Name           string
+	Client         *oidc.Client
+	UsernameClaim  string
+	AutoProvision  bool     // create the users logging in for the first time
+	LinkByUsername bool     // link a first login to the provisioned user of the same username, see `linkable`
+	RoleClaim      string   // set the role of the users it provisioned from the claim on every login, if not empty
+	AdminValues    []string // values of the role claim granting the admin role
+}
+
+// FederationOptions are the upstream providers, by name.
+type FederationOptions struct {
+	Providers map[string]*FederatedProvider
+}
+
+// FederatedLogin is a login started with an upstream provider, kept by the browser until the
+// provider redirects it back.
+type FederatedLogin struct {
+	State        string
+	Nonce        string
+	CodeVerifier string
+}
+
+// FederationUseCase is the use case for the login with upstream providers.
+type FederationUseCase struct {
+	tx        Transaction
+	repo      FederationRepo
+	userRepo  UserRepo
+	eventRepo EventRepo
+	auth      *AuthUseCase
+	providers map[string]*FederatedProvider
+}
+
+// NewFederationUseCase creates a new FederationUseCase.
+func NewFederationUseCase(tx Transaction, repo FederationRepo, userRepo UserRepo, eventRepo EventRepo, auth *AuthUseCase, opts *FederationOptions) *FederationUseCase {
+	return &FederationUseCase{
+		tx:        tx,
+		repo:      repo,
+		userRepo:  userRepo,
+		eventRepo: eventRepo,
+		auth:      auth,
+		providers: opts.Providers,
+	}
+}
+
+// Providers returns the names of the upstream providers.
+func (uc *FederationUseCase) Providers() []string {
+	names := make([]string, 0, len(uc.providers))
+	for name := range uc.providers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// StartLogin starts a login with an upstream provider, returning the URL the user is redirected
+// to and the login to finish once the provider redirects back.
+func (uc *FederationUseCase) StartLogin(ctx context.Context, provider string) (string, *FederatedLogin, error) {
+	p, ok := uc.providers[provider]
+	if !ok {
+		return "", nil, fmt.Errorf("%w: %s", ErrFederationProviderNotFound, provider)
+	}
+
+	var login FederatedLogin
+	for _, value := range []*string{&login.State, &login.Nonce, &login.CodeVerifier} {
+		token, err := oidc.GenerateToken()
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to generate token: %w", err)
+		}
+		*value = token
+	}
+	authURL, err := p.Client.AuthCodeURL(ctx, login.State, login.Nonce, login.CodeVerifier)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to build authorization url of provider[%s]: %w", provider, err)
+	}
+	return authURL, &login, nil
+}
+
+// FinishLogin exchanges the code the upstream provider redirected back with, and logs in the
+// local user linked to the identity, linking or provisioning it on first login.
+func (uc *FederationUseCase) FinishLogin(ctx context.Context, provider string, login *FederatedLogin, code string) (user *User, token string, expiresAt time.Time, err error) {
+	p, ok := uc.providers[provider]
+	if !ok {
+		err = fmt.Errorf("%w: %s", ErrFederationProviderNotFound, provider)
+		return
+	}
+
+	claims, err := p.Client.Exchange(ctx, code, login.CodeVerifier, login.Nonce)
+	if err != nil {
+		err = fmt.Errorf("failed to sign in with provider[%s]: %w", provider, err)
+		return
+	}
+
+	err = uc.tx.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		if user, err = uc.localUser(ctx, p, claims); err != nil {
+			return err
+		}
+		if !user.Status.IsNormal() {
+			return fmt.Errorf("%w: user[%s] is %s", ErrFederatedLoginDenied, user.Username, user.Status)
+		}
+		if user, err = uc.mapRole(ctx, p, claims, user); err != nil {
+			return err
+		}
+		return uc.eventRepo.Append(ctx, NewUserEvent(EventTypeUserLoggedIn, user, user.Username))
+	})
+	if err != nil {
+		return
+	}
+
+	token, expiresAt, err = uc.auth.generateToken(ctx, user.Username)
+	if err != nil {
+		err = fmt.Errorf("failed to generate token: %w", err)
+	}
+	return
+}
+
+// Return the local user of the identity the provider signed in, linking or provisioning it.
+func (uc *FederationUseCase) localUser(ctx context.Context, p *FederatedProvider, claims jwt.MapClaims) (*User, error) {
+	subject, _ := claims["sub"].(string)
+	identity, err := uc.repo.GetIdentity(ctx, p.Name, subject)
+	switch {
+	case err == nil:
+		if err := uc.repo.TouchIdentity(ctx, identity.ID); err != nil {
+			return nil, fmt.Errorf("failed to touch external identity[id=%s]: %w", identity.ID, err)
+		}
+		user, err := uc.userRepo.GetUserByID(ctx, identity.UserID)
+		if errors.Is(err, ErrUserNotFound) {
+			return nil, fmt.Errorf("%w: the linked user[id=%s] was deleted", ErrFederatedLoginDenied, identity.UserID)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user[id=%s]: %w", identity.UserID, err)
+		}
+		return user, nil
+	case !errors.Is(err, ErrExternalIdentityNotFound):
+		return nil, fmt.Errorf("failed to get external identity: %w", err)
+	}
+
+	usernameClaim := p.UsernameClaim
+	if usernameClaim == "" {
+		usernameClaim = DefaultFederationUsernameClaim
+	}
+	username, _ := claims[usernameClaim].(string)
+	if username == "" || len(username) > maxUsernameLength {
+		return nil, fmt.Errorf("%w: invalid or missing username claim[%s]", ErrFederatedLoginDenied, usernameClaim)
+	}
+
+	user, err := uc.userRepo.GetUserByUsername(ctx, username)
+	switch {
+	case err == nil:
+		if !p.LinkByUsername {
+			return nil, fmt.Errorf("%w: user[%s] exists and is not linked", ErrFederatedLoginDenied, username)
+		}
+		if err := linkable(user, claims); err != nil {
+			return nil, fmt.Errorf("%w: user[%s] %w", ErrFederatedLoginDenied, username, err)
+		}
+		// The user may only be linked to one identity of the provider
+		if _, err := uc.repo.GetIdentityByUserID(ctx, p.Name, user.ID); err == nil {
+			return nil, fmt.Errorf("%w: user[%s] is linked to another identity", ErrFederatedLoginDenied, username)
+		} else if !errors.Is(err, ErrExternalIdentityNotFound) {
+			return nil, fmt.Errorf("failed to get external identity of user[%s]: %w", username, err)
+		}
+	case !errors.Is(err, ErrUserNotFound):
+		return nil, fmt.Errorf("failed to get user by username[%s]: %w", username, err)
+	case !p.AutoProvision:
+		return nil, fmt.Errorf("%w: user[%s] is not provisioned", ErrFederatedLoginDenied, username)
+	default:
+		if user, err = uc.provision(ctx, p, username); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := uc.repo.CreateIdentity(ctx, &ExternalIdentity{Provider: p.Name, Subject: subject, UserID: user.ID}); err != nil {
+		return nil, fmt.Errorf("failed to link user[%s] to provider[%s]: %w", username, p.Name, err)
+	}
+	return user, nil
+}
+
+// Check that a user may be linked by username to an identity signed in by a provider.
+//
+// A username proves nothing, so the user must have been provisioned without a password of
+// its own, by SCIM or another provider as recorded by its source: the admins and the users
+// created by an admin, imported or synced from LDAP are never linked, whatever the name of
+// their creator. The identity must also have the email of the user, verified by the provider.
+func linkable(user *User, claims jwt.MapClaims) error {
+	if user.Role == UserRoleAdmin {
+		return errors.New("is an admin")
+	}
+	if user.Source != ScimActor && !strings.HasPrefix(user.Source, federationActorPrefix) {
+		return errors.New("has a password login")
+	}
+	email, _ := claims["email"].(string)
+	if verified, _ := claims["email_verified"].(bool); !verified || user.Email == "" || !strings.EqualFold(email, user.Email) {
+		return errors.New("does not have the verified email of the identity")
+	}
+	return nil
+}
+
+// Create the user of a first login, with the default role and status.
+//
+// The password is random: the user logs in with the provider, unless an admin resets it.
+func (uc *FederationUseCase) provision(ctx context.Context, p *FederatedProvider, username string) (*User, error) {
+	password, err := oidc.GenerateToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate password: %w", err)
+	}
+	params := UserCreateParams{
+		Username: username,
+		Password: password,
+		Role:     int32(DefaultUserRole),
+		Status:   int32(DefaultUserStatus),
+		Creator:  p.actor(),
+		UpdateBy: p.actor(),
+		Source:   p.actor(),
+	}
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid create user params: %w", err)
+	}
+	user, err := uc.userRepo.CreateUser(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+	if err := uc.eventRepo.Append(ctx, NewUserEvent(EventTypeUserCreated, user, params.Creator)); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// Set the role of the user from the role claim, if the provider maps it and provisioned the user.
+//
+// The role of a linked user is managed locally, so a provider cannot make it an admin.
+func (uc *FederationUseCase) mapRole(ctx context.Context, p *FederatedProvider, claims jwt.MapClaims, user *User) (*User, error) {
+	if p.RoleClaim == "" || user.Source != p.actor() {
+		return user, nil
+	}
+	role := UserRoleUser
+	for _, value := range claimValues(claims, p.RoleClaim) {
+		if slices.Contains(p.AdminValues, value) {
+			role = UserRoleAdmin
+			break
+		}
+	}
+	if user.Role == role {
+		return user, nil
+	}
+
+	before, err := uc.userRepo.LockUserByID(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user[id=%s]: %w", user.ID, err)
+	}
+	r := int32(role)
+	after, err := uc.userRepo.UpdateUser(ctx, user.ID, UserUpdateParams{Role: &r, UpdatedBy: p.actor()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update role of user[id=%s]: %w", user.ID, err)
+	}
+	if err := uc.eventRepo.Append(ctx, userChangedEvents(before, after, p.actor())...); err != nil {
+		return nil, err
+	}
+	return after, nil
+}
+
+// The actor recorded for the changes of a provider, e.g. `federation:corp`.
+func (p *FederatedProvider) actor() string {
+	return federationActorPrefix + p.Name
+}
+
+// Return the values of a claim, a string or an array of strings such as `groups`.
+func claimValues(claims jwt.MapClaims, name string) []string {
+	switch v := claims[name].(type) {
+	case string:
+		return []string{v}
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
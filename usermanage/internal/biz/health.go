@@ -27,8 +27,11 @@ func (uc *HealthUseCase) PingDB(ctx context.Context) error {
 	return nil
 }
 
-// PingRedis checks the redis connection.
+// PingRedis checks the redis connection, if Redis is used.
 func (uc *HealthUseCase) PingRedis(ctx context.Context) error {
+	if uc.rdb == nil {
+		return nil
+	}
 	if err := uc.rdb.Ping(ctx).Err(); err != nil {
 		return fmt.Errorf("redis ping error: %w", err)
 	}
@@ -0,0 +1,960 @@
+package biz
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+	"usermanage/internal/pkg/id"
+	"usermanage/internal/pkg/jwt"
+	"usermanage/internal/pkg/oidc"
+
+	gojwt "github.com/golang-jwt/jwt/v5"
+)
+
+// The scopes a client may request.
+const (
+	OAuthScopeOpenID        = "openid"         // an ID token is issued
+	OAuthScopeProfile       = "profile"        // the username and role
+	OAuthScopeEmail         = "email"          // the email address
+	OAuthScopePhone         = "phone"          // the phone number
+	OAuthScopeOfflineAccess = "offline_access" // accepted, a refresh token is always issued
+)
+
+// OAuthScopes are the supported scopes.
+var OAuthScopes = []string{OAuthScopeOpenID, OAuthScopeProfile, OAuthScopeEmail, OAuthScopePhone, OAuthScopeOfflineAccess}
+
+// The grant types of the token endpoint.
+const (
+	OAuthGrantTypeAuthorizationCode = "authorization_code"
+	OAuthGrantTypeRefreshToken      = "refresh_token"
+)
+
+// OAuthResponseTypeCode is the only response type, the authorization code flow.
+const OAuthResponseTypeCode = "code"
+
+// The hints of the type of a token introspected or revoked (RFC 7009, section 2.1).
+const (
+	OAuthTokenTypeHintAccessToken  = "access_token"
+	OAuthTokenTypeHintRefreshToken = "refresh_token"
+)
+
+// The error codes returned to the clients (RFC 6749 and OpenID Connect Core).
+const (
+	OAuthErrInvalidRequest          = "invalid_request"
+	OAuthErrInvalidClient           = "invalid_client"
+	OAuthErrInvalidGrant            = "invalid_grant"
+	OAuthErrInvalidScope            = "invalid_scope"
+	OAuthErrInvalidToken            = "invalid_token"
+	OAuthErrInsufficientScope       = "insufficient_scope"
+	OAuthErrUnsupportedGrantType    = "unsupported_grant_type"
+	OAuthErrUnsupportedResponseType = "unsupported_response_type"
+	OAuthErrAccessDenied            = "access_denied"
+	OAuthErrLoginRequired           = "login_required"
+	OAuthErrConsentRequired         = "consent_required"
+)
+
+var (
+	// ErrOAuthClientNotFound is returned when an OAuth client does not exist.
+	ErrOAuthClientNotFound = errors.New("oauth client not found")
+
+	// ErrOAuthGrantNotFound is returned when an authorization code or a refresh token does not exist.
+	ErrOAuthGrantNotFound = errors.New("oauth grant not found")
+
+	// ErrInvalidOAuthClient is returned when the parameters of a client are invalid.
+	ErrInvalidOAuthClient = errors.New("invalid oauth client")
+)
+
+// OAuthError is an error caused by the request of a client, returned to it as is.
+type OAuthError struct {
+	Code        string
+	Description string
+}
+
+func (e *OAuthError) Error() string {
+	return e.Code + ": " + e.Description
+}
+
+func newOAuthError(code, format string, args ...any) *OAuthError {
+	return &OAuthError{Code: code, Description: fmt.Sprintf(format, args...)}
+}
+
+// OAuthRepo is the repository for the OAuth clients and the grants issued to them.
+//
+// Authorization codes and refresh tokens are stored by their hash, see `oidc.HashToken`.
+type OAuthRepo interface {
+	// ListClients returns a paginated list of clients.
+	ListClients(ctx context.Context, params OAuthClientListParams) (*OAuthClientListResult, error)
+
+	// GetClient gets the client by ID, ErrOAuthClientNotFound if it does not exist.
+	GetClient(ctx context.Context, id string) (*OAuthClient, error)
+
+	// CreateClient creates a new client.
+	CreateClient(ctx context.Context, params OAuthClientCreateParams) (*OAuthClient, error)
+
+	// DeleteClient deletes a client by ID along with its codes, refresh tokens and consents.
+	DeleteClient(ctx context.Context, id string) error
+
+	// CreateAuthorizationCode saves an authorization code, the expired codes are removed.
+	CreateAuthorizationCode(ctx context.Context, code *OAuthAuthorizationCode) error
+
+	// TakeAuthorizationCode deletes the authorization code and returns it, so it is used once.
+	// ErrOAuthGrantNotFound if it does not exist.
+	TakeAuthorizationCode(ctx context.Context, hash string) (*OAuthAuthorizationCode, error)
+
+	// CreateRefreshToken saves a refresh token, the expired tokens of the user are removed.
+	CreateRefreshToken(ctx context.Context, token *OAuthRefreshToken) error
+
+	// GetRefreshToken gets the refresh token, ErrOAuthGrantNotFound if it does not exist.
+	GetRefreshToken(ctx context.Context, hash string) (*OAuthRefreshToken, error)
+
+	// UseRefreshToken marks the refresh token as used, it reports false if it already was.
+	UseRefreshToken(ctx context.Context, hash string) (bool, error)
+
+	// RefreshTokenFamilyExists reports whether any refresh token of the authorization is left.
+	RefreshTokenFamilyExists(ctx context.Context, familyID string) (bool, error)
+
+	// DeleteRefreshTokenFamily deletes the refresh tokens rotated from the same authorization.
+	DeleteRefreshTokenFamily(ctx context.Context, familyID string) error
+
+	// GetConsent returns the scopes the user consented to the client getting, nil if none.
+	GetConsent(ctx context.Context, username, clientID string) ([]string, error)
+
+	// SaveConsent saves the scopes the user consented to the client getting.
+	SaveConsent(ctx context.Context, username, clientID string, scopes []string) error
+}
+
+// OAuthClient is an application logging its users in with OpenID Connect.
+type OAuthClient struct {
+	ID             string    `json:"id"`
+	Name           string    `json:"name"`
+	Secret         string    `json:"-"` // only set when created
+	SecretHash     string    `json:"-"`
+	RedirectURIs   []string  `json:"redirectUris"`
+	Public         bool      `json:"public"`         // has no secret, e.g. a single-page app
+	SkipConsent    bool      `json:"skipConsent"`    // the users are not asked to consent, e.g. a first-party app
+	ResourceServer bool      `json:"resourceServer"` // may introspect the tokens, e.g. an API gateway
+	Creator        string    `json:"creator"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+// OAuthClientListParams represents all parameters for client listing.
+type OAuthClientListParams struct {
+	Page     int32 `json:"page"`      // current page number (1-based)
+	PageSize int32 `json:"page_size"` // page size
+}
+
+// GetPage returns the page and size.
+func (p *OAuthClientListParams) GetPage() (page, size int32) {
+	p.Page, p.PageSize = normalizePage(p.Page, p.PageSize)
+	return p.Page, p.PageSize
+}
+
+// OAuthClientListResult represents the result of client listing.
+type OAuthClientListResult struct {
+	TotalCount int64
+	Clients    []*OAuthClient
+}
+
+// OAuthClientCreateParams represents the parameters for creating a client.
+type OAuthClientCreateParams struct {
+	Name           string   `json:"name"`
+	SecretHash     string   `json:"-"`
+	RedirectURIs   []string `json:"redirectUris"`
+	Public         bool     `json:"public"`
+	SkipConsent    bool     `json:"skipConsent"`
+	ResourceServer bool     `json:"resourceServer"`
+	Creator        string   `json:"creator"`
+}
+
+// OAuthAuthorizationCode is issued to a client once the user logged in, then exchanged for tokens.
+type OAuthAuthorizationCode struct {
+	Hash          string
+	ClientID      string
+	UserID        string
+	Username      string
+	RedirectURI   string
+	Scopes        []string
+	Nonce         string
+	CodeChallenge string // S256
+	AuthTime      time.Time
+	ExpiresAt     time.Time
+}
+
+// OAuthRefreshToken lets a client get new tokens without the user.
+//
+// Refresh tokens are rotated: every use issues a new one in the same family. Using a token twice
+// means it leaked, the whole family is then revoked.
+type OAuthRefreshToken struct {
+	Hash      string
+	FamilyID  string
+	ClientID  string
+	UserID    string
+	Username  string
+	Scopes    []string
+	AuthTime  time.Time
+	ExpiresAt time.Time
+	UsedAt    *time.Time // set once exchanged
+}
+
+// OAuthAuthorizationRequest is the request of a client to the authorization endpoint.
+type OAuthAuthorizationRequest struct {
+	ClientID            string
+	RedirectURI         string
+	ResponseType        string
+	Scope               string
+	State               string
+	Nonce               string
+	CodeChallenge       string
+	CodeChallengeMethod string
+}
+
+// OAuthTokenRequest is the request of a client to the token endpoint.
+type OAuthTokenRequest struct {
+	GrantType    string
+	ClientID     string
+	ClientSecret string
+	Code         string
+	RedirectURI  string
+	CodeVerifier string
+	RefreshToken string
+	Scope        string
+}
+
+// OAuthTokenResponse is the successful response of the token endpoint.
+type OAuthTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	IDToken      string `json:"id_token,omitempty"`
+	Scope        string `json:"scope"`
+}
+
+// OAuthTokenParams is the request of a client to the introspection (RFC 7662) or revocation
+// (RFC 7009) endpoint.
+type OAuthTokenParams struct {
+	ClientID      string
+	ClientSecret  string
+	Token         string
+	TokenTypeHint string // OAuthTokenTypeHintAccessToken or OAuthTokenTypeHintRefreshToken, the lookup order only
+}
+
+// OAuthIntrospection is the response of the introspection endpoint, only `active` is set for an
+// inactive token.
+type OAuthIntrospection struct {
+	Active    bool   `json:"active"`
+	Scope     string `json:"scope,omitempty"`
+	ClientID  string `json:"client_id,omitempty"`
+	Username  string `json:"username,omitempty"`
+	TokenType string `json:"token_type,omitempty"`
+	ExpiresAt int64  `json:"exp,omitempty"`
+	IssuedAt  int64  `json:"iat,omitempty"`
+	Subject   string `json:"sub,omitempty"` // the user ID, as in the ID tokens
+	Issuer    string `json:"iss,omitempty"`
+	Role      string `json:"role,omitempty"`
+}
+
+// OAuthOptions configures the OpenID Connect provider.
+type OAuthOptions struct {
+	Issuer          string       // the public URL of the server, the `iss` claim of the ID tokens
+	Signer          *oidc.Signer // signs the ID tokens, nil if the provider is disabled
+	CodeTTL         time.Duration
+	IDTokenTTL      time.Duration
+	RefreshTokenTTL time.Duration
+}
+
+// OAuthUseCase is the use case of the OpenID Connect provider.
+//
+// The access tokens are login tokens of the user, issued to the client with the granted scopes,
+// so they are accepted by the API like the tokens of `AuthUseCase.Login`.
+type OAuthUseCase struct {
+	tx        Transaction
+	oauthRepo OAuthRepo
+	auth      *AuthUseCase
+	opts      *OAuthOptions
+}
+
+// NewOAuthUseCase creates a new OAuthUseCase.
+func NewOAuthUseCase(tx Transaction, oauthRepo OAuthRepo, auth *AuthUseCase, opts *OAuthOptions) *OAuthUseCase {
+	return &OAuthUseCase{tx: tx, oauthRepo: oauthRepo, auth: auth, opts: opts}
+}
+
+// Issuer returns the issuer identifier of the provider.
+func (uc *OAuthUseCase) Issuer() string {
+	return uc.opts.Issuer
+}
+
+// JWKS returns the JWK set verifying the ID tokens.
+func (uc *OAuthUseCase) JWKS() oidc.JSONWebKeySet {
+	return uc.opts.Signer.JWKS()
+}
+
+// ListClients lists clients.
+func (uc *OAuthUseCase) ListClients(ctx context.Context, params OAuthClientListParams) (*OAuthClientListResult, error) {
+	return uc.oauthRepo.ListClients(ctx, params)
+}
+
+// GetClient gets a client by ID.
+func (uc *OAuthUseCase) GetClient(ctx context.Context, id string) (*OAuthClient, error) {
+	if id == "" {
+		return nil, errors.New("client id is required")
+	}
+
+	client, err := uc.oauthRepo.GetClient(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get oauth client[id=%s]: %w", id, err)
+	}
+	return client, nil
+}
+
+// CreateClient registers a client.
+//
+// A secret is generated for confidential clients, the returned client carries it
+// so it can be handed over to the application.
+func (uc *OAuthUseCase) CreateClient(ctx context.Context, params OAuthClientCreateParams) (*OAuthClient, error) {
+	if strings.TrimSpace(params.Name) == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidOAuthClient)
+	}
+	if len(params.RedirectURIs) == 0 {
+		return nil, fmt.Errorf("%w: at least one redirect uri is required", ErrInvalidOAuthClient)
+	}
+	// Anyone can claim the ID of a public client
+	if params.Public && params.ResourceServer {
+		return nil, fmt.Errorf("%w: public clients cannot be resource servers", ErrInvalidOAuthClient)
+	}
+	for _, uri := range params.RedirectURIs {
+		if err := validateRedirectURI(uri); err != nil {
+			return nil, err
+		}
+	}
+
+	var secret string
+	if !params.Public {
+		var err error
+		if secret, err = oidc.GenerateToken(); err != nil {
+			return nil, fmt.Errorf("failed to generate client secret: %w", err)
+		}
+		params.SecretHash = oidc.HashToken(secret)
+	}
+
+	client, err := uc.oauthRepo.CreateClient(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create oauth client[name=%s]: %w", params.Name, err)
+	}
+	client.Secret = secret
+	return client, nil
+}
+
+// DeleteClient deletes a client by ID, its refresh tokens and the access tokens issued with them
+// are revoked.
+func (uc *OAuthUseCase) DeleteClient(ctx context.Context, id string) error {
+	if id == "" {
+		return errors.New("client id is required")
+	}
+
+	if err := uc.oauthRepo.DeleteClient(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete oauth client[id=%s]: %w", id, err)
+	}
+	return nil
+}
+
+// CheckAuthorizationRequest validates a request to the authorization endpoint and returns
+// its client and requested scopes.
+//
+// The client and its redirect URI are checked first: when they are invalid, no client is
+// returned and the error must be shown to the user rather than sent to the redirect URI.
+func (uc *OAuthUseCase) CheckAuthorizationRequest(ctx context.Context, req *OAuthAuthorizationRequest) (*OAuthClient, []string, error) {
+	if req.ClientID == "" {
+		return nil, nil, newOAuthError(OAuthErrInvalidRequest, "client_id is required")
+	}
+	client, err := uc.oauthRepo.GetClient(ctx, req.ClientID)
+	if err != nil {
+		if errors.Is(err, ErrOAuthClientNotFound) {
+			return nil, nil, newOAuthError(OAuthErrInvalidClient, "unknown client")
+		}
+		return nil, nil, fmt.Errorf("failed to get oauth client[id=%s]: %w", req.ClientID, err)
+	}
+	if !slices.Contains(client.RedirectURIs, req.RedirectURI) {
+		return nil, nil, newOAuthError(OAuthErrInvalidRequest, "redirect_uri is not registered for the client")
+	}
+
+	if req.ResponseType != OAuthResponseTypeCode {
+		return client, nil, newOAuthError(OAuthErrUnsupportedResponseType, "response_type must be code")
+	}
+	scopes, err := parseOAuthScopes(req.Scope)
+	if err != nil {
+		return client, nil, err
+	}
+	if req.CodeChallenge == "" {
+		return client, nil, newOAuthError(OAuthErrInvalidRequest, "code_challenge is required")
+	}
+	if req.CodeChallengeMethod != oidc.CodeChallengeMethodS256 {
+		return client, nil, newOAuthError(OAuthErrInvalidRequest, "code_challenge_method must be S256")
+	}
+	if !oidc.ValidCodeVerifier(req.CodeChallenge) {
+		return client, nil, newOAuthError(OAuthErrInvalidRequest, "invalid code_challenge")
+	}
+	if len(req.Nonce) > 256 {
+		return client, nil, newOAuthError(OAuthErrInvalidRequest, "nonce is too long")
+	}
+	return client, scopes, nil
+}
+
+// NeedsConsent reports whether the user has to consent to the client getting the scopes.
+func (uc *OAuthUseCase) NeedsConsent(ctx context.Context, client *OAuthClient, username string, scopes []string) (bool, error) {
+	if client.SkipConsent {
+		return false, nil
+	}
+
+	granted, err := uc.oauthRepo.GetConsent(ctx, username, client.ID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get consent of user[%s] to client[id=%s]: %w", username, client.ID, err)
+	}
+	for _, scope := range scopes {
+		if !slices.Contains(granted, scope) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Authorize issues an authorization code to the client for the logged in user, who consented
+// to the scopes if needed. The consent is remembered.
+func (uc *OAuthUseCase) Authorize(ctx context.Context, client *OAuthClient, req *OAuthAuthorizationRequest, scopes []string, user *User, authTime time.Time, consented bool) (string, error) {
+	if !user.Status.IsNormal() {
+		return "", newOAuthError(OAuthErrAccessDenied, "the user is %s", user.Status)
+	}
+
+	if consented && !client.SkipConsent {
+		granted, err := uc.oauthRepo.GetConsent(ctx, user.Username, client.ID)
+		if err != nil {
+			return "", fmt.Errorf("failed to get consent of user[%s] to client[id=%s]: %w", user.Username, client.ID, err)
+		}
+		for _, scope := range scopes {
+			if !slices.Contains(granted, scope) {
+				granted = append(granted, scope)
+			}
+		}
+		if err := uc.oauthRepo.SaveConsent(ctx, user.Username, client.ID, granted); err != nil {
+			return "", fmt.Errorf("failed to save consent of user[%s] to client[id=%s]: %w", user.Username, client.ID, err)
+		}
+	}
+
+	code, err := oidc.GenerateToken()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate authorization code: %w", err)
+	}
+	err = uc.oauthRepo.CreateAuthorizationCode(ctx, &OAuthAuthorizationCode{
+		Hash:          oidc.HashToken(code),
+		ClientID:      client.ID,
+		UserID:        user.ID,
+		Username:      user.Username,
+		RedirectURI:   req.RedirectURI,
+		Scopes:        scopes,
+		Nonce:         req.Nonce,
+		CodeChallenge: req.CodeChallenge,
+		AuthTime:      authTime,
+		ExpiresAt:     time.Now().Add(uc.opts.CodeTTL),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create authorization code: %w", err)
+	}
+	return code, nil
+}
+
+// Exchange authenticates the client and exchanges an authorization code or a refresh token for tokens.
+func (uc *OAuthUseCase) Exchange(ctx context.Context, req *OAuthTokenRequest) (*OAuthTokenResponse, error) {
+	client, err := uc.authenticateClient(ctx, req.ClientID, req.ClientSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp *OAuthTokenResponse
+	var oauthErr *OAuthError
+	err = uc.tx.InTx(ctx, func(ctx context.Context) (err error) {
+		switch req.GrantType {
+		case OAuthGrantTypeAuthorizationCode:
+			resp, err = uc.exchangeCode(ctx, client, req)
+		case OAuthGrantTypeRefreshToken:
+			resp, err = uc.refresh(ctx, client, req)
+		case "":
+			err = newOAuthError(OAuthErrInvalidRequest, "grant_type is required")
+		default:
+			err = newOAuthError(OAuthErrUnsupportedGrantType, "unsupported grant_type %q", req.GrantType)
+		}
+		// The errors of the client are committed: a code is used once, a reused refresh token stays revoked
+		if errors.As(err, &oauthErr) {
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if oauthErr != nil {
+		return nil, oauthErr
+	}
+	return resp, nil
+}
+
+// UserInfo returns the claims about the user of an access token, according to its scopes.
+func (uc *OAuthUseCase) UserInfo(ctx context.Context, accessToken string) (map[string]any, error) {
+	claims, err := jwt.ParseToken(accessToken)
+	if err != nil {
+		return nil, newOAuthError(OAuthErrInvalidToken, "invalid or expired access token")
+	}
+	scopes := strings.Fields(claims.Scope)
+	if !slices.Contains(scopes, OAuthScopeOpenID) {
+		return nil, newOAuthError(OAuthErrInsufficientScope, "the openid scope is required")
+	}
+
+	active, err := uc.grantActive(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+	if !active {
+		return nil, newOAuthError(OAuthErrInvalidToken, "the authorization was revoked")
+	}
+	user, err := uc.auth.VerifyToken(ctx, accessToken)
+	if err != nil {
+		return nil, newOAuthError(OAuthErrInvalidToken, "invalid or expired access token")
+	}
+	if !user.Status.IsNormal() {
+		return nil, newOAuthError(OAuthErrInvalidToken, "the user is %s", user.Status)
+	}
+	return oauthUserClaims(user, scopes), nil
+}
+
+// Introspect authenticates the client and returns the state of an access or refresh token.
+//
+// Only the resource servers, such as an API gateway, introspect the tokens: any access token,
+// including the login tokens, so they validate the tokens without sharing the JWT secret.
+// Refresh tokens are only introspected by the client they were issued to.
+func (uc *OAuthUseCase) Introspect(ctx context.Context, params *OAuthTokenParams) (*OAuthIntrospection, error) {
+	client, err := uc.authenticateClient(ctx, params.ClientID, params.ClientSecret)
+	if err != nil {
+		return nil, err
+	}
+	// Anyone can claim the ID of a public client, which cannot be a resource server
+	if client.Public || !client.ResourceServer {
+		return nil, newOAuthError(OAuthErrInvalidClient, "only resource servers can introspect tokens")
+	}
+	if params.Token == "" {
+		return nil, newOAuthError(OAuthErrInvalidRequest, "token is required")
+	}
+
+	lookups := []func(context.Context, *OAuthClient, string) (*OAuthIntrospection, error){
+		uc.introspectAccessToken, uc.introspectRefreshToken,
+	}
+	if params.TokenTypeHint == OAuthTokenTypeHintRefreshToken {
+		slices.Reverse(lookups)
+	}
+	for _, lookup := range lookups {
+		introspection, err := lookup(ctx, client, params.Token)
+		if err != nil || introspection != nil {
+			return introspection, err
+		}
+	}
+	return &OAuthIntrospection{Active: false}, nil
+}
+
+// Revoke authenticates the client and revokes an access or refresh token issued to it, revoking
+// a refresh token revokes the refresh and access tokens issued from the same authorization.
+//
+// Unknown tokens and the tokens of other clients are ignored: the response is the same, so it
+// does not tell whether a token exists (RFC 7009, section 2.2).
+func (uc *OAuthUseCase) Revoke(ctx context.Context, params *OAuthTokenParams) error {
+	client, err := uc.authenticateClient(ctx, params.ClientID, params.ClientSecret)
+	if err != nil {
+		return err
+	}
+	if params.Token == "" {
+		return newOAuthError(OAuthErrInvalidRequest, "token is required")
+	}
+
+	revocations := []func(context.Context, *OAuthClient, string) (bool, error){
+		uc.revokeAccessToken, uc.revokeRefreshToken,
+	}
+	if params.TokenTypeHint == OAuthTokenTypeHintRefreshToken {
+		slices.Reverse(revocations)
+	}
+	for _, revoke := range revocations {
+		if found, err := revoke(ctx, client, params.Token); err != nil || found {
+			return err
+		}
+	}
+	return nil
+}
+
+// Return the state of an access token, nil if it is not one.
+func (uc *OAuthUseCase) introspectAccessToken(ctx context.Context, _ *OAuthClient, token string) (*OAuthIntrospection, error) {
+	claims, err := jwt.ParseToken(token)
+	if err != nil {
+		return nil, nil //nolint:nilerr // not an access token, or an expired one
+	}
+	inactive := &OAuthIntrospection{Active: false}
+
+	// The token is signed but may have been revoked, by a logout or a password change
+	exists, err := uc.auth.TokenExists(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check token: %w", err)
+	}
+	if !exists {
+		return inactive, nil
+	}
+	active, err := uc.grantActive(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+	if !active {
+		return inactive, nil
+	}
+	user, err := uc.auth.VerifyToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return inactive, nil
+		}
+		return nil, fmt.Errorf("failed to verify token: %w", err)
+	}
+	if !user.Status.IsNormal() {
+		return inactive, nil
+	}
+
+	introspection := &OAuthIntrospection{
+		Active:    true,
+		Scope:     claims.Scope,
+		ClientID:  claims.ClientID,
+		Username:  user.Username,
+		TokenType: "Bearer",
+		Subject:   user.ID,
+		Issuer:    uc.opts.Issuer,
+		Role:      user.Role.String(),
+	}
+	if claims.ExpiresAt != nil {
+		introspection.ExpiresAt = claims.ExpiresAt.Unix()
+	}
+	if claims.IssuedAt != nil {
+		introspection.IssuedAt = claims.IssuedAt.Unix()
+	}
+	return introspection, nil
+}
+
+// Return the state of a refresh token issued to the client, nil if it is not one.
+func (uc *OAuthUseCase) introspectRefreshToken(ctx context.Context, client *OAuthClient, token string) (*OAuthIntrospection, error) {
+	refreshToken, err := uc.oauthRepo.GetRefreshToken(ctx, oidc.HashToken(token))
+	if err != nil {
+		if errors.Is(err, ErrOAuthGrantNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+	inactive := &OAuthIntrospection{Active: false}
+	if refreshToken.ClientID != client.ID || refreshToken.UsedAt != nil || time.Now().After(refreshToken.ExpiresAt) {
+		return inactive, nil
+	}
+	user, err := uc.activeUser(ctx, refreshToken.Username, refreshToken.UserID)
+	if err != nil {
+		var oauthErr *OAuthError
+		if errors.As(err, &oauthErr) {
+			return inactive, nil
+		}
+		return nil, err
+	}
+	return &OAuthIntrospection{
+		Active:    true,
+		Scope:     strings.Join(refreshToken.Scopes, " "),
+		ClientID:  refreshToken.ClientID,
+		Username:  user.Username,
+		ExpiresAt: refreshToken.ExpiresAt.Unix(),
+		Subject:   user.ID,
+		Issuer:    uc.opts.Issuer,
+		Role:      user.Role.String(),
+	}, nil
+}
+
+// Revoke an access token issued to the client, it reports false if the token is not an access token.
+func (uc *OAuthUseCase) revokeAccessToken(ctx context.Context, client *OAuthClient, token string) (bool, error) {
+	claims, err := jwt.ParseToken(token)
+	if err != nil {
+		return false, nil //nolint:nilerr // not an access token, or an expired one
+	}
+	if claims.ClientID != client.ID {
+		return true, nil
+	}
+	if err := uc.auth.DeleteToken(ctx, token); err != nil {
+		return true, fmt.Errorf("failed to delete token: %w", err)
+	}
+	return true, nil
+}
+
+// Revoke the family of a refresh token issued to the client, it reports false if the token is
+// not a refresh token.
+func (uc *OAuthUseCase) revokeRefreshToken(ctx context.Context, client *OAuthClient, token string) (bool, error) {
+	refreshToken, err := uc.oauthRepo.GetRefreshToken(ctx, oidc.HashToken(token))
+	if err != nil {
+		if errors.Is(err, ErrOAuthGrantNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+	if refreshToken.ClientID != client.ID {
+		return true, nil
+	}
+	if err := uc.oauthRepo.DeleteRefreshTokenFamily(ctx, refreshToken.FamilyID); err != nil {
+		return true, fmt.Errorf("failed to revoke refresh token: %w", err)
+	}
+	return true, nil
+}
+
+// Authenticate a client with its secret, public clients have none.
+func (uc *OAuthUseCase) authenticateClient(ctx context.Context, clientID, secret string) (*OAuthClient, error) {
+	if clientID == "" {
+		return nil, newOAuthError(OAuthErrInvalidClient, "client authentication is required")
+	}
+	client, err := uc.oauthRepo.GetClient(ctx, clientID)
+	if err != nil {
+		if errors.Is(err, ErrOAuthClientNotFound) {
+			return nil, newOAuthError(OAuthErrInvalidClient, "client authentication failed")
+		}
+		return nil, fmt.Errorf("failed to get oauth client[id=%s]: %w", clientID, err)
+	}
+
+	if client.Public {
+		if secret != "" {
+			return nil, newOAuthError(OAuthErrInvalidClient, "public clients have no secret")
+		}
+		return client, nil
+	}
+	if subtle.ConstantTimeCompare([]byte(oidc.HashToken(secret)), []byte(client.SecretHash)) != 1 {
+		return nil, newOAuthError(OAuthErrInvalidClient, "client authentication failed")
+	}
+	return client, nil
+}
+
+// Exchange an authorization code, with its PKCE code verifier.
+func (uc *OAuthUseCase) exchangeCode(ctx context.Context, client *OAuthClient, req *OAuthTokenRequest) (*OAuthTokenResponse, error) {
+	if req.Code == "" {
+		return nil, newOAuthError(OAuthErrInvalidRequest, "code is required")
+	}
+	code, err := uc.oauthRepo.TakeAuthorizationCode(ctx, oidc.HashToken(req.Code))
+	if err != nil {
+		if errors.Is(err, ErrOAuthGrantNotFound) {
+			return nil, newOAuthError(OAuthErrInvalidGrant, "invalid authorization code")
+		}
+		return nil, fmt.Errorf("failed to take authorization code: %w", err)
+	}
+	switch {
+	case code.ClientID != client.ID:
+		return nil, newOAuthError(OAuthErrInvalidGrant, "the authorization code was issued to another client")
+	case time.Now().After(code.ExpiresAt):
+		return nil, newOAuthError(OAuthErrInvalidGrant, "the authorization code expired")
+	case code.RedirectURI != req.RedirectURI:
+		return nil, newOAuthError(OAuthErrInvalidGrant, "redirect_uri does not match the authorization request")
+	case !oidc.VerifyCodeChallenge(code.CodeChallenge, req.CodeVerifier):
+		return nil, newOAuthError(OAuthErrInvalidGrant, "invalid code_verifier")
+	}
+
+	user, err := uc.activeUser(ctx, code.Username, code.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.issueTokens(ctx, client, user, code.Scopes, code.AuthTime, code.Nonce, id.GenerateUUID(true))
+}
+
+// Exchange a refresh token for new tokens, rotating it.
+func (uc *OAuthUseCase) refresh(ctx context.Context, client *OAuthClient, req *OAuthTokenRequest) (*OAuthTokenResponse, error) {
+	if req.RefreshToken == "" {
+		return nil, newOAuthError(OAuthErrInvalidRequest, "refresh_token is required")
+	}
+	hash := oidc.HashToken(req.RefreshToken)
+	token, err := uc.oauthRepo.GetRefreshToken(ctx, hash)
+	if err != nil {
+		if errors.Is(err, ErrOAuthGrantNotFound) {
+			return nil, newOAuthError(OAuthErrInvalidGrant, "invalid refresh token")
+		}
+		return nil, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+	if token.ClientID != client.ID {
+		return nil, newOAuthError(OAuthErrInvalidGrant, "the refresh token was issued to another client")
+	}
+	if time.Now().After(token.ExpiresAt) {
+		return nil, newOAuthError(OAuthErrInvalidGrant, "the refresh token expired")
+	}
+
+	scopes := token.Scopes
+	if req.Scope != "" {
+		if scopes, err = parseOAuthScopes(req.Scope); err != nil {
+			return nil, err
+		}
+		for _, scope := range scopes {
+			if !slices.Contains(token.Scopes, scope) {
+				return nil, newOAuthError(OAuthErrInvalidScope, "scope %s was not granted", scope)
+			}
+		}
+	}
+
+	unused, err := uc.oauthRepo.UseRefreshToken(ctx, hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to use refresh token: %w", err)
+	}
+	if !unused {
+		if err := uc.oauthRepo.DeleteRefreshTokenFamily(ctx, token.FamilyID); err != nil {
+			return nil, fmt.Errorf("failed to revoke reused refresh token: %w", err)
+		}
+		return nil, newOAuthError(OAuthErrInvalidGrant, "the refresh token was already used")
+	}
+
+	user, err := uc.activeUser(ctx, token.Username, token.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.issueTokens(ctx, client, user, scopes, token.AuthTime, "", token.FamilyID)
+}
+
+// Report whether the authorization an access token was issued from is still granted, as long as
+// any refresh token rotated from it is left: revoking the refresh tokens revokes the access
+// tokens issued with them (RFC 7009, section 2.1). The login tokens have no authorization.
+func (uc *OAuthUseCase) grantActive(ctx context.Context, claims *jwt.Claims) (bool, error) {
+	if claims.ClientID == "" {
+		return true, nil
+	}
+	if claims.Grant == "" {
+		return false, nil
+	}
+	exists, err := uc.oauthRepo.RefreshTokenFamilyExists(ctx, claims.Grant)
+	if err != nil {
+		return false, fmt.Errorf("failed to check refresh tokens: %w", err)
+	}
+	return exists, nil
+}
+
+// Return the user a grant was issued for, if still the same user and active.
+func (uc *OAuthUseCase) activeUser(ctx context.Context, username, userID string) (*User, error) {
+	user, err := uc.auth.GetUserByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return nil, newOAuthError(OAuthErrInvalidGrant, "the user no longer exists")
+		}
+		return nil, err
+	}
+	if user.ID != userID {
+		return nil, newOAuthError(OAuthErrInvalidGrant, "the user no longer exists")
+	}
+	if !user.Status.IsNormal() {
+		return nil, newOAuthError(OAuthErrInvalidGrant, "the user is %s", user.Status)
+	}
+	return user, nil
+}
+
+// Issue an access token, a refresh token and, for the openid scope, an ID token.
+func (uc *OAuthUseCase) issueTokens(ctx context.Context, client *OAuthClient, user *User, scopes []string, authTime time.Time, nonce, familyID string) (*OAuthTokenResponse, error) {
+	scope := strings.Join(scopes, " ")
+	accessToken, expiresAt, err := uc.auth.generateToken(ctx, user.Username, jwt.WithClient(client.ID, scope), jwt.WithGrant(familyID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate access token: %w", err)
+	}
+
+	refreshToken, err := oidc.GenerateToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	now := time.Now()
+	err = uc.oauthRepo.CreateRefreshToken(ctx, &OAuthRefreshToken{
+		Hash:      oidc.HashToken(refreshToken),
+		FamilyID:  familyID,
+		ClientID:  client.ID,
+		UserID:    user.ID,
+		Username:  user.Username,
+		Scopes:    scopes,
+		AuthTime:  authTime,
+		ExpiresAt: now.Add(uc.opts.RefreshTokenTTL),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create refresh token: %w", err)
+	}
+
+	resp := &OAuthTokenResponse{
+		AccessToken:  accessToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(expiresAt.Sub(now).Seconds()),
+		RefreshToken: refreshToken,
+		Scope:        scope,
+	}
+	if slices.Contains(scopes, OAuthScopeOpenID) {
+		claims := gojwt.MapClaims(oauthUserClaims(user, scopes))
+		claims["iss"] = uc.opts.Issuer
+		claims["aud"] = client.ID
+		claims["iat"] = now.Unix()
+		claims["exp"] = now.Add(uc.opts.IDTokenTTL).Unix()
+		claims["auth_time"] = authTime.Unix()
+		claims["at_hash"] = oidc.AccessTokenHash(accessToken)
+		if nonce != "" {
+			claims["nonce"] = nonce
+		}
+		if resp.IDToken, err = uc.opts.Signer.Sign(claims); err != nil {
+			return nil, fmt.Errorf("failed to sign id token: %w", err)
+		}
+	}
+	return resp, nil
+}
+
+// Return the claims about a user released by the scopes, the subject is the user ID.
+func oauthUserClaims(user *User, scopes []string) map[string]any {
+	claims := map[string]any{"sub": user.ID}
+	if slices.Contains(scopes, OAuthScopeProfile) {
+		claims["preferred_username"] = user.Username
+		claims["role"] = user.Role.String()
+		claims["updated_at"] = user.UpdatedAt.Unix()
+	}
+	// The addresses are not verified by this service
+	if slices.Contains(scopes, OAuthScopeEmail) && user.Email != "" {
+		claims["email"] = user.Email
+		claims["email_verified"] = false
+	}
+	if slices.Contains(scopes, OAuthScopePhone) && user.Phone != "" {
+		claims["phone_number"] = user.Phone
+		claims["phone_number_verified"] = false
+	}
+	return claims
+}
+
+// Parse the space-separated scopes of a request, all of them must be supported.
+func parseOAuthScopes(scope string) ([]string, error) {
+	var scopes []string
+	for _, s := range strings.Fields(scope) {
+		if !slices.Contains(OAuthScopes, s) {
+			return nil, newOAuthError(OAuthErrInvalidScope, "unsupported scope %s", s)
+		}
+		if !slices.Contains(scopes, s) {
+			scopes = append(scopes, s)
+		}
+	}
+	if len(scopes) == 0 {
+		return nil, newOAuthError(OAuthErrInvalidScope, "scope is required")
+	}
+	return scopes, nil
+}
+
+// Validate a redirect URI of a client: an absolute https URL without fragment,
+// http is allowed on the loopback interface for development.
+func validateRedirectURI(uri string) error {
+	u, err := url.Parse(uri)
+	if err != nil || u.Host == "" || u.Fragment != "" {
+		return fmt.Errorf("%w: invalid redirect uri[%s]", ErrInvalidOAuthClient, uri)
+	}
+	switch u.Scheme {
+	case "https":
+		return nil
+	case "http":
+		if host := u.Hostname(); host == "localhost" || host == "127.0.0.1" || host == "::1" {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: redirect uri[%s] must use https", ErrInvalidOAuthClient, uri)
+}
@@ -0,0 +1,12 @@
+package biz
+
+import "context"
+
+// Transaction runs a unit of work atomically.
+//
+// Repositories called with the context passed to fn take part in the same transaction.
+type Transaction interface {
+	// InTx runs fn inside a transaction. The transaction is committed if fn returns nil,
+	// otherwise it is rolled back.
+	InTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
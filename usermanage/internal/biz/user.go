@@ -5,7 +5,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/mail"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
+	"usermanage/internal/pkg/auth"
 	"usermanage/internal/pkg/constants"
 )
 
@@ -14,6 +19,10 @@ type UserRepo interface {
 	// GetUserByID gets the user by ID.
 	GetUserByID(ctx context.Context, id string) (*User, error)
 
+	// LockUserByID gets the user by ID and locks it until the end of the transaction
+	// carried by the context, so concurrent changes of the user are applied one after the other.
+	LockUserByID(ctx context.Context, id string) (*User, error)
+
 	// GetUserByUsername gets the user by username.
 	GetUserByUsername(ctx context.Context, username string) (*User, error)
 
@@ -34,6 +43,10 @@ type UserRepo interface {
 	// ListUsers returns a paginated list of users.
 	ListUsers(ctx context.Context, params UserListParams) (*UserListResult, error)
 
+	// IterateUsers calls fn with the users matching the filters of params, ignoring the
+	// pagination and sort, in batches of `batchSize` ordered by ID. It stops at the first error.
+	IterateUsers(ctx context.Context, params UserListParams, batchSize int, fn func(users []*User) error) error
+
 	// CreateUser creates a new user.
 	CreateUser(ctx context.Context, params UserCreateParams) (*User, error)
 
@@ -61,8 +74,34 @@ type UserRepo interface {
 	//
 	// The `password` parameter is plaintext.
 	VerifyPassword(ctx context.Context, id, password string) (bool, error)
+
+	// ListUserRevisions returns a paginated list of the user's revisions, newest first.
+	ListUserRevisions(ctx context.Context, id string, params UserRevisionListParams) (*UserRevisionListResult, error)
+
+	// GetUserRevisionAt returns the latest revision of the user recorded at or before `at`.
+	GetUserRevisionAt(ctx context.Context, id string, at time.Time) (*UserRevision, error)
+
+	// RestoreUserRevision replaces the user with the snapshot of the given revision
+	// and records the rollback as a new revision.
+	RestoreUserRevision(ctx context.Context, id string, revision int64, operator string) (*User, error)
+
+	// ListDeletedUsers returns a paginated list of the soft-deleted users, most recently deleted first.
+	ListDeletedUsers(ctx context.Context, params DeletedUserListParams) (*UserListResult, error)
+
+	// GetDeletedUserByID gets a soft-deleted user by ID.
+	GetDeletedUserByID(ctx context.Context, id string) (*User, error)
+
+	// UndeleteUser brings a soft-deleted user back and records it as a new revision.
+	// It fails if a live user has the same username.
+	UndeleteUser(ctx context.Context, id string, operator string) (*User, error)
+
+	// PurgeUser permanently removes a soft-deleted user and its revisions.
+	PurgeUser(ctx context.Context, id string) error
 }
 
+// phonePattern matches the E.164 phone numbers.
+var phonePattern = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+
 // User is the user entity.
 type User struct {
 	ID        string     `json:"id"`
@@ -70,9 +109,56 @@ type User struct {
 	Role      UserRole   `json:"role"`
 	Status    UserStatus `json:"status"`
 	Creator   string     `json:"creator"`
+	Source    string     `json:"source,omitempty"` // provisioner, e.g. `ldap`
 	CreatedAt time.Time  `json:"createdAt"`
 	UpdatedBy string     `json:"updatedBy"`
 	UpdatedAt time.Time  `json:"updatedAt"`
+	DeletedAt *time.Time `json:"deletedAt,omitempty"` // only set on deleted users
+	Email     string     `json:"email,omitempty"`     // encrypted at rest
+	Phone     string     `json:"phone,omitempty"`     // encrypted at rest
+	Version   int64      `json:"version"`             // incremented by every change
+}
+
+var (
+	// ErrVersionConflict is returned when a change is based on an outdated version of a user.
+	ErrVersionConflict = errors.New("version conflict")
+
+	// ErrUserNotFound is returned when a user does not exist.
+	ErrUserNotFound = errors.New("user not found")
+
+	// ErrUsernameExists is returned when another user has the username.
+	ErrUsernameExists = errors.New("already exists")
+
+	// ErrRevisionNotRestorable is returned when a revision cannot be restored.
+	ErrRevisionNotRestorable = errors.New("revision cannot be restored")
+)
+
+// ETag returns the entity tag of the user's version, e.g. `"3"`.
+func (u *User) ETag() string {
+	return strconv.Quote(strconv.FormatInt(u.Version, 10))
+}
+
+// ParseETag returns the version of an entity tag returned by `User.ETag`.
+//
+// Weak tags are accepted, quotes are optional.
+func ParseETag(etag string) (int64, error) {
+	value := strings.TrimPrefix(strings.TrimSpace(etag), "W/")
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		value = unquoted
+	}
+	version, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || version <= 0 {
+		return 0, fmt.Errorf("invalid etag[%s]", etag)
+	}
+	return version, nil
+}
+
+// Check the user is at the version a change is based on, 0 skips the check.
+func checkVersion(u *User, version int64) error {
+	if version != 0 && u.Version != version {
+		return fmt.Errorf("%w: user[id=%s] is at version %d, not %d", ErrVersionConflict, u.ID, u.Version, version)
+	}
+	return nil
 }
 
 // UserListParams represents all parameters for user listing
@@ -81,6 +167,9 @@ type UserListParams struct {
 	PageSize  int32  `json:"page_size"`  // page size
 	Username  string `json:"username"`   // filter by username (optional)
 	Status    int32  `json:"status"`     // filter by status (optional)
+	Role      int32  `json:"role"`       // filter by role (optional)
+	Email     string `json:"-"`          // filter by email, exact match (optional)
+	Offset    int32  `json:"offset"`     // users skipped before the page, for offsets which are not page boundaries (optional)
 	SortBy    string `json:"sort_by"`    // sort field (optional)
 	SortOrder string `json:"sort_order"` // sort direction: asc/desc (optional)
 }
@@ -113,6 +202,8 @@ func (p *UserListParams) GetPage() (page, size int32) {
 type UserListResult struct {
 	TotalCount int64
 	Users      []*User
+	// ResourceVersion is the version to watch from to receive the changes made after the listing.
+	ResourceVersion uint64
 }
 
 // UserCreateParams represents the parameters for creating a user.
@@ -123,6 +214,9 @@ type UserCreateParams struct {
 	Status   int32  `json:"status"`
 	Creator  string `json:"creator"`
 	UpdateBy string `json:"updated_by"`
+	Email    string `json:"-"`
+	Phone    string `json:"-"`
+	Source   string `json:"source,omitempty"` // set by the provisioners only, never by the API
 }
 
 // String implements fmt.Stringer interface
@@ -148,7 +242,7 @@ func (p *UserCreateParams) Validate() error {
 	if !UserStatus(p.Status).IsValid() {
 		return errors.New("invalid status")
 	}
-	return nil
+	return validateContacts(p.Email, p.Phone)
 }
 
 // UserUpdateParams represents the parameters for updating a user.
@@ -156,7 +250,10 @@ type UserUpdateParams struct {
 	Username  *string `json:"username,omitempty"`
 	Role      *int32  `json:"role,omitempty"`
 	Status    *int32  `json:"status,omitempty"`
+	Email     *string `json:"-"` // empty removes the email
+	Phone     *string `json:"-"` // empty removes the phone
 	UpdatedBy string  `json:"updated_by,omitempty"`
+	Version   int64   `json:"version,omitempty"` // version the update is based on, 0 skips the check
 }
 
 // String implements fmt.Stringer interface
@@ -179,7 +276,14 @@ func (p *UserUpdateParams) Validate() error {
 	if p.Status != nil && !UserStatus(*p.Status).IsValid() {
 		return errors.New("invalid status")
 	}
-	return nil
+	var email, phone string
+	if p.Email != nil {
+		email = *p.Email
+	}
+	if p.Phone != nil {
+		phone = *p.Phone
+	}
+	return validateContacts(email, phone)
 }
 
 // UserReplaceParams represents the parameters for replacing a user.
@@ -187,7 +291,10 @@ type UserReplaceParams struct {
 	Username  string `json:"username"`
 	Role      int32  `json:"role"`
 	Status    int32  `json:"status"`
+	Email     string `json:"-"`
+	Phone     string `json:"-"`
 	UpdatedBy string `json:"updated_by"`
+	Version   int64  `json:"version,omitempty"` // version the replacement is based on, 0 skips the check
 }
 
 // String implements fmt.Stringer interface
@@ -210,23 +317,54 @@ func (p *UserReplaceParams) Validate() error {
 	if !UserStatus(p.Status).IsValid() {
 		return errors.New("invalid status")
 	}
+	return validateContacts(p.Email, p.Phone)
+}
+
+// Validate the optional email and phone of a user.
+func validateContacts(email, phone string) error {
+	if email != "" {
+		if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+			return errors.New("invalid email")
+		}
+	}
+	if phone != "" && !phonePattern.MatchString(phone) {
+		return errors.New("invalid phone, expected E.164 e.g. +15550100")
+	}
 	return nil
 }
 
 // UserUseCase is the use case for user.
 type UserUseCase struct {
+	tx        Transaction
 	userRepo  UserRepo
 	tokenRepo TokenRepo
+	eventRepo EventRepo
 }
 
 // NewUserUseCase creates a new UserUseCase.
-func NewUserUseCase(userRepo UserRepo, tokenRepo TokenRepo) *UserUseCase {
-	return &UserUseCase{userRepo: userRepo, tokenRepo: tokenRepo}
+func NewUserUseCase(tx Transaction, userRepo UserRepo, tokenRepo TokenRepo, eventRepo EventRepo) *UserUseCase {
+	return &UserUseCase{tx: tx, userRepo: userRepo, tokenRepo: tokenRepo, eventRepo: eventRepo}
 }
 
 // ListUsers lists users.
 func (uc *UserUseCase) ListUsers(ctx context.Context, params UserListParams) (*UserListResult, error) {
-	return uc.userRepo.ListUsers(ctx, params)
+	// Read the version first, a change made during the listing is then streamed again rather than missed
+	version, err := uc.eventRepo.LatestVersion(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest resource version: %w", err)
+	}
+	result, err := uc.userRepo.ListUsers(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	result.ResourceVersion = version
+	return result, nil
+}
+
+// IterateUsers calls fn with the users matching the filters of a listing, in batches
+// ordered by ID. The pagination and sort of the params are ignored.
+func (uc *UserUseCase) IterateUsers(ctx context.Context, params UserListParams, batchSize int, fn func([]*User) error) error {
+	return uc.userRepo.IterateUsers(ctx, params, batchSize, fn)
 }
 
 // GetUser gets a user by ID.
@@ -250,9 +388,16 @@ func (uc *UserUseCase) CreateUser(ctx context.Context, params UserCreateParams)
 		return nil, fmt.Errorf("invalid create user params: %w", err)
 	}
 
-	user, err := uc.userRepo.CreateUser(ctx, params)
+	var user *User
+	err := uc.tx.InTx(ctx, func(ctx context.Context) (err error) {
+		user, err = uc.userRepo.CreateUser(ctx, params)
+		if err != nil {
+			return fmt.Errorf("failed to create user: %w", err)
+		}
+		return uc.eventRepo.Append(ctx, NewUserEvent(EventTypeUserCreated, user, params.Creator))
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create user: %w", err)
+		return nil, err
 	}
 	return user, nil
 }
@@ -266,9 +411,23 @@ func (uc *UserUseCase) UpdateUser(ctx context.Context, id string, params UserUpd
 		return nil, fmt.Errorf("invalid update user params: %w", err)
 	}
 
-	user, err := uc.userRepo.UpdateUser(ctx, id, params)
+	var user *User
+	err := uc.tx.InTx(ctx, func(ctx context.Context) error {
+		before, err := uc.userRepo.LockUserByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get user[id=%s]: %w", id, err)
+		}
+		if err := checkVersion(before, params.Version); err != nil {
+			return err
+		}
+		user, err = uc.userRepo.UpdateUser(ctx, id, params)
+		if err != nil {
+			return fmt.Errorf("failed to update user[id=%s]: %w", id, err)
+		}
+		return uc.eventRepo.Append(ctx, userChangedEvents(before, user, params.UpdatedBy)...)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to update user[id=%s]: %w", id, err)
+		return nil, err
 	}
 	return user, nil
 }
@@ -282,27 +441,38 @@ func (uc *UserUseCase) ReplaceUser(ctx context.Context, id string, params UserRe
 		return nil, fmt.Errorf("invalid replace user params: %w", err)
 	}
 
-	user, err := uc.userRepo.ReplaceUser(ctx, id, params)
+	var user *User
+	err := uc.tx.InTx(ctx, func(ctx context.Context) error {
+		before, err := uc.userRepo.LockUserByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get user[id=%s]: %w", id, err)
+		}
+		if err := checkVersion(before, params.Version); err != nil {
+			return err
+		}
+		user, err = uc.userRepo.ReplaceUser(ctx, id, params)
+		if err != nil {
+			return fmt.Errorf("failed to replace user[id=%s]: %w", id, err)
+		}
+		return uc.eventRepo.Append(ctx, userChangedEvents(before, user, params.UpdatedBy)...)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to replace user[id=%s]: %w", id, err)
+		return nil, err
 	}
 	return user, nil
 }
 
 // DeleteUser deletes a user.
-func (uc *UserUseCase) DeleteUser(ctx context.Context, id string) error {
+//
+// `version` is the version of the user the deletion is based on, 0 skips the check.
+func (uc *UserUseCase) DeleteUser(ctx context.Context, id string, version int64) error {
 	if id == "" {
 		return errors.New("user id is required")
 	}
 
-	user, err := uc.userRepo.GetUserByID(ctx, id)
+	username, err := uc.deleteUser(ctx, id, version)
 	if err != nil {
-		return fmt.Errorf("failed to get user[id=%s]: %w", id, err)
-	}
-
-	username := user.Username
-	if err := uc.userRepo.DeleteUser(ctx, id); err != nil {
-		return fmt.Errorf("failed to delete user[id=%s]: %w", id, err)
+		return err
 	}
 
 	if err := uc.tokenRepo.DeleteTokensByUsername(ctx, username); err != nil {
@@ -312,6 +482,26 @@ func (uc *UserUseCase) DeleteUser(ctx context.Context, id string) error {
 	return nil
 }
 
+// Delete a user in a transaction, leaving its sessions to the caller, and return its username.
+func (uc *UserUseCase) deleteUser(ctx context.Context, id string, version int64) (string, error) {
+	var username string
+	err := uc.tx.InTx(ctx, func(ctx context.Context) error {
+		user, err := uc.userRepo.LockUserByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get user[id=%s]: %w", id, err)
+		}
+		if err := checkVersion(user, version); err != nil {
+			return err
+		}
+		username = user.Username
+		if err := uc.userRepo.DeleteUser(ctx, id); err != nil {
+			return fmt.Errorf("failed to delete user[id=%s]: %w", id, err)
+		}
+		return uc.eventRepo.Append(ctx, NewUserEvent(EventTypeUserDeleted, user, auth.Username(ctx)))
+	})
+	return username, err
+}
+
 // ResetUserPassword resets the user password.
 func (uc *UserUseCase) ResetUserPassword(ctx context.Context, id string, newPassword string) (*User, error) {
 	if id == "" {
@@ -0,0 +1,230 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"usermanage/internal/pkg/auth"
+	"usermanage/internal/pkg/db"
+)
+
+// MaxUserBatchSize is the maximum number of users of a batch operation.
+const MaxUserBatchSize = 1000
+
+var (
+	// ErrInvalidUserBatch is returned when the parameters of a batch operation are invalid.
+	ErrInvalidUserBatch = errors.New("invalid user batch")
+
+	// errBatchRolledBack marks the items of an all-or-nothing batch rolled back by another item.
+	errBatchRolledBack = errors.New("rolled back")
+)
+
+// UserBatchMode tells how the failures of a batch operation are handled.
+type UserBatchMode int32
+
+const (
+	UserBatchModeUnknown      UserBatchMode = iota
+	UserBatchModeBestEffort                 // every user is changed in its own transaction
+	UserBatchModeAllOrNothing               // a single transaction, rolled back at the first failure
+)
+
+// UserBatchSelector selects the users of a batch operation, by ID or by the filters of a listing.
+type UserBatchSelector struct {
+	IDs    []string
+	Filter *UserListParams // the pagination and sort are ignored, at least one filter is required
+}
+
+// UserBatchUpdateParams represents the parameters for changing many users.
+type UserBatchUpdateParams struct {
+	Selector UserBatchSelector
+	Role     *int32
+	Status   *int32
+	Mode     UserBatchMode
+}
+
+// UserBatchDeleteParams represents the parameters for deleting many users.
+type UserBatchDeleteParams struct {
+	Selector UserBatchSelector
+	Mode     UserBatchMode
+}
+
+// UserBatchItemResult is the outcome of a batch operation for a user.
+type UserBatchItemResult struct {
+	ID       string
+	Username string
+	Err      error
+}
+
+// UserBatchResult is the report of a batch operation.
+type UserBatchResult struct {
+	Succeeded  int
+	Failed     int
+	RolledBack bool // a failure rolled every change of an all-or-nothing batch back
+	Items      []UserBatchItemResult
+}
+
+// Validate the mode and the selector of a batch.
+func validateUserBatch(mode UserBatchMode, selector UserBatchSelector) error {
+	if mode != UserBatchModeUnknown && mode != UserBatchModeBestEffort && mode != UserBatchModeAllOrNothing {
+		return fmt.Errorf("%w: invalid mode", ErrInvalidUserBatch)
+	}
+	switch {
+	case len(selector.IDs) > 0 && selector.Filter != nil:
+		return fmt.Errorf("%w: either ids or a filter is required, not both", ErrInvalidUserBatch)
+	case len(selector.IDs) > MaxUserBatchSize:
+		return fmt.Errorf("%w: at most %d users are allowed", ErrInvalidUserBatch, MaxUserBatchSize)
+	case selector.Filter != nil:
+		if f := selector.Filter; f.Username == "" && f.Status == 0 && f.Role == 0 && f.Email == "" {
+			return fmt.Errorf("%w: the filter matches every user", ErrInvalidUserBatch)
+		}
+	case len(selector.IDs) == 0:
+		return fmt.Errorf("%w: ids or a filter is required", ErrInvalidUserBatch)
+	}
+	return nil
+}
+
+// Validate() validates the batch update params.
+func (p *UserBatchUpdateParams) Validate() error {
+	if p.Role == nil && p.Status == nil {
+		return fmt.Errorf("%w: a role or a status is required", ErrInvalidUserBatch)
+	}
+	if p.Role != nil && !UserRole(*p.Role).IsValid() {
+		return fmt.Errorf("%w: invalid role", ErrInvalidUserBatch)
+	}
+	if p.Status != nil && !UserStatus(*p.Status).IsValid() {
+		return fmt.Errorf("%w: invalid status", ErrInvalidUserBatch)
+	}
+	return validateUserBatch(p.Mode, p.Selector)
+}
+
+// Validate() validates the batch delete params.
+func (p *UserBatchDeleteParams) Validate() error {
+	return validateUserBatch(p.Mode, p.Selector)
+}
+
+// BatchUpdateUsers changes the role or the status of many users, revoking the sessions
+// of the users no longer in the normal status.
+func (uc *UserUseCase) BatchUpdateUsers(ctx context.Context, params UserBatchUpdateParams) (*UserBatchResult, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	update := UserUpdateParams{Role: params.Role, Status: params.Status, UpdatedBy: auth.Username(ctx)}
+	return uc.runUserBatch(ctx, params.Selector, params.Mode, func(ctx context.Context, id string) (string, bool, error) {
+		user, err := uc.UpdateUser(ctx, id, update)
+		if err != nil {
+			return "", false, err
+		}
+		return user.Username, !user.Status.IsNormal(), nil
+	})
+}
+
+// BatchDeleteUsers deletes many users and revokes their sessions.
+func (uc *UserUseCase) BatchDeleteUsers(ctx context.Context, params UserBatchDeleteParams) (*UserBatchResult, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	return uc.runUserBatch(ctx, params.Selector, params.Mode, func(ctx context.Context, id string) (string, bool, error) {
+		username, err := uc.deleteUser(ctx, id, 0)
+		return username, err == nil, err
+	})
+}
+
+// Apply a change to every selected user, each in its own transaction or all in one.
+//
+// The change runs in the transaction and reports whether the sessions of the user must be
+// revoked. They are revoked once the transaction commits, so a rolled back change keeps
+// them; a failure to revoke them fails the item although its change is committed.
+func (uc *UserUseCase) runUserBatch(ctx context.Context, selector UserBatchSelector, mode UserBatchMode,
+	change func(ctx context.Context, id string) (username string, revoke bool, err error)) (*UserBatchResult, error) {
+	ids, err := uc.selectUserBatch(ctx, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &UserBatchResult{Items: make([]UserBatchItemResult, len(ids))}
+	apply := func(ctx context.Context, i int) error {
+		item := &result.Items[i]
+		item.ID = ids[i]
+		username, revoke, err := change(ctx, item.ID)
+		item.Username, item.Err = username, err
+		if err != nil || !revoke {
+			return err
+		}
+		db.AfterCommit(ctx, func() {
+			if err := uc.tokenRepo.DeleteTokensByUsername(ctx, username); err != nil {
+				item.Err = fmt.Errorf("changed, but failed to delete tokens by username[%s]: %w", username, err)
+			}
+		})
+		return nil
+	}
+
+	if mode == UserBatchModeAllOrNothing {
+		err := uc.tx.InTx(ctx, func(ctx context.Context) error {
+			for i := range ids {
+				if err := apply(ctx, i); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			result.RolledBack = true
+			for i := range result.Items {
+				result.Items[i].ID = ids[i]
+				if result.Items[i].Err == nil {
+					result.Items[i].Err = errBatchRolledBack
+				}
+			}
+		}
+	} else {
+		for i := range ids {
+			err := uc.tx.InTx(ctx, func(ctx context.Context) error {
+				return apply(ctx, i)
+			})
+			if err != nil {
+				result.Items[i].Err = err
+			}
+		}
+	}
+
+	for _, item := range result.Items {
+		if item.Err == nil {
+			result.Succeeded++
+		} else {
+			result.Failed++
+		}
+	}
+	return result, nil
+}
+
+// Return the IDs of the users selected by a batch, without duplicates.
+func (uc *UserUseCase) selectUserBatch(ctx context.Context, selector UserBatchSelector) ([]string, error) {
+	if selector.Filter == nil {
+		ids := make([]string, 0, len(selector.IDs))
+		seen := make(map[string]bool, len(selector.IDs))
+		for _, id := range selector.IDs {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+		return ids, nil
+	}
+
+	var ids []string
+	err := uc.userRepo.IterateUsers(ctx, *selector.Filter, MaxUserBatchSize+1, func(users []*User) error {
+		for _, user := range users {
+			ids = append(ids, user.ID)
+		}
+		if len(ids) > MaxUserBatchSize {
+			return fmt.Errorf("%w: the filter matches more than %d users", ErrInvalidUserBatch, MaxUserBatchSize)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
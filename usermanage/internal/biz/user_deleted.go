@@ -0,0 +1,100 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"usermanage/internal/pkg/constants"
+)
+
+// DeletedUserListParams represents all parameters for deleted user listing.
+type DeletedUserListParams struct {
+	Page          int32     `json:"page"`           // current page number (1-based)
+	PageSize      int32     `json:"page_size"`      // page size
+	Username      string    `json:"username"`       // filter by username (optional)
+	DeletedBefore time.Time `json:"deleted_before"` // filter by deletion time (optional)
+}
+
+// GetPage returns the page and size.
+func (p *DeletedUserListParams) GetPage() (page, size int32) {
+	p.Page, p.PageSize = normalizePage(p.Page, p.PageSize)
+	return p.Page, p.PageSize
+}
+
+// ListDeletedUsers lists the soft-deleted users, most recently deleted first.
+func (uc *UserUseCase) ListDeletedUsers(ctx context.Context, params DeletedUserListParams) (*UserListResult, error) {
+	result, err := uc.userRepo.ListDeletedUsers(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deleted users: %w", err)
+	}
+	return result, nil
+}
+
+// UndeleteUser brings a soft-deleted user back.
+//
+// The user keeps its previous password, its sessions were revoked on deletion.
+func (uc *UserUseCase) UndeleteUser(ctx context.Context, id string, operator string) (*User, error) {
+	if id == "" {
+		return nil, errors.New("user id is required")
+	}
+
+	var user *User
+	err := uc.tx.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		user, err = uc.userRepo.UndeleteUser(ctx, id, operator)
+		if err != nil {
+			return fmt.Errorf("failed to undelete user[id=%s]: %w", id, err)
+		}
+		return uc.eventRepo.Append(ctx, NewUserEvent(EventTypeUserUndeleted, user, operator))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// PurgeUser permanently removes a soft-deleted user.
+func (uc *UserUseCase) PurgeUser(ctx context.Context, id string, operator string) error {
+	if id == "" {
+		return errors.New("user id is required")
+	}
+
+	return uc.tx.InTx(ctx, func(ctx context.Context) error {
+		user, err := uc.userRepo.GetDeletedUserByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get deleted user[id=%s]: %w", id, err)
+		}
+		if err := uc.userRepo.PurgeUser(ctx, id); err != nil {
+			return fmt.Errorf("failed to purge user[id=%s]: %w", id, err)
+		}
+		// The personal data of the user is gone with its events, the purge is told by ID only
+		return uc.eventRepo.Append(ctx, NewUserEvent(EventTypeUserPurged, &User{ID: user.ID}, operator))
+	})
+}
+
+// PurgeDeletedUsers permanently removes up to `limit` users deleted before the given time
+// and returns how many were purged.
+func (uc *UserUseCase) PurgeDeletedUsers(ctx context.Context, deletedBefore time.Time, limit int32) (int, error) {
+	if limit <= 0 || limit > constants.MaxPageSize {
+		limit = constants.MaxPageSize
+	}
+
+	result, err := uc.userRepo.ListDeletedUsers(ctx, DeletedUserListParams{
+		Page:          constants.DefaultPage,
+		PageSize:      limit,
+		DeletedBefore: deletedBefore,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list users deleted before %s: %w", deletedBefore.Format(time.RFC3339), err)
+	}
+
+	purged := 0
+	for _, user := range result.Users {
+		if err := uc.PurgeUser(ctx, user.ID, ""); err != nil {
+			return purged, err
+		}
+		purged++
+	}
+	return purged, nil
+}
@@ -0,0 +1,290 @@
+package biz
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"usermanage/internal/pkg/xlsx"
+)
+
+const (
+	// userExportBatchSize is the number of users read per query.
+	userExportBatchSize = 500
+	// userExportBufferSize is how much is buffered before writing, the output is also flushed after every batch.
+	userExportBufferSize = 64 << 10
+)
+
+// ErrInvalidUserExport is returned when the parameters of an export are invalid.
+var ErrInvalidUserExport = errors.New("invalid user export")
+
+// UserExportFormat is the format of an export file.
+type UserExportFormat int32
+
+const (
+	UserExportFormatUnknown UserExportFormat = iota
+	UserExportFormatCSV
+	UserExportFormatJSONLines
+	UserExportFormatXLSX
+)
+
+// ContentType returns the media type of the format.
+func (f UserExportFormat) ContentType() string {
+	switch f {
+	case UserExportFormatCSV:
+		return "text/csv; charset=utf-8"
+	case UserExportFormatJSONLines:
+		return "application/x-ndjson"
+	case UserExportFormatXLSX:
+		return xlsx.ContentType
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// Extension returns the file extension of the format.
+func (f UserExportFormat) Extension() string {
+	switch f {
+	case UserExportFormatCSV:
+		return "csv"
+	case UserExportFormatJSONLines:
+		return "jsonl"
+	case UserExportFormatXLSX:
+		return "xlsx"
+	default:
+		return "bin"
+	}
+}
+
+// UserExportColumns are the columns of an export, in their default order.
+var UserExportColumns = []string{
+	"id", "username", "role", "status", "email", "phone", "creator", "updated_by", "created_at", "updated_at",
+}
+
+// UserExportParams represents the parameters of an export.
+type UserExportParams struct {
+	Filter  UserListParams // the pagination and sort are ignored
+	Format  UserExportFormat
+	Columns []string // all by default
+}
+
+// Validate validates the user export params.
+func (p *UserExportParams) Validate() error {
+	if p.Format < UserExportFormatCSV || p.Format > UserExportFormatXLSX {
+		return fmt.Errorf("%w: invalid format", ErrInvalidUserExport)
+	}
+	seen := make(map[string]bool, len(p.Columns))
+	for _, column := range p.Columns {
+		if !isUserExportColumn(column) {
+			return fmt.Errorf("%w: unknown column[%s]", ErrInvalidUserExport, column)
+		}
+		if seen[column] {
+			return fmt.Errorf("%w: duplicate column[%s]", ErrInvalidUserExport, column)
+		}
+		seen[column] = true
+	}
+	return nil
+}
+
+// Report whether a column can be exported.
+func isUserExportColumn(column string) bool {
+	for _, c := range UserExportColumns {
+		if c == column {
+			return true
+		}
+	}
+	return false
+}
+
+// userExportEncoder writes the rows of an export in a format.
+type userExportEncoder interface {
+	WriteRow(user *User) error
+	// Flush writes the buffered rows to the underlying writer.
+	Flush() error
+	// Close ends the file, after which nothing can be written.
+	Close() error
+}
+
+// ExportUsers writes the users matching the filters of params to w, reading them in
+// batches so they are never all held in memory. w is written after every batch.
+//
+// Nothing is written if params are invalid, otherwise an error may happen halfway.
+func (uc *UserUseCase) ExportUsers(ctx context.Context, params UserExportParams, w io.Writer) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+	if len(params.Columns) == 0 {
+		params.Columns = UserExportColumns
+	}
+
+	bw := bufio.NewWriterSize(w, userExportBufferSize)
+	enc, err := newUserExportEncoder(bw, params.Format, params.Columns)
+	if err != nil {
+		return err
+	}
+	err = uc.userRepo.IterateUsers(ctx, params.Filter, userExportBatchSize, func(users []*User) error {
+		for _, user := range users {
+			if err := enc.WriteRow(user); err != nil {
+				return fmt.Errorf("failed to write user[id=%s]: %w", user.ID, err)
+			}
+		}
+		if err := enc.Flush(); err != nil {
+			return fmt.Errorf("failed to write users: %w", err)
+		}
+		return bw.Flush()
+	})
+	if err != nil {
+		return err
+	}
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("failed to end export: %w", err)
+	}
+	return bw.Flush()
+}
+
+// Create the encoder of a format, the header is written right away.
+func newUserExportEncoder(w io.Writer, format UserExportFormat, columns []string) (userExportEncoder, error) {
+	switch format {
+	case UserExportFormatCSV:
+		enc := &csvUserEncoder{w: csv.NewWriter(w), columns: columns}
+		return enc, enc.w.Write(columns)
+	case UserExportFormatJSONLines:
+		return &jsonLinesUserEncoder{w: w, columns: columns}, nil
+	case UserExportFormatXLSX:
+		xw, err := xlsx.NewWriter(w, "Users")
+		if err != nil {
+			return nil, err
+		}
+		enc := &xlsxUserEncoder{w: xw, columns: columns}
+		return enc, xw.WriteRow(columns)
+	default:
+		return nil, fmt.Errorf("%w: invalid format", ErrInvalidUserExport)
+	}
+}
+
+// csvUserEncoder writes CSV with a header row.
+type csvUserEncoder struct {
+	w       *csv.Writer
+	columns []string
+}
+
+func (e *csvUserEncoder) WriteRow(user *User) error {
+	record := make([]string, len(e.columns))
+	for i, column := range e.columns {
+		record[i] = csvSafe(userExportValue(user, column))
+	}
+	return e.w.Write(record)
+}
+
+func (e *csvUserEncoder) Flush() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func (e *csvUserEncoder) Close() error {
+	return e.Flush()
+}
+
+// jsonLinesUserEncoder writes a JSON object per user, with the keys in the order of the columns.
+type jsonLinesUserEncoder struct {
+	w       io.Writer
+	columns []string
+}
+
+func (e *jsonLinesUserEncoder) WriteRow(user *User) error {
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, column := range e.columns {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, _ := json.Marshal(column)
+		value, _ := json.Marshal(userExportValue(user, column))
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(e.w, b.String())
+	return err
+}
+
+func (e *jsonLinesUserEncoder) Flush() error { return nil }
+
+func (e *jsonLinesUserEncoder) Close() error { return nil }
+
+// xlsxUserEncoder writes a workbook with a header row.
+type xlsxUserEncoder struct {
+	w       *xlsx.Writer
+	columns []string
+}
+
+func (e *xlsxUserEncoder) WriteRow(user *User) error {
+	cells := make([]string, len(e.columns))
+	for i, column := range e.columns {
+		cells[i] = csvSafe(userExportValue(user, column))
+	}
+	return e.w.WriteRow(cells)
+}
+
+func (e *xlsxUserEncoder) Flush() error {
+	return e.w.Flush()
+}
+
+func (e *xlsxUserEncoder) Close() error {
+	return e.w.Close()
+}
+
+// Return the value of a column of a user as text.
+func userExportValue(user *User, column string) string {
+	switch column {
+	case "id":
+		return user.ID
+	case "username":
+		return user.Username
+	case "role":
+		return user.Role.String()
+	case "status":
+		return user.Status.String()
+	case "email":
+		return user.Email
+	case "phone":
+		return user.Phone
+	case "creator":
+		return user.Creator
+	case "updated_by":
+		return user.UpdatedBy
+	case "created_at":
+		return user.CreatedAt.UTC().Format(time.RFC3339)
+	case "updated_at":
+		return user.UpdatedAt.UTC().Format(time.RFC3339)
+	default:
+		return ""
+	}
+}
+
+// formulaPrefixes are the first characters making a spreadsheet application run a cell
+// as a formula.
+const formulaPrefixes = "=+-@\t\r"
+
+// Neutralize a CSV or XLSX value which a spreadsheet application would run as a formula,
+// by prefixing it with a quote. Phones are prefixed too, `csvUnescape` restores them.
+func csvSafe(value string) string {
+	if value != "" && strings.IndexByte(formulaPrefixes, value[0]) >= 0 {
+		return "'" + value
+	}
+	return value
+}
+
+// Restore a value neutralized by `csvSafe`, so exported files can be imported again.
+func csvUnescape(value string) string {
+	if len(value) > 1 && value[0] == '\'' && strings.IndexByte(formulaPrefixes, value[1]) >= 0 {
+		return value[1:]
+	}
+	return value
+}
@@ -0,0 +1,74 @@
+package biz
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserExportParams_Validate(t *testing.T) {
+	params := UserExportParams{Format: UserExportFormatXLSX, Columns: []string{"username", "email"}}
+	assert.NoError(t, params.Validate())
+
+	for _, params := range []UserExportParams{
+		{Format: UserExportFormatUnknown},
+		{Format: UserExportFormatCSV, Columns: []string{"password"}},
+		{Format: UserExportFormatCSV, Columns: []string{"id", "id"}},
+	} {
+		assert.ErrorIs(t, params.Validate(), ErrInvalidUserExport)
+	}
+}
+
+func TestCSVSafe(t *testing.T) {
+	for value, expected := range map[string]string{
+		"alice":             "alice",
+		"+15550100":         "'+15550100",
+		"-1":                "'-1",
+		"\tcmd":             "'\tcmd",
+		"=HYPERLINK(\"x\")": "'=HYPERLINK(\"x\")",
+		"+SUM(A1:A2)":       "'+SUM(A1:A2)",
+		"@cmd":              "'@cmd",
+		"":                  "",
+	} {
+		assert.Equal(t, expected, csvSafe(value), value)
+		assert.Equal(t, value, csvUnescape(expected), value)
+	}
+	assert.Equal(t, "'quoted", csvUnescape("'quoted"))
+}
+
+func TestNewUserExportEncoder(t *testing.T) {
+	user := &User{Username: "=HYPERLINK(\"x\")", Phone: "+15550100"}
+	columns := []string{"username", "phone"}
+
+	var buf bytes.Buffer
+	enc, err := newUserExportEncoder(&buf, UserExportFormatCSV, columns)
+	require.NoError(t, err)
+	require.NoError(t, enc.WriteRow(user))
+	require.NoError(t, enc.Close())
+	assert.Equal(t, "username,phone\n\"'=HYPERLINK(\"\"x\"\")\",'+15550100\n", buf.String())
+
+	// Imported again as exported
+	rows, err := ParseUserImport(&buf, UserImportFormatCSV)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, user.Username, rows[0].Params.Username)
+	assert.Equal(t, user.Phone, rows[0].Params.Phone)
+
+	buf.Reset()
+	enc, err = newUserExportEncoder(&buf, UserExportFormatXLSX, columns)
+	require.NoError(t, err)
+	require.NoError(t, enc.WriteRow(user))
+	require.NoError(t, enc.Close())
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	sheet, err := zr.Open("xl/worksheets/sheet1.xml")
+	require.NoError(t, err)
+	content, err := io.ReadAll(sheet)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), `<t xml:space="preserve">&#39;=HYPERLINK(&#34;x&#34;)</t>`)
+	assert.Contains(t, string(content), `<t xml:space="preserve">&#39;+15550100</t>`)
+}
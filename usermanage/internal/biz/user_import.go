@@ -0,0 +1,443 @@
+package biz
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"usermanage/internal/pkg/auth"
+)
+
+const (
+	// userImportChunkSize is the number of rows written per transaction.
+	userImportChunkSize = 100
+	// MaxUserImportRows is the maximum number of rows of an import.
+	MaxUserImportRows = 10000
+)
+
+var (
+	// ErrInvalidUserImport is returned when an import file or its options are invalid.
+	ErrInvalidUserImport = errors.New("invalid user import")
+
+	// errDryRun rolls back the transaction of a dry run.
+	errDryRun = errors.New("dry run")
+)
+
+// UserImportFormat is the format of an import file.
+type UserImportFormat int32
+
+const (
+	UserImportFormatUnknown UserImportFormat = iota
+	UserImportFormatCSV
+	UserImportFormatJSONLines
+)
+
+// UserImportMode tells what to do with the rows of existing usernames.
+type UserImportMode int32
+
+const (
+	UserImportModeUnknown UserImportMode = iota
+	UserImportModeCreate                 // fail the row
+	UserImportModeUpsert                 // update the fields given by the row
+)
+
+// UserImportRowStatus is the outcome of an import row.
+type UserImportRowStatus int32
+
+const (
+	UserImportRowStatusUnknown UserImportRowStatus = iota
+	UserImportRowStatusCreated
+	UserImportRowStatusUpdated
+	UserImportRowStatusUnchanged
+	UserImportRowStatusFailed
+)
+
+// UserImportParams represents the parameters of an import.
+type UserImportParams struct {
+	Format   UserImportFormat
+	Mode     UserImportMode
+	DryRun   bool
+	Operator string
+}
+
+// UserImportRow is a row of an import file.
+type UserImportRow struct {
+	Line   int // line of the row in the file, starting at 1
+	Params UserCreateParams
+	Update UserUpdateParams // the fields given by the row, the changes to an existing user
+	Err    error            // set if the row cannot be parsed or is invalid
+}
+
+// UserImportRowResult is the outcome of an import row.
+type UserImportRowResult struct {
+	Line     int
+	Username string
+	Status   UserImportRowStatus
+	UserID   string
+	Error    string
+}
+
+// UserImportResult is the report of an import.
+type UserImportResult struct {
+	Total     int
+	Created   int
+	Updated   int
+	Unchanged int
+	Failed    int
+	DryRun    bool
+	Rows      []UserImportRowResult
+}
+
+// userImportRecord is a row of a JSON Lines file, role and status are names or numbers.
+type userImportRecord struct {
+	Username string          `json:"username"`
+	Role     json.RawMessage `json:"role"`
+	Status   json.RawMessage `json:"status"`
+	Email    *string         `json:"email"`
+	Phone    *string         `json:"phone"`
+}
+
+// ParseUserImport reads the rows of an import file.
+//
+// CSV files start with a header row naming the columns: `username` is required, `role`,
+// `status`, `email` and `phone` are optional. Roles and statuses are names (`admin`,
+// `locked`) or numbers, the default role and status are used if empty. Rows which cannot
+// be parsed are returned with an error, the file is only rejected if it is not readable.
+// CSV values neutralized against formulas by the export, e.g. `'+15550100`, are restored.
+//
+// An existing user is only changed on the fields a row gives: its role and status if not
+// empty, its email and phone if the file has their column or key, empty values removing them.
+func ParseUserImport(r io.Reader, format UserImportFormat) ([]UserImportRow, error) {
+	switch format {
+	case UserImportFormatCSV:
+		return parseUserImportCSV(r)
+	case UserImportFormatJSONLines:
+		return parseUserImportJSONLines(r)
+	default:
+		return nil, errors.New("invalid import format")
+	}
+}
+
+// Read the rows of a CSV file.
+func parseUserImportCSV(r io.Reader) ([]UserImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("missing CSV header")
+		}
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		switch name {
+		case "username", "role", "status", "email", "phone":
+		default:
+			return nil, fmt.Errorf("unknown CSV column[%s]", name)
+		}
+		columns[name] = i
+	}
+	if _, ok := columns["username"]; !ok {
+		return nil, errors.New("missing CSV column[username]")
+	}
+
+	var rows []UserImportRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if len(rows) >= MaxUserImportRows {
+			return nil, fmt.Errorf("too many rows, at most %d are allowed", MaxUserImportRows)
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			rows = append(rows, UserImportRow{Line: parseErr.StartLine, Err: parseErr.Err})
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return csvUnescape(strings.TrimSpace(record[i]))
+			}
+			return ""
+		}
+		row := UserImportRow{Line: line, Params: UserCreateParams{
+			Username: field("username"),
+			Email:    field("email"),
+			Phone:    field("phone"),
+		}}
+		row.Params.Role, row.Params.Status, row.Err = parseRoleAndStatus(field("role"), field("status"))
+		_, email := columns["email"]
+		_, phone := columns["phone"]
+		row.Update = importRowUpdate(row.Params, field("role") != "", field("status") != "", email, phone)
+		rows = append(rows, row)
+	}
+}
+
+// Read the rows of a JSON Lines file, blank lines are skipped.
+func parseUserImportJSONLines(r io.Reader) ([]UserImportRow, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var rows []UserImportRow
+	for line := 1; scanner.Scan(); line++ {
+		content := bytes.TrimSpace(scanner.Bytes())
+		if len(content) == 0 {
+			continue
+		}
+		if len(rows) >= MaxUserImportRows {
+			return nil, fmt.Errorf("too many rows, at most %d are allowed", MaxUserImportRows)
+		}
+
+		var record userImportRecord
+		decoder := json.NewDecoder(bytes.NewReader(content))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&record); err != nil {
+			rows = append(rows, UserImportRow{Line: line, Err: fmt.Errorf("invalid JSON: %w", err)})
+			continue
+		}
+		row := UserImportRow{Line: line, Params: UserCreateParams{
+			Username: strings.TrimSpace(record.Username),
+		}}
+		if record.Email != nil {
+			row.Params.Email = strings.TrimSpace(*record.Email)
+		}
+		if record.Phone != nil {
+			row.Params.Phone = strings.TrimSpace(*record.Phone)
+		}
+		role, roleErr := jsonEnumValue(record.Role)
+		status, statusErr := jsonEnumValue(record.Status)
+		if row.Err = errors.Join(roleErr, statusErr); row.Err == nil {
+			row.Params.Role, row.Params.Status, row.Err = parseRoleAndStatus(role, status)
+		}
+		row.Update = importRowUpdate(row.Params, role != "", status != "", record.Email != nil, record.Phone != nil)
+		rows = append(rows, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read JSON Lines: %w", err)
+	}
+	return rows, nil
+}
+
+// Return a JSON string or number as text, empty if absent or null.
+func jsonEnumValue(raw json.RawMessage) (string, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return "", nil
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return strings.TrimSpace(s), nil
+	}
+	var n int32
+	if err := json.Unmarshal(raw, &n); err == nil {
+		return strconv.Itoa(int(n)), nil
+	}
+	return "", fmt.Errorf("invalid value[%s], expected a name or a number", raw)
+}
+
+// Return the changes of an import row to an existing user, made of the fields it gives.
+func importRowUpdate(p UserCreateParams, role, status, email, phone bool) UserUpdateParams {
+	var update UserUpdateParams
+	if role {
+		update.Role = &p.Role
+	}
+	if status {
+		update.Status = &p.Status
+	}
+	if email {
+		update.Email = &p.Email
+	}
+	if phone {
+		update.Phone = &p.Phone
+	}
+	return update
+}
+
+// Parse the role and the status of an import row, empty values are the defaults.
+func parseRoleAndStatus(role, status string) (int32, int32, error) {
+	r, err := parseUserEnum(role, int32(DefaultUserRole), func(v int32) string { return UserRole(v).String() })
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid role[%s]", role)
+	}
+	s, err := parseUserEnum(status, int32(DefaultUserStatus), func(v int32) string { return UserStatus(v).String() })
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid status[%s]", status)
+	}
+	return r, s, nil
+}
+
+// Parse a role or a status given by name or number, validity is left to `Validate`.
+func parseUserEnum(value string, defaultValue int32, name func(int32) string) (int32, error) {
+	if value == "" {
+		return defaultValue, nil
+	}
+	if n, err := strconv.ParseInt(value, 10, 32); err == nil {
+		return int32(n), nil
+	}
+	for v := int32(1); name(v) != "unknown"; v++ {
+		if strings.EqualFold(value, name(v)) {
+			return v, nil
+		}
+	}
+	return 0, errors.New("unknown name")
+}
+
+// ImportUsers creates, or upserts by username, the users of an import file.
+//
+// Every row is validated like `CreateUser` and reported. The rows are written in chunks,
+// each in a transaction: if writing a row fails, the other rows of its chunk are rolled
+// back and reported as failed too. A dry run does the same but rolls every chunk back.
+func (uc *UserUseCase) ImportUsers(ctx context.Context, r io.Reader, params UserImportParams) (*UserImportResult, error) {
+	if params.Mode == UserImportModeUnknown {
+		params.Mode = UserImportModeCreate
+	}
+	if params.Mode != UserImportModeCreate && params.Mode != UserImportModeUpsert {
+		return nil, fmt.Errorf("%w: invalid mode", ErrInvalidUserImport)
+	}
+	if params.Operator == "" {
+		params.Operator = auth.Username(ctx)
+	}
+	rows, err := ParseUserImport(r, params.Format)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidUserImport, err)
+	}
+
+	result := &UserImportResult{Total: len(rows), DryRun: params.DryRun, Rows: make([]UserImportRowResult, len(rows))}
+	pending := make([]int, 0, len(rows))
+	seen := make(map[string]int, len(rows))
+	for i := range rows {
+		row := &rows[i]
+		result.Rows[i] = UserImportRowResult{Line: row.Line, Username: row.Params.Username}
+		if row.Err == nil {
+			row.Params.Password = DefaultUserPassword
+			row.Params.Creator = params.Operator
+			row.Params.UpdateBy = params.Operator
+			row.Err = row.Params.Validate()
+		}
+		if row.Err == nil {
+			if line, ok := seen[row.Params.Username]; ok {
+				row.Err = fmt.Errorf("duplicate username, already at line %d", line)
+			} else {
+				seen[row.Params.Username] = row.Line
+			}
+		}
+		if row.Err != nil {
+			result.Rows[i].Status = UserImportRowStatusFailed
+			result.Rows[i].Error = row.Err.Error()
+			continue
+		}
+		pending = append(pending, i)
+	}
+
+	for start := 0; start < len(pending); start += userImportChunkSize {
+		chunk := pending[start:min(start+userImportChunkSize, len(pending))]
+		if err := uc.importChunk(ctx, rows, chunk, params, result); err != nil {
+			if ctx.Err() != nil {
+				return nil, err
+			}
+			for _, i := range chunk {
+				result.Rows[i] = UserImportRowResult{Line: rows[i].Line, Username: rows[i].Params.Username,
+					Status: UserImportRowStatusFailed, Error: err.Error()}
+			}
+		}
+	}
+
+	for _, row := range result.Rows {
+		switch row.Status {
+		case UserImportRowStatusCreated:
+			result.Created++
+		case UserImportRowStatusUpdated:
+			result.Updated++
+		case UserImportRowStatusUnchanged:
+			result.Unchanged++
+		default:
+			result.Failed++
+		}
+	}
+	return result, nil
+}
+
+// Write a chunk of valid rows in a transaction, recording the outcome of every row.
+// Nothing is recorded if the chunk is rolled back.
+func (uc *UserUseCase) importChunk(ctx context.Context, rows []UserImportRow, chunk []int, params UserImportParams, result *UserImportResult) error {
+	outcomes := make([]UserImportRowResult, len(chunk))
+	err := uc.tx.InTx(ctx, func(ctx context.Context) error {
+		for j, i := range chunk {
+			row := rows[i]
+			outcome, err := uc.importRow(ctx, row, params.Mode)
+			if err != nil {
+				return fmt.Errorf("line %d: %w", row.Line, err)
+			}
+			outcome.Line, outcome.Username = row.Line, row.Params.Username
+			if params.DryRun && outcome.Status == UserImportRowStatusCreated {
+				outcome.UserID = ""
+			}
+			outcomes[j] = outcome
+		}
+		if params.DryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		return err
+	}
+	for j, i := range chunk {
+		result.Rows[i] = outcomes[j]
+	}
+	return nil
+}
+
+// Create the user of a row, or update it with the fields the row gives. An existing username
+// fails the row in the create mode.
+func (uc *UserUseCase) importRow(ctx context.Context, row UserImportRow, mode UserImportMode) (UserImportRowResult, error) {
+	create := row.Params
+	exists, err := uc.userRepo.ExistsByUsername(ctx, create.Username)
+	if err != nil {
+		return UserImportRowResult{}, fmt.Errorf("failed to check username[%s]: %w", create.Username, err)
+	}
+	if !exists {
+		user, err := uc.CreateUser(ctx, create)
+		if err != nil {
+			return UserImportRowResult{}, err
+		}
+		return UserImportRowResult{Status: UserImportRowStatusCreated, UserID: user.ID}, nil
+	}
+	if mode != UserImportModeUpsert {
+		return UserImportRowResult{Status: UserImportRowStatusFailed, Error: "username already exists"}, nil
+	}
+
+	existing, err := uc.userRepo.GetUserByUsername(ctx, create.Username)
+	if err != nil {
+		return UserImportRowResult{}, err
+	}
+	update := row.Update
+	if (update.Role == nil || existing.Role == UserRole(*update.Role)) &&
+		(update.Status == nil || existing.Status == UserStatus(*update.Status)) &&
+		(update.Email == nil || existing.Email == *update.Email) &&
+		(update.Phone == nil || existing.Phone == *update.Phone) {
+		return UserImportRowResult{Status: UserImportRowStatusUnchanged, UserID: existing.ID}, nil
+	}
+	update.UpdatedBy = create.UpdateBy
+	update.Version = existing.Version
+	user, err := uc.UpdateUser(ctx, existing.ID, update)
+	if err != nil {
+		return UserImportRowResult{}, err
+	}
+	return UserImportRowResult{Status: UserImportRowStatusUpdated, UserID: user.ID}, nil
+}
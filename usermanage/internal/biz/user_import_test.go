@@ -0,0 +1,73 @@
+package biz
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseUserImport_CSV(t *testing.T) {
+	content := "username,role,status,email\n" +
+		"alice,admin,normal,alice@example.com\n" +
+		"bob,,3,\n" +
+		"carol,owner,normal,\n" +
+		"\"dave,x\n"
+	rows, err := ParseUserImport(strings.NewReader(content), UserImportFormatCSV)
+	require.NoError(t, err)
+	require.Len(t, rows, 4)
+
+	assert.Equal(t, 2, rows[0].Line)
+	assert.NoError(t, rows[0].Err)
+	assert.Equal(t, UserCreateParams{Username: "alice", Role: int32(UserRoleAdmin), Status: int32(UserStatusNormal),
+		Email: "alice@example.com"}, rows[0].Params)
+
+	assert.NoError(t, rows[1].Err)
+	assert.Equal(t, int32(DefaultUserRole), rows[1].Params.Role)
+	assert.Equal(t, int32(UserStatusLocked), rows[1].Params.Status)
+	// Empty roles are not given, empty emails of the column remove it
+	assert.Nil(t, rows[1].Update.Role)
+	assert.Equal(t, int32(UserStatusLocked), *rows[1].Update.Status)
+	assert.Equal(t, "", *rows[1].Update.Email)
+	assert.Nil(t, rows[1].Update.Phone)
+
+	assert.EqualError(t, rows[2].Err, "invalid role[owner]")
+	assert.Equal(t, 5, rows[3].Line)
+	assert.Error(t, rows[3].Err)
+
+	_, err = ParseUserImport(strings.NewReader("name,role\nalice,admin\n"), UserImportFormatCSV)
+	assert.EqualError(t, err, "unknown CSV column[name]")
+	_, err = ParseUserImport(strings.NewReader("role\nadmin\n"), UserImportFormatCSV)
+	assert.EqualError(t, err, "missing CSV column[username]")
+}
+
+func TestParseUserImport_JSONLines(t *testing.T) {
+	content := `{"username": "alice", "role": "admin", "phone": "+15550100"}` + "\n" +
+		"\n" +
+		`{"username": "bob", "role": 2, "status": "disabled"}` + "\n" +
+		`{"username": "carol", "role": true}` + "\n" +
+		`{"username": "dave", "age": 3}` + "\n"
+	rows, err := ParseUserImport(strings.NewReader(content), UserImportFormatJSONLines)
+	require.NoError(t, err)
+	require.Len(t, rows, 4)
+
+	assert.Equal(t, 1, rows[0].Line)
+	assert.NoError(t, rows[0].Err)
+	assert.Equal(t, UserCreateParams{Username: "alice", Role: int32(UserRoleAdmin), Status: int32(DefaultUserStatus),
+		Phone: "+15550100"}, rows[0].Params)
+
+	assert.Equal(t, int32(UserRoleAdmin), *rows[0].Update.Role)
+	assert.Nil(t, rows[0].Update.Status)
+	assert.Nil(t, rows[0].Update.Email)
+	assert.Equal(t, "+15550100", *rows[0].Update.Phone)
+
+	assert.Equal(t, 3, rows[1].Line)
+	assert.NoError(t, rows[1].Err)
+	assert.Equal(t, int32(UserRoleUser), rows[1].Params.Role)
+	assert.Equal(t, int32(UserStatusDisabled), rows[1].Params.Status)
+
+	assert.Error(t, rows[2].Err)
+	assert.Equal(t, 5, rows[3].Line)
+	assert.ErrorContains(t, rows[3].Err, "invalid JSON")
+}
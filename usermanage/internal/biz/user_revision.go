@@ -0,0 +1,143 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"usermanage/internal/pkg/constants"
+)
+
+// UserRevisionAction represents the kind of change recorded by a user revision.
+type UserRevisionAction int32
+
+const (
+	UserRevisionActionUnknown UserRevisionAction = iota
+	UserRevisionActionCreate
+	UserRevisionActionUpdate
+	UserRevisionActionReplace
+	UserRevisionActionDelete
+	UserRevisionActionRestore
+	UserRevisionActionUndelete
+)
+
+// String returns the string repetition of the revision action.
+func (a UserRevisionAction) String() string {
+	switch a {
+	case UserRevisionActionCreate:
+		return "create"
+	case UserRevisionActionUpdate:
+		return "update"
+	case UserRevisionActionReplace:
+		return "replace"
+	case UserRevisionActionDelete:
+		return "delete"
+	case UserRevisionActionRestore:
+		return "restore"
+	case UserRevisionActionUndelete:
+		return "undelete"
+	default:
+		return "unknown"
+	}
+}
+
+// UserRevision is a versioned change of a user record.
+type UserRevision struct {
+	UserID    string             `json:"userId"`
+	Revision  int64              `json:"revision"`
+	Action    UserRevisionAction `json:"action"`
+	Snapshot  *User              `json:"snapshot"`
+	Changes   []UserFieldChange  `json:"changes"`
+	Operator  string             `json:"operator"`
+	CreatedAt time.Time          `json:"createdAt"`
+}
+
+// UserFieldChange is the change of a single field between two revisions.
+type UserFieldChange struct {
+	Field    string `json:"field"`
+	OldValue string `json:"oldValue"`
+	NewValue string `json:"newValue"`
+}
+
+// UserRevisionListParams represents all parameters for user revision listing.
+type UserRevisionListParams struct {
+	Page     int32 `json:"page"`      // current page number (1-based)
+	PageSize int32 `json:"page_size"` // page size
+}
+
+// GetPage returns the page and size.
+func (p *UserRevisionListParams) GetPage() (page, size int32) {
+	if p.Page <= 0 {
+		p.Page = constants.DefaultPage
+	}
+	if p.PageSize <= 0 {
+		p.PageSize = constants.DefaultPageSize
+	}
+	maxPageSize := constants.MaxPageSize
+	if p.PageSize > maxPageSize {
+		p.PageSize = maxPageSize
+	}
+	return p.Page, p.PageSize
+}
+
+// UserRevisionListResult represents the result of user revision listing.
+type UserRevisionListResult struct {
+	TotalCount int64
+	Revisions  []*UserRevision
+}
+
+// ListUserRevisions lists the change history of a user, newest first.
+func (uc *UserUseCase) ListUserRevisions(ctx context.Context, id string, params UserRevisionListParams) (*UserRevisionListResult, error) {
+	if id == "" {
+		return nil, errors.New("user id is required")
+	}
+
+	result, err := uc.userRepo.ListUserRevisions(ctx, id, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user revisions[id=%s]: %w", id, err)
+	}
+	return result, nil
+}
+
+// GetUserAtTime returns the state of a user as it was at the given point in time.
+func (uc *UserUseCase) GetUserAtTime(ctx context.Context, id string, at time.Time) (*User, error) {
+	if id == "" {
+		return nil, errors.New("user id is required")
+	}
+
+	revision, err := uc.userRepo.GetUserRevisionAt(ctx, id, at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user revision[id=%s, at=%s]: %w", id, at.Format(time.RFC3339), err)
+	}
+	if revision.Action == UserRevisionActionDelete {
+		return nil, fmt.Errorf("user[id=%s] was deleted at %s", id, revision.CreatedAt.Format(time.RFC3339))
+	}
+	return revision.Snapshot, nil
+}
+
+// RestoreUserRevision rolls a user back to the state recorded by a previous revision.
+func (uc *UserUseCase) RestoreUserRevision(ctx context.Context, id string, revision int64, operator string) (*User, error) {
+	if id == "" {
+		return nil, errors.New("user id is required")
+	}
+	if revision <= 0 {
+		return nil, errors.New("revision must be positive")
+	}
+
+	var user *User
+	err := uc.tx.InTx(ctx, func(ctx context.Context) error {
+		before, err := uc.userRepo.LockUserByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get user[id=%s]: %w", id, err)
+		}
+		user, err = uc.userRepo.RestoreUserRevision(ctx, id, revision, operator)
+		if err != nil {
+			return fmt.Errorf("failed to restore user[id=%s] to revision[%d]: %w", id, revision, err)
+		}
+		return uc.eventRepo.Append(ctx, userChangedEvents(before, user, operator)...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
@@ -0,0 +1,30 @@
+package biz
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserETag(t *testing.T) {
+	user := &User{ID: "1", Version: 3}
+	assert.Equal(t, `"3"`, user.ETag())
+
+	for _, etag := range []string{`"3"`, `W/"3"`, "3", ` "3" `} {
+		version, err := ParseETag(etag)
+		require.NoError(t, err, etag)
+		assert.Equal(t, int64(3), version, etag)
+	}
+	for _, etag := range []string{"", `"abc"`, `"0"`, `"-1"`} {
+		_, err := ParseETag(etag)
+		assert.Error(t, err, etag)
+	}
+}
+
+func TestCheckVersion(t *testing.T) {
+	user := &User{ID: "1", Version: 3}
+	assert.NoError(t, checkVersion(user, 0))
+	assert.NoError(t, checkVersion(user, 3))
+	assert.ErrorIs(t, checkVersion(user, 2), ErrVersionConflict)
+}
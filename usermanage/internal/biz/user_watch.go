@@ -0,0 +1,86 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	// userWatchPollInterval is how often watchers look for new changes.
+	userWatchPollInterval = time.Second
+	// userWatchBatchSize is the maximum number of changes read at once.
+	userWatchBatchSize = 100
+)
+
+// userWatchEventTypes are the events streamed to watchers.
+var userWatchEventTypes = []EventType{
+	EventTypeUserCreated,
+	EventTypeUserUpdated,
+	EventTypeUserDeleted,
+	EventTypeUserUndeleted,
+}
+
+// UserWatchParams represents the parameters for watching users.
+type UserWatchParams struct {
+	// ResourceVersion resumes the watch after the given version.
+	// Only the changes made from now on are streamed if it is 0.
+	ResourceVersion uint64
+	// Username restricts the watch to the changes of a single user (optional).
+	Username string
+}
+
+// WatchUsers streams the changes of users to `send` until the context is done
+// or `send` fails.
+//
+// Changes are read back from the stored domain events, so the version of an event
+// is its position in the commit order of the store and a watch can be resumed from any version.
+func (uc *UserUseCase) WatchUsers(ctx context.Context, params UserWatchParams, send func(*Event) error) error {
+	var userID string
+	if params.Username != "" {
+		user, err := uc.userRepo.GetUserByUsername(ctx, params.Username)
+		if err != nil {
+			return fmt.Errorf("failed to get user[username=%s]: %w", params.Username, err)
+		}
+		userID = user.ID
+	}
+
+	version := params.ResourceVersion
+	if version == 0 {
+		latest, err := uc.eventRepo.LatestVersion(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get latest resource version: %w", err)
+		}
+		version = latest
+	}
+
+	ticker := time.NewTicker(userWatchPollInterval)
+	defer ticker.Stop()
+	for {
+		events, err := uc.eventRepo.ListEvents(ctx, EventQuery{
+			AfterVersion: version,
+			AggregateID:  userID,
+			Types:        userWatchEventTypes,
+			Limit:        userWatchBatchSize,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list events after version[%d]: %w", version, err)
+		}
+		for _, event := range events {
+			if err := send(event); err != nil {
+				return err
+			}
+			version = event.Version
+		}
+		// Keep reading while full batches are found to catch up quickly
+		if len(events) == userWatchBatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
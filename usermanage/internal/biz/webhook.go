@@ -0,0 +1,276 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+	"usermanage/internal/pkg/constants"
+	"usermanage/internal/pkg/webhook"
+)
+
+// WebhookEventTypeAll subscribes a webhook to all event types.
+const WebhookEventTypeAll = "*"
+
+// WebhookRepo is the repository for webhooks and their deliveries.
+type WebhookRepo interface {
+	// ListWebhooks returns a paginated list of webhooks.
+	ListWebhooks(ctx context.Context, params WebhookListParams) (*WebhookListResult, error)
+
+	// GetWebhook gets the webhook by ID.
+	GetWebhook(ctx context.Context, id string) (*Webhook, error)
+
+	// CreateWebhook creates a new active webhook.
+	CreateWebhook(ctx context.Context, params WebhookCreateParams) (*Webhook, error)
+
+	// DeleteWebhook deletes a webhook by ID, its pending deliveries are dropped.
+	DeleteWebhook(ctx context.Context, id string) error
+
+	// ListDeliveries returns a paginated list of the deliveries of a webhook, newest first.
+	ListDeliveries(ctx context.Context, params WebhookDeliveryListParams) (*WebhookDeliveryListResult, error)
+
+	// GetDelivery gets the delivery by ID along with its attempts.
+	GetDelivery(ctx context.Context, id string) (*WebhookDelivery, error)
+
+	// RedeliverDelivery schedules the delivery to be sent again right away.
+	RedeliverDelivery(ctx context.Context, id string) (*WebhookDelivery, error)
+}
+
+// Webhook is an HTTP endpoint notified about domain events.
+type Webhook struct {
+	ID          string    `json:"id"`
+	URL         string    `json:"url"`
+	EventTypes  []string  `json:"eventTypes"`
+	Secret      string    `json:"-"`
+	Description string    `json:"description"`
+	Active      bool      `json:"active"`
+	Creator     string    `json:"creator"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// WebhookDeliveryStatus represents the state of a webhook delivery.
+type WebhookDeliveryStatus int32
+
+const (
+	WebhookDeliveryStatusUnknown WebhookDeliveryStatus = iota
+	WebhookDeliveryStatusPending
+	WebhookDeliveryStatusSucceeded
+	WebhookDeliveryStatusRetrying
+	WebhookDeliveryStatusDead
+)
+
+// WebhookDelivery is an event delivered to a webhook.
+type WebhookDelivery struct {
+	ID             string                    `json:"id"`
+	WebhookID      string                    `json:"webhookId"`
+	EventID        string                    `json:"eventId"`
+	EventType      string                    `json:"eventType"`
+	Status         WebhookDeliveryStatus     `json:"status"`
+	Attempts       int32                     `json:"attempts"`
+	LastStatusCode int32                     `json:"lastStatusCode"`
+	LastError      string                    `json:"lastError"`
+	NextAttemptAt  time.Time                 `json:"nextAttemptAt"`
+	DeliveredAt    *time.Time                `json:"deliveredAt"`
+	CreatedAt      time.Time                 `json:"createdAt"`
+	AttemptLog     []*WebhookDeliveryAttempt `json:"attemptLog,omitempty"`
+}
+
+// WebhookDeliveryAttempt is a single HTTP request of a webhook delivery.
+type WebhookDeliveryAttempt struct {
+	Attempt    int32         `json:"attempt"`
+	StatusCode int32         `json:"statusCode"`
+	Error      string        `json:"error"`
+	Duration   time.Duration `json:"duration"`
+	CreatedAt  time.Time     `json:"createdAt"`
+}
+
+// WebhookListParams represents all parameters for webhook listing.
+type WebhookListParams struct {
+	Page     int32 `json:"page"`      // current page number (1-based)
+	PageSize int32 `json:"page_size"` // page size
+}
+
+// GetPage returns the page and size.
+func (p *WebhookListParams) GetPage() (page, size int32) {
+	p.Page, p.PageSize = normalizePage(p.Page, p.PageSize)
+	return p.Page, p.PageSize
+}
+
+// WebhookListResult represents the result of webhook listing.
+type WebhookListResult struct {
+	TotalCount int64
+	Webhooks   []*Webhook
+}
+
+// WebhookCreateParams represents the parameters for creating a webhook.
+type WebhookCreateParams struct {
+	URL         string   `json:"url"`
+	EventTypes  []string `json:"eventTypes"`
+	Secret      string   `json:"-"`
+	Description string   `json:"description"`
+	Creator     string   `json:"creator"`
+}
+
+// WebhookDeliveryListParams represents all parameters for webhook delivery listing.
+type WebhookDeliveryListParams struct {
+	WebhookID string                `json:"webhook_id"`
+	Status    WebhookDeliveryStatus `json:"status"`    // filter by status (optional)
+	Page      int32                 `json:"page"`      // current page number (1-based)
+	PageSize  int32                 `json:"page_size"` // page size
+}
+
+// GetPage returns the page and size.
+func (p *WebhookDeliveryListParams) GetPage() (page, size int32) {
+	p.Page, p.PageSize = normalizePage(p.Page, p.PageSize)
+	return p.Page, p.PageSize
+}
+
+// WebhookDeliveryListResult represents the result of webhook delivery listing.
+type WebhookDeliveryListResult struct {
+	TotalCount int64
+	Deliveries []*WebhookDelivery
+}
+
+// WebhookUseCase is the use case for webhook.
+type WebhookUseCase struct {
+	webhookRepo WebhookRepo
+}
+
+// NewWebhookUseCase creates a new WebhookUseCase.
+func NewWebhookUseCase(webhookRepo WebhookRepo) *WebhookUseCase {
+	return &WebhookUseCase{webhookRepo: webhookRepo}
+}
+
+// ListWebhooks lists webhooks.
+func (uc *WebhookUseCase) ListWebhooks(ctx context.Context, params WebhookListParams) (*WebhookListResult, error) {
+	return uc.webhookRepo.ListWebhooks(ctx, params)
+}
+
+// GetWebhook gets a webhook by ID.
+func (uc *WebhookUseCase) GetWebhook(ctx context.Context, id string) (*Webhook, error) {
+	if id == "" {
+		return nil, errors.New("webhook id is required")
+	}
+
+	hook, err := uc.webhookRepo.GetWebhook(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook[id=%s]: %w", id, err)
+	}
+	return hook, nil
+}
+
+// CreateWebhook registers a webhook.
+//
+// A random secret is generated when none is given, the returned webhook carries
+// the secret so it can be handed over to the receiver.
+func (uc *WebhookUseCase) CreateWebhook(ctx context.Context, params WebhookCreateParams) (*Webhook, error) {
+	u, err := url.Parse(params.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid webhook url[%s]", params.URL)
+	}
+	if len(params.EventTypes) == 0 {
+		return nil, errors.New("at least one event type is required")
+	}
+	for _, eventType := range params.EventTypes {
+		if !isWebhookEventType(eventType) {
+			return nil, fmt.Errorf("unknown event type[%s]", eventType)
+		}
+	}
+	if params.Secret == "" {
+		secret, err := webhook.GenerateSecret()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate webhook secret: %w", err)
+		}
+		params.Secret = secret
+	}
+
+	hook, err := uc.webhookRepo.CreateWebhook(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook[url=%s]: %w", params.URL, err)
+	}
+	return hook, nil
+}
+
+// DeleteWebhook deletes a webhook by ID.
+func (uc *WebhookUseCase) DeleteWebhook(ctx context.Context, id string) error {
+	if id == "" {
+		return errors.New("webhook id is required")
+	}
+
+	if err := uc.webhookRepo.DeleteWebhook(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete webhook[id=%s]: %w", id, err)
+	}
+	return nil
+}
+
+// ListWebhookDeliveries lists the deliveries of a webhook, newest first.
+func (uc *WebhookUseCase) ListWebhookDeliveries(ctx context.Context, params WebhookDeliveryListParams) (*WebhookDeliveryListResult, error) {
+	if params.WebhookID == "" {
+		return nil, errors.New("webhook id is required")
+	}
+
+	result, err := uc.webhookRepo.ListDeliveries(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deliveries of webhook[id=%s]: %w", params.WebhookID, err)
+	}
+	return result, nil
+}
+
+// GetWebhookDelivery gets a delivery by ID along with its attempts.
+func (uc *WebhookUseCase) GetWebhookDelivery(ctx context.Context, id string) (*WebhookDelivery, error) {
+	if id == "" {
+		return nil, errors.New("delivery id is required")
+	}
+
+	delivery, err := uc.webhookRepo.GetDelivery(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook delivery[id=%s]: %w", id, err)
+	}
+	return delivery, nil
+}
+
+// RedeliverWebhookDelivery schedules a delivery to be sent again right away.
+//
+// The delivery gets a fresh set of attempts, the attempts already made are kept.
+func (uc *WebhookUseCase) RedeliverWebhookDelivery(ctx context.Context, id string) (*WebhookDelivery, error) {
+	if id == "" {
+		return nil, errors.New("delivery id is required")
+	}
+
+	delivery, err := uc.webhookRepo.RedeliverDelivery(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to redeliver webhook delivery[id=%s]: %w", id, err)
+	}
+	return delivery, nil
+}
+
+// Report whether webhooks can subscribe to the event type.
+func isWebhookEventType(eventType string) bool {
+	return slices.Contains([]string{
+		WebhookEventTypeAll,
+		string(EventTypeUserCreated),
+		string(EventTypeUserUpdated),
+		string(EventTypeUserDeleted),
+		string(EventTypeUserLocked),
+		string(EventTypeUserLoggedIn),
+		string(EventTypeUserUndeleted),
+		string(EventTypeUserPurged),
+	}, eventType)
+}
+
+// Return the page and size with defaults applied and the size capped.
+func normalizePage(page, size int32) (int32, int32) {
+	if page <= 0 {
+		page = constants.DefaultPage
+	}
+	if size <= 0 {
+		size = constants.DefaultPageSize
+	}
+	if size > constants.MaxPageSize {
+		size = constants.MaxPageSize
+	}
+	return page, size
+}
@@ -4,4 +4,4 @@ import (
 	"github.com/google/wire"
 )
 
-var ProviderSet = wire.NewSet(NewHealthUseCase, NewAuthUseCase, NewUserUseCase)
+var ProviderSet = wire.NewSet(NewHealthUseCase, NewAuthUseCase, NewUserUseCase, NewWebhookUseCase, NewOAuthUseCase, NewFederationUseCase)
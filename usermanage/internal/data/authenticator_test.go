@@ -0,0 +1,183 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+	"usermanage/gen/proto/conf"
+	"usermanage/internal/biz"
+	"usermanage/internal/pkg/jwt"
+	"usermanage/internal/pkg/ldap"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDirectory is a directory of entries with their passwords, unreachable when down.
+type fakeDirectory struct {
+	passwords map[string]string
+	entries   map[string]*ldap.Entry
+	down      bool
+}
+
+func (d *fakeDirectory) Authenticate(_ context.Context, username, password string) (*ldap.Entry, error) {
+	if d.down {
+		return nil, errors.New("connection refused")
+	}
+	if p, ok := d.passwords[username]; !ok || p != password {
+		return nil, fmt.Errorf("%w: user[%s]", ldap.ErrInvalidCredentials, username)
+	}
+	return d.entries[username], nil
+}
+
+func TestAuthUseCase_LDAP(t *testing.T) {
+	require.NoError(t, jwt.Initialize([]byte("secret"), time.Hour))
+	database := newTestDatabase(t)
+	users := NewUserRepo(database, newTestEnvelope(t, false), log.DefaultLogger)
+	events := NewEventRepo(database, log.DefaultLogger)
+	directory := &fakeDirectory{
+		passwords: map[string]string{"jane": "jane-secret", "bob": "bob-secret", "carol": "carol-secret", "root": "root-secret", "dave": "dave-secret"},
+		entries: map[string]*ldap.Entry{
+			"jane": {DN: "uid=jane,ou=people,dc=example,dc=com", Attributes: map[string][]string{
+				"mail":            {"jane@example.com"},
+				"telephoneNumber": {"+1 555 0100"},
+				"memberOf":        {"CN=Admins,OU=Groups,DC=example,DC=com"},
+			}},
+			"bob": {DN: "uid=bob,ou=people,dc=example,dc=com", Attributes: map[string][]string{
+				"mail": {"not an email"},
+			}},
+			"carol": {DN: "uid=carol,ou=people,dc=example,dc=com", Attributes: map[string][]string{
+				"mail": {"carol@example.com"},
+			}},
+			"root": {DN: "uid=root,ou=people,dc=example,dc=com", Attributes: map[string][]string{
+				"memberOf": {"cn=staff,ou=groups,dc=example,dc=com"},
+			}},
+			"dave": {DN: "uid=dave,ou=people,dc=example,dc=com", Attributes: map[string][]string{
+				"memberOf": {"CN=Admins,OU=Groups,DC=example,DC=com"},
+			}},
+		},
+	}
+	newAuth := func(backends []string, autoProvision bool) *biz.AuthUseCase {
+		return biz.NewAuthUseCase(NewTransaction(database), users, NewMemoryTokenRepo(&conf.Data{}), events, &biz.AuthOptions{
+			Backends: backends,
+			LDAP: &biz.LDAPOptions{
+				Directory:      directory,
+				EmailAttribute: "mail",
+				PhoneAttribute: "telephoneNumber",
+				AdminGroups:    []string{"cn=admins,ou=groups,dc=example,dc=com"},
+				AutoProvision:  autoProvision,
+			},
+		})
+	}
+	ctx := context.Background()
+
+	t.Run("users are not provisioned without auto provisioning", func(t *testing.T) {
+		_, _, _, err := newAuth([]string{biz.AuthBackendLDAP}, false).Login(ctx, "jane", "jane-secret")
+		assert.ErrorIs(t, err, biz.ErrInvalidCredentials)
+		exists, err := users.ExistsByUsername(ctx, "jane")
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	auth := newAuth([]string{biz.AuthBackendLDAP, biz.AuthBackendLocal}, true)
+
+	t.Run("first login provisions the user", func(t *testing.T) {
+		user, token, _, err := auth.Login(ctx, "jane", "jane-secret")
+		require.NoError(t, err)
+		assert.NotEmpty(t, token)
+		assert.Equal(t, "jane", user.Username)
+		assert.Equal(t, "jane@example.com", user.Email)
+		assert.Equal(t, "+15550100", user.Phone)
+		assert.Equal(t, biz.UserRoleAdmin, user.Role)
+		assert.Equal(t, "ldap", user.Creator)
+		assert.Equal(t, "ldap", user.Source)
+
+		// The local password is random, not the directory's
+		_, _, _, err = newAuth(nil, false).Login(ctx, "jane", "jane-secret")
+		assert.ErrorIs(t, err, biz.ErrInvalidCredentials)
+	})
+
+	t.Run("the entry is synced on every login", func(t *testing.T) {
+		entry := directory.entries["jane"]
+		entry.Attributes["mail"] = []string{"jane.doe@example.com"}
+		entry.Attributes["memberOf"] = []string{"cn=staff,ou=groups,dc=example,dc=com"}
+
+		user, _, _, err := auth.Login(ctx, "jane", "jane-secret")
+		require.NoError(t, err)
+		assert.Equal(t, "jane.doe@example.com", user.Email)
+		assert.Equal(t, biz.UserRoleUser, user.Role)
+		assert.Equal(t, "ldap", user.UpdatedBy)
+	})
+
+	t.Run("invalid attributes are not synced", func(t *testing.T) {
+		user, _, _, err := auth.Login(ctx, "bob", "bob-secret")
+		require.NoError(t, err)
+		assert.Empty(t, user.Email)
+	})
+
+	t.Run("local users fall back to the local backend", func(t *testing.T) {
+		createTestUser(t, users, "carol")
+		user, _, _, err := auth.Login(ctx, "carol", "P@ssw0rd")
+		require.NoError(t, err)
+		assert.Equal(t, "carol", user.Username)
+		assert.Empty(t, user.Email, "not synced from the entry of the same username")
+
+		// The directory password of the same username is not the local user's
+		_, _, _, err = auth.Login(ctx, "carol", "carol-secret")
+		assert.ErrorIs(t, err, biz.ErrInvalidCredentials)
+		_, _, _, err = auth.Login(ctx, "carol", "wrong")
+		assert.ErrorIs(t, err, biz.ErrInvalidCredentials)
+	})
+
+	t.Run("local admins are not taken over", func(t *testing.T) {
+		root, err := users.CreateUser(ctx, biz.UserCreateParams{
+			Username: "root",
+			Password: "P@ssw0rd",
+			Role:     int32(biz.UserRoleAdmin),
+			Status:   int32(biz.UserStatusNormal),
+			Creator:  "admin",
+		})
+		require.NoError(t, err)
+
+		localFirst := newAuth([]string{biz.AuthBackendLocal, biz.AuthBackendLDAP}, true)
+		_, _, _, err = localFirst.Login(ctx, "root", "root-secret")
+		assert.ErrorIs(t, err, biz.ErrInvalidCredentials)
+		user, _, _, err := localFirst.Login(ctx, "root", "P@ssw0rd")
+		require.NoError(t, err)
+		assert.Equal(t, biz.UserRoleAdmin, user.Role, "not demoted by the groups of the entry")
+		assert.Equal(t, root.Version, user.Version)
+	})
+
+	t.Run("the users created by an account named ldap are not taken over", func(t *testing.T) {
+		dave, err := users.CreateUser(ctx, biz.UserCreateParams{
+			Username: "dave",
+			Password: "P@ssw0rd",
+			Role:     int32(biz.UserRoleUser),
+			Status:   int32(biz.UserStatusNormal),
+			Creator:  "ldap",
+		})
+		require.NoError(t, err)
+
+		_, _, _, err = auth.Login(ctx, "dave", "dave-secret")
+		assert.ErrorIs(t, err, biz.ErrInvalidCredentials)
+		user, err := users.GetUserByUsername(ctx, "dave")
+		require.NoError(t, err)
+		assert.Equal(t, biz.UserRoleUser, user.Role, "not synced from the entry")
+		assert.Equal(t, dave.Version, user.Version)
+	})
+
+	t.Run("an unreachable directory does not block the local users", func(t *testing.T) {
+		directory.down = true
+		t.Cleanup(func() { directory.down = false })
+
+		_, _, _, err := auth.Login(ctx, "carol", "P@ssw0rd")
+		require.NoError(t, err)
+
+		_, _, _, err = auth.Login(ctx, "jane", "jane-secret")
+		assert.Error(t, err)
+		assert.ErrorContains(t, err, "connection refused")
+	})
+}
@@ -3,26 +3,33 @@ package data
 import (
 	"context"
 	"fmt"
+	"usermanage/gen/proto/conf"
+	"usermanage/internal/data/migrations"
 	"usermanage/internal/data/model"
 	"usermanage/internal/pkg/constants"
 	"usermanage/internal/pkg/db"
+	"usermanage/internal/pkg/envelope"
+	"usermanage/internal/pkg/migrate"
 	"usermanage/internal/pkg/password"
 
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
 )
 
 type Data struct {
 	db     *db.Database
 	rdb    redis.UniversalClient
+	env    *envelope.Envelope
 	logger *log.Helper
 }
 
 // NewData creates a new Data instance
-func NewData(db *db.Database, rdb redis.UniversalClient, logger log.Logger) (*Data, error) {
+func NewData(db *db.Database, rdb redis.UniversalClient, env *envelope.Envelope, logger log.Logger) (*Data, error) {
 	return &Data{
 		db:     db,
 		rdb:    rdb,
+		env:    env,
 		logger: log.NewHelper(logger),
 	}, nil
 }
@@ -33,11 +40,46 @@ func (d *Data) Cleanup() {
 	d.logger.Info("closing the data resources")
 }
 
-// Migrate migrate database schema.
-func (d *Data) Migrate() error {
+// Migrator returns the migrator of the database schema.
+func (d *Data) Migrator(c *conf.Data) (*migrate.Migrator, error) {
+	ms, err := migrations.Load(d.db.Dialector.Name())
+	if err != nil {
+		return nil, err
+	}
+	return migrate.New(d.db.DB, ms, migrate.WithLockTimeout(c.Database.GetMigrateLockTimeout().AsDuration())), nil
+}
+
+// Migrate applies the pending migrations of the database schema.
+func (d *Data) Migrate(ctx context.Context, c *conf.Data) error {
 	d.logger.Info("migrate database schema")
-	models := []any{model.User{}}
-	return d.db.AutoMigrate(models...)
+	m, err := d.Migrator(c)
+	if err != nil {
+		return err
+	}
+	applied, err := m.Up(ctx, 0)
+	for _, migration := range applied {
+		d.logger.Infow("msg", "applied migration", "version", migration.Version, "name", migration.Name)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to migrate database schema: %w", err)
+	}
+	return nil
+}
+
+// RotateKeys re-encrypts the encrypted columns not encrypted under the current key,
+// `batchSize` rows at a time, and returns the number of updated rows.
+//
+// Once it has completed, the previous keys can be removed from the key file.
+func (d *Data) RotateKeys(ctx context.Context, batchSize int) (int, error) {
+	if d.env == nil {
+		return 0, db.ErrEncryptionNotConfigured
+	}
+	d.logger.Infow("msg", "re-encrypt user personal data", "batch_size", batchSize)
+	updated, err := d.db.ReencryptColumns(ctx, "users", []string{"email", "phone"}, batchSize)
+	if err != nil {
+		return updated, fmt.Errorf("failed to re-encrypt users: %w", err)
+	}
+	return updated, nil
 }
 
 // InitializeAdminAccount creates the root admin account if it does not exist.
@@ -73,9 +115,14 @@ func (d *Data) InitializeAdminAccount(ctx context.Context) error {
 	}
 
 	d.logger.Info("creating admin account")
-	if err := d.db.WithContext(ctx).
-		Create(adminUser).Error; err != nil {
-		return fmt.Errorf("failed to create admin account: %w", err)
+	err = d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(adminUser).Error; err != nil {
+			return fmt.Errorf("failed to create admin account: %w", err)
+		}
+		return recordUserRevision(tx, nil, adminUser, model.UserRevisionActionCreate, adminUser.Creator)
+	})
+	if err != nil {
+		return err
 	}
 
 	d.logger.Infow("msg", "admin account created successfully", "credential", passwd)
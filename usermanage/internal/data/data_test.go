@@ -0,0 +1,37 @@
+package data
+
+import (
+	"context"
+	"testing"
+	"usermanage/gen/proto/conf"
+	"usermanage/internal/data/migrations"
+	"usermanage/internal/pkg/db"
+	"usermanage/internal/pkg/migrate"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Create an in-memory SQLite database with the schema migrated.
+func newTestDatabase(t *testing.T) *db.Database {
+	t.Helper()
+
+	database, err := db.NewDatabase(&conf.Data{
+		Database: &conf.Data_Database{
+			Driver: conf.DatabaseDriver_DATABASE_DRIVER_SQLITE,
+			Name:   "test",
+			Dsn:    ":memory:",
+		},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if sqlDB, err := database.DB.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	ms, err := migrations.Load(database.Dialector.Name())
+	require.NoError(t, err)
+	_, err = migrate.New(database.DB, ms).Up(context.Background(), 0)
+	require.NoError(t, err)
+	return database
+}
@@ -0,0 +1,266 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	eventv1 "usermanage/gen/proto/api/event/v1"
+	userv1 "usermanage/gen/proto/api/user/v1"
+	"usermanage/gen/proto/conf"
+	"usermanage/internal/biz"
+	"usermanage/internal/data/model"
+	"usermanage/internal/pkg/broker"
+	"usermanage/internal/pkg/db"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// userEventTopic is the broker topic of all user events.
+const userEventTopic = "user"
+
+type eventRepo struct {
+	db     *db.Database
+	logger *log.Helper
+}
+
+// NewEventRepo creates a new outbox backed event repository.
+func NewEventRepo(db *db.Database, logger log.Logger) biz.EventRepo {
+	return &eventRepo{
+		db:     db,
+		logger: log.NewHelper(logger),
+	}
+}
+
+// NewEventBroker creates the broker the outbox relay publishes events to.
+//
+// Events are turned into webhook deliveries first, then published to the configured broker.
+func NewEventBroker(c *conf.Data, db *db.Database, rdb redis.UniversalClient) (broker.Broker, error) {
+	enqueuer := &webhookEnqueuer{db: db}
+	outbox := c.GetOutbox()
+	if outbox.GetBroker() == conf.EventBroker_EVENT_BROKER_MEMORY {
+		return broker.NewFanout(enqueuer, broker.NewMemory()), nil
+	}
+	if rdb == nil {
+		return nil, fmt.Errorf("the redis stream outbox broker requires Redis, configure `data.redis.addrs`")
+	}
+
+	streamPrefix := outbox.GetStreamPrefix()
+	if streamPrefix == "" {
+		streamPrefix = "events:"
+	}
+	return broker.NewFanout(enqueuer, broker.NewRedisStream(rdb, broker.RedisStreamOption{
+		StreamPrefix: c.GetRedis().GetKeyPrefix() + streamPrefix,
+		MaxLen:       outbox.GetStreamMaxLen(),
+	})), nil
+}
+
+// Append implements biz.EventRepo.
+func (r *eventRepo) Append(ctx context.Context, events ...*biz.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	rows := make([]model.OutboxEvent, 0, len(events))
+	for _, event := range events {
+		payload, err := protojson.Marshal(toEventMessage(event))
+		if err != nil {
+			return fmt.Errorf("failed to marshal event[id=%s]: %w", event.ID, err)
+		}
+		rows = append(rows, model.OutboxEvent{
+			EventID:     event.ID,
+			Topic:       userEventTopic,
+			Type:        string(event.Type),
+			AggregateID: event.User.ID,
+			Payload:     string(payload),
+			CreatedAt:   event.OccurredAt,
+		})
+	}
+	if err := r.db.Conn(ctx).Create(&rows).Error; err != nil {
+		return fmt.Errorf("failed to append events to outbox: %w", err)
+	}
+	return nil
+}
+
+// ListEvents implements biz.EventRepo.
+//
+// The version of an event is the commit sequence of its outbox row, the events are listed
+// once the outbox relay has numbered them.
+func (r *eventRepo) ListEvents(ctx context.Context, query biz.EventQuery) ([]*biz.Event, error) {
+	tx := r.db.Conn(ctx).Where("commit_seq > ?", query.AfterVersion)
+	if query.AggregateID != "" {
+		tx = tx.Where("aggregate_id = ?", query.AggregateID)
+	}
+	if len(query.Types) > 0 {
+		types := make([]string, 0, len(query.Types))
+		for _, t := range query.Types {
+			types = append(types, string(t))
+		}
+		tx = tx.Where("type IN ?", types)
+	}
+	if query.Limit > 0 {
+		tx = tx.Limit(query.Limit)
+	}
+
+	var rows []model.OutboxEvent
+	if err := tx.Order("commit_seq").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to find events after version[%d]: %w", query.AfterVersion, err)
+	}
+
+	events := make([]*biz.Event, 0, len(rows))
+	for _, row := range rows {
+		var msg eventv1.Event
+		if err := protojson.Unmarshal([]byte(row.Payload), &msg); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal event[id=%s]: %w", row.EventID, err)
+		}
+		event := fromEventMessage(&msg)
+		event.Version = *row.CommitSeq
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// LatestVersion implements biz.EventRepo.
+//
+// It reads from the replica the request lists users from, so the listing is at
+// least as recent as the returned version.
+func (r *eventRepo) LatestVersion(ctx context.Context) (uint64, error) {
+	var version uint64
+	if err := r.db.Read(ctx).
+		Model(&model.OutboxEvent{}).
+		Select("COALESCE(MAX(commit_seq), 0)").
+		Scan(&version).Error; err != nil {
+		return 0, fmt.Errorf("failed to get latest event version: %w", err)
+	}
+	return version, nil
+}
+
+// Convert biz Event to the event message published to consumers.
+func toEventMessage(e *biz.Event) *eventv1.Event {
+	msg := &eventv1.Event{
+		Id:          e.ID,
+		AggregateId: e.User.ID,
+		Actor:       e.Actor,
+		OccurredAt:  timestamppb.New(e.OccurredAt),
+	}
+
+	user := toEventUser(e.User)
+	switch e.Type {
+	case biz.EventTypeUserCreated:
+		msg.Type = eventv1.EventType_EVENT_TYPE_USER_CREATED
+		msg.Payload = &eventv1.Event_UserCreated{UserCreated: &eventv1.UserCreated{User: user}}
+	case biz.EventTypeUserUpdated:
+		changes := make([]*userv1.UserFieldChange, 0, len(e.Changes))
+		for _, change := range e.Changes {
+			changes = append(changes, &userv1.UserFieldChange{
+				Field:    change.Field,
+				OldValue: change.OldValue,
+				NewValue: change.NewValue,
+			})
+		}
+		msg.Type = eventv1.EventType_EVENT_TYPE_USER_UPDATED
+		msg.Payload = &eventv1.Event_UserUpdated{UserUpdated: &eventv1.UserUpdated{User: user, Changes: changes}}
+	case biz.EventTypeUserDeleted:
+		msg.Type = eventv1.EventType_EVENT_TYPE_USER_DELETED
+		msg.Payload = &eventv1.Event_UserDeleted{UserDeleted: &eventv1.UserDeleted{User: user}}
+	case biz.EventTypeUserLocked:
+		msg.Type = eventv1.EventType_EVENT_TYPE_USER_LOCKED
+		msg.Payload = &eventv1.Event_UserLocked{UserLocked: &eventv1.UserLocked{User: user}}
+	case biz.EventTypeUserLoggedIn:
+		msg.Type = eventv1.EventType_EVENT_TYPE_USER_LOGGED_IN
+		msg.Payload = &eventv1.Event_UserLoggedIn{UserLoggedIn: &eventv1.UserLoggedIn{User: user}}
+	case biz.EventTypeUserUndeleted:
+		msg.Type = eventv1.EventType_EVENT_TYPE_USER_UNDELETED
+		msg.Payload = &eventv1.Event_UserUndeleted{UserUndeleted: &eventv1.UserUndeleted{User: user}}
+	case biz.EventTypeUserPurged:
+		msg.Type = eventv1.EventType_EVENT_TYPE_USER_PURGED
+		msg.Payload = &eventv1.Event_UserPurged{UserPurged: &eventv1.UserPurged{User: user}}
+	}
+	return msg
+}
+
+// Convert biz User to the user carried by events.
+func toEventUser(u *biz.User) *userv1.UserPublic {
+	if u == nil {
+		return nil
+	}
+
+	user := &userv1.UserPublic{
+		Id:        u.ID,
+		Username:  u.Username,
+		Role:      userv1.UserRole(u.Role),
+		Status:    userv1.UserStatus(u.Status),
+		Creator:   u.Creator,
+		CreatedAt: timestamppb.New(u.CreatedAt),
+		UpdatedBy: u.UpdatedBy,
+		UpdatedAt: timestamppb.New(u.UpdatedAt),
+		Etag:      u.ETag(),
+	}
+	if u.DeletedAt != nil {
+		user.DeletedAt = timestamppb.New(*u.DeletedAt)
+	}
+	return user
+}
+
+// Convert the event message read back from the outbox to biz Event.
+func fromEventMessage(msg *eventv1.Event) *biz.Event {
+	event := &biz.Event{
+		ID:         msg.Id,
+		Actor:      msg.Actor,
+		OccurredAt: msg.OccurredAt.AsTime(),
+	}
+
+	var user *userv1.UserPublic
+	switch payload := msg.Payload.(type) {
+	case *eventv1.Event_UserCreated:
+		event.Type = biz.EventTypeUserCreated
+		user = payload.UserCreated.GetUser()
+	case *eventv1.Event_UserUpdated:
+		event.Type = biz.EventTypeUserUpdated
+		user = payload.UserUpdated.GetUser()
+		for _, change := range payload.UserUpdated.GetChanges() {
+			event.Changes = append(event.Changes, biz.UserFieldChange{
+				Field:    change.Field,
+				OldValue: change.OldValue,
+				NewValue: change.NewValue,
+			})
+		}
+	case *eventv1.Event_UserDeleted:
+		event.Type = biz.EventTypeUserDeleted
+		user = payload.UserDeleted.GetUser()
+	case *eventv1.Event_UserLocked:
+		event.Type = biz.EventTypeUserLocked
+		user = payload.UserLocked.GetUser()
+	case *eventv1.Event_UserLoggedIn:
+		event.Type = biz.EventTypeUserLoggedIn
+		user = payload.UserLoggedIn.GetUser()
+	case *eventv1.Event_UserUndeleted:
+		event.Type = biz.EventTypeUserUndeleted
+		user = payload.UserUndeleted.GetUser()
+	case *eventv1.Event_UserPurged:
+		event.Type = biz.EventTypeUserPurged
+		user = payload.UserPurged.GetUser()
+	}
+	if user != nil {
+		event.User = &biz.User{
+			ID:        user.Id,
+			Username:  user.Username,
+			Role:      biz.UserRole(user.Role),
+			Status:    biz.UserStatus(user.Status),
+			Creator:   user.Creator,
+			CreatedAt: user.CreatedAt.AsTime(),
+			UpdatedBy: user.UpdatedBy,
+			UpdatedAt: user.UpdatedAt.AsTime(),
+		}
+		if user.DeletedAt != nil {
+			deletedAt := user.DeletedAt.AsTime()
+			event.User.DeletedAt = &deletedAt
+		}
+		// Events recorded by earlier versions have no etag
+		if version, err := biz.ParseETag(user.Etag); err == nil {
+			event.User.Version = version
+		}
+	}
+	return event
+}
@@ -0,0 +1,78 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"usermanage/gen/proto/conf"
+	"usermanage/internal/biz"
+	"usermanage/internal/pkg/jwt"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingEventRepo fails to append events.
+type failingEventRepo struct {
+	biz.EventRepo
+}
+
+func (failingEventRepo) Append(context.Context, ...*biz.Event) error {
+	return errors.New("outbox unavailable")
+}
+
+func TestAuthUseCase_LoginEventFailure(t *testing.T) {
+	require.NoError(t, jwt.Initialize([]byte("secret"), time.Hour))
+	database := newTestDatabase(t)
+	users := NewUserRepo(database, nil, log.DefaultLogger)
+	tokens := NewMemoryTokenRepo(&conf.Data{})
+	auth := biz.NewAuthUseCase(NewTransaction(database), users, tokens, failingEventRepo{NewEventRepo(database, log.DefaultLogger)}, nil)
+	createTestUser(t, users, "foo")
+
+	_, token, _, err := auth.Login(context.Background(), "foo", "P@ssw0rd")
+	assert.Error(t, err)
+	assert.Empty(t, token)
+
+	// No session is left behind for the failed login
+	active, err := tokens.UserHasActiveSession(context.Background(), "foo")
+	require.NoError(t, err)
+	assert.False(t, active)
+}
+
+func TestEventMessageRoundTrip(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	user := &biz.User{
+		ID:        "u1",
+		Username:  "alice",
+		Role:      biz.UserRoleUser,
+		Status:    biz.UserStatusLocked,
+		Creator:   "admin",
+		CreatedAt: now,
+		UpdatedBy: "admin",
+		UpdatedAt: now,
+	}
+	event := &biz.Event{
+		ID:         "e1",
+		Type:       biz.EventTypeUserUpdated,
+		Actor:      "admin",
+		OccurredAt: now,
+		User:       user,
+		Changes:    []biz.UserFieldChange{{Field: "status", OldValue: "normal", NewValue: "locked"}},
+	}
+
+	assert.Equal(t, event, fromEventMessage(toEventMessage(event)))
+
+	for _, eventType := range []biz.EventType{
+		biz.EventTypeUserCreated,
+		biz.EventTypeUserDeleted,
+		biz.EventTypeUserLocked,
+		biz.EventTypeUserLoggedIn,
+		biz.EventTypeUserUndeleted,
+		biz.EventTypeUserPurged,
+	} {
+		event := &biz.Event{ID: "e2", Type: eventType, Actor: "admin", OccurredAt: now, User: user}
+		assert.Equal(t, event, fromEventMessage(toEventMessage(event)), eventType)
+	}
+}
@@ -0,0 +1,84 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"usermanage/internal/biz"
+	"usermanage/internal/data/model"
+	"usermanage/internal/pkg/db"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+type federationRepo struct {
+	db     *db.Database
+	logger *log.Helper
+}
+
+// NewFederationRepo creates a new federation repository.
+func NewFederationRepo(db *db.Database, logger log.Logger) biz.FederationRepo {
+	return &federationRepo{
+		db:     db,
+		logger: log.NewHelper(logger),
+	}
+}
+
+// GetIdentity implements biz.FederationRepo.
+func (r *federationRepo) GetIdentity(ctx context.Context, provider, subject string) (*biz.ExternalIdentity, error) {
+	var identity model.ExternalIdentity
+	if err := r.db.Conn(ctx).
+		Where("provider = ? AND subject = ?", provider, subject).
+		First(&identity).Error; err != nil {
+		return nil, fmt.Errorf("failed to get external identity[%s/%s]: %w", provider, subject, recordNotFound(err, biz.ErrExternalIdentityNotFound))
+	}
+	return r.toBizIdentity(&identity), nil
+}
+
+// GetIdentityByUserID implements biz.FederationRepo.
+func (r *federationRepo) GetIdentityByUserID(ctx context.Context, provider, userID string) (*biz.ExternalIdentity, error) {
+	var identity model.ExternalIdentity
+	if err := r.db.Conn(ctx).
+		Where("provider = ? AND user_id = ?", provider, userID).
+		First(&identity).Error; err != nil {
+		return nil, fmt.Errorf("failed to get external identity of user[id=%s]: %w", userID, recordNotFound(err, biz.ErrExternalIdentityNotFound))
+	}
+	return r.toBizIdentity(&identity), nil
+}
+
+// CreateIdentity implements biz.FederationRepo.
+func (r *federationRepo) CreateIdentity(ctx context.Context, identity *biz.ExternalIdentity) (*biz.ExternalIdentity, error) {
+	row := model.ExternalIdentity{
+		Provider:    identity.Provider,
+		Subject:     identity.Subject,
+		UserID:      identity.UserID,
+		LastLoginAt: time.Now(),
+	}
+	if err := r.db.Conn(ctx).Create(&row).Error; err != nil {
+		return nil, fmt.Errorf("failed to create external identity[%s/%s]: %w", identity.Provider, identity.Subject, err)
+	}
+	return r.toBizIdentity(&row), nil
+}
+
+// TouchIdentity implements biz.FederationRepo.
+func (r *federationRepo) TouchIdentity(ctx context.Context, id string) error {
+	if err := r.db.Conn(ctx).
+		Model(&model.ExternalIdentity{}).
+		Where("id = ?", id).
+		Update("last_login_at", time.Now()).Error; err != nil {
+		return fmt.Errorf("failed to update last login of external identity[id=%s]: %w", id, err)
+	}
+	return nil
+}
+
+// Convert model ExternalIdentity to biz ExternalIdentity.
+func (r *federationRepo) toBizIdentity(i *model.ExternalIdentity) *biz.ExternalIdentity {
+	return &biz.ExternalIdentity{
+		ID:          i.ID,
+		Provider:    i.Provider,
+		Subject:     i.Subject,
+		UserID:      i.UserID,
+		CreatedAt:   i.CreatedAt,
+		LastLoginAt: i.LastLoginAt,
+	}
+}
@@ -0,0 +1,189 @@
+package data
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+	"usermanage/gen/proto/conf"
+	"usermanage/internal/biz"
+	"usermanage/internal/pkg/jwt"
+	"usermanage/internal/pkg/oidc"
+	"usermanage/internal/pkg/oidc/oidctest"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFederationUseCase(t *testing.T) {
+	require.NoError(t, jwt.Initialize([]byte("secret"), time.Hour))
+	idp, err := oidctest.NewIdP()
+	require.NoError(t, err)
+	t.Cleanup(idp.Close)
+
+	database := newTestDatabase(t)
+	users := NewUserRepo(database, nil, log.DefaultLogger)
+	events := NewEventRepo(database, log.DefaultLogger)
+	auth := biz.NewAuthUseCase(NewTransaction(database), users, NewMemoryTokenRepo(&conf.Data{}), events, nil)
+	newProvider := func(name string) *biz.FederatedProvider {
+		client, err := oidc.NewClient(oidc.ClientConfig{
+			Issuer:       idp.Issuer(),
+			ClientID:     oidctest.ClientID,
+			ClientSecret: oidctest.ClientSecret,
+			RedirectURL:  "http://localhost:8000/v1/auth/federation/" + name + "/callback",
+		})
+		require.NoError(t, err)
+		return &biz.FederatedProvider{Name: name, Client: client}
+	}
+	corp := newProvider("corp")
+	corp.AutoProvision = true
+	corp.RoleClaim = "groups"
+	corp.AdminValues = []string{"usermanage-admins"}
+	partner := newProvider("partner")
+	linked := newProvider("linked")
+	linked.LinkByUsername = true
+	linked.RoleClaim = "groups"
+	linked.AdminValues = []string{"usermanage-admins"}
+	uc := biz.NewFederationUseCase(NewTransaction(database), NewFederationRepo(database, log.DefaultLogger), users, events, auth,
+		&biz.FederationOptions{Providers: map[string]*biz.FederatedProvider{"corp": corp, "partner": partner, "linked": linked}})
+	ctx := context.Background()
+
+	noRedirect := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	login := func(provider string, claims map[string]any) (*biz.User, error) {
+		t.Helper()
+		idp.SetUser(claims)
+		authURL, state, err := uc.StartLogin(ctx, provider)
+		require.NoError(t, err)
+		resp, err := noRedirect.Get(authURL)
+		require.NoError(t, err)
+		resp.Body.Close()
+		location, err := url.Parse(resp.Header.Get("Location"))
+		require.NoError(t, err)
+		require.Equal(t, state.State, location.Query().Get("state"))
+
+		user, token, _, err := uc.FinishLogin(ctx, provider, state, location.Query().Get("code"))
+		if err == nil {
+			current, err := auth.VerifyToken(ctx, token)
+			require.NoError(t, err)
+			assert.Equal(t, user.Username, current.Username)
+		}
+		return user, err
+	}
+
+	t.Run("first login provisions the user", func(t *testing.T) {
+		user, err := login("corp", map[string]any{"sub": "1001", "preferred_username": "jane"})
+		require.NoError(t, err)
+		assert.Equal(t, "jane", user.Username)
+		assert.Equal(t, biz.DefaultUserRole, user.Role)
+		assert.Equal(t, biz.DefaultUserStatus, user.Status)
+		assert.Equal(t, "federation:corp", user.Creator)
+		assert.Equal(t, "federation:corp", user.Source)
+
+		// The identity stays linked when the username changes upstream
+		again, err := login("corp", map[string]any{"sub": "1001", "preferred_username": "jane.doe"})
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, again.ID)
+		assert.Equal(t, "jane", again.Username)
+	})
+
+	t.Run("role is mapped on every login", func(t *testing.T) {
+		user, err := login("corp", map[string]any{"sub": "1001", "groups": []any{"staff", "usermanage-admins"}})
+		require.NoError(t, err)
+		assert.Equal(t, biz.UserRoleAdmin, user.Role)
+		assert.Equal(t, "federation:corp", user.UpdatedBy)
+
+		user, err = login("corp", map[string]any{"sub": "1001", "groups": []any{"staff"}})
+		require.NoError(t, err)
+		assert.Equal(t, biz.UserRoleUser, user.Role)
+	})
+
+	t.Run("local users are not taken over", func(t *testing.T) {
+		createTestUser(t, users, "bob")
+		_, err := login("corp", map[string]any{"sub": "1002", "preferred_username": "bob"})
+		assert.ErrorIs(t, err, biz.ErrFederatedLoginDenied)
+	})
+
+	t.Run("users are not provisioned without auto provisioning", func(t *testing.T) {
+		_, err := login("partner", map[string]any{"sub": "2001", "preferred_username": "carol"})
+		assert.ErrorIs(t, err, biz.ErrFederatedLoginDenied)
+		exists, err := users.ExistsByUsername(ctx, "carol")
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("link by username", func(t *testing.T) {
+		newTestEnvelope(t, false)
+		provision := func(username, creator, source string, role biz.UserRole) *biz.User {
+			t.Helper()
+			user, err := users.CreateUser(ctx, biz.UserCreateParams{
+				Username: username,
+				Password: "P@ssw0rd",
+				Role:     int32(role),
+				Status:   int32(biz.UserStatusNormal),
+				Creator:  creator,
+				Source:   source,
+				Email:    username + "@example.com",
+			})
+			require.NoError(t, err)
+			return user
+		}
+		identity := func(sub, username string, verified bool) map[string]any {
+			return map[string]any{
+				"sub":                sub,
+				"preferred_username": username,
+				"email":              username + "@example.com",
+				"email_verified":     verified,
+				"groups":             []any{"usermanage-admins"},
+			}
+		}
+
+		dave := provision("dave", biz.ScimActor, biz.ScimActor, biz.UserRoleUser)
+		_, err := login("linked", identity("3001", "dave", false))
+		assert.ErrorIs(t, err, biz.ErrFederatedLoginDenied, "unverified email")
+		user, err := login("linked", identity("3001", "dave", true))
+		require.NoError(t, err)
+		assert.Equal(t, dave.ID, user.ID)
+		assert.Equal(t, biz.UserRoleUser, user.Role, "the role of a linked user is not mapped")
+
+		// Another identity of the provider cannot claim the linked user
+		_, err = login("linked", identity("3002", "dave", true))
+		assert.ErrorIs(t, err, biz.ErrFederatedLoginDenied)
+
+		// Neither admins nor the users with a password login are linked
+		provision("frank", biz.ScimActor, biz.ScimActor, biz.UserRoleAdmin)
+		_, err = login("linked", identity("3003", "frank", true))
+		assert.ErrorIs(t, err, biz.ErrFederatedLoginDenied)
+		for _, creator := range []string{"admin", "ldap"} {
+			provision("grace-"+creator, creator, creator, biz.UserRoleUser)
+			_, err = login("linked", identity("3004-"+creator, "grace-"+creator, true))
+			assert.ErrorIs(t, err, biz.ErrFederatedLoginDenied, creator)
+		}
+
+		// Nor the users created by accounts named after a provisioner
+		for username, creator := range map[string]string{"heidi": biz.ScimActor, "ivan": "federation:corp"} {
+			provision(username, creator, "", biz.UserRoleUser)
+			_, err = login("linked", identity("3005-"+username, username, true))
+			assert.ErrorIs(t, err, biz.ErrFederatedLoginDenied, creator)
+		}
+	})
+
+	t.Run("inactive users cannot log in", func(t *testing.T) {
+		user, err := login("corp", map[string]any{"sub": "1003", "preferred_username": "erin"})
+		require.NoError(t, err)
+		status := int32(biz.UserStatusDisabled)
+		_, err = users.UpdateUser(ctx, user.ID, biz.UserUpdateParams{Status: &status})
+		require.NoError(t, err)
+
+		_, err = login("corp", map[string]any{"sub": "1003", "preferred_username": "erin"})
+		assert.ErrorIs(t, err, biz.ErrFederatedLoginDenied)
+	})
+
+	t.Run("unknown provider", func(t *testing.T) {
+		_, _, err := uc.StartLogin(ctx, "unknown")
+		assert.ErrorIs(t, err, biz.ErrFederationProviderNotFound)
+	})
+}
@@ -0,0 +1,24 @@
+// Package migrations embeds the versioned SQL schema migrations of every
+// supported database dialect.
+//
+// Never edit a migration once released, add a new one instead.
+package migrations
+
+import (
+	"embed"
+	"fmt"
+	"usermanage/internal/pkg/migrate"
+)
+
+//go:embed mysql/*.sql postgres/*.sql sqlite/*.sql
+var files embed.FS
+
+// Load returns the migrations of a database dialect.
+func Load(dialect string) ([]*migrate.Migration, error) {
+	switch dialect {
+	case "mysql", "postgres", "sqlite":
+		return migrate.Load(files, dialect)
+	default:
+		return nil, fmt.Errorf("unsupported database dialect for migrations: %s", dialect)
+	}
+}
@@ -0,0 +1,29 @@
+package migrations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	mysql, err := Load("mysql")
+	require.NoError(t, err)
+	require.NotEmpty(t, mysql)
+
+	// Every dialect must have the same migrations
+	for _, dialect := range []string{"postgres", "sqlite"} {
+		migrations, err := Load(dialect)
+		require.NoError(t, err)
+		require.Equal(t, len(mysql), len(migrations), dialect)
+		for i := range mysql {
+			assert.Equal(t, mysql[i].Version, migrations[i].Version, dialect)
+			assert.Equal(t, mysql[i].Name, migrations[i].Name, dialect)
+			assert.NotEmpty(t, migrations[i].Down, dialect)
+		}
+	}
+
+	_, err = Load("sqlserver")
+	assert.Error(t, err)
+}
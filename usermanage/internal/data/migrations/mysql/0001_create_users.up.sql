@@ -0,0 +1,18 @@
+-- Tables are created with IF NOT EXISTS so databases created by the former
+-- AutoMigrate are adopted as they are.
+CREATE TABLE IF NOT EXISTS `users` (
+  `id` varchar(32) NOT NULL,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `is_deleted` datetime(3) NULL,
+  `username` varchar(64),
+  `password` varchar(128),
+  `role` int,
+  `status` int,
+  `creator` varchar(64),
+  `updated_by` varchar(64),
+  PRIMARY KEY (`id`),
+  INDEX `idx_users_username` (`username`),
+  -- Usernames are unique among live users only, requires MySQL 8.0.13+
+  UNIQUE INDEX `idx_users_live_username` ((IF(`is_deleted` IS NULL, `username`, NULL)))
+);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS `user_revisions` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `user_id` varchar(32),
+  `revision` bigint,
+  `action` int,
+  `snapshot` text,
+  `changes` text,
+  `operator` varchar(64),
+  `created_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_user_revision` (`user_id`, `revision`),
+  INDEX `idx_user_revisions_created_at` (`created_at`)
+);
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS `outbox_events` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `event_id` varchar(32),
+  `topic` varchar(64),
+  `type` varchar(64),
+  `aggregate_id` varchar(32),
+  `payload` text,
+  `created_at` datetime(3) NULL,
+  `published_at` datetime(3) NULL,
+  `attempts` int,
+  `last_error` varchar(512),
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_outbox_events_event_id` (`event_id`),
+  INDEX `idx_outbox_events_aggregate_id` (`aggregate_id`),
+  INDEX `idx_outbox_events_published_at` (`published_at`)
+);
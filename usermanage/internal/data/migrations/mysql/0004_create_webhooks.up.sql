@@ -0,0 +1,45 @@
+CREATE TABLE IF NOT EXISTS `webhooks` (
+  `id` varchar(32) NOT NULL,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `is_deleted` datetime(3) NULL,
+  `url` varchar(512),
+  `event_types` text,
+  `secret` varchar(128),
+  `description` varchar(256),
+  `active` boolean,
+  `creator` varchar(64),
+  PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `webhook_deliveries` (
+  `id` varchar(32) NOT NULL,
+  `webhook_id` varchar(32),
+  `event_id` varchar(32),
+  `event_type` varchar(64),
+  `payload` text,
+  `status` int,
+  `attempts` int,
+  `next_attempt_at` datetime(3) NULL,
+  `last_status_code` int,
+  `last_error` varchar(512),
+  `delivered_at` datetime(3) NULL,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_webhook_event` (`webhook_id`, `event_id`),
+  INDEX `idx_webhook_delivery_due` (`status`, `next_attempt_at`),
+  INDEX `idx_webhook_deliveries_created_at` (`created_at`)
+);
+
+CREATE TABLE IF NOT EXISTS `webhook_delivery_attempts` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `delivery_id` varchar(32),
+  `attempt` int,
+  `status_code` int,
+  `error` varchar(512),
+  `duration_ms` bigint,
+  `created_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_webhook_delivery_attempts_delivery_id` (`delivery_id`)
+);
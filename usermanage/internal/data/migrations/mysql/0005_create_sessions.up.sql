@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS `sessions` (
+  `token` varchar(512) NOT NULL,
+  `username` varchar(64),
+  `expires_at` datetime(3) NULL,
+  `created_at` datetime(3) NULL,
+  PRIMARY KEY (`token`),
+  INDEX `idx_sessions_username` (`username`),
+  INDEX `idx_sessions_expires_at` (`expires_at`)
+);
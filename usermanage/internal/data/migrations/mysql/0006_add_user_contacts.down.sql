@@ -0,0 +1,6 @@
+DROP INDEX `idx_users_phone_index` ON `users`;
+DROP INDEX `idx_users_email_index` ON `users`;
+ALTER TABLE `users` DROP COLUMN `phone_index`;
+ALTER TABLE `users` DROP COLUMN `phone`;
+ALTER TABLE `users` DROP COLUMN `email_index`;
+ALTER TABLE `users` DROP COLUMN `email`;
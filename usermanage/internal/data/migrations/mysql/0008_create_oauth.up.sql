@@ -0,0 +1,58 @@
+CREATE TABLE IF NOT EXISTS `oauth_clients` (
+  `id` varchar(32) NOT NULL,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `is_deleted` datetime(3) NULL,
+  `name` varchar(128),
+  `secret_hash` varchar(64),
+  `redirect_uris` text,
+  `public` boolean,
+  `skip_consent` boolean,
+  `creator` varchar(64),
+  PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `oauth_authorization_codes` (
+  `code` varchar(64) NOT NULL,
+  `client_id` varchar(32),
+  `user_id` varchar(32),
+  `username` varchar(64),
+  `redirect_uri` varchar(512),
+  `scopes` text,
+  `nonce` varchar(256),
+  `code_challenge` varchar(128),
+  `auth_time` datetime(3) NULL,
+  `expires_at` datetime(3) NULL,
+  `created_at` datetime(3) NULL,
+  PRIMARY KEY (`code`),
+  INDEX `idx_oauth_authorization_codes_client_id` (`client_id`),
+  INDEX `idx_oauth_authorization_codes_expires_at` (`expires_at`)
+);
+
+CREATE TABLE IF NOT EXISTS `oauth_refresh_tokens` (
+  `token` varchar(64) NOT NULL,
+  `family_id` varchar(32),
+  `client_id` varchar(32),
+  `user_id` varchar(32),
+  `username` varchar(64),
+  `scopes` text,
+  `auth_time` datetime(3) NULL,
+  `expires_at` datetime(3) NULL,
+  `used_at` datetime(3) NULL,
+  `created_at` datetime(3) NULL,
+  PRIMARY KEY (`token`),
+  INDEX `idx_oauth_refresh_tokens_family_id` (`family_id`),
+  INDEX `idx_oauth_refresh_tokens_client_id` (`client_id`),
+  INDEX `idx_oauth_refresh_tokens_username` (`username`),
+  INDEX `idx_oauth_refresh_tokens_expires_at` (`expires_at`)
+);
+
+CREATE TABLE IF NOT EXISTS `oauth_consents` (
+  `username` varchar(64) NOT NULL,
+  `client_id` varchar(32) NOT NULL,
+  `scopes` text,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  PRIMARY KEY (`username`, `client_id`),
+  INDEX `idx_oauth_consents_client_id` (`client_id`)
+);
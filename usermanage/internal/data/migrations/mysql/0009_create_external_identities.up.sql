@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS `external_identities` (
+  `id` varchar(32) NOT NULL,
+  `provider` varchar(64),
+  `subject` varchar(255),
+  `user_id` varchar(32),
+  `created_at` datetime(3) NULL,
+  `last_login_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_external_identities_provider_subject` (`provider`, `subject`),
+  INDEX `idx_external_identities_user_id` (`user_id`)
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS `outbox_sequences`;
+ALTER TABLE `outbox_events` DROP INDEX `idx_outbox_events_commit_seq`;
+ALTER TABLE `outbox_events` DROP COLUMN `commit_seq`;
@@ -0,0 +1,10 @@
+-- The events are numbered in commit order by the outbox relay, the existing ones keep their ID
+ALTER TABLE `outbox_events` ADD COLUMN `commit_seq` bigint unsigned NULL;
+UPDATE `outbox_events` SET `commit_seq` = `id`;
+ALTER TABLE `outbox_events` ADD UNIQUE INDEX `idx_outbox_events_commit_seq` (`commit_seq`);
+CREATE TABLE IF NOT EXISTS `outbox_sequences` (
+  `id` bigint unsigned,
+  `last_value` bigint unsigned NOT NULL,
+  PRIMARY KEY (`id`)
+);
+INSERT INTO `outbox_sequences` (`id`, `last_value`) SELECT 1, COALESCE(MAX(`id`), 0) FROM `outbox_events`;
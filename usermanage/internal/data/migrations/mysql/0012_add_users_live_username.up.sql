@@ -0,0 +1,6 @@
+-- Databases adopted from AutoMigrate skipped the index of 0001_create_users along with the
+-- existing table. MySQL has no CREATE INDEX IF NOT EXISTS, the index is created only if missing.
+SET @statement = IF((SELECT COUNT(*) FROM information_schema.statistics WHERE table_schema = DATABASE() AND table_name = 'users' AND index_name = 'idx_users_live_username') = 0, 'CREATE UNIQUE INDEX `idx_users_live_username` ON `users` ((IF(`is_deleted` IS NULL, `username`, NULL)))', 'DO 0');
+PREPARE statement FROM @statement;
+EXECUTE statement;
+DEALLOCATE PREPARE statement;
@@ -0,0 +1 @@
+ALTER TABLE `oauth_clients` ADD COLUMN `resource_server` boolean NOT NULL DEFAULT false;
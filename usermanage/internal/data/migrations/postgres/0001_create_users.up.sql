@@ -0,0 +1,18 @@
+-- Tables and indexes are created with IF NOT EXISTS so databases created by the
+-- former AutoMigrate are adopted as they are.
+CREATE TABLE IF NOT EXISTS "users" (
+  "id" varchar(32) NOT NULL,
+  "created_at" timestamptz,
+  "updated_at" timestamptz,
+  "is_deleted" timestamptz,
+  "username" varchar(64),
+  "password" varchar(128),
+  "role" integer,
+  "status" integer,
+  "creator" varchar(64),
+  "updated_by" varchar(64),
+  PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_users_username" ON "users" ("username");
+-- Usernames are unique among live users only
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_users_live_username" ON "users" ("username") WHERE "is_deleted" IS NULL;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS "user_revisions" (
+  "id" bigserial,
+  "user_id" varchar(32),
+  "revision" bigint,
+  "action" integer,
+  "snapshot" text,
+  "changes" text,
+  "operator" varchar(64),
+  "created_at" timestamptz,
+  PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_user_revision" ON "user_revisions" ("user_id", "revision");
+CREATE INDEX IF NOT EXISTS "idx_user_revisions_created_at" ON "user_revisions" ("created_at");
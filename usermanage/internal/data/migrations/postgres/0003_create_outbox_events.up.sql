@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS "outbox_events" (
+  "id" bigserial,
+  "event_id" varchar(32),
+  "topic" varchar(64),
+  "type" varchar(64),
+  "aggregate_id" varchar(32),
+  "payload" text,
+  "created_at" timestamptz,
+  "published_at" timestamptz,
+  "attempts" integer,
+  "last_error" varchar(512),
+  PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_outbox_events_event_id" ON "outbox_events" ("event_id");
+CREATE INDEX IF NOT EXISTS "idx_outbox_events_aggregate_id" ON "outbox_events" ("aggregate_id");
+CREATE INDEX IF NOT EXISTS "idx_outbox_events_published_at" ON "outbox_events" ("published_at");
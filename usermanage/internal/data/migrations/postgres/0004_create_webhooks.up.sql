@@ -0,0 +1,45 @@
+CREATE TABLE IF NOT EXISTS "webhooks" (
+  "id" varchar(32) NOT NULL,
+  "created_at" timestamptz,
+  "updated_at" timestamptz,
+  "is_deleted" timestamptz,
+  "url" varchar(512),
+  "event_types" text,
+  "secret" varchar(128),
+  "description" varchar(256),
+  "active" boolean,
+  "creator" varchar(64),
+  PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "webhook_deliveries" (
+  "id" varchar(32) NOT NULL,
+  "webhook_id" varchar(32),
+  "event_id" varchar(32),
+  "event_type" varchar(64),
+  "payload" text,
+  "status" integer,
+  "attempts" integer,
+  "next_attempt_at" timestamptz,
+  "last_status_code" integer,
+  "last_error" varchar(512),
+  "delivered_at" timestamptz,
+  "created_at" timestamptz,
+  "updated_at" timestamptz,
+  PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_webhook_event" ON "webhook_deliveries" ("webhook_id", "event_id");
+CREATE INDEX IF NOT EXISTS "idx_webhook_delivery_due" ON "webhook_deliveries" ("status", "next_attempt_at");
+CREATE INDEX IF NOT EXISTS "idx_webhook_deliveries_created_at" ON "webhook_deliveries" ("created_at");
+
+CREATE TABLE IF NOT EXISTS "webhook_delivery_attempts" (
+  "id" bigserial,
+  "delivery_id" varchar(32),
+  "attempt" integer,
+  "status_code" integer,
+  "error" varchar(512),
+  "duration_ms" bigint,
+  "created_at" timestamptz,
+  PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_webhook_delivery_attempts_delivery_id" ON "webhook_delivery_attempts" ("delivery_id");
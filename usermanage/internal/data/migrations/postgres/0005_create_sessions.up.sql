@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS "sessions" (
+  "token" varchar(512) NOT NULL,
+  "username" varchar(64),
+  "expires_at" timestamptz,
+  "created_at" timestamptz,
+  PRIMARY KEY ("token")
+);
+CREATE INDEX IF NOT EXISTS "idx_sessions_username" ON "sessions" ("username");
+CREATE INDEX IF NOT EXISTS "idx_sessions_expires_at" ON "sessions" ("expires_at");
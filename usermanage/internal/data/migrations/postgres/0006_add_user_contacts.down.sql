@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS "idx_users_phone_index";
+DROP INDEX IF EXISTS "idx_users_email_index";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "phone_index";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "phone";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "email_index";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "email";
@@ -0,0 +1,6 @@
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "email" text;
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "email_index" varchar(64);
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "phone" text;
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "phone_index" varchar(64);
+CREATE INDEX IF NOT EXISTS "idx_users_email_index" ON "users" ("email_index");
+CREATE INDEX IF NOT EXISTS "idx_users_phone_index" ON "users" ("phone_index");
@@ -0,0 +1,58 @@
+CREATE TABLE IF NOT EXISTS "oauth_clients" (
+  "id" varchar(32) NOT NULL,
+  "created_at" timestamptz,
+  "updated_at" timestamptz,
+  "is_deleted" timestamptz,
+  "name" varchar(128),
+  "secret_hash" varchar(64),
+  "redirect_uris" text,
+  "public" boolean,
+  "skip_consent" boolean,
+  "creator" varchar(64),
+  PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "oauth_authorization_codes" (
+  "code" varchar(64) NOT NULL,
+  "client_id" varchar(32),
+  "user_id" varchar(32),
+  "username" varchar(64),
+  "redirect_uri" varchar(512),
+  "scopes" text,
+  "nonce" varchar(256),
+  "code_challenge" varchar(128),
+  "auth_time" timestamptz,
+  "expires_at" timestamptz,
+  "created_at" timestamptz,
+  PRIMARY KEY ("code")
+);
+CREATE INDEX IF NOT EXISTS "idx_oauth_authorization_codes_client_id" ON "oauth_authorization_codes" ("client_id");
+CREATE INDEX IF NOT EXISTS "idx_oauth_authorization_codes_expires_at" ON "oauth_authorization_codes" ("expires_at");
+
+CREATE TABLE IF NOT EXISTS "oauth_refresh_tokens" (
+  "token" varchar(64) NOT NULL,
+  "family_id" varchar(32),
+  "client_id" varchar(32),
+  "user_id" varchar(32),
+  "username" varchar(64),
+  "scopes" text,
+  "auth_time" timestamptz,
+  "expires_at" timestamptz,
+  "used_at" timestamptz,
+  "created_at" timestamptz,
+  PRIMARY KEY ("token")
+);
+CREATE INDEX IF NOT EXISTS "idx_oauth_refresh_tokens_family_id" ON "oauth_refresh_tokens" ("family_id");
+CREATE INDEX IF NOT EXISTS "idx_oauth_refresh_tokens_client_id" ON "oauth_refresh_tokens" ("client_id");
+CREATE INDEX IF NOT EXISTS "idx_oauth_refresh_tokens_username" ON "oauth_refresh_tokens" ("username");
+CREATE INDEX IF NOT EXISTS "idx_oauth_refresh_tokens_expires_at" ON "oauth_refresh_tokens" ("expires_at");
+
+CREATE TABLE IF NOT EXISTS "oauth_consents" (
+  "username" varchar(64) NOT NULL,
+  "client_id" varchar(32) NOT NULL,
+  "scopes" text,
+  "created_at" timestamptz,
+  "updated_at" timestamptz,
+  PRIMARY KEY ("username", "client_id")
+);
+CREATE INDEX IF NOT EXISTS "idx_oauth_consents_client_id" ON "oauth_consents" ("client_id");
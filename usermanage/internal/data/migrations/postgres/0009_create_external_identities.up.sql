@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS "external_identities" (
+  "id" varchar(32) NOT NULL,
+  "provider" varchar(64),
+  "subject" varchar(255),
+  "user_id" varchar(32),
+  "created_at" timestamptz,
+  "last_login_at" timestamptz,
+  PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_external_identities_provider_subject" ON "external_identities" ("provider", "subject");
+CREATE INDEX IF NOT EXISTS "idx_external_identities_user_id" ON "external_identities" ("user_id");
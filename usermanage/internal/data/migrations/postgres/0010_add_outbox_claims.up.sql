@@ -0,0 +1 @@
+ALTER TABLE "outbox_events" ADD COLUMN IF NOT EXISTS "claimed_until" timestamptz;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "outbox_sequences";
+DROP INDEX IF EXISTS "idx_outbox_events_commit_seq";
+ALTER TABLE "outbox_events" DROP COLUMN IF EXISTS "commit_seq";
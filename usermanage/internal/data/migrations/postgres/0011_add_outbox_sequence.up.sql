@@ -0,0 +1,10 @@
+-- The events are numbered in commit order by the outbox relay, the existing ones keep their ID
+ALTER TABLE "outbox_events" ADD COLUMN IF NOT EXISTS "commit_seq" bigint;
+UPDATE "outbox_events" SET "commit_seq" = "id";
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_outbox_events_commit_seq" ON "outbox_events" ("commit_seq");
+CREATE TABLE IF NOT EXISTS "outbox_sequences" (
+  "id" bigint,
+  "last_value" bigint NOT NULL,
+  PRIMARY KEY ("id")
+);
+INSERT INTO "outbox_sequences" ("id", "last_value") SELECT 1, COALESCE(MAX("id"), 0) FROM "outbox_events";
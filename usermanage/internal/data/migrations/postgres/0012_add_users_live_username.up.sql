@@ -0,0 +1,2 @@
+-- Usernames are unique among live users only, a no-op where 0001_create_users created it
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_users_live_username" ON "users" ("username") WHERE "is_deleted" IS NULL;
@@ -0,0 +1 @@
+ALTER TABLE "oauth_clients" ADD COLUMN IF NOT EXISTS "resource_server" boolean NOT NULL DEFAULT false;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS `users` (
+  `id` text NOT NULL,
+  `created_at` datetime,
+  `updated_at` datetime,
+  `is_deleted` datetime,
+  `username` text,
+  `password` text,
+  `role` integer,
+  `status` integer,
+  `creator` text,
+  `updated_by` text,
+  PRIMARY KEY (`id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_users_username` ON `users` (`username`);
+-- Usernames are unique among live users only
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_users_live_username` ON `users` (`username`) WHERE `is_deleted` IS NULL;
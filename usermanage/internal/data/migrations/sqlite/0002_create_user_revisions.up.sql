@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS `user_revisions` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `user_id` text,
+  `revision` integer,
+  `action` integer,
+  `snapshot` text,
+  `changes` text,
+  `operator` text,
+  `created_at` datetime
+);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_user_revision` ON `user_revisions` (`user_id`, `revision`);
+CREATE INDEX IF NOT EXISTS `idx_user_revisions_created_at` ON `user_revisions` (`created_at`);
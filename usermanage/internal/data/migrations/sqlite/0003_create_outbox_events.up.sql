@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS `outbox_events` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `event_id` text,
+  `topic` text,
+  `type` text,
+  `aggregate_id` text,
+  `payload` text,
+  `created_at` datetime,
+  `published_at` datetime,
+  `attempts` integer,
+  `last_error` text
+);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_outbox_events_event_id` ON `outbox_events` (`event_id`);
+CREATE INDEX IF NOT EXISTS `idx_outbox_events_aggregate_id` ON `outbox_events` (`aggregate_id`);
+CREATE INDEX IF NOT EXISTS `idx_outbox_events_published_at` ON `outbox_events` (`published_at`);
@@ -0,0 +1,44 @@
+CREATE TABLE IF NOT EXISTS `webhooks` (
+  `id` text NOT NULL,
+  `created_at` datetime,
+  `updated_at` datetime,
+  `is_deleted` datetime,
+  `url` text,
+  `event_types` text,
+  `secret` text,
+  `description` text,
+  `active` numeric,
+  `creator` text,
+  PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `webhook_deliveries` (
+  `id` text NOT NULL,
+  `webhook_id` text,
+  `event_id` text,
+  `event_type` text,
+  `payload` text,
+  `status` integer,
+  `attempts` integer,
+  `next_attempt_at` datetime,
+  `last_status_code` integer,
+  `last_error` text,
+  `delivered_at` datetime,
+  `created_at` datetime,
+  `updated_at` datetime,
+  PRIMARY KEY (`id`)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_webhook_event` ON `webhook_deliveries` (`webhook_id`, `event_id`);
+CREATE INDEX IF NOT EXISTS `idx_webhook_delivery_due` ON `webhook_deliveries` (`status`, `next_attempt_at`);
+CREATE INDEX IF NOT EXISTS `idx_webhook_deliveries_created_at` ON `webhook_deliveries` (`created_at`);
+
+CREATE TABLE IF NOT EXISTS `webhook_delivery_attempts` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `delivery_id` text,
+  `attempt` integer,
+  `status_code` integer,
+  `error` text,
+  `duration_ms` integer,
+  `created_at` datetime
+);
+CREATE INDEX IF NOT EXISTS `idx_webhook_delivery_attempts_delivery_id` ON `webhook_delivery_attempts` (`delivery_id`);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS `sessions` (
+  `token` text NOT NULL,
+  `username` text,
+  `expires_at` datetime,
+  `created_at` datetime,
+  PRIMARY KEY (`token`)
+);
+CREATE INDEX IF NOT EXISTS `idx_sessions_username` ON `sessions` (`username`);
+CREATE INDEX IF NOT EXISTS `idx_sessions_expires_at` ON `sessions` (`expires_at`);
@@ -0,0 +1,6 @@
+ALTER TABLE `users` ADD COLUMN `email` text;
+ALTER TABLE `users` ADD COLUMN `email_index` text;
+ALTER TABLE `users` ADD COLUMN `phone` text;
+ALTER TABLE `users` ADD COLUMN `phone_index` text;
+CREATE INDEX IF NOT EXISTS `idx_users_email_index` ON `users` (`email_index`);
+CREATE INDEX IF NOT EXISTS `idx_users_phone_index` ON `users` (`phone_index`);
@@ -0,0 +1,58 @@
+CREATE TABLE IF NOT EXISTS `oauth_clients` (
+  `id` text NOT NULL,
+  `created_at` datetime,
+  `updated_at` datetime,
+  `is_deleted` datetime,
+  `name` text,
+  `secret_hash` text,
+  `redirect_uris` text,
+  `public` numeric,
+  `skip_consent` numeric,
+  `creator` text,
+  PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `oauth_authorization_codes` (
+  `code` text NOT NULL,
+  `client_id` text,
+  `user_id` text,
+  `username` text,
+  `redirect_uri` text,
+  `scopes` text,
+  `nonce` text,
+  `code_challenge` text,
+  `auth_time` datetime,
+  `expires_at` datetime,
+  `created_at` datetime,
+  PRIMARY KEY (`code`)
+);
+CREATE INDEX IF NOT EXISTS `idx_oauth_authorization_codes_client_id` ON `oauth_authorization_codes` (`client_id`);
+CREATE INDEX IF NOT EXISTS `idx_oauth_authorization_codes_expires_at` ON `oauth_authorization_codes` (`expires_at`);
+
+CREATE TABLE IF NOT EXISTS `oauth_refresh_tokens` (
+  `token` text NOT NULL,
+  `family_id` text,
+  `client_id` text,
+  `user_id` text,
+  `username` text,
+  `scopes` text,
+  `auth_time` datetime,
+  `expires_at` datetime,
+  `used_at` datetime,
+  `created_at` datetime,
+  PRIMARY KEY (`token`)
+);
+CREATE INDEX IF NOT EXISTS `idx_oauth_refresh_tokens_family_id` ON `oauth_refresh_tokens` (`family_id`);
+CREATE INDEX IF NOT EXISTS `idx_oauth_refresh_tokens_client_id` ON `oauth_refresh_tokens` (`client_id`);
+CREATE INDEX IF NOT EXISTS `idx_oauth_refresh_tokens_username` ON `oauth_refresh_tokens` (`username`);
+CREATE INDEX IF NOT EXISTS `idx_oauth_refresh_tokens_expires_at` ON `oauth_refresh_tokens` (`expires_at`);
+
+CREATE TABLE IF NOT EXISTS `oauth_consents` (
+  `username` text NOT NULL,
+  `client_id` text NOT NULL,
+  `scopes` text,
+  `created_at` datetime,
+  `updated_at` datetime,
+  PRIMARY KEY (`username`, `client_id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_oauth_consents_client_id` ON `oauth_consents` (`client_id`);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS `external_identities` (
+  `id` text NOT NULL,
+  `provider` text,
+  `subject` text,
+  `user_id` text,
+  `created_at` datetime,
+  `last_login_at` datetime,
+  PRIMARY KEY (`id`)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_external_identities_provider_subject` ON `external_identities` (`provider`, `subject`);
+CREATE INDEX IF NOT EXISTS `idx_external_identities_user_id` ON `external_identities` (`user_id`);
@@ -0,0 +1,9 @@
+-- The events are numbered in commit order by the outbox relay, the existing ones keep their ID
+ALTER TABLE `outbox_events` ADD COLUMN `commit_seq` integer;
+UPDATE `outbox_events` SET `commit_seq` = `id`;
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_outbox_events_commit_seq` ON `outbox_events` (`commit_seq`);
+CREATE TABLE IF NOT EXISTS `outbox_sequences` (
+  `id` integer PRIMARY KEY,
+  `last_value` integer NOT NULL
+);
+INSERT INTO `outbox_sequences` (`id`, `last_value`) SELECT 1, COALESCE(MAX(`id`), 0) FROM `outbox_events`;
@@ -0,0 +1 @@
+-- The index belongs to 0001_create_users and is dropped with the table.
@@ -0,0 +1 @@
+ALTER TABLE `oauth_clients` ADD COLUMN `resource_server` numeric NOT NULL DEFAULT false;
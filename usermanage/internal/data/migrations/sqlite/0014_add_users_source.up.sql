@@ -0,0 +1,5 @@
+ALTER TABLE `users` ADD COLUMN `source` text NOT NULL DEFAULT '';
+-- The provisioners were told apart by the creator only, unless an account has its name
+UPDATE `users` SET `source` = `creator`
+WHERE (`creator` IN ('ldap', 'scim') OR `creator` LIKE 'federation:%')
+  AND `creator` NOT IN (SELECT `username` FROM `users` WHERE `username` IS NOT NULL);
@@ -0,0 +1,24 @@
+package model
+
+import (
+	"time"
+	"usermanage/internal/pkg/id"
+
+	"gorm.io/gorm"
+)
+
+// ExternalIdentity represents a user of an upstream OpenID Connect provider, linked to a local user.
+type ExternalIdentity struct {
+	ID          string `gorm:"primaryKey;size:32"`
+	Provider    string `gorm:"size:64;uniqueIndex:idx_external_identities_provider_subject"`
+	Subject     string `gorm:"size:255;uniqueIndex:idx_external_identities_provider_subject"`
+	UserID      string `gorm:"size:32;index"`
+	CreatedAt   time.Time
+	LastLoginAt time.Time
+}
+
+// BeforeCreate a Gorm hook to be run before the identity is created.
+func (i *ExternalIdentity) BeforeCreate(tx *gorm.DB) (err error) {
+	i.ID = id.GenerateUUID(true)
+	return
+}
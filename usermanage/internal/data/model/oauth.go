@@ -0,0 +1,88 @@
+package model
+
+import (
+	"time"
+	"usermanage/internal/pkg/id"
+
+	"gorm.io/gorm"
+)
+
+// OAuthClient represents an application logging its users in with OpenID Connect.
+type OAuthClient struct {
+	BaseModel
+	Name           string   `json:"name" gorm:"size:128"`
+	SecretHash     string   `json:"-" gorm:"size:64"` // hex-encoded SHA-256 of the secret, empty for public clients
+	RedirectURIs   []string `json:"redirectUris" gorm:"type:text;serializer:json"`
+	Public         bool     `json:"public"`
+	SkipConsent    bool     `json:"skipConsent"`
+	ResourceServer bool     `json:"resourceServer"` // may introspect the tokens
+	Creator        string   `json:"creator" gorm:"size:64"`
+}
+
+// TableName overrides the `o_auth_clients` default.
+func (OAuthClient) TableName() string {
+	return "oauth_clients"
+}
+
+// BeforeCreate a Gorm hook to be run before the client is created.
+func (c *OAuthClient) BeforeCreate(tx *gorm.DB) (err error) {
+	c.ID = id.GenerateUUID(true)
+	return
+}
+
+// OAuthAuthorizationCode represents an authorization code waiting to be exchanged for tokens.
+//
+// The code itself is not stored, only its hex-encoded SHA-256.
+type OAuthAuthorizationCode struct {
+	Code          string   `gorm:"primaryKey;size:64"`
+	ClientID      string   `gorm:"size:32;index"`
+	UserID        string   `gorm:"size:32"`
+	Username      string   `gorm:"size:64"`
+	RedirectURI   string   `gorm:"size:512"`
+	Scopes        []string `gorm:"type:text;serializer:json"`
+	Nonce         string   `gorm:"size:256"`
+	CodeChallenge string   `gorm:"size:128"`
+	AuthTime      time.Time
+	ExpiresAt     time.Time `gorm:"index"`
+	CreatedAt     time.Time
+}
+
+// TableName overrides the `o_auth_authorization_codes` default.
+func (OAuthAuthorizationCode) TableName() string {
+	return "oauth_authorization_codes"
+}
+
+// OAuthRefreshToken represents a refresh token, used once then replaced by a new one of the same family.
+//
+// The token itself is not stored, only its hex-encoded SHA-256.
+type OAuthRefreshToken struct {
+	Token     string   `gorm:"primaryKey;size:64"`
+	FamilyID  string   `gorm:"size:32;index"`
+	ClientID  string   `gorm:"size:32;index"`
+	UserID    string   `gorm:"size:32"`
+	Username  string   `gorm:"size:64;index"`
+	Scopes    []string `gorm:"type:text;serializer:json"`
+	AuthTime  time.Time
+	ExpiresAt time.Time  `gorm:"index"`
+	UsedAt    *time.Time // set once exchanged
+	CreatedAt time.Time
+}
+
+// TableName overrides the `o_auth_refresh_tokens` default.
+func (OAuthRefreshToken) TableName() string {
+	return "oauth_refresh_tokens"
+}
+
+// OAuthConsent represents the scopes a user consented to a client getting.
+type OAuthConsent struct {
+	Username  string   `gorm:"primaryKey;size:64"`
+	ClientID  string   `gorm:"primaryKey;size:32;index"`
+	Scopes    []string `gorm:"type:text;serializer:json"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// TableName overrides the `o_auth_consents` default.
+func (OAuthConsent) TableName() string {
+	return "oauth_consents"
+}
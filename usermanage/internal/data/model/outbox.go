@@ -0,0 +1,33 @@
+package model
+
+import "time"
+
+// OutboxEvent is a domain event waiting in the outbox to be published.
+//
+// Events are written in the same transaction as the change they describe and
+// published afterwards by the outbox relay, in `ID` order.
+//
+// IDs are allocated when the events are written, not when their transaction commits,
+// so the relay numbers the committed events in `CommitSeq`, the order they are read back in.
+type OutboxEvent struct {
+	ID          uint64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	CommitSeq   *uint64    `json:"commitSeq" gorm:"uniqueIndex"`
+	EventID     string     `json:"eventId" gorm:"size:32;uniqueIndex"`
+	Topic       string     `json:"topic" gorm:"size:64"`
+	Type        string     `json:"type" gorm:"size:64"`
+	AggregateID string     `json:"aggregateId" gorm:"size:32;index"`
+	Payload     string     `json:"payload" gorm:"type:text"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	PublishedAt *time.Time `json:"publishedAt" gorm:"index"`
+	Attempts    int32      `json:"attempts"`
+	LastError   string     `json:"lastError" gorm:"size:512"`
+	// ClaimedUntil is set while a relay instance publishes the event
+	ClaimedUntil *time.Time `json:"claimedUntil"`
+}
+
+// OutboxSequence is the last number given to the committed events, a single row locked
+// while numbering them.
+type OutboxSequence struct {
+	ID        uint64 `json:"id" gorm:"primaryKey"`
+	LastValue uint64 `json:"lastValue"`
+}
@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+// Session represents a login token of the database session store.
+//
+// The token itself is not stored, only its hex-encoded SHA-256.
+type Session struct {
+	Token     string    `gorm:"primaryKey;size:512"`
+	Username  string    `gorm:"size:64;index"`
+	ExpiresAt time.Time `gorm:"index"`
+	CreatedAt time.Time
+}
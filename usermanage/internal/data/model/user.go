@@ -20,11 +20,21 @@ type User struct {
 	// MustChangePassword bool   `json:"mustChangePassword" gorm:"default:true"`
 	Creator   string `json:"creator" gorm:"size:64"`
 	UpdatedBy string `json:"updatedBy" gorm:"size:64"`
+	// Source is the provisioner of the user, e.g. `ldap`, empty for the users created by an admin.
+	Source string `json:"source" gorm:"size:64;not null;default:''"`
+	// Email and Phone are encrypted at rest, they are looked up by their blind index.
+	Email      string `json:"email" gorm:"type:text;serializer:encrypted"`
+	EmailIndex string `json:"emailIndex" gorm:"size:64;index"`
+	Phone      string `json:"phone" gorm:"type:text;serializer:encrypted"`
+	PhoneIndex string `json:"phoneIndex" gorm:"size:64;index"`
+	// Version starts at 1 and is incremented by every change, for optimistic concurrency control.
+	Version int64 `json:"version" gorm:"not null;default:1"`
 }
 
 // BeforeCreate a Gorm hook to be run before the user is created.
 func (u *User) BeforeCreate(tx *gorm.DB) (err error) {
 	u.ID = id.GenerateUUID(true)
+	u.Version = 1
 	u.SetPassword(u.Password)
 	if !u.Role.IsValid() {
 		u.Role = constants.DefaultUserRole
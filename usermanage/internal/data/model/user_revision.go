@@ -0,0 +1,74 @@
+package model
+
+import (
+	"time"
+	"usermanage/internal/pkg/constants"
+)
+
+// UserRevisionAction represents the kind of change recorded by a user revision.
+type UserRevisionAction int32
+
+const (
+	UserRevisionActionUnknown UserRevisionAction = iota
+	UserRevisionActionCreate
+	UserRevisionActionUpdate
+	UserRevisionActionReplace
+	UserRevisionActionDelete
+	UserRevisionActionRestore
+	UserRevisionActionUndelete
+)
+
+// UserRevision represents a versioned change of a user record.
+//
+// Revisions are append-only, `Revision` starts at 1 and increases by one for every
+// change of the same user.
+type UserRevision struct {
+	ID        uint64             `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID    string             `json:"userId" gorm:"size:32;uniqueIndex:idx_user_revision,priority:1"`
+	Revision  int64              `json:"revision" gorm:"uniqueIndex:idx_user_revision,priority:2"`
+	Action    UserRevisionAction `json:"action"`
+	Snapshot  UserSnapshot       `json:"snapshot" gorm:"type:text;serializer:json"`
+	Changes   []UserFieldChange  `json:"changes" gorm:"type:text;serializer:json"`
+	Operator  string             `json:"operator" gorm:"size:64"`
+	CreatedAt time.Time          `json:"createdAt" gorm:"index"`
+}
+
+// UserSnapshot is the state of a user captured by a revision.
+//
+// # Note
+//
+// The password is never part of a snapshot, the email and phone are only recorded
+// by their blind index.
+type UserSnapshot struct {
+	Username   string               `json:"username"`
+	Role       constants.UserRole   `json:"role"`
+	Status     constants.UserStatus `json:"status"`
+	Creator    string               `json:"creator"`
+	UpdatedBy  string               `json:"updatedBy"`
+	CreatedAt  time.Time            `json:"createdAt"`
+	UpdatedAt  time.Time            `json:"updatedAt"`
+	EmailIndex string               `json:"emailIndex,omitempty"`
+	PhoneIndex string               `json:"phoneIndex,omitempty"`
+}
+
+// UserFieldChange is the change of a single field between two revisions.
+type UserFieldChange struct {
+	Field    string `json:"field"`
+	OldValue string `json:"oldValue"`
+	NewValue string `json:"newValue"`
+}
+
+// Snapshot returns the snapshot of the user's current state.
+func (u *User) Snapshot() UserSnapshot {
+	return UserSnapshot{
+		Username:   u.Username,
+		Role:       u.Role,
+		Status:     u.Status,
+		Creator:    u.Creator,
+		UpdatedBy:  u.UpdatedBy,
+		CreatedAt:  u.CreatedAt,
+		UpdatedAt:  u.UpdatedAt,
+		EmailIndex: u.EmailIndex,
+		PhoneIndex: u.PhoneIndex,
+	}
+}
@@ -0,0 +1,84 @@
+package model
+
+import (
+	"slices"
+	"time"
+	"usermanage/internal/pkg/id"
+
+	"gorm.io/gorm"
+)
+
+// WebhookEventTypeAll subscribes a webhook to all event types.
+const WebhookEventTypeAll = "*"
+
+// WebhookDeliveryStatus represents the state of a webhook delivery.
+type WebhookDeliveryStatus int32
+
+const (
+	WebhookDeliveryStatusUnknown WebhookDeliveryStatus = iota
+	WebhookDeliveryStatusPending
+	WebhookDeliveryStatusSucceeded
+	WebhookDeliveryStatusRetrying
+	WebhookDeliveryStatusDead
+)
+
+// Webhook represents an HTTP endpoint notified about domain events.
+type Webhook struct {
+	BaseModel
+	URL         string   `json:"url" gorm:"size:512"`
+	EventTypes  []string `json:"eventTypes" gorm:"type:text;serializer:json"`
+	Secret      string   `json:"-" gorm:"size:128"`
+	Description string   `json:"description" gorm:"size:256"`
+	Active      bool     `json:"active"`
+	Creator     string   `json:"creator" gorm:"size:64"`
+}
+
+// BeforeCreate a Gorm hook to be run before the webhook is created.
+func (w *Webhook) BeforeCreate(tx *gorm.DB) (err error) {
+	w.ID = id.GenerateUUID(true)
+	return
+}
+
+// Subscribes reports whether the webhook is subscribed to the event type.
+func (w *Webhook) Subscribes(eventType string) bool {
+	return slices.Contains(w.EventTypes, WebhookEventTypeAll) || slices.Contains(w.EventTypes, eventType)
+}
+
+// WebhookDelivery represents an event to be delivered to a webhook.
+//
+// There is one delivery per webhook and event, retried until it succeeds or
+// runs out of attempts.
+type WebhookDelivery struct {
+	ID             string                `json:"id" gorm:"primaryKey;size:32"`
+	WebhookID      string                `json:"webhookId" gorm:"size:32;uniqueIndex:idx_webhook_event,priority:1"`
+	EventID        string                `json:"eventId" gorm:"size:32;uniqueIndex:idx_webhook_event,priority:2"`
+	EventType      string                `json:"eventType" gorm:"size:64"`
+	Payload        string                `json:"payload" gorm:"type:text"`
+	Status         WebhookDeliveryStatus `json:"status" gorm:"index:idx_webhook_delivery_due,priority:1"`
+	Attempts       int32                 `json:"attempts"`
+	NextAttemptAt  time.Time             `json:"nextAttemptAt" gorm:"index:idx_webhook_delivery_due,priority:2"`
+	LastStatusCode int32                 `json:"lastStatusCode"`
+	LastError      string                `json:"lastError" gorm:"size:512"`
+	DeliveredAt    *time.Time            `json:"deliveredAt"`
+	CreatedAt      time.Time             `json:"createdAt" gorm:"index"`
+	UpdatedAt      time.Time             `json:"updatedAt"`
+}
+
+// BeforeCreate a Gorm hook to be run before the delivery is created.
+func (d *WebhookDelivery) BeforeCreate(tx *gorm.DB) (err error) {
+	if d.ID == "" {
+		d.ID = id.GenerateUUID(true)
+	}
+	return
+}
+
+// WebhookDeliveryAttempt records a single HTTP request of a webhook delivery.
+type WebhookDeliveryAttempt struct {
+	ID         uint64    `json:"id" gorm:"primaryKey;autoIncrement"`
+	DeliveryID string    `json:"deliveryId" gorm:"size:32;index"`
+	Attempt    int32     `json:"attempt"`
+	StatusCode int32     `json:"statusCode"`
+	Error      string    `json:"error" gorm:"size:512"`
+	DurationMs int64     `json:"durationMs"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
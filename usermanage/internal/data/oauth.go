@@ -0,0 +1,291 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"usermanage/internal/biz"
+	"usermanage/internal/data/model"
+	"usermanage/internal/pkg/db"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type oauthRepo struct {
+	db     *db.Database
+	logger *log.Helper
+}
+
+// NewOAuthRepo creates a new OAuth repository.
+func NewOAuthRepo(db *db.Database, logger log.Logger) biz.OAuthRepo {
+	return &oauthRepo{
+		db:     db,
+		logger: log.NewHelper(logger),
+	}
+}
+
+// ListClients implements biz.OAuthRepo.
+func (r *oauthRepo) ListClients(ctx context.Context, params biz.OAuthClientListParams) (*biz.OAuthClientListResult, error) {
+	var totalCount int64
+	var clients []model.OAuthClient
+
+	query := r.db.Conn(ctx).Model(&model.OAuthClient{})
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, fmt.Errorf("failed to count oauth clients: %w", err)
+	}
+
+	page, pageSize := params.GetPage()
+	offset := (page - 1) * pageSize
+	if err := query.
+		Offset(int(offset)).
+		Limit(int(pageSize)).
+		Order("created_at DESC").
+		Find(&clients).Error; err != nil {
+		return nil, fmt.Errorf("failed to find oauth clients: %w", err)
+	}
+
+	bizClients := make([]*biz.OAuthClient, 0, len(clients))
+	for _, client := range clients {
+		bizClients = append(bizClients, r.toBizClient(&client))
+	}
+	return &biz.OAuthClientListResult{
+		TotalCount: totalCount,
+		Clients:    bizClients,
+	}, nil
+}
+
+// GetClient implements biz.OAuthRepo.
+func (r *oauthRepo) GetClient(ctx context.Context, id string) (*biz.OAuthClient, error) {
+	var client model.OAuthClient
+	if err := r.db.Conn(ctx).Where("id = ?", id).First(&client).Error; err != nil {
+		return nil, fmt.Errorf("failed to get oauth client by id[%s]: %w", id, recordNotFound(err, biz.ErrOAuthClientNotFound))
+	}
+	return r.toBizClient(&client), nil
+}
+
+// CreateClient implements biz.OAuthRepo.
+func (r *oauthRepo) CreateClient(ctx context.Context, params biz.OAuthClientCreateParams) (*biz.OAuthClient, error) {
+	client := model.OAuthClient{
+		Name:           params.Name,
+		SecretHash:     params.SecretHash,
+		RedirectURIs:   params.RedirectURIs,
+		Public:         params.Public,
+		SkipConsent:    params.SkipConsent,
+		ResourceServer: params.ResourceServer,
+		Creator:        params.Creator,
+	}
+	if err := r.db.Conn(ctx).Create(&client).Error; err != nil {
+		return nil, fmt.Errorf("failed to create oauth client: %w", err)
+	}
+	return r.toBizClient(&client), nil
+}
+
+// DeleteClient implements biz.OAuthRepo.
+func (r *oauthRepo) DeleteClient(ctx context.Context, id string) error {
+	return r.db.InTx(ctx, func(ctx context.Context) error {
+		tx := r.db.Conn(ctx)
+		var client model.OAuthClient
+		if err := tx.Where("id = ?", id).First(&client).Error; err != nil {
+			return fmt.Errorf("failed to get oauth client by id[%s]: %w", id, recordNotFound(err, biz.ErrOAuthClientNotFound))
+		}
+		if err := tx.Delete(&client).Error; err != nil {
+			return fmt.Errorf("failed to delete oauth client by id[%s]: %w", id, err)
+		}
+		for _, grant := range []any{&model.OAuthAuthorizationCode{}, &model.OAuthRefreshToken{}, &model.OAuthConsent{}} {
+			if err := tx.Where("client_id = ?", id).Delete(grant).Error; err != nil {
+				return fmt.Errorf("failed to delete grants of oauth client[id=%s]: %w", id, err)
+			}
+		}
+		return nil
+	})
+}
+
+// CreateAuthorizationCode implements biz.OAuthRepo.
+func (r *oauthRepo) CreateAuthorizationCode(ctx context.Context, code *biz.OAuthAuthorizationCode) error {
+	return r.db.InTx(ctx, func(ctx context.Context) error {
+		tx := r.db.Conn(ctx)
+		if err := tx.Where("expires_at <= ?", time.Now()).Delete(&model.OAuthAuthorizationCode{}).Error; err != nil {
+			return fmt.Errorf("failed to delete expired authorization codes: %w", err)
+		}
+		if err := tx.Create(&model.OAuthAuthorizationCode{
+			Code:          code.Hash,
+			ClientID:      code.ClientID,
+			UserID:        code.UserID,
+			Username:      code.Username,
+			RedirectURI:   code.RedirectURI,
+			Scopes:        code.Scopes,
+			Nonce:         code.Nonce,
+			CodeChallenge: code.CodeChallenge,
+			AuthTime:      code.AuthTime,
+			ExpiresAt:     code.ExpiresAt,
+		}).Error; err != nil {
+			return fmt.Errorf("failed to create authorization code of client[id=%s]: %w", code.ClientID, err)
+		}
+		return nil
+	})
+}
+
+// TakeAuthorizationCode implements biz.OAuthRepo.
+//
+// The code is deleted before it is read: of two concurrent exchanges, only one deletes it.
+func (r *oauthRepo) TakeAuthorizationCode(ctx context.Context, hash string) (*biz.OAuthAuthorizationCode, error) {
+	var code *biz.OAuthAuthorizationCode
+	err := r.db.InTx(ctx, func(ctx context.Context) error {
+		tx := r.db.Conn(ctx)
+		var row model.OAuthAuthorizationCode
+		if err := tx.Where("code = ?", hash).First(&row).Error; err != nil {
+			return fmt.Errorf("failed to get authorization code: %w", recordNotFound(err, biz.ErrOAuthGrantNotFound))
+		}
+		result := tx.Where("code = ?", hash).Delete(&model.OAuthAuthorizationCode{})
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete authorization code: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("authorization code already used: %w", biz.ErrOAuthGrantNotFound)
+		}
+		code = &biz.OAuthAuthorizationCode{
+			Hash:          row.Code,
+			ClientID:      row.ClientID,
+			UserID:        row.UserID,
+			Username:      row.Username,
+			RedirectURI:   row.RedirectURI,
+			Scopes:        row.Scopes,
+			Nonce:         row.Nonce,
+			CodeChallenge: row.CodeChallenge,
+			AuthTime:      row.AuthTime,
+			ExpiresAt:     row.ExpiresAt,
+		}
+		return nil
+	})
+	return code, err
+}
+
+// CreateRefreshToken implements biz.OAuthRepo.
+func (r *oauthRepo) CreateRefreshToken(ctx context.Context, token *biz.OAuthRefreshToken) error {
+	return r.db.InTx(ctx, func(ctx context.Context) error {
+		tx := r.db.Conn(ctx)
+		if err := tx.Where("username = ? AND expires_at <= ?", token.Username, time.Now()).
+			Delete(&model.OAuthRefreshToken{}).Error; err != nil {
+			return fmt.Errorf("failed to delete expired refresh tokens of user[%s]: %w", token.Username, err)
+		}
+		if err := tx.Create(&model.OAuthRefreshToken{
+			Token:     token.Hash,
+			FamilyID:  token.FamilyID,
+			ClientID:  token.ClientID,
+			UserID:    token.UserID,
+			Username:  token.Username,
+			Scopes:    token.Scopes,
+			AuthTime:  token.AuthTime,
+			ExpiresAt: token.ExpiresAt,
+		}).Error; err != nil {
+			return fmt.Errorf("failed to create refresh token of client[id=%s]: %w", token.ClientID, err)
+		}
+		return nil
+	})
+}
+
+// GetRefreshToken implements biz.OAuthRepo.
+func (r *oauthRepo) GetRefreshToken(ctx context.Context, hash string) (*biz.OAuthRefreshToken, error) {
+	var row model.OAuthRefreshToken
+	if err := r.db.Conn(ctx).Where("token = ?", hash).First(&row).Error; err != nil {
+		return nil, fmt.Errorf("failed to get refresh token: %w", recordNotFound(err, biz.ErrOAuthGrantNotFound))
+	}
+	return &biz.OAuthRefreshToken{
+		Hash:      row.Token,
+		FamilyID:  row.FamilyID,
+		ClientID:  row.ClientID,
+		UserID:    row.UserID,
+		Username:  row.Username,
+		Scopes:    row.Scopes,
+		AuthTime:  row.AuthTime,
+		ExpiresAt: row.ExpiresAt,
+		UsedAt:    row.UsedAt,
+	}, nil
+}
+
+// UseRefreshToken implements biz.OAuthRepo.
+func (r *oauthRepo) UseRefreshToken(ctx context.Context, hash string) (bool, error) {
+	result := r.db.Conn(ctx).
+		Model(&model.OAuthRefreshToken{}).
+		Where("token = ? AND used_at IS NULL", hash).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to mark refresh token as used: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// RefreshTokenFamilyExists implements biz.OAuthRepo.
+func (r *oauthRepo) RefreshTokenFamilyExists(ctx context.Context, familyID string) (bool, error) {
+	var count int64
+	if err := r.db.Conn(ctx).Model(&model.OAuthRefreshToken{}).Where("family_id = ?", familyID).Limit(1).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to count refresh tokens of family[%s]: %w", familyID, err)
+	}
+	return count > 0, nil
+}
+
+// DeleteRefreshTokenFamily implements biz.OAuthRepo.
+func (r *oauthRepo) DeleteRefreshTokenFamily(ctx context.Context, familyID string) error {
+	if err := r.db.Conn(ctx).Where("family_id = ?", familyID).Delete(&model.OAuthRefreshToken{}).Error; err != nil {
+		return fmt.Errorf("failed to delete refresh tokens of family[%s]: %w", familyID, err)
+	}
+	return nil
+}
+
+// GetConsent implements biz.OAuthRepo.
+func (r *oauthRepo) GetConsent(ctx context.Context, username, clientID string) ([]string, error) {
+	var consent model.OAuthConsent
+	err := r.db.Conn(ctx).Where("username = ? AND client_id = ?", username, clientID).First(&consent).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get consent of user[%s] to client[id=%s]: %w", username, clientID, err)
+	}
+	return consent.Scopes, nil
+}
+
+// SaveConsent implements biz.OAuthRepo.
+func (r *oauthRepo) SaveConsent(ctx context.Context, username, clientID string, scopes []string) error {
+	consent := model.OAuthConsent{Username: username, ClientID: clientID, Scopes: scopes}
+	if err := r.db.Conn(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "username"}, {Name: "client_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"scopes", "updated_at"}),
+		}).
+		Create(&consent).Error; err != nil {
+		return fmt.Errorf("failed to save consent of user[%s] to client[id=%s]: %w", username, clientID, err)
+	}
+	return nil
+}
+
+// Convert model OAuthClient to biz OAuthClient.
+func (r *oauthRepo) toBizClient(c *model.OAuthClient) *biz.OAuthClient {
+	if c == nil {
+		return nil
+	}
+
+	return &biz.OAuthClient{
+		ID:             c.ID,
+		Name:           c.Name,
+		SecretHash:     c.SecretHash,
+		RedirectURIs:   c.RedirectURIs,
+		Public:         c.Public,
+		SkipConsent:    c.SkipConsent,
+		ResourceServer: c.ResourceServer,
+		Creator:        c.Creator,
+		CreatedAt:      c.CreatedAt,
+		UpdatedAt:      c.UpdatedAt,
+	}
+}
+
+// Wrap a record not found error with the sentinel of the missing resource.
+func recordNotFound(err, sentinel error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("%w: %w", sentinel, err)
+	}
+	return err
+}
@@ -0,0 +1,342 @@
+package data
+
+import (
+	"context"
+	"testing"
+	"time"
+	"usermanage/gen/proto/conf"
+	"usermanage/internal/biz"
+	"usermanage/internal/pkg/jwt"
+	"usermanage/internal/pkg/oidc"
+
+	"github.com/go-kratos/kratos/v2/log"
+	gojwt "github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOAuthUseCase(t *testing.T) {
+	require.NoError(t, jwt.Initialize([]byte("secret"), time.Hour))
+	database := newTestDatabase(t)
+	users := NewUserRepo(database, nil, log.DefaultLogger)
+	tokens := NewMemoryTokenRepo(&conf.Data{})
+	repo := NewOAuthRepo(database, log.DefaultLogger)
+	signer, err := oidc.GenerateSigner()
+	require.NoError(t, err)
+	auth := biz.NewAuthUseCase(NewTransaction(database), users, tokens, NewEventRepo(database, log.DefaultLogger), nil)
+	uc := biz.NewOAuthUseCase(NewTransaction(database), repo, auth, &biz.OAuthOptions{
+		Issuer:          "https://login.example.com",
+		Signer:          signer,
+		CodeTTL:         time.Minute,
+		IDTokenTTL:      time.Hour,
+		RefreshTokenTTL: time.Hour,
+	})
+	ctx := context.Background()
+
+	user := createTestUser(t, users, "foo")
+	client, err := uc.CreateClient(ctx, biz.OAuthClientCreateParams{
+		Name:         "App",
+		RedirectURIs: []string{"https://app.example.com/callback"},
+		Creator:      "admin",
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, client.Secret)
+
+	const verifier = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	req := &biz.OAuthAuthorizationRequest{
+		ClientID:            client.ID,
+		RedirectURI:         "https://app.example.com/callback",
+		ResponseType:        "code",
+		Scope:               "openid profile",
+		Nonce:               "n-0S6_WzA2Mj",
+		CodeChallenge:       oidc.CodeChallenge(verifier),
+		CodeChallengeMethod: "S256",
+	}
+	authorize := func() string {
+		t.Helper()
+		client, scopes, err := uc.CheckAuthorizationRequest(ctx, req)
+		require.NoError(t, err)
+		code, err := uc.Authorize(ctx, client, req, scopes, user, time.Now(), true)
+		require.NoError(t, err)
+		return code
+	}
+	exchange := func(code, verifier string) (*biz.OAuthTokenResponse, error) {
+		return uc.Exchange(ctx, &biz.OAuthTokenRequest{
+			GrantType:    "authorization_code",
+			ClientID:     client.ID,
+			ClientSecret: client.Secret,
+			Code:         code,
+			RedirectURI:  req.RedirectURI,
+			CodeVerifier: verifier,
+		})
+	}
+	assertOAuthError := func(err error, code string) {
+		t.Helper()
+		var oauthErr *biz.OAuthError
+		if assert.ErrorAs(t, err, &oauthErr) {
+			assert.Equal(t, code, oauthErr.Code)
+		}
+	}
+
+	t.Run("invalid authorization requests", func(t *testing.T) {
+		invalid := *req
+		invalid.RedirectURI = "https://evil.example.com/callback"
+		c, _, err := uc.CheckAuthorizationRequest(ctx, &invalid)
+		assert.Nil(t, c)
+		assertOAuthError(err, biz.OAuthErrInvalidRequest)
+
+		invalid = *req
+		invalid.CodeChallengeMethod = "plain"
+		c, _, err = uc.CheckAuthorizationRequest(ctx, &invalid)
+		assert.NotNil(t, c)
+		assertOAuthError(err, biz.OAuthErrInvalidRequest)
+
+		invalid = *req
+		invalid.Scope = "openid admin"
+		_, _, err = uc.CheckAuthorizationRequest(ctx, &invalid)
+		assertOAuthError(err, biz.OAuthErrInvalidScope)
+	})
+
+	t.Run("consent is remembered", func(t *testing.T) {
+		needed, err := uc.NeedsConsent(ctx, client, "foo", []string{"openid"})
+		require.NoError(t, err)
+		assert.True(t, needed)
+
+		authorize()
+		needed, err = uc.NeedsConsent(ctx, client, "foo", []string{"openid"})
+		require.NoError(t, err)
+		assert.False(t, needed)
+		needed, err = uc.NeedsConsent(ctx, client, "foo", []string{"openid", "email"})
+		require.NoError(t, err)
+		assert.True(t, needed)
+	})
+
+	t.Run("code is used once", func(t *testing.T) {
+		code := authorize()
+		_, err := exchange(code, verifier[1:]+"x")
+		assertOAuthError(err, biz.OAuthErrInvalidGrant)
+		_, err = exchange(code, verifier)
+		assertOAuthError(err, biz.OAuthErrInvalidGrant)
+	})
+
+	t.Run("client authentication", func(t *testing.T) {
+		_, err := uc.Exchange(ctx, &biz.OAuthTokenRequest{
+			GrantType:    "authorization_code",
+			ClientID:     client.ID,
+			ClientSecret: "wrong",
+			Code:         authorize(),
+			RedirectURI:  req.RedirectURI,
+			CodeVerifier: verifier,
+		})
+		assertOAuthError(err, biz.OAuthErrInvalidClient)
+	})
+
+	var resp *biz.OAuthTokenResponse
+	t.Run("code exchange issues tokens", func(t *testing.T) {
+		resp, err = exchange(authorize(), verifier)
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer", resp.TokenType)
+		assert.Equal(t, "openid profile", resp.Scope)
+		assert.NotEmpty(t, resp.RefreshToken)
+
+		claims := gojwt.MapClaims{}
+		_, err = gojwt.ParseWithClaims(resp.IDToken, claims, func(*gojwt.Token) (any, error) {
+			return signer.PublicKey(), nil
+		}, gojwt.WithIssuer("https://login.example.com"), gojwt.WithAudience(client.ID))
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, claims["sub"])
+		assert.Equal(t, "n-0S6_WzA2Mj", claims["nonce"])
+		assert.Equal(t, "foo", claims["preferred_username"])
+		assert.Equal(t, oidc.AccessTokenHash(resp.AccessToken), claims["at_hash"])
+
+		info, err := uc.UserInfo(ctx, resp.AccessToken)
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, info["sub"])
+		assert.Equal(t, "user", info["role"])
+		assert.NotContains(t, info, "email")
+
+		current, err := auth.VerifyToken(ctx, resp.AccessToken)
+		require.NoError(t, err)
+		assert.Equal(t, "foo", current.Username)
+	})
+
+	t.Run("refresh tokens are rotated", func(t *testing.T) {
+		refresh := func(token, scope string) (*biz.OAuthTokenResponse, error) {
+			return uc.Exchange(ctx, &biz.OAuthTokenRequest{
+				GrantType:    "refresh_token",
+				ClientID:     client.ID,
+				ClientSecret: client.Secret,
+				RefreshToken: token,
+				Scope:        scope,
+			})
+		}
+		_, err := refresh(resp.RefreshToken, "openid email")
+		assertOAuthError(err, biz.OAuthErrInvalidScope)
+
+		next, err := refresh(resp.RefreshToken, "openid")
+		require.NoError(t, err)
+		assert.Equal(t, "openid", next.Scope)
+		assert.NotEqual(t, resp.RefreshToken, next.RefreshToken)
+
+		// Reusing the first token revokes the one it was rotated into
+		_, err = refresh(resp.RefreshToken, "")
+		assertOAuthError(err, biz.OAuthErrInvalidGrant)
+		_, err = refresh(next.RefreshToken, "")
+		assertOAuthError(err, biz.OAuthErrInvalidGrant)
+	})
+
+	t.Run("introspection and revocation", func(t *testing.T) {
+		other, err := uc.CreateClient(ctx, biz.OAuthClientCreateParams{
+			Name:           "Gateway",
+			RedirectURIs:   []string{"https://gateway.example.com/callback"},
+			ResourceServer: true,
+		})
+		require.NoError(t, err)
+		spa, err := uc.CreateClient(ctx, biz.OAuthClientCreateParams{
+			Name:         "SPA",
+			RedirectURIs: []string{"http://localhost:3000/callback"},
+			Public:       true,
+		})
+		require.NoError(t, err)
+		introspect := func(c *biz.OAuthClient, token, hint string) (*biz.OAuthIntrospection, error) {
+			return uc.Introspect(ctx, &biz.OAuthTokenParams{ClientID: c.ID, ClientSecret: c.Secret, Token: token, TokenTypeHint: hint})
+		}
+		revoke := func(c *biz.OAuthClient, token, hint string) error {
+			return uc.Revoke(ctx, &biz.OAuthTokenParams{ClientID: c.ID, ClientSecret: c.Secret, Token: token, TokenTypeHint: hint})
+		}
+		resp, err := exchange(authorize(), verifier)
+		require.NoError(t, err)
+
+		// Resource servers introspect any access token, e.g. an API gateway
+		_, err = introspect(client, resp.AccessToken, "")
+		assertOAuthError(err, biz.OAuthErrInvalidClient)
+		info, err := introspect(other, resp.AccessToken, "")
+		require.NoError(t, err)
+		assert.True(t, info.Active)
+		assert.Equal(t, user.ID, info.Subject)
+		assert.Equal(t, "foo", info.Username)
+		assert.Equal(t, "user", info.Role)
+		assert.Equal(t, "openid profile", info.Scope)
+		assert.Equal(t, client.ID, info.ClientID)
+		assert.Equal(t, "Bearer", info.TokenType)
+		assert.Greater(t, info.ExpiresAt, time.Now().Unix())
+
+		_, loginToken, _, err := auth.Login(ctx, "foo", "P@ssw0rd")
+		require.NoError(t, err)
+		info, err = introspect(other, loginToken, "")
+		require.NoError(t, err)
+		assert.True(t, info.Active)
+		assert.Empty(t, info.ClientID)
+
+		// Refresh tokens only by the client they were issued to, if a resource server
+		gatewayReq := *req
+		gatewayReq.ClientID, gatewayReq.RedirectURI = other.ID, other.RedirectURIs[0]
+		_, scopes, err := uc.CheckAuthorizationRequest(ctx, &gatewayReq)
+		require.NoError(t, err)
+		code, err := uc.Authorize(ctx, other, &gatewayReq, scopes, user, time.Now(), true)
+		require.NoError(t, err)
+		gatewayResp, err := uc.Exchange(ctx, &biz.OAuthTokenRequest{
+			GrantType:    "authorization_code",
+			ClientID:     other.ID,
+			ClientSecret: other.Secret,
+			Code:         code,
+			RedirectURI:  other.RedirectURIs[0],
+			CodeVerifier: verifier,
+		})
+		require.NoError(t, err)
+		info, err = introspect(other, gatewayResp.RefreshToken, "refresh_token")
+		require.NoError(t, err)
+		assert.True(t, info.Active)
+		assert.Empty(t, info.TokenType)
+		info, err = introspect(other, resp.RefreshToken, "")
+		require.NoError(t, err)
+		assert.False(t, info.Active)
+
+		info, err = introspect(other, "unknown", "")
+		require.NoError(t, err)
+		assert.Equal(t, &biz.OAuthIntrospection{Active: false}, info)
+		_, err = introspect(spa, resp.AccessToken, "")
+		assertOAuthError(err, biz.OAuthErrInvalidClient)
+		_, err = uc.Introspect(ctx, &biz.OAuthTokenParams{ClientID: other.ID, ClientSecret: "wrong", Token: resp.AccessToken})
+		assertOAuthError(err, biz.OAuthErrInvalidClient)
+
+		// The tokens of other clients and unknown tokens are ignored
+		require.NoError(t, revoke(other, resp.AccessToken, ""))
+		require.NoError(t, revoke(spa, resp.RefreshToken, ""))
+		require.NoError(t, revoke(other, "unknown", ""))
+		info, err = introspect(other, resp.AccessToken, "")
+		require.NoError(t, err)
+		assert.True(t, info.Active)
+
+		require.NoError(t, revoke(client, resp.AccessToken, "refresh_token"))
+		info, err = introspect(other, resp.AccessToken, "")
+		require.NoError(t, err)
+		assert.False(t, info.Active)
+		_, err = auth.VerifyToken(ctx, resp.AccessToken)
+		assert.Error(t, err)
+
+		// Revoking a refresh token revokes the tokens rotated from it and their access tokens
+		next, err := uc.Exchange(ctx, &biz.OAuthTokenRequest{
+			GrantType:    "refresh_token",
+			ClientID:     other.ID,
+			ClientSecret: other.Secret,
+			RefreshToken: gatewayResp.RefreshToken,
+		})
+		require.NoError(t, err)
+		info, err = introspect(other, gatewayResp.RefreshToken, "")
+		require.NoError(t, err)
+		assert.False(t, info.Active, "used refresh token")
+		for _, token := range []string{gatewayResp.AccessToken, next.AccessToken} {
+			info, err = introspect(other, token, "")
+			require.NoError(t, err)
+			assert.True(t, info.Active)
+		}
+		require.NoError(t, revoke(other, gatewayResp.RefreshToken, ""))
+		for _, token := range []string{next.RefreshToken, gatewayResp.AccessToken, next.AccessToken} {
+			info, err = introspect(other, token, "")
+			require.NoError(t, err)
+			assert.False(t, info.Active)
+		}
+		_, err = uc.UserInfo(ctx, next.AccessToken)
+		assertOAuthError(err, biz.OAuthErrInvalidToken)
+	})
+
+	t.Run("deleting the client revokes its grants", func(t *testing.T) {
+		resp, err := exchange(authorize(), verifier)
+		require.NoError(t, err)
+		require.NoError(t, uc.DeleteClient(ctx, client.ID))
+
+		_, err = uc.GetClient(ctx, client.ID)
+		assert.ErrorIs(t, err, biz.ErrOAuthClientNotFound)
+		_, err = repo.GetRefreshToken(ctx, oidc.HashToken(resp.RefreshToken))
+		assert.ErrorIs(t, err, biz.ErrOAuthGrantNotFound)
+	})
+}
+
+func TestOAuthUseCase_CreateClient(t *testing.T) {
+	database := newTestDatabase(t)
+	uc := biz.NewOAuthUseCase(NewTransaction(database), NewOAuthRepo(database, log.DefaultLogger), nil, &biz.OAuthOptions{})
+	ctx := context.Background()
+
+	client, err := uc.CreateClient(ctx, biz.OAuthClientCreateParams{
+		Name:         "SPA",
+		RedirectURIs: []string{"http://localhost:3000/callback"},
+		Public:       true,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, client.Secret)
+	assert.Empty(t, client.SecretHash)
+
+	_, err = uc.CreateClient(ctx, biz.OAuthClientCreateParams{
+		Name:           "SPA",
+		RedirectURIs:   []string{"http://localhost:3000/callback"},
+		Public:         true,
+		ResourceServer: true,
+	})
+	assert.ErrorIs(t, err, biz.ErrInvalidOAuthClient)
+
+	for _, uri := range []string{"http://app.example.com/callback", "https://app.example.com/#callback", "/callback"} {
+		_, err := uc.CreateClient(ctx, biz.OAuthClientCreateParams{Name: "App", RedirectURIs: []string{uri}})
+		assert.ErrorIs(t, err, biz.ErrInvalidOAuthClient, uri)
+	}
+}
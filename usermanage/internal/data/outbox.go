@@ -0,0 +1,246 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+	"usermanage/gen/proto/conf"
+	"usermanage/internal/data/model"
+	"usermanage/internal/pkg/broker"
+	"usermanage/internal/pkg/db"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	defaultOutboxPollInterval = time.Second
+	defaultOutboxBatchSize    = 100
+	// outboxClaimTTL is how long a batch is claimed by the instance publishing it, another
+	// instance takes it over afterwards, e.g. when the first one died while publishing.
+	outboxClaimTTL = 30 * time.Second
+)
+
+// OutboxRelay publishes the events stored in the outbox to the broker.
+//
+// It implements the kratos `transport.Server` interface so it runs alongside the
+// HTTP and gRPC servers. Several instances may run at the same time, pending rows
+// are claimed for a while in a short transaction locking them with
+// `FOR UPDATE SKIP LOCKED`, so every batch is relayed by one instance and no row
+// lock is held while publishing over the network.
+type OutboxRelay struct {
+	db        *db.Database
+	broker    broker.Broker
+	interval  time.Duration
+	batchSize int
+	logger    *log.Helper
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+// NewOutboxRelay creates a new outbox relay.
+func NewOutboxRelay(c *conf.Data, db *db.Database, b broker.Broker, logger log.Logger) *OutboxRelay {
+	interval := defaultOutboxPollInterval
+	if d := c.GetOutbox().GetPollInterval(); d != nil && d.AsDuration() > 0 {
+		interval = d.AsDuration()
+	}
+	batchSize := defaultOutboxBatchSize
+	if n := c.GetOutbox().GetBatchSize(); n > 0 {
+		batchSize = int(n)
+	}
+
+	return &OutboxRelay{
+		db:        db,
+		broker:    b,
+		interval:  interval,
+		batchSize: batchSize,
+		logger:    log.NewHelper(logger),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+}
+
+// Start implements transport.Server.
+//
+// It blocks until `Stop` is called or the context is done.
+func (r *OutboxRelay) Start(ctx context.Context) error {
+	defer close(r.done)
+	r.logger.Infow("msg", "outbox relay started", "interval", r.interval, "batch_size", r.batchSize)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.stop:
+			return nil
+		case <-ticker.C:
+			// Keep relaying while full batches are found to drain a backlog quickly
+			for {
+				n, err := r.RelayOnce(ctx)
+				if err != nil {
+					r.logger.Errorw("msg", "failed to relay outbox events", "error", err)
+					break
+				}
+				if n < r.batchSize {
+					break
+				}
+			}
+		}
+	}
+}
+
+// Stop implements transport.Server, calling it again is a no-op.
+func (r *OutboxRelay) Stop(ctx context.Context) error {
+	var err error
+	r.stopOnce.Do(func() {
+		close(r.stop)
+		select {
+		case <-r.done:
+		case <-ctx.Done():
+		}
+		r.logger.Info("outbox relay stopped")
+		err = r.broker.Close()
+	})
+	return err
+}
+
+// RelayOnce numbers the events committed since the last call, then publishes one batch of
+// pending events and returns how many were published.
+func (r *OutboxRelay) RelayOnce(ctx context.Context) (int, error) {
+	if err := r.sequence(ctx); err != nil {
+		return 0, err
+	}
+
+	rows, err := r.claim(ctx)
+	if err != nil || len(rows) == 0 {
+		return 0, err
+	}
+
+	ids := make([]uint64, 0, len(rows))
+	msgs := make([]*broker.Message, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+		msgs = append(msgs, &broker.Message{
+			ID:      row.EventID,
+			Topic:   row.Topic,
+			Key:     row.AggregateID,
+			Type:    row.Type,
+			Payload: []byte(row.Payload),
+		})
+	}
+
+	conn := r.db.Conn(ctx)
+	if err := r.broker.Publish(ctx, msgs...); err != nil {
+		// Keep the rows pending and record the failure, they are retried on the next tick
+		lastError := err.Error()
+		if len(lastError) > 512 {
+			lastError = lastError[:512]
+		}
+		if err := conn.Model(&model.OutboxEvent{}).
+			Where("id IN ?", ids).
+			Updates(map[string]any{
+				"attempts":      gorm.Expr("attempts + 1"),
+				"last_error":    lastError,
+				"claimed_until": nil,
+			}).Error; err != nil {
+			return 0, fmt.Errorf("failed to record outbox publish failure: %w", err)
+		}
+		r.logger.Errorw("msg", "failed to publish outbox events", "count", len(msgs), "error", err)
+		return 0, nil
+	}
+
+	if err := conn.Model(&model.OutboxEvent{}).
+		Where("id IN ?", ids).
+		Updates(map[string]any{
+			"published_at":  time.Now(),
+			"claimed_until": nil,
+		}).Error; err != nil {
+		// The events are published again once the claim expires, consumers deduplicate by event ID
+		return 0, fmt.Errorf("failed to mark outbox events as published: %w", err)
+	}
+	return len(rows), nil
+}
+
+// Number the committed events that have no sequence yet, in the order they are seen committed.
+//
+// The sequence row is locked first, so the events numbered by another instance are committed
+// before these are read: an event is always numbered after every event visible before it, and
+// a watcher resuming after a number never skips an event committed late with a lower ID.
+func (r *OutboxRelay) sequence(ctx context.Context) error {
+	return r.db.InTx(ctx, func(ctx context.Context) error {
+		tx := r.db.Conn(ctx)
+		var seq model.OutboxSequence
+		if err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", 1).
+			Take(&seq).Error; err != nil {
+			return fmt.Errorf("failed to lock outbox sequence: %w", err)
+		}
+
+		var ids []uint64
+		if err := tx.Model(&model.OutboxEvent{}).
+			Where("commit_seq IS NULL").
+			Order("id").
+			Pluck("id", &ids).Error; err != nil {
+			return fmt.Errorf("failed to fetch unnumbered outbox events: %w", err)
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		for _, id := range ids {
+			seq.LastValue++
+			if err := tx.Model(&model.OutboxEvent{}).
+				Where("id = ?", id).
+				Update("commit_seq", seq.LastValue).Error; err != nil {
+				return fmt.Errorf("failed to number outbox event[id=%d]: %w", id, err)
+			}
+		}
+		if err := tx.Model(&seq).Update("last_value", seq.LastValue).Error; err != nil {
+			return fmt.Errorf("failed to update outbox sequence: %w", err)
+		}
+		return nil
+	})
+}
+
+// Claim a batch of pending events for outboxClaimTTL, the events claimed by another
+// instance are skipped until the claim expires.
+func (r *OutboxRelay) claim(ctx context.Context) ([]model.OutboxEvent, error) {
+	var rows []model.OutboxEvent
+	err := r.db.InTx(ctx, func(ctx context.Context) error {
+		tx := r.db.Conn(ctx)
+		now := time.Now()
+		if err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("published_at IS NULL AND (claimed_until IS NULL OR claimed_until < ?)", now).
+			Order("id").
+			Limit(r.batchSize).
+			Find(&rows).Error; err != nil {
+			return fmt.Errorf("failed to fetch pending outbox events: %w", err)
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+
+		ids := make([]uint64, 0, len(rows))
+		for _, row := range rows {
+			ids = append(ids, row.ID)
+		}
+		if err := tx.Model(&model.OutboxEvent{}).
+			Where("id IN ?", ids).
+			Update("claimed_until", now.Add(outboxClaimTTL)).Error; err != nil {
+			return fmt.Errorf("failed to claim outbox events: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
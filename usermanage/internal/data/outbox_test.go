@@ -0,0 +1,146 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"usermanage/gen/proto/conf"
+	"usermanage/internal/biz"
+	"usermanage/internal/data/model"
+	"usermanage/internal/pkg/broker"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingBroker fails every publish.
+type failingBroker struct{}
+
+func (failingBroker) Publish(context.Context, ...*broker.Message) error {
+	return errors.New("broker unavailable")
+}
+
+func (failingBroker) Close() error {
+	return nil
+}
+
+func TestOutboxRelay(t *testing.T) {
+	database := newTestDatabase(t)
+	events := NewEventRepo(database, log.DefaultLogger)
+	ctx := context.Background()
+	appendEvents := func(n int) {
+		t.Helper()
+		for range n {
+			require.NoError(t, events.Append(ctx, biz.NewUserEvent(biz.EventTypeUserCreated, &biz.User{ID: "u1", Username: "foo"}, "admin")))
+		}
+	}
+	pending := func() []model.OutboxEvent {
+		t.Helper()
+		var rows []model.OutboxEvent
+		require.NoError(t, database.DB.Where("published_at IS NULL").Order("id").Find(&rows).Error)
+		return rows
+	}
+
+	t.Run("a failed publish keeps the events pending", func(t *testing.T) {
+		appendEvents(2)
+		relay := NewOutboxRelay(&conf.Data{}, database, failingBroker{}, log.DefaultLogger)
+		n, err := relay.RelayOnce(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, n)
+
+		rows := pending()
+		require.Len(t, rows, 2)
+		assert.EqualValues(t, 1, rows[0].Attempts)
+		assert.Equal(t, "broker unavailable", rows[0].LastError)
+		assert.Nil(t, rows[0].ClaimedUntil, "released for the next attempt")
+	})
+
+	t.Run("claimed events are skipped until the claim expires", func(t *testing.T) {
+		rows := pending()
+		claimedUntil := time.Now().Add(time.Minute)
+		require.NoError(t, database.DB.Model(&model.OutboxEvent{}).
+			Where("id = ?", rows[0].ID).
+			Update("claimed_until", claimedUntil).Error)
+
+		memory := broker.NewMemory()
+		relay := NewOutboxRelay(&conf.Data{}, database, memory, log.DefaultLogger)
+		n, err := relay.RelayOnce(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+		require.Len(t, memory.Messages(), 1)
+		assert.Equal(t, rows[1].EventID, memory.Messages()[0].ID)
+
+		// Another instance died while publishing it
+		require.NoError(t, database.DB.Model(&model.OutboxEvent{}).
+			Where("id = ?", rows[0].ID).
+			Update("claimed_until", time.Now().Add(-time.Second)).Error)
+		n, err = relay.RelayOnce(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+		assert.Empty(t, pending())
+	})
+
+	t.Run("batches are relayed in order", func(t *testing.T) {
+		appendEvents(3)
+		memory := broker.NewMemory()
+		relay := NewOutboxRelay(&conf.Data{Outbox: &conf.Data_Outbox{BatchSize: 2}}, database, memory, log.DefaultLogger)
+		n, err := relay.RelayOnce(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, n)
+		n, err = relay.RelayOnce(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+		assert.Len(t, memory.Messages(), 3)
+	})
+
+	t.Run("events are versioned in commit order", func(t *testing.T) {
+		relay := NewOutboxRelay(&conf.Data{}, database, broker.NewMemory(), log.DefaultLogger)
+		_, err := relay.RelayOnce(ctx)
+		require.NoError(t, err)
+		latest, err := events.LatestVersion(ctx)
+		require.NoError(t, err)
+
+		// The second event is numbered before the first one commits, e.g. in a longer transaction
+		appendEvents(1)
+		require.NoError(t, database.DB.Model(&model.OutboxEvent{}).
+			Where("commit_seq IS NULL").
+			Update("id", 1000).Error)
+		_, err = relay.RelayOnce(ctx)
+		require.NoError(t, err)
+		appendEvents(1)
+		require.NoError(t, database.DB.Model(&model.OutboxEvent{}).
+			Where("commit_seq IS NULL").
+			Update("id", 999).Error)
+
+		list := func(after uint64) []*biz.Event {
+			t.Helper()
+			list, err := events.ListEvents(ctx, biz.EventQuery{AfterVersion: after})
+			require.NoError(t, err)
+			return list
+		}
+		// Not listed until numbered
+		require.Len(t, list(latest), 1)
+		resumeAfter := list(latest)[0].Version
+		assert.Empty(t, list(resumeAfter))
+
+		_, err = relay.RelayOnce(ctx)
+		require.NoError(t, err)
+		late := list(resumeAfter)
+		require.Len(t, late, 1, "the event committed late is not skipped")
+		assert.Equal(t, resumeAfter+1, late[0].Version)
+	})
+
+	t.Run("stop is idempotent", func(t *testing.T) {
+		relay := NewOutboxRelay(&conf.Data{}, database, broker.NewMemory(), log.DefaultLogger)
+		started := make(chan error, 1)
+		go func() { started <- relay.Start(ctx) }()
+
+		stopCtx, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+		require.NoError(t, relay.Stop(stopCtx))
+		require.NoError(t, relay.Stop(stopCtx))
+		require.NoError(t, <-started)
+	})
+}
@@ -0,0 +1,125 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"usermanage/gen/proto/conf"
+	"usermanage/internal/data/model"
+	"usermanage/internal/pkg/db"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+const (
+	defaultSessionSweepInterval  = 10 * time.Minute
+	defaultSessionSweepBatchSize = 1000
+)
+
+// SessionSweeper removes the expired sessions of the database session store.
+//
+// It implements the kratos `transport.Server` interface so it runs alongside the
+// HTTP and gRPC servers. It does nothing when the sessions are stored elsewhere.
+// Several instances may sweep at the same time, deleting a session twice is harmless.
+type SessionSweeper struct {
+	db        *db.Database
+	enabled   bool
+	interval  time.Duration
+	batchSize int
+	logger    *log.Helper
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewSessionSweeper creates a new session sweeper.
+func NewSessionSweeper(c *conf.Data, db *db.Database, logger log.Logger) *SessionSweeper {
+	interval := defaultSessionSweepInterval
+	if d := c.GetSessionSweepInterval(); d != nil && d.AsDuration() > 0 {
+		interval = d.AsDuration()
+	}
+
+	return &SessionSweeper{
+		db:        db,
+		enabled:   c.GetSessionStore() == conf.SessionStore_SESSION_STORE_DATABASE,
+		interval:  interval,
+		batchSize: defaultSessionSweepBatchSize,
+		logger:    log.NewHelper(logger),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+}
+
+// Start implements transport.Server.
+//
+// It blocks until `Stop` is called or the context is done.
+func (s *SessionSweeper) Start(ctx context.Context) error {
+	defer close(s.done)
+	if !s.enabled {
+		select {
+		case <-ctx.Done():
+		case <-s.stop:
+		}
+		return nil
+	}
+	s.logger.Infow("msg", "session sweeper started", "interval", s.interval)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		n, err := s.SweepOnce(ctx)
+		if n > 0 {
+			s.logger.Infow("msg", "removed expired sessions", "count", n)
+		}
+		if err != nil {
+			s.logger.Errorw("msg", "failed to remove expired sessions", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.stop:
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stop implements transport.Server.
+func (s *SessionSweeper) Stop(ctx context.Context) error {
+	close(s.stop)
+	select {
+	case <-s.done:
+	case <-ctx.Done():
+	}
+	s.logger.Info("session sweeper stopped")
+	return nil
+}
+
+// SweepOnce removes the sessions expired by now, batch by batch, and returns how many were removed.
+func (s *SessionSweeper) SweepOnce(ctx context.Context) (int, error) {
+	total := 0
+	for {
+		var tokens []string
+		if err := s.db.Conn(ctx).
+			Model(&model.Session{}).
+			Where("expires_at <= ?", time.Now()).
+			Limit(s.batchSize).
+			Pluck("token", &tokens).Error; err != nil {
+			return total, fmt.Errorf("failed to find expired sessions: %w", err)
+		}
+		if len(tokens) == 0 {
+			return total, nil
+		}
+
+		result := s.db.Conn(ctx).
+			Where("token IN ? AND expires_at <= ?", tokens, time.Now()).
+			Delete(&model.Session{})
+		if result.Error != nil {
+			return total, fmt.Errorf("failed to delete expired sessions: %w", result.Error)
+		}
+		total += int(result.RowsAffected)
+		if len(tokens) < s.batchSize {
+			return total, nil
+		}
+	}
+}
@@ -2,62 +2,104 @@ package data
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"time"
+	"usermanage/gen/proto/conf"
 	"usermanage/internal/biz"
+	"usermanage/internal/pkg/db"
 
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/redis/go-redis/v9"
 )
 
+// NewTokenRepo returns the TokenRepo of the configured session store.
+func NewTokenRepo(c *conf.Data, db *db.Database, rdb redis.UniversalClient, logger log.Logger) (biz.TokenRepo, error) {
+	switch c.GetSessionStore() {
+	case conf.SessionStore_SESSION_STORE_DATABASE:
+		return NewSQLTokenRepo(c, db, logger), nil
+	case conf.SessionStore_SESSION_STORE_MEMORY:
+		return NewMemoryTokenRepo(c), nil
+	default:
+		if rdb == nil {
+			return nil, fmt.Errorf("the redis session store requires Redis, configure `data.redis.addrs`")
+		}
+		return NewRedisTokenRepo(c, rdb, logger), nil
+	}
+}
+
 type redisTokenRepo struct {
-	client redis.UniversalClient
-	logger *log.Helper
+	client      redis.UniversalClient
+	prefix      string
+	maxSessions int
+	logger      *log.Helper
 }
 
 // NewRedisTokenRepo returns a new instance of RedisTokenRepo.
-func NewRedisTokenRepo(client redis.UniversalClient, logger log.Logger) biz.TokenRepo {
+//
+// Keys are namespaced by the configured Redis key prefix. Sessions are keyed by the
+// SHA-256 of their token, so reading Redis does not give away usable tokens. Sessions
+// stored under the raw token by earlier versions are rekeyed the first time they are used.
+//
+// The sorted set of a user's sessions is only changed by scripts, each of them touching
+// that set only, so the sessions are spread over the slots of a Redis Cluster.
+func NewRedisTokenRepo(c *conf.Data, client redis.UniversalClient, logger log.Logger) biz.TokenRepo {
 	return &redisTokenRepo{
-		client: client,
-		logger: log.NewHelper(logger),
+		client:      client,
+		prefix:      c.GetRedis().GetKeyPrefix(),
+		maxSessions: int(c.GetMaxSessionsPerUser()),
+		logger:      log.NewHelper(logger),
 	}
 }
 
 // DeleteToken implements biz.TokenRepo.
 func (r *redisTokenRepo) DeleteToken(ctx context.Context, token string) error {
-	username, err := r.GetUsernameByToken(ctx, token)
-	if err != nil {
-		return err
-	}
-
-	if err := r.client.Del(ctx, r.tokenKey(token)).Err(); err != nil {
-		return err
+	hash := tokenHash(token)
+	for range 2 {
+		username, err := r.client.GetDel(ctx, r.tokenKey(hash)).Result()
+		if err == nil {
+			return r.removeSessions(ctx, username, hash)
+		}
+		if err != redis.Nil {
+			return err
+		}
+		// Rekey a legacy session, then delete it
+		if _, err := r.rekeyLegacyToken(ctx, token); err == redis.Nil {
+			break
+		} else if err != nil {
+			return fmt.Errorf("failed to rekey legacy session: %w", err)
+		}
 	}
-
-	return r.client.SRem(ctx, r.userTokensKey(username), token).Err()
+	return fmt.Errorf("token not found")
 }
 
 // DeleteTokensByUsername implements biz.TokenRepo.
+//
+// Only the sessions read are removed from the user set, a session stored meanwhile stays.
 func (r *redisTokenRepo) DeleteTokensByUsername(ctx context.Context, username string) error {
-	tokens, err := r.client.SMembers(ctx, r.userTokensKey(username)).Result()
-	if err != nil {
+	if err := r.upgradeUserSessions(ctx, username); err != nil {
 		return err
 	}
-	if len(tokens) == 0 {
-		return nil
+	members, err := r.client.ZRange(ctx, r.userTokensKey(username), 0, -1).Result()
+	if err != nil || len(members) == 0 {
+		return err
 	}
-
-	keys := make([]string, len(tokens)+1)
-	for i, token := range tokens {
-		keys[i] = r.tokenKey(token)
+	// The keys of a Cluster are in different slots, so they are deleted one by one
+	for _, member := range members {
+		if err := r.client.Del(ctx, r.tokenKey(member)).Err(); err != nil {
+			return err
+		}
 	}
-	keys[len(tokens)] = r.userTokensKey(username)
-	return r.client.Del(ctx, keys...).Err()
+	return r.removeSessions(ctx, username, members...)
 }
 
 // GetUsernameByToken implements biz.TokenRepo.
 func (r *redisTokenRepo) GetUsernameByToken(ctx context.Context, token string) (string, error) {
-	username, err := r.client.Get(ctx, r.tokenKey(token)).Result()
+	username, err := r.client.Get(ctx, r.tokenKey(tokenHash(token))).Result()
+	if err == redis.Nil {
+		username, err = r.rekeyLegacyToken(ctx, token)
+	}
 	if err == redis.Nil {
 		return "", fmt.Errorf("token not found")
 	}
@@ -66,49 +108,194 @@ func (r *redisTokenRepo) GetUsernameByToken(ctx context.Context, token string) (
 
 // ExtendTokenExpiry implements biz.TokenRepo.
 func (r *redisTokenRepo) ExtendTokenExpiry(ctx context.Context, token string, duration time.Duration) error {
-	return r.client.Expire(ctx, r.tokenKey(token), duration).Err()
+	hash := tokenHash(token)
+	for range 2 {
+		username, err := r.client.Get(ctx, r.tokenKey(hash)).Result()
+		if err == nil {
+			return r.extendSession(ctx, username, hash, duration)
+		}
+		if err != redis.Nil {
+			return err
+		}
+		if _, err := r.rekeyLegacyToken(ctx, token); err == redis.Nil {
+			break
+		} else if err != nil {
+			return fmt.Errorf("failed to rekey legacy session: %w", err)
+		}
+	}
+	return nil
 }
 
 // StoreToken implements biz.TokenRepo.
+//
+// When the user has more sessions than allowed, those expiring first are evicted.
 func (r *redisTokenRepo) StoreToken(ctx context.Context, token string, username string, expiration time.Duration) error {
-	err := r.client.Set(ctx, r.tokenKey(token), username, expiration).Err()
-	if err != nil {
-		return err
-	}
-	return r.client.SAdd(ctx, r.userTokensKey(username), token).Err()
+	return r.storeSession(ctx, tokenHash(token), username, expiration, r.maxSessions, "")
 }
 
 // TokenExists implements biz.TokenRepo.
 func (r *redisTokenRepo) TokenExists(ctx context.Context, token string) (bool, error) {
-	exists, err := r.client.Exists(ctx, r.tokenKey(token)).Result()
-	return exists > 0, err
+	exists, err := r.client.Exists(ctx, r.tokenKey(tokenHash(token))).Result()
+	if err != nil || exists > 0 {
+		return exists > 0, err
+	}
+
+	_, err = r.rekeyLegacyToken(ctx, token)
+	if err == redis.Nil {
+		return false, nil
+	}
+	return err == nil, err
 }
 
 // UserHasActiveSession implements biz.TokenRepo.
 func (r *redisTokenRepo) UserHasActiveSession(ctx context.Context, username string) (bool, error) {
-	tokens, err := r.client.SMembers(ctx, r.userTokensKey(username)).Result()
-	if err != nil {
+	if err := r.upgradeUserSessions(ctx, username); err != nil {
 		return false, err
 	}
+	count, err := countSessionsScript.Run(ctx, r.client,
+		[]string{r.userTokensKey(username)},
+		time.Now().UnixMilli(),
+	).Int()
+	return count > 0, err
+}
 
-	for _, token := range tokens {
-		exists, err := r.client.Exists(ctx, r.tokenKey(token)).Result()
+// Store a session, adding it to the user set first and evicting the sessions in excess of
+// `maxSessions`, `replaced` is the member of the legacy session it replaces, if any.
+//
+// The evicted sessions stay in the user set until their keys are deleted, so a failure in
+// between leaves them revocable by DeleteTokensByUsername.
+func (r *redisTokenRepo) storeSession(ctx context.Context, hash, username string, ttl time.Duration, maxSessions int, replaced string) error {
+	if err := r.upgradeUserSessions(ctx, username); err != nil {
+		return err
+	}
+	now := time.Now()
+	evicted, err := addSessionScript.Run(ctx, r.client,
+		[]string{r.userTokensKey(username)},
+		hash, now.Add(ttl).UnixMilli(), now.UnixMilli(), maxSessions, replaced,
+	).StringSlice()
+	if err != nil {
+		return err
+	}
+	if err := r.client.Set(ctx, r.tokenKey(hash), username, ttl).Err(); err != nil {
+		return err
+	}
+	if len(evicted) == 0 {
+		return nil
+	}
+	for _, member := range evicted {
+		if err := r.client.Del(ctx, r.tokenKey(member)).Err(); err != nil {
+			return err
+		}
+	}
+	if err := r.removeSessions(ctx, username, evicted...); err != nil {
+		return err
+	}
+	r.logger.WithContext(ctx).Infow("msg", "evicted the sessions expiring first", "user.name", username, "count", len(evicted))
+	return nil
+}
+
+// Extend a session, its new expiry is recorded in the user set first.
+func (r *redisTokenRepo) extendSession(ctx context.Context, username, hash string, ttl time.Duration) error {
+	if err := r.upgradeUserSessions(ctx, username); err != nil {
+		return err
+	}
+	now := time.Now()
+	if err := addSessionScript.Run(ctx, r.client,
+		[]string{r.userTokensKey(username)},
+		hash, now.Add(ttl).UnixMilli(), now.UnixMilli(), 0, "",
+	).Err(); err != nil {
+		return err
+	}
+	extended, err := r.client.PExpire(ctx, r.tokenKey(hash), ttl).Result()
+	if err != nil || extended {
+		return err
+	}
+	// Expired in between
+	return r.removeSessions(ctx, username, hash)
+}
+
+// Remove sessions from the user set, once their keys are deleted.
+func (r *redisTokenRepo) removeSessions(ctx context.Context, username string, members ...string) error {
+	if err := r.upgradeUserSessions(ctx, username); err != nil {
+		return err
+	}
+	args := make([]any, 0, len(members)+1)
+	args = append(args, time.Now().UnixMilli())
+	for _, member := range members {
+		args = append(args, member)
+	}
+	return removeSessionsScript.Run(ctx, r.client, []string{r.userTokensKey(username)}, args...).Err()
+}
+
+// Convert the user set written by earlier versions, a plain set of raw tokens or hashes,
+// to the sorted set scored by the expiry of the sessions.
+func (r *redisTokenRepo) upgradeUserSessions(ctx context.Context, username string) error {
+	key := r.userTokensKey(username)
+	kind, err := r.client.Type(ctx, key).Result()
+	if err != nil || kind != "set" {
+		return err
+	}
+	members, err := r.client.SMembers(ctx, key).Result()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UnixMilli()
+	args := make([]any, 0, 2*len(members)+1)
+	args = append(args, now)
+	for _, member := range members {
+		// Raw tokens and hashes are both keyed under the same prefix
+		ttl, err := r.client.PTTL(ctx, r.tokenKey(member)).Result()
 		if err != nil {
-			return false, err
+			return err
 		}
-		if exists > 0 {
-			return true, nil
+		if ttl > 0 {
+			args = append(args, now+ttl.Milliseconds(), member)
 		}
 	}
-	return false, nil
+	return upgradeUserSessionsScript.Run(ctx, r.client, []string{key}, args...).Err()
 }
 
-// Return the key for storing a token.
-func (r *redisTokenRepo) tokenKey(token string) string {
-	return "token:" + token
+// Move a session stored under its raw token to the key of its hash, keeping its TTL.
+//
+// It returns the username of the session, or redis.Nil if there is no such session.
+func (r *redisTokenRepo) rekeyLegacyToken(ctx context.Context, token string) (string, error) {
+	legacyKey := r.tokenKey(token)
+	username, err := r.client.Get(ctx, legacyKey).Result()
+	if err != nil {
+		return "", err
+	}
+	ttl, err := r.client.PTTL(ctx, legacyKey).Result()
+	if err != nil {
+		return "", err
+	}
+	if ttl <= 0 {
+		// Expired in between, sessions were never stored without expiry
+		return "", redis.Nil
+	}
+
+	if err := r.storeSession(ctx, tokenHash(token), username, ttl, 0, token); err != nil {
+		return "", err
+	}
+	if err := r.client.Del(ctx, legacyKey).Err(); err != nil {
+		return "", err
+	}
+	r.logger.WithContext(ctx).Infow("msg", "rekeyed legacy session", "user.name", username)
+	return username, nil
 }
 
-// Return the key for storing a user's tokens.
+// Return the key for storing a session, by the hash of its token.
+func (r *redisTokenRepo) tokenKey(hash string) string {
+	return r.prefix + "token:" + hash
+}
+
+// Return the key of the sorted set of a user's token hashes.
 func (r *redisTokenRepo) userTokensKey(username string) string {
-	return "user_tokens:" + username
+	return r.prefix + "user_tokens:" + username
+}
+
+// Return the hex-encoded SHA-256 of a token, the stores never keep the token itself.
+func tokenHash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
 }
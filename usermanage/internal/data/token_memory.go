@@ -0,0 +1,172 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+	"usermanage/gen/proto/conf"
+	"usermanage/internal/biz"
+)
+
+type memorySession struct {
+	username  string
+	expiresAt time.Time
+}
+
+type memoryTokenRepo struct {
+	maxSessions int
+
+	mu       sync.Mutex
+	sessions map[string]memorySession       // token hash -> session
+	tokens   map[string]map[string]struct{} // username -> token hashes
+}
+
+// NewMemoryTokenRepo returns a TokenRepo keeping the sessions in memory.
+//
+// It is intended for tests and single instance development setups, sessions are
+// lost on restart. Expired sessions are removed when they are looked up.
+func NewMemoryTokenRepo(c *conf.Data) biz.TokenRepo {
+	return &memoryTokenRepo{
+		maxSessions: int(c.GetMaxSessionsPerUser()),
+		sessions:    make(map[string]memorySession),
+		tokens:      make(map[string]map[string]struct{}),
+	}
+}
+
+// StoreToken implements biz.TokenRepo.
+func (r *memoryTokenRepo) StoreToken(_ context.Context, token string, username string, expiration time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	token = tokenHash(token)
+
+	r.sessions[token] = memorySession{username: username, expiresAt: time.Now().Add(expiration)}
+	if r.tokens[username] == nil {
+		r.tokens[username] = make(map[string]struct{})
+	}
+	r.tokens[username][token] = struct{}{}
+	r.evict(username, token)
+	return nil
+}
+
+// GetUsernameByToken implements biz.TokenRepo.
+func (r *memoryTokenRepo) GetUsernameByToken(_ context.Context, token string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	token = tokenHash(token)
+
+	session, ok := r.lookup(token)
+	if !ok {
+		return "", fmt.Errorf("token not found")
+	}
+	return session.username, nil
+}
+
+// DeleteTokensByUsername implements biz.TokenRepo.
+func (r *memoryTokenRepo) DeleteTokensByUsername(_ context.Context, username string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for token := range r.tokens[username] {
+		delete(r.sessions, token)
+	}
+	delete(r.tokens, username)
+	return nil
+}
+
+// DeleteToken implements biz.TokenRepo.
+func (r *memoryTokenRepo) DeleteToken(_ context.Context, token string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	token = tokenHash(token)
+
+	session, ok := r.lookup(token)
+	if !ok {
+		return fmt.Errorf("token not found")
+	}
+	r.remove(token, session.username)
+	return nil
+}
+
+// TokenExists implements biz.TokenRepo.
+func (r *memoryTokenRepo) TokenExists(_ context.Context, token string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	token = tokenHash(token)
+
+	_, ok := r.lookup(token)
+	return ok, nil
+}
+
+// ExtendTokenExpiry implements biz.TokenRepo.
+func (r *memoryTokenRepo) ExtendTokenExpiry(_ context.Context, token string, duration time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	token = tokenHash(token)
+
+	if session, ok := r.lookup(token); ok {
+		session.expiresAt = time.Now().Add(duration)
+		r.sessions[token] = session
+	}
+	return nil
+}
+
+// UserHasActiveSession implements biz.TokenRepo.
+func (r *memoryTokenRepo) UserHasActiveSession(_ context.Context, username string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for token := range r.tokens[username] {
+		if _, ok := r.lookup(token); ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Return the session of a token, removing it if it has expired.
+//
+// The caller must hold the lock.
+func (r *memoryTokenRepo) lookup(token string) (memorySession, bool) {
+	session, ok := r.sessions[token]
+	if !ok {
+		return memorySession{}, false
+	}
+	if !time.Now().Before(session.expiresAt) {
+		r.remove(token, session.username)
+		return memorySession{}, false
+	}
+	return session, true
+}
+
+// Evict the sessions of a user expiring first while there are more than allowed,
+// except the session just stored. The caller must hold the lock.
+func (r *memoryTokenRepo) evict(username, stored string) {
+	if r.maxSessions <= 0 {
+		return
+	}
+	for token := range r.tokens[username] {
+		r.lookup(token)
+	}
+	for len(r.tokens[username]) > r.maxSessions {
+		oldest := ""
+		for token := range r.tokens[username] {
+			if token == stored {
+				continue
+			}
+			if oldest == "" || r.sessions[token].expiresAt.Before(r.sessions[oldest].expiresAt) {
+				oldest = token
+			}
+		}
+		r.remove(oldest, username)
+	}
+}
+
+// Remove a token from both indexes, the caller must hold the lock.
+func (r *memoryTokenRepo) remove(token, username string) {
+	delete(r.sessions, token)
+	delete(r.tokens[username], token)
+	if len(r.tokens[username]) == 0 {
+		delete(r.tokens, username)
+	}
+}
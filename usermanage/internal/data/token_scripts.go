@@ -0,0 +1,84 @@
+package data
+
+import "github.com/redis/go-redis/v9"
+
+// The session scripts keep the sorted set of a user's sessions consistent.
+//
+// A user set holds the token hashes of the user scored by their expiry in milliseconds,
+// expired members are pruned whenever the set changes, and the set itself expires with
+// its last session. Sets written by earlier versions are plain sets of raw tokens or
+// hashes, they are converted on first use.
+//
+// Every script touches the user set only, the one key it is given, so they run on any
+// node of a Redis Cluster. The session keys live in other slots and are written around
+// the scripts: a session is added to its user set before its key is stored and removed
+// after its key is deleted, so a user set never misses a live session.
+const sessionScriptHelpers = `
+local function touch(set, now)
+  redis.call('ZREMRANGEBYSCORE', set, '-inf', now)
+  local last = redis.call('ZRANGE', set, -1, -1, 'WITHSCORES')
+  if #last > 0 then
+    redis.call('PEXPIREAT', set, math.ceil(tonumber(last[2])))
+  end
+end
+`
+
+// KEYS: user set.
+// ARGV: now (ms), then the expiry (ms) and member of every live session.
+// Converts a plain set written by earlier versions, returns 1 if it was one.
+var upgradeUserSessionsScript = redis.NewScript(sessionScriptHelpers + `
+if redis.call('TYPE', KEYS[1]).ok ~= 'set' then
+  return 0
+end
+redis.call('DEL', KEYS[1])
+for i = 2, #ARGV, 2 do
+  redis.call('ZADD', KEYS[1], ARGV[i], ARGV[i + 1])
+end
+touch(KEYS[1], tonumber(ARGV[1]))
+return 1
+`)
+
+// KEYS: user set.
+// ARGV: token hash, expiry (ms), now (ms), max sessions (0: unlimited), member replaced.
+// Returns the members to evict, left in the set: the caller deletes their session keys, then
+// removes them with removeSessionsScript.
+var addSessionScript = redis.NewScript(sessionScriptHelpers + `
+local now = tonumber(ARGV[3])
+if ARGV[5] ~= '' then
+  redis.call('ZREM', KEYS[1], ARGV[5])
+end
+redis.call('ZADD', KEYS[1], ARGV[2], ARGV[1])
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now)
+
+local evicted = {}
+local max = tonumber(ARGV[4])
+local excess = redis.call('ZCARD', KEYS[1]) - max
+if max > 0 and excess > 0 then
+  -- Evict the sessions expiring first, never the one just added
+  for _, member in ipairs(redis.call('ZRANGE', KEYS[1], 0, excess)) do
+    if #evicted < excess and member ~= ARGV[1] then
+      table.insert(evicted, member)
+    end
+  end
+end
+touch(KEYS[1], now)
+return evicted
+`)
+
+// KEYS: user set.
+// ARGV: now (ms), then the members to remove.
+var removeSessionsScript = redis.NewScript(sessionScriptHelpers + `
+for i = 2, #ARGV do
+  redis.call('ZREM', KEYS[1], ARGV[i])
+end
+touch(KEYS[1], tonumber(ARGV[1]))
+return 0
+`)
+
+// KEYS: user set.
+// ARGV: now (ms).
+// Returns the number of active sessions.
+var countSessionsScript = redis.NewScript(sessionScriptHelpers + `
+touch(KEYS[1], tonumber(ARGV[1]))
+return redis.call('ZCARD', KEYS[1])
+`)
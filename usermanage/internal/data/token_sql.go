@@ -0,0 +1,153 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"usermanage/gen/proto/conf"
+	"usermanage/internal/biz"
+	"usermanage/internal/data/model"
+	"usermanage/internal/pkg/db"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type sqlTokenRepo struct {
+	db          *db.Database
+	maxSessions int
+	logger      *log.Helper
+}
+
+// NewSQLTokenRepo returns a TokenRepo storing the sessions in the database.
+//
+// Sessions are keyed by the SHA-256 of their token. Tokens are always read from the primary, a replica may not know a token issued a moment ago.
+// Expired sessions are ignored, then removed by the `SessionSweeper`.
+func NewSQLTokenRepo(c *conf.Data, db *db.Database, logger log.Logger) biz.TokenRepo {
+	return &sqlTokenRepo{
+		db:          db,
+		maxSessions: int(c.GetMaxSessionsPerUser()),
+		logger:      log.NewHelper(logger),
+	}
+}
+
+// StoreToken implements biz.TokenRepo.
+//
+// When the user has more sessions than allowed, those expiring first are evicted.
+func (r *sqlTokenRepo) StoreToken(ctx context.Context, token string, username string, expiration time.Duration) error {
+	now := time.Now()
+	session := model.Session{
+		Token:     tokenHash(token),
+		Username:  username,
+		ExpiresAt: now.Add(expiration),
+		CreatedAt: now,
+	}
+	return r.db.InTx(ctx, func(ctx context.Context) error {
+		tx := r.db.Conn(ctx)
+		if err := tx.
+			Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "token"}},
+				DoUpdates: clause.AssignmentColumns([]string{"username", "expires_at"}),
+			}).
+			Create(&session).Error; err != nil {
+			return fmt.Errorf("failed to store session of user[%s]: %w", username, err)
+		}
+		if r.maxSessions <= 0 {
+			return nil
+		}
+
+		var others []string
+		if err := tx.Model(&model.Session{}).
+			Where("username = ? AND expires_at > ? AND token <> ?", username, now, session.Token).
+			Order("expires_at DESC").
+			Pluck("token", &others).Error; err != nil {
+			return fmt.Errorf("failed to find sessions of user[%s]: %w", username, err)
+		}
+		if len(others) < r.maxSessions {
+			return nil
+		}
+		excess := others[r.maxSessions-1:]
+		if err := tx.Where("token IN ?", excess).Delete(&model.Session{}).Error; err != nil {
+			return fmt.Errorf("failed to evict sessions of user[%s]: %w", username, err)
+		}
+		r.logger.WithContext(ctx).Infow("msg", "evicted the oldest sessions", "user.name", username, "count", len(excess))
+		return nil
+	})
+}
+
+// GetUsernameByToken implements biz.TokenRepo.
+func (r *sqlTokenRepo) GetUsernameByToken(ctx context.Context, token string) (string, error) {
+	var session model.Session
+	err := r.db.Conn(ctx).
+		Where("token = ? AND expires_at > ?", tokenHash(token), time.Now()).
+		First(&session).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", fmt.Errorf("token not found")
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get session: %w", err)
+	}
+	return session.Username, nil
+}
+
+// DeleteTokensByUsername implements biz.TokenRepo.
+func (r *sqlTokenRepo) DeleteTokensByUsername(ctx context.Context, username string) error {
+	if err := r.db.Conn(ctx).
+		Where("username = ?", username).
+		Delete(&model.Session{}).Error; err != nil {
+		return fmt.Errorf("failed to delete sessions of user[%s]: %w", username, err)
+	}
+	return nil
+}
+
+// DeleteToken implements biz.TokenRepo.
+func (r *sqlTokenRepo) DeleteToken(ctx context.Context, token string) error {
+	result := r.db.Conn(ctx).
+		Where("token = ?", tokenHash(token)).
+		Delete(&model.Session{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete session: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("token not found")
+	}
+	return nil
+}
+
+// TokenExists implements biz.TokenRepo.
+func (r *sqlTokenRepo) TokenExists(ctx context.Context, token string) (bool, error) {
+	var count int64
+	if err := r.db.Conn(ctx).
+		Model(&model.Session{}).
+		Where("token = ? AND expires_at > ?", tokenHash(token), time.Now()).
+		Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check session: %w", err)
+	}
+	return count > 0, nil
+}
+
+// ExtendTokenExpiry implements biz.TokenRepo.
+func (r *sqlTokenRepo) ExtendTokenExpiry(ctx context.Context, token string, duration time.Duration) error {
+	now := time.Now()
+	if err := r.db.Conn(ctx).
+		Model(&model.Session{}).
+		Where("token = ? AND expires_at > ?", tokenHash(token), now).
+		Update("expires_at", now.Add(duration)).Error; err != nil {
+		return fmt.Errorf("failed to extend session: %w", err)
+	}
+	return nil
+}
+
+// UserHasActiveSession implements biz.TokenRepo.
+func (r *sqlTokenRepo) UserHasActiveSession(ctx context.Context, username string) (bool, error) {
+	var count int64
+	if err := r.db.Conn(ctx).
+		Model(&model.Session{}).
+		Where("username = ? AND expires_at > ?", username, time.Now()).
+		Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check sessions of user[%s]: %w", username, err)
+	}
+	return count > 0, nil
+}
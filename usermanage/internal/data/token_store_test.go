@@ -0,0 +1,146 @@
+package data
+
+import (
+	"context"
+	"testing"
+	"time"
+	"usermanage/gen/proto/conf"
+	"usermanage/internal/biz"
+	"usermanage/internal/data/model"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testTokenStore is a TokenRepo under test, and how to let time pass for it.
+type testTokenStore struct {
+	repo   biz.TokenRepo
+	elapse func(time.Duration)
+}
+
+// Run the behaviour shared by every TokenRepo against the Redis, database and memory stores.
+func TestTokenRepo(t *testing.T) {
+	stores := map[string]func(t *testing.T, c *conf.Data) testTokenStore{
+		"redis": func(t *testing.T, c *conf.Data) testTokenStore {
+			s, repo := newTestRedisTokenRepo(t, c)
+			return testTokenStore{repo: repo, elapse: func(d time.Duration) {
+				time.Sleep(d)
+				s.FastForward(d)
+			}}
+		},
+		"database": func(t *testing.T, c *conf.Data) testTokenStore {
+			return testTokenStore{repo: NewSQLTokenRepo(c, newTestDatabase(t), log.DefaultLogger), elapse: time.Sleep}
+		},
+		"memory": func(t *testing.T, c *conf.Data) testTokenStore {
+			return testTokenStore{repo: NewMemoryTokenRepo(c), elapse: time.Sleep}
+		},
+	}
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			t.Run("Store and get", func(t *testing.T) {
+				repo := newStore(t, &conf.Data{}).repo
+				require.NoError(t, repo.StoreToken(ctx, "t1", "foo", time.Minute))
+
+				username, err := repo.GetUsernameByToken(ctx, "t1")
+				require.NoError(t, err)
+				assert.Equal(t, "foo", username)
+				exists, err := repo.TokenExists(ctx, "t1")
+				require.NoError(t, err)
+				assert.True(t, exists)
+				active, err := repo.UserHasActiveSession(ctx, "foo")
+				require.NoError(t, err)
+				assert.True(t, active)
+
+				_, err = repo.GetUsernameByToken(ctx, "missing")
+				assert.ErrorContains(t, err, "token not found")
+			})
+
+			t.Run("Expired", func(t *testing.T) {
+				store := newStore(t, &conf.Data{})
+				require.NoError(t, store.repo.StoreToken(ctx, "t1", "foo", 50*time.Millisecond))
+				store.elapse(100 * time.Millisecond)
+
+				exists, err := store.repo.TokenExists(ctx, "t1")
+				require.NoError(t, err)
+				assert.False(t, exists)
+				active, err := store.repo.UserHasActiveSession(ctx, "foo")
+				require.NoError(t, err)
+				assert.False(t, active)
+				_, err = store.repo.GetUsernameByToken(ctx, "t1")
+				assert.Error(t, err)
+			})
+
+			t.Run("Extend", func(t *testing.T) {
+				store := newStore(t, &conf.Data{})
+				require.NoError(t, store.repo.StoreToken(ctx, "t1", "foo", 50*time.Millisecond))
+				require.NoError(t, store.repo.ExtendTokenExpiry(ctx, "t1", time.Minute))
+				store.elapse(100 * time.Millisecond)
+
+				exists, err := store.repo.TokenExists(ctx, "t1")
+				require.NoError(t, err)
+				assert.True(t, exists)
+				active, err := store.repo.UserHasActiveSession(ctx, "foo")
+				require.NoError(t, err)
+				assert.True(t, active)
+			})
+
+			t.Run("Delete", func(t *testing.T) {
+				repo := newStore(t, &conf.Data{}).repo
+				require.NoError(t, repo.StoreToken(ctx, "t1", "foo", time.Minute))
+				require.NoError(t, repo.StoreToken(ctx, "t2", "foo", time.Minute))
+				require.NoError(t, repo.StoreToken(ctx, "t3", "bar", time.Minute))
+
+				require.NoError(t, repo.DeleteToken(ctx, "t1"))
+				assert.ErrorContains(t, repo.DeleteToken(ctx, "t1"), "token not found")
+				exists, err := repo.TokenExists(ctx, "t2")
+				require.NoError(t, err)
+				assert.True(t, exists)
+
+				require.NoError(t, repo.DeleteTokensByUsername(ctx, "foo"))
+				active, err := repo.UserHasActiveSession(ctx, "foo")
+				require.NoError(t, err)
+				assert.False(t, active)
+				active, err = repo.UserHasActiveSession(ctx, "bar")
+				require.NoError(t, err)
+				assert.True(t, active)
+			})
+
+			t.Run("Max sessions", func(t *testing.T) {
+				repo := newStore(t, &conf.Data{MaxSessionsPerUser: 2}).repo
+				require.NoError(t, repo.StoreToken(ctx, "t1", "foo", time.Minute))
+				require.NoError(t, repo.StoreToken(ctx, "t2", "foo", 2*time.Minute))
+				require.NoError(t, repo.StoreToken(ctx, "other", "bar", time.Minute))
+				// Shorter than the others, still kept over them
+				require.NoError(t, repo.StoreToken(ctx, "t3", "foo", 30*time.Second))
+
+				for token, want := range map[string]bool{"t1": false, "t2": true, "t3": true, "other": true} {
+					exists, err := repo.TokenExists(ctx, token)
+					require.NoError(t, err)
+					assert.Equal(t, want, exists, token)
+				}
+			})
+		})
+	}
+}
+
+func TestSessionSweeper(t *testing.T) {
+	ctx := context.Background()
+	database := newTestDatabase(t)
+	repo := NewSQLTokenRepo(&conf.Data{}, database, log.DefaultLogger)
+	require.NoError(t, repo.StoreToken(ctx, "expired1", "foo", -time.Minute))
+	require.NoError(t, repo.StoreToken(ctx, "expired2", "foo", -time.Second))
+	require.NoError(t, repo.StoreToken(ctx, "active", "foo", time.Minute))
+
+	sweeper := NewSessionSweeper(&conf.Data{SessionStore: conf.SessionStore_SESSION_STORE_DATABASE}, database, log.DefaultLogger)
+	sweeper.batchSize = 1
+	n, err := sweeper.SweepOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	var tokens []string
+	require.NoError(t, database.Model(&model.Session{}).Pluck("token", &tokens).Error)
+	assert.Equal(t, []string{tokenHash("active")}, tokens, "sessions are stored by token hash")
+}
@@ -2,44 +2,63 @@ package data
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
+	"usermanage/gen/proto/conf"
 
+	"github.com/alicebob/miniredis/v2"
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/go-redis/redismock/v9"
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
-func TestRedisTokenRepo_StoreToken(t *testing.T) {
-	client, mock := redismock.NewClientMock()
-	repo := NewRedisTokenRepo(client, log.DefaultLogger)
+// Create a Redis token repository backed by an in-process Redis server.
+func newTestRedisTokenRepo(t *testing.T, c *conf.Data) (*miniredis.Miniredis, *redisTokenRepo) {
+	t.Helper()
 
+	s := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: s.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return s, NewRedisTokenRepo(c, client, log.DefaultLogger).(*redisTokenRepo)
+}
+
+func TestRedisTokenRepo_StoreToken(t *testing.T) {
+	s, repo := newTestRedisTokenRepo(t, &conf.Data{})
 	ctx := context.Background()
 	token := "test-token"
 	username := "testuser"
 	expiration := 15 * time.Minute
 
-	// Mock expectations
-	mock.ExpectSet("token:"+token, username, expiration).SetVal("OK")
-	mock.ExpectSAdd("user_tokens:"+username, token).SetVal(1)
-
-	// Execute the method
-	err := repo.StoreToken(ctx, token, username, expiration)
-	assert.NoError(t, err)
-	assert.NoError(t, mock.ExpectationsWereMet())
+	require.NoError(t, repo.StoreToken(ctx, token, username, expiration))
+
+	// The session is keyed by the token hash, the user set is scored by expiry and expires with it
+	hash := tokenHash(token)
+	stored, err := s.Get("token:" + hash)
+	require.NoError(t, err)
+	assert.Equal(t, username, stored)
+	assert.Equal(t, expiration, s.TTL("token:"+hash))
+	assert.False(t, s.Exists("token:"+token))
+
+	assert.Equal(t, "zset", s.Type("user_tokens:"+username))
+	score, err := s.ZScore("user_tokens:"+username, hash)
+	require.NoError(t, err)
+	assert.InDelta(t, time.Now().Add(expiration).UnixMilli(), score, 1000)
+	assert.InDelta(t, expiration, s.TTL("user_tokens:"+username), float64(time.Second))
 }
 
 func TestRedisTokenRepo_GetUsernameByToken(t *testing.T) {
 	client, mock := redismock.NewClientMock()
-	repo := NewRedisTokenRepo(client, log.DefaultLogger)
+	repo := NewRedisTokenRepo(&conf.Data{}, client, log.DefaultLogger)
 
 	ctx := context.Background()
 	token := "test-token"
 	username := "testuser"
 
 	t.Run("Token exists", func(t *testing.T) {
-		mock.ExpectGet("token:" + token).SetVal(username)
+		mock.ExpectGet("token:" + tokenHash(token)).SetVal(username)
 
 		result, err := repo.GetUsernameByToken(ctx, token)
 		assert.NoError(t, err)
@@ -48,6 +67,7 @@ func TestRedisTokenRepo_GetUsernameByToken(t *testing.T) {
 	})
 
 	t.Run("Token not found", func(t *testing.T) {
+		mock.ExpectGet("token:" + tokenHash(token)).SetErr(redis.Nil)
 		mock.ExpectGet("token:" + token).SetErr(redis.Nil)
 
 		result, err := repo.GetUsernameByToken(ctx, token)
@@ -59,72 +79,78 @@ func TestRedisTokenRepo_GetUsernameByToken(t *testing.T) {
 }
 
 func TestRedisTokenRepo_DeleteToken(t *testing.T) {
-	client, mock := redismock.NewClientMock()
-	repo := NewRedisTokenRepo(client, log.DefaultLogger)
-
+	s, repo := newTestRedisTokenRepo(t, &conf.Data{})
 	ctx := context.Background()
-	token := "test-token"
 	username := "testuser"
 
-	mock.ExpectGet("token:" + token).SetVal(username)
-	mock.ExpectDel("token:" + token).SetVal(1)
-	mock.ExpectSRem("user_tokens:"+username, token).SetVal(1)
+	require.NoError(t, repo.StoreToken(ctx, "token1", username, time.Minute))
+	require.NoError(t, repo.StoreToken(ctx, "token2", username, time.Minute))
 
-	err := repo.DeleteToken(ctx, token)
-	assert.NoError(t, err)
-	assert.NoError(t, mock.ExpectationsWereMet())
+	require.NoError(t, repo.DeleteToken(ctx, "token1"))
+	assert.False(t, s.Exists("token:"+tokenHash("token1")))
+	members, err := s.ZMembers("user_tokens:" + username)
+	require.NoError(t, err)
+	assert.Equal(t, []string{tokenHash("token2")}, members)
+
+	assert.ErrorContains(t, repo.DeleteToken(ctx, "token1"), "token not found")
+
+	// The user set goes away with the last session
+	require.NoError(t, repo.DeleteToken(ctx, "token2"))
+	assert.False(t, s.Exists("user_tokens:"+username))
 }
 
 func TestRedisTokenRepo_DeleteTokensByUsername(t *testing.T) {
-	client, mock := redismock.NewClientMock()
-	repo := NewRedisTokenRepo(client, log.DefaultLogger)
-
+	s, repo := newTestRedisTokenRepo(t, &conf.Data{})
 	ctx := context.Background()
 	username := "testuser"
-	tokens := []string{"token1", "token2"}
 
 	t.Run("With tokens", func(t *testing.T) {
-		mock.ExpectSMembers("user_tokens:" + username).SetVal(tokens)
-		mock.ExpectDel("token:token1", "token:token2", "user_tokens:"+username).SetVal(3)
-
-		err := repo.DeleteTokensByUsername(ctx, username)
-		assert.NoError(t, err)
-		assert.NoError(t, mock.ExpectationsWereMet())
+		require.NoError(t, repo.StoreToken(ctx, "token1", username, time.Minute))
+		require.NoError(t, repo.StoreToken(ctx, "token2", username, time.Minute))
+		require.NoError(t, repo.StoreToken(ctx, "token3", "other", time.Minute))
+
+		require.NoError(t, repo.DeleteTokensByUsername(ctx, username))
+		assert.False(t, s.Exists("token:"+tokenHash("token1")))
+		assert.False(t, s.Exists("token:"+tokenHash("token2")))
+		assert.False(t, s.Exists("user_tokens:"+username))
+		assert.True(t, s.Exists("token:"+tokenHash("token3")))
 	})
 
 	t.Run("No tokens", func(t *testing.T) {
-		mock.ExpectSMembers("user_tokens:" + username).SetVal([]string{})
-
-		err := repo.DeleteTokensByUsername(ctx, username)
-		assert.NoError(t, err)
-		assert.NoError(t, mock.ExpectationsWereMet())
+		assert.NoError(t, repo.DeleteTokensByUsername(ctx, "nobody"))
 	})
 }
 
 func TestRedisTokenRepo_ExtendTokenExpiry(t *testing.T) {
-	client, mock := redismock.NewClientMock()
-	repo := NewRedisTokenRepo(client, log.DefaultLogger)
-
+	s, repo := newTestRedisTokenRepo(t, &conf.Data{})
 	ctx := context.Background()
 	token := "test-token"
+	username := "testuser"
 	duration := 30 * time.Minute
 
-	mock.ExpectExpire("token:"+token, duration).SetVal(true)
+	require.NoError(t, repo.StoreToken(ctx, token, username, time.Minute))
+	require.NoError(t, repo.ExtendTokenExpiry(ctx, token, duration))
 
-	err := repo.ExtendTokenExpiry(ctx, token, duration)
-	assert.NoError(t, err)
-	assert.NoError(t, mock.ExpectationsWereMet())
+	hash := tokenHash(token)
+	assert.Equal(t, duration, s.TTL("token:"+hash))
+	score, err := s.ZScore("user_tokens:"+username, hash)
+	require.NoError(t, err)
+	assert.InDelta(t, time.Now().Add(duration).UnixMilli(), score, 1000)
+	assert.InDelta(t, duration, s.TTL("user_tokens:"+username), float64(time.Second))
+
+	// Extending a missing token is a no-op
+	assert.NoError(t, repo.ExtendTokenExpiry(ctx, "missing", duration))
 }
 
 func TestRedisTokenRepo_TokenExists(t *testing.T) {
 	client, mock := redismock.NewClientMock()
-	repo := NewRedisTokenRepo(client, log.DefaultLogger)
+	repo := NewRedisTokenRepo(&conf.Data{}, client, log.DefaultLogger)
 
 	ctx := context.Background()
 	token := "test-token"
 
 	t.Run("Token exists", func(t *testing.T) {
-		mock.ExpectExists("token:" + token).SetVal(1)
+		mock.ExpectExists("token:" + tokenHash(token)).SetVal(1)
 
 		exists, err := repo.TokenExists(ctx, token)
 		assert.NoError(t, err)
@@ -133,7 +159,8 @@ func TestRedisTokenRepo_TokenExists(t *testing.T) {
 	})
 
 	t.Run("Token does not exist", func(t *testing.T) {
-		mock.ExpectExists("token:" + token).SetVal(0)
+		mock.ExpectExists("token:" + tokenHash(token)).SetVal(0)
+		mock.ExpectGet("token:" + token).RedisNil()
 
 		exists, err := repo.TokenExists(ctx, token)
 		assert.NoError(t, err)
@@ -142,41 +169,142 @@ func TestRedisTokenRepo_TokenExists(t *testing.T) {
 	})
 }
 
-func TestRedisTokenRepo_UserHasActiveSession(t *testing.T) {
-	client, mock := redismock.NewClientMock()
-	repo := NewRedisTokenRepo(client, log.DefaultLogger)
+func TestRedisTokenRepo_KeyPrefix(t *testing.T) {
+	s, repo := newTestRedisTokenRepo(t, &conf.Data{Redis: &conf.Data_Redis{KeyPrefix: "staging:"}})
+	ctx := context.Background()
+	token := "test-token"
+	username := "testuser"
 
+	require.NoError(t, repo.StoreToken(ctx, token, username, 15*time.Minute))
+	assert.True(t, s.Exists("staging:token:"+tokenHash(token)))
+	assert.True(t, s.Exists("staging:user_tokens:"+username))
+
+	result, err := repo.GetUsernameByToken(ctx, token)
+	assert.NoError(t, err)
+	assert.Equal(t, username, result)
+}
+
+func TestRedisTokenRepo_LegacySessions(t *testing.T) {
+	s, repo := newTestRedisTokenRepo(t, &conf.Data{})
 	ctx := context.Background()
 	username := "testuser"
-	tokens := []string{"token1", "token2"}
 
-	t.Run("User has active session", func(t *testing.T) {
-		mock.ExpectSMembers("user_tokens:" + username).SetVal(tokens)
-		mock.ExpectExists("token:" + tokens[0]).SetVal(1)
+	// Sessions keyed by raw token in a plain set, as written by earlier versions
+	require.NoError(t, s.Set("token:legacy1", username))
+	s.SetTTL("token:legacy1", 10*time.Minute)
+	require.NoError(t, s.Set("token:legacy2", username))
+	s.SetTTL("token:legacy2", 10*time.Minute)
+	_, err := s.SAdd("user_tokens:"+username, "legacy1", "legacy2")
+	require.NoError(t, err)
+
+	// The set is converted to a sorted set on first use
+	active, err := repo.UserHasActiveSession(ctx, username)
+	require.NoError(t, err)
+	assert.True(t, active)
+	assert.Equal(t, "zset", s.Type("user_tokens:"+username))
+
+	// A session is rekeyed by hash with its remaining TTL
+	result, err := repo.GetUsernameByToken(ctx, "legacy1")
+	require.NoError(t, err)
+	assert.Equal(t, username, result)
+	assert.False(t, s.Exists("token:legacy1"))
+	assert.Equal(t, 10*time.Minute, s.TTL("token:"+tokenHash("legacy1")))
+	members, err := s.ZMembers("user_tokens:" + username)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{tokenHash("legacy1"), "legacy2"}, members)
+
+	// Sessions not rekeyed yet are deleted as well
+	require.NoError(t, repo.DeleteTokensByUsername(ctx, username))
+	assert.False(t, s.Exists("token:legacy2"))
+	assert.False(t, s.Exists("token:"+tokenHash("legacy1")))
+}
 
-		hasSession, err := repo.UserHasActiveSession(ctx, username)
-		assert.NoError(t, err)
-		assert.True(t, hasSession)
-		assert.NoError(t, mock.ExpectationsWereMet())
-	})
+func TestRedisTokenRepo_Cluster(t *testing.T) {
+	s := miniredis.RunT(t)
+	client := redis.NewClusterClient(&redis.ClusterOptions{Addrs: []string{s.Addr()}})
+	t.Cleanup(func() { client.Close() })
+	repo := NewRedisTokenRepo(&conf.Data{}, client, log.DefaultLogger)
+	ctx := context.Background()
 
-	t.Run("No active session", func(t *testing.T) {
-		mock.ExpectSMembers("user_tokens:" + username).SetVal(tokens)
-		mock.ExpectExists("token:" + tokens[0]).SetVal(0)
-		mock.ExpectExists("token:" + tokens[1]).SetVal(0)
+	// The keys have no hash tag, the sessions are spread over the slots
+	require.NoError(t, repo.StoreToken(ctx, "test-token", "testuser", time.Minute))
+	assert.True(t, s.Exists("token:"+tokenHash("test-token")))
+	assert.True(t, s.Exists("user_tokens:testuser"))
+
+	// The sessions of earlier versions share the keys, so they are revoked as well
+	require.NoError(t, s.Set("token:legacy", "testuser"))
+	s.SetTTL("token:legacy", time.Minute)
+	_, err := s.ZAdd("user_tokens:testuser", float64(time.Now().Add(time.Minute).UnixMilli()), "legacy")
+	require.NoError(t, err)
+	require.NoError(t, repo.DeleteTokensByUsername(ctx, "testuser"))
+	assert.False(t, s.Exists("token:legacy"))
+	assert.False(t, s.Exists("token:"+tokenHash("test-token")))
+	assert.False(t, s.Exists("user_tokens:testuser"))
+}
 
-		hasSession, err := repo.UserHasActiveSession(ctx, username)
-		assert.NoError(t, err)
-		assert.False(t, hasSession)
-		assert.NoError(t, mock.ExpectationsWereMet())
-	})
+func TestRedisTokenRepo_RekeyErrors(t *testing.T) {
+	client, mock := redismock.NewClientMock()
+	repo := NewRedisTokenRepo(&conf.Data{}, client, log.DefaultLogger)
+	ctx := context.Background()
+	token := "test-token"
+	failure := errors.New("connection reset")
 
-	t.Run("No tokens", func(t *testing.T) {
-		mock.ExpectSMembers("user_tokens:" + username).SetVal([]string{})
+	mock.ExpectGetDel("token:" + tokenHash(token)).RedisNil()
+	mock.ExpectGet("token:" + token).SetErr(failure)
+	assert.ErrorIs(t, repo.DeleteToken(ctx, token), failure)
 
-		hasSession, err := repo.UserHasActiveSession(ctx, username)
-		assert.NoError(t, err)
-		assert.False(t, hasSession)
-		assert.NoError(t, mock.ExpectationsWereMet())
-	})
+	mock.ExpectGet("token:" + tokenHash(token)).RedisNil()
+	mock.ExpectGet("token:" + token).SetErr(failure)
+	assert.ErrorIs(t, repo.ExtendTokenExpiry(ctx, token, time.Minute), failure)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// failingDel is a Redis hook failing the DEL commands while set.
+type failingDel struct {
+	failing bool
+}
+
+func (h *failingDel) DialHook(next redis.DialHook) redis.DialHook { return next }
+
+func (h *failingDel) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		if h.failing && cmd.Name() == "del" {
+			return errors.New("connection reset")
+		}
+		return next(ctx, cmd)
+	}
+}
+
+func (h *failingDel) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return next
+}
+
+func TestRedisTokenRepo_EvictionFailure(t *testing.T) {
+	s := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: s.Addr()})
+	t.Cleanup(func() { client.Close() })
+	hook := &failingDel{}
+	client.AddHook(hook)
+	repo := NewRedisTokenRepo(&conf.Data{MaxSessionsPerUser: 1}, client, log.DefaultLogger)
+	ctx := context.Background()
+
+	require.NoError(t, repo.StoreToken(ctx, "t1", "foo", time.Minute))
+	hook.failing = true
+	assert.Error(t, repo.StoreToken(ctx, "t2", "foo", 2*time.Minute))
+	hook.failing = false
+
+	// The session to evict is still live, and still in the user set
+	exists, err := repo.TokenExists(ctx, "t1")
+	require.NoError(t, err)
+	assert.True(t, exists)
+	_, err = s.ZScore("user_tokens:foo", tokenHash("t1"))
+	require.NoError(t, err)
+
+	require.NoError(t, repo.DeleteTokensByUsername(ctx, "foo"))
+	for _, token := range []string{"t1", "t2"} {
+		exists, err := repo.TokenExists(ctx, token)
+		require.NoError(t, err)
+		assert.False(t, exists, token)
+	}
 }
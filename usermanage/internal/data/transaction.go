@@ -0,0 +1,21 @@
+package data
+
+import (
+	"context"
+	"usermanage/internal/biz"
+	"usermanage/internal/pkg/db"
+)
+
+type transaction struct {
+	db *db.Database
+}
+
+// NewTransaction creates a new database backed transaction manager.
+func NewTransaction(db *db.Database) biz.Transaction {
+	return &transaction{db: db}
+}
+
+// InTx implements biz.Transaction.
+func (t *transaction) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return t.db.InTx(ctx, fn)
+}
@@ -4,25 +4,35 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 	"usermanage/internal/biz"
 	"usermanage/internal/data/model"
+	"usermanage/internal/pkg/auth"
 	"usermanage/internal/pkg/constants"
 	"usermanage/internal/pkg/db"
+	"usermanage/internal/pkg/envelope"
 	"usermanage/internal/pkg/password"
 
 	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type userRepo struct {
 	db     *db.Database
+	env    *envelope.Envelope // nil when encryption is not configured
 	logger *log.Helper
 }
 
 // NewUserRepo creates a new user repository.
-func NewUserRepo(db *db.Database, logger log.Logger) biz.UserRepo {
+//
+// The email and phone of users are encrypted by the "encrypted" serializer, and
+// looked up by their blind index computed with `env`.
+func NewUserRepo(db *db.Database, env *envelope.Envelope, logger log.Logger) biz.UserRepo {
 	return &userRepo{
 		db:     db,
+		env:    env,
 		logger: log.NewHelper(logger),
 	}
 }
@@ -30,23 +40,32 @@ func NewUserRepo(db *db.Database, logger log.Logger) biz.UserRepo {
 // GetUserByID implements user.UserRepo.
 func (r *userRepo) GetUserByID(ctx context.Context, id string) (*biz.User, error) {
 	var user model.User
-	err := r.db.WithContext(ctx).
+	err := r.db.Read(ctx).
 		Where("id = ?", id).
 		First(&user).Error
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user by id[%s]: %w", id, err)
+		return nil, fmt.Errorf("failed to get user by id[%s]: %w", id, userNotFound(err))
 	}
 	return r.toBizUser(&user), nil
 }
 
+// LockUserByID implements biz.UserRepo.
+func (r *userRepo) LockUserByID(ctx context.Context, id string) (*biz.User, error) {
+	user, err := lockUser(r.db.Conn(ctx), id)
+	if err != nil {
+		return nil, err
+	}
+	return r.toBizUser(user), nil
+}
+
 // GetUserByUsername implements biz.UserRepo.
 func (r *userRepo) GetUserByUsername(ctx context.Context, username string) (*biz.User, error) {
 	user := model.User{}
-	err := r.db.WithContext(ctx).
+	err := r.db.Read(ctx).
 		Where("username = ?", username).
 		First(&user).Error
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user by username[%s]: %w", username, err)
+		return nil, fmt.Errorf("failed to get user by username[%s]: %w", username, userNotFound(err))
 	}
 	return r.toBizUser(&user), nil
 }
@@ -54,7 +73,7 @@ func (r *userRepo) GetUserByUsername(ctx context.Context, username string) (*biz
 // ExistsByID implements biz.UserRepo.
 func (r *userRepo) ExistsByID(ctx context.Context, id string) (bool, error) {
 	var exists bool
-	err := r.db.WithContext(ctx).
+	err := r.db.Conn(ctx).
 		Model(&model.User{}).
 		Select("COUNT(1) > 0").
 		Where("id = ?", id).
@@ -66,9 +85,12 @@ func (r *userRepo) ExistsByID(ctx context.Context, id string) (bool, error) {
 }
 
 // ExistsByUsername implements biz.UserRepo.
+//
+// Only live users are considered, the username of a deleted user can be reused.
+// It guards writes, so it always reads from the primary.
 func (r *userRepo) ExistsByUsername(ctx context.Context, username string) (bool, error) {
 	var exists bool
-	err := r.db.WithContext(ctx).
+	err := r.db.Conn(ctx).
 		Model(&model.User{}).
 		Select("COUNT(1) > 0").
 		Where("username = ?", username).
@@ -80,17 +102,19 @@ func (r *userRepo) ExistsByUsername(ctx context.Context, username string) (bool,
 }
 
 // FindByCredentials implements biz.UserRepo.
+//
+// It reads from the primary so a password is usable as soon as it is changed.
 func (r *userRepo) FindByCredentials(ctx context.Context, username string, rawPassword string) (*biz.User, error) {
 	var user model.User
-	err := r.db.WithContext(ctx).
+	err := r.db.Conn(ctx).
 		Where("username = ?", username).
 		First(&user).Error
 	if err != nil {
-		return nil, fmt.Errorf("failed to find user by username[%s]: %w", username, err)
+		return nil, fmt.Errorf("failed to find user by username[%s]: %w", username, userNotFound(err))
 	}
 
 	if !user.VerifyPassword(rawPassword) {
-		return nil, errors.New("invalid password")
+		return nil, fmt.Errorf("%w: invalid password of user[%s]", biz.ErrInvalidCredentials, username)
 	}
 
 	return r.toBizUser(&user), nil
@@ -98,13 +122,20 @@ func (r *userRepo) FindByCredentials(ctx context.Context, username string, rawPa
 
 // DeleteUser implements biz.UserRepo.
 func (r *userRepo) DeleteUser(ctx context.Context, id string) error {
-	var user model.User
-	if err := r.db.WithContext(ctx).
-		Where("id = ?", id).
-		Delete(&user).Error; err != nil {
-		return fmt.Errorf("failed to delete user by id[%s]: %w", id, err)
-	}
-	return nil
+	return r.db.InTx(ctx, func(ctx context.Context) error {
+		tx := r.db.Conn(ctx)
+		user, err := lockUser(tx, id)
+		if err != nil {
+			return err
+		}
+		if err := tx.Delete(user).Error; err != nil {
+			return fmt.Errorf("failed to delete user by id[%s]: %w", id, err)
+		}
+		if err := deleteOAuthGrants(tx, user, true); err != nil {
+			return err
+		}
+		return recordUserRevision(tx, user, user, model.UserRevisionActionDelete, auth.Username(ctx))
+	})
 }
 
 // ListUsers implements biz.UserRepo.
@@ -112,19 +143,21 @@ func (r *userRepo) ListUsers(ctx context.Context, params biz.UserListParams) (*b
 	var totalCount int64
 	var users []model.User
 
-	// TODO: add query conditions
-
-	query := r.db.WithContext(ctx).Model(&model.User{})
+	query, err := r.filterUsers(r.db.Read(ctx).Model(&model.User{}), params)
+	if err != nil {
+		return nil, err
+	}
 	if err := query.Count(&totalCount).Error; err != nil {
 		return nil, fmt.Errorf("failed to count users: %w", err)
 	}
 
 	page, pageSize := params.GetPage()
-	offset := (page - 1) * pageSize
+	offset := (page-1)*pageSize + max(params.Offset, 0)
 	if err := query.
 		Offset(int(offset)).
 		Limit(int(params.PageSize)).
 		Order("created_at DESC").
+		Order("id").
 		Find(&users).Error; err != nil {
 		return nil, fmt.Errorf("failed to find users: %w", err)
 	}
@@ -139,17 +172,67 @@ func (r *userRepo) ListUsers(ctx context.Context, params biz.UserListParams) (*b
 	}, nil
 }
 
-// CreateUser implements biz.UserRepo.
-func (r *userRepo) CreateUser(ctx context.Context, params biz.UserCreateParams) (*biz.User, error) {
-	username := params.Username
-	exists, err := r.ExistsByUsername(ctx, username)
-	if err != nil {
-		return nil, fmt.Errorf("failed to check user exists by username[%s]: %w", username, err)
+// IterateUsers implements biz.UserRepo.
+func (r *userRepo) IterateUsers(ctx context.Context, params biz.UserListParams, batchSize int, fn func(users []*biz.User) error) error {
+	if batchSize <= 0 {
+		return errors.New("batch size must be positive")
 	}
-	if exists {
-		return nil, fmt.Errorf("username[%s] already exists", username)
+
+	lastID := ""
+	for {
+		query, err := r.filterUsers(r.db.Read(ctx).Model(&model.User{}), params)
+		if err != nil {
+			return err
+		}
+		var users []model.User
+		if err := query.
+			Where("id > ?", lastID).
+			Order("id").
+			Limit(batchSize).
+			Find(&users).Error; err != nil {
+			return fmt.Errorf("failed to find users after id[%s]: %w", lastID, err)
+		}
+		if len(users) == 0 {
+			return nil
+		}
+
+		bizUsers := make([]*biz.User, 0, len(users))
+		for _, user := range users {
+			bizUsers = append(bizUsers, r.toBizUser(&user))
+		}
+		if err := fn(bizUsers); err != nil {
+			return err
+		}
+		if len(users) < batchSize {
+			return nil
+		}
+		lastID = users[len(users)-1].ID
 	}
+}
 
+// Apply the filters of a listing: exact username, status, role and email.
+func (r *userRepo) filterUsers(query *gorm.DB, params biz.UserListParams) (*gorm.DB, error) {
+	if params.Username != "" {
+		query = query.Where("username = ?", params.Username)
+	}
+	if params.Status != 0 {
+		query = query.Where("status = ?", params.Status)
+	}
+	if params.Role != 0 {
+		query = query.Where("role = ?", params.Role)
+	}
+	if params.Email != "" {
+		if r.env == nil {
+			return nil, db.ErrEncryptionNotConfigured
+		}
+		query = query.Where("email_index = ?", r.blindIndex(emailField, params.Email))
+	}
+	return query, nil
+}
+
+// CreateUser implements biz.UserRepo.
+func (r *userRepo) CreateUser(ctx context.Context, params biz.UserCreateParams) (*biz.User, error) {
+	username := params.Username
 	user := model.User{
 		Username:  username,
 		Password:  params.Password,
@@ -157,95 +240,76 @@ func (r *userRepo) CreateUser(ctx context.Context, params biz.UserCreateParams)
 		Status:    constants.UserStatus(params.Status),
 		Creator:   params.Creator,
 		UpdatedBy: params.UpdateBy,
+		Source:    params.Source,
 	}
-	if err := r.db.WithContext(ctx).
-		Create(&user).Error; err != nil {
-		return nil, fmt.Errorf("failed to create user: %w", err)
+	r.setEmail(&user, params.Email)
+	r.setPhone(&user, params.Phone)
+	err := r.db.InTx(ctx, func(ctx context.Context) error {
+		tx := r.db.Conn(ctx)
+		if err := checkUsernameAvailable(tx, username, ""); err != nil {
+			return err
+		}
+		if err := tx.Create(&user).Error; err != nil {
+			return fmt.Errorf("failed to create user: %w", usernameConflict(err, username))
+		}
+		return recordUserRevision(tx, nil, &user, model.UserRevisionActionCreate, params.Creator)
+	})
+	if err != nil {
+		return nil, err
 	}
 	return r.toBizUser(&user), nil
 }
 
 // UpdateUser implements biz.UserRepo.
 func (r *userRepo) UpdateUser(ctx context.Context, id string, params biz.UserUpdateParams) (*biz.User, error) {
-	// Check if the user exists
-	existingUser, err := r.GetUserByID(ctx, id)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get user by id[%s]: %w", id, err)
-	}
+	var user *model.User
+	err := r.db.InTx(ctx, func(ctx context.Context) error {
+		tx := r.db.Conn(ctx)
+		existingUser, err := lockUser(tx, id)
+		if err != nil {
+			return err
+		}
 
-	updateUsername := params.Username
-	if updateUsername != nil {
-		if *updateUsername == existingUser.Username {
-			params.Username = nil
-		} else {
-			exists, err := r.ExistsByUsername(ctx, *updateUsername)
-			if err != nil {
-				return nil, fmt.Errorf("failed to check user exists by username[%s]: %w", *updateUsername, err)
-			}
-			if exists {
-				return nil, fmt.Errorf("username[%s] already exists", *updateUsername)
+		updateUsername := params.Username
+		if updateUsername != nil {
+			if *updateUsername == existingUser.Username {
+				params.Username = nil
+			} else if err := checkUsernameAvailable(tx, *updateUsername, id); err != nil {
+				return err
 			}
 		}
-	}
-
-	// Update the user
-	result := r.db.WithContext(ctx).
-		Model(&model.User{}).
-		Where("id = ?", id).
-		Updates(params).
-		Update("updated_at", time.Now())
-	if result.Error != nil {
-		return nil, fmt.Errorf("failed to update user by id[%s]: %w", id, result.Error)
-	}
-	if result.RowsAffected == 0 {
-		return nil, fmt.Errorf("user[id=%s] not found", id)
-	}
 
-	// Fetch the updated user
-	user, err := r.GetUserByID(ctx, id)
+		user, err = r.updateWithRevision(tx, existingUser, params, params.UpdatedBy, model.UserRevisionActionUpdate)
+		return err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user by id[%s]: %w", id, err)
+		return nil, fmt.Errorf("failed to update user by id[%s]: %w", id, err)
 	}
-	return user, nil
+	return r.toBizUser(user), nil
 }
 
 // ReplaceUser implements biz.UserRepo.
 func (r *userRepo) ReplaceUser(ctx context.Context, id string, params biz.UserReplaceParams) (*biz.User, error) {
-	// Check if the user exists
-	replaceUsername := params.Username
-	existingUser, err := r.GetUserByID(ctx, id)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get user by id[%s]: %w", id, err)
-	}
-	if replaceUsername != existingUser.Username {
-		exists, err := r.ExistsByUsername(ctx, replaceUsername)
+	var user *model.User
+	err := r.db.InTx(ctx, func(ctx context.Context) error {
+		tx := r.db.Conn(ctx)
+		existingUser, err := lockUser(tx, id)
 		if err != nil {
-			return nil, fmt.Errorf("failed to check user exists by username[%s]: %w", replaceUsername, err)
+			return err
 		}
-		if exists {
-			return nil, fmt.Errorf("username[%s] already exists", replaceUsername)
+		if params.Username != existingUser.Username {
+			if err := checkUsernameAvailable(tx, params.Username, id); err != nil {
+				return err
+			}
 		}
-	}
 
-	// Replace the user
-	result := r.db.WithContext(ctx).
-		Model(&model.User{}).
-		Where("id = ?", id).
-		Updates(params).
-		Update("updated_at", time.Now())
-	if result.Error != nil {
-		return nil, fmt.Errorf("failed to replace user by id[%s]: %w", id, result.Error)
-	}
-	if result.RowsAffected == 0 {
-		return nil, fmt.Errorf("user[id=%s] not found", id)
-	}
-
-	// Fetch the replaced user
-	user, err := r.GetUserByID(ctx, id)
+		user, err = r.updateWithRevision(tx, existingUser, params, params.UpdatedBy, model.UserRevisionActionReplace)
+		return err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user by id[%s]: %w", id, err)
+		return nil, fmt.Errorf("failed to replace user by id[%s]: %w", id, err)
 	}
-	return user, nil
+	return r.toBizUser(user), nil
 }
 
 // ResetUserPassword implements biz.UserRepo.
@@ -256,26 +320,32 @@ func (r *userRepo) ResetUserPassword(ctx context.Context, id string, newPassword
 		return nil, fmt.Errorf("failed to hash password: %w", err)
 	}
 
-	var user model.User
-	result := r.db.WithContext(ctx).
-		Where("id = ?", id).
-		First(&user)
-	if result.Error != nil {
-		return nil, fmt.Errorf("failed to find user by id[%s]: %w", id, result.Error)
-	}
+	var user *model.User
+	err = r.db.InTx(ctx, func(ctx context.Context) error {
+		tx := r.db.Conn(ctx)
+		user, err = lockUser(tx, id)
+		if err != nil {
+			return err
+		}
 
-	user.Password = hashedPassword
-	user.UpdatedAt = time.Now()
-	if err := r.db.Save(&user).Error; err != nil {
-		return nil, fmt.Errorf("failed to update user password: %w", err)
+		user.Password = hashedPassword
+		user.UpdatedAt = time.Now()
+		user.Version++
+		if err := tx.Save(user).Error; err != nil {
+			return fmt.Errorf("failed to update user password: %w", err)
+		}
+		return deleteOAuthGrants(tx, user, false)
+	})
+	if err != nil {
+		return nil, err
 	}
-	return r.toBizUser(&user), nil
+	return r.toBizUser(user), nil
 }
 
 // VerifyPassword implements biz.UserRepo.
 func (r *userRepo) VerifyPassword(ctx context.Context, id string, password string) (bool, error) {
 	var user model.User
-	err := r.db.WithContext(ctx).
+	err := r.db.Conn(ctx).
 		Where("id = ?", id).
 		First(&user).Error
 	if err != nil {
@@ -284,20 +354,242 @@ func (r *userRepo) VerifyPassword(ctx context.Context, id string, password strin
 	return user.VerifyPassword(password), nil
 }
 
+// Apply the changes to the locked user and record the resulting revision inside the given transaction.
+func (r *userRepo) updateWithRevision(tx *gorm.DB, before *model.User, changes any, operator string, action model.UserRevisionAction) (*model.User, error) {
+	id := before.ID
+	columns, values := r.userChanges(before, changes)
+	// Updating from a model.User, not a map, so the encrypted fields go through their serializer
+	result := tx.Model(&model.User{}).
+		Where("id = ?", id).
+		Select(columns).
+		Updates(&values)
+	if result.Error != nil {
+		if username, ok := changedUsername(changes); ok {
+			return nil, usernameConflict(result.Error, username)
+		}
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, fmt.Errorf("user[id=%s] not found", id)
+	}
+
+	var after model.User
+	if err := tx.Where("id = ?", id).First(&after).Error; err != nil {
+		return nil, fmt.Errorf("failed to get user by id[%s]: %w", id, err)
+	}
+	if err := revokeChangedOAuthGrants(tx, before, &after); err != nil {
+		return nil, err
+	}
+	if err := recordUserRevision(tx, before, &after, action, operator); err != nil {
+		return nil, err
+	}
+	return &after, nil
+}
+
+// Delete the OAuth authorization codes and refresh tokens issued for the user inside the
+// given transaction, and with `consents` the consents given under its username.
+//
+// The grants are only checked against the ID and the status of their user when used, so they
+// are deleted whenever its password changes or it stops being active.
+func deleteOAuthGrants(tx *gorm.DB, user *model.User, consents bool) error {
+	for _, grant := range []any{&model.OAuthAuthorizationCode{}, &model.OAuthRefreshToken{}} {
+		if err := tx.Where("user_id = ?", user.ID).Delete(grant).Error; err != nil {
+			return fmt.Errorf("failed to delete oauth grants of user[id=%s]: %w", user.ID, err)
+		}
+	}
+	if consents {
+		if err := tx.Where("username = ?", user.Username).Delete(&model.OAuthConsent{}).Error; err != nil {
+			return fmt.Errorf("failed to delete oauth consents of user[id=%s]: %w", user.ID, err)
+		}
+	}
+	return nil
+}
+
+// Delete the OAuth grants the change of a user invalidates inside the given transaction: its
+// authorization codes and refresh tokens when it stops being active, and the consents given
+// under its old username when it is renamed, which would pass to the next user taking it.
+func revokeChangedOAuthGrants(tx *gorm.DB, before, after *model.User) error {
+	if before.Status == constants.UserStatusNormal && after.Status != constants.UserStatusNormal {
+		if err := deleteOAuthGrants(tx, before, false); err != nil {
+			return err
+		}
+	}
+	if before.Username != after.Username {
+		if err := tx.Where("username = ?", before.Username).Delete(&model.OAuthConsent{}).Error; err != nil {
+			return fmt.Errorf("failed to delete oauth consents of user[id=%s]: %w", before.ID, err)
+		}
+	}
+	return nil
+}
+
+// Return the columns of the user changed by the update or replace params, and their values.
+func (r *userRepo) userChanges(before *model.User, changes any) ([]string, model.User) {
+	columns := []string{"updated_at", "version"}
+	var values model.User
+	values.ID = before.ID // encrypted fields are bound to the row
+	values.UpdatedAt = time.Now()
+	values.Version = before.Version + 1
+
+	switch c := changes.(type) {
+	case biz.UserUpdateParams:
+		if c.Username != nil {
+			columns = append(columns, "username")
+			values.Username = *c.Username
+		}
+		if c.Role != nil {
+			columns = append(columns, "role")
+			values.Role = constants.UserRole(*c.Role)
+		}
+		if c.Status != nil {
+			columns = append(columns, "status")
+			values.Status = constants.UserStatus(*c.Status)
+		}
+		if c.Email != nil {
+			columns = append(columns, "email", "email_index")
+			r.setEmail(&values, *c.Email)
+		}
+		if c.Phone != nil {
+			columns = append(columns, "phone", "phone_index")
+			r.setPhone(&values, *c.Phone)
+		}
+		if c.UpdatedBy != "" {
+			columns = append(columns, "updated_by")
+			values.UpdatedBy = c.UpdatedBy
+		}
+	case biz.UserReplaceParams:
+		columns = append(columns, "username", "role", "status", "email", "email_index", "phone", "phone_index")
+		values.Username = c.Username
+		values.Role = constants.UserRole(c.Role)
+		values.Status = constants.UserStatus(c.Status)
+		r.setEmail(&values, c.Email)
+		r.setPhone(&values, c.Phone)
+		if c.UpdatedBy != "" {
+			columns = append(columns, "updated_by")
+			values.UpdatedBy = c.UpdatedBy
+		}
+	}
+	return columns, values
+}
+
+// Set the email of the user and its blind index.
+func (r *userRepo) setEmail(u *model.User, email string) {
+	u.Email = email
+	u.EmailIndex = r.blindIndex(emailField, email)
+}
+
+// Set the phone of the user and its blind index.
+func (r *userRepo) setPhone(u *model.User, phone string) {
+	u.Phone = phone
+	u.PhoneIndex = r.blindIndex(phoneField, phone)
+}
+
+const (
+	emailField = "email"
+	phoneField = "phone"
+)
+
+// Return the blind index of the value of an encrypted field, empty for an empty value.
+//
+// Emails are case-insensitive, so they are indexed in lower case.
+func (r *userRepo) blindIndex(field, value string) string {
+	value = strings.TrimSpace(value)
+	if value == "" || r.env == nil {
+		return ""
+	}
+	if field == emailField {
+		value = strings.ToLower(value)
+	}
+	return r.env.BlindIndex(field, value)
+}
+
+// Get the live user by ID and lock its row until the end of the transaction,
+// so concurrent changes of the same user are applied one after the other.
+func lockUser(tx *gorm.DB, id string) (*model.User, error) {
+	var user model.User
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", id).
+		First(&user).Error; err != nil {
+		return nil, fmt.Errorf("failed to get user by id[%s]: %w", id, userNotFound(err))
+	}
+	return &user, nil
+}
+
+// Check no live user other than `excludeID` has the username.
+//
+// The check locks the matching rows, and on MySQL the index gap the username would be
+// inserted in, until the end of the transaction so concurrent writers cannot take the
+// username in the meantime. PostgreSQL and SQLite do not lock gaps, there the unique
+// index `idx_users_live_username` rejects the loser of the race, see `usernameConflict`.
+func checkUsernameAvailable(tx *gorm.DB, username, excludeID string) error {
+	query := tx.Model(&model.User{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("username = ?", username)
+	if excludeID != "" {
+		query = query.Where("id <> ?", excludeID)
+	}
+
+	var ids []string
+	if err := query.Limit(1).Pluck("id", &ids).Error; err != nil {
+		return fmt.Errorf("failed to check user exists by username[%s]: %w", username, err)
+	}
+	if len(ids) > 0 {
+		return fmt.Errorf("username[%s] %w", username, biz.ErrUsernameExists)
+	}
+	return nil
+}
+
+// Turn the violation of the live username unique index into an "already exists" error.
+func usernameConflict(err error, username string) error {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return fmt.Errorf("username[%s] %w: %w", username, biz.ErrUsernameExists, err)
+	}
+	return err
+}
+
+// Turn the "record not found" error of a user lookup into `biz.ErrUserNotFound`.
+func userNotFound(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("%w: %w", biz.ErrUserNotFound, err)
+	}
+	return err
+}
+
+// Return the username set by the changes, if any.
+func changedUsername(changes any) (string, bool) {
+	switch c := changes.(type) {
+	case biz.UserUpdateParams:
+		if c.Username != nil {
+			return *c.Username, true
+		}
+	case biz.UserReplaceParams:
+		return c.Username, true
+	}
+	return "", false
+}
+
 // Convert model User to biz User.
 func (r *userRepo) toBizUser(u *model.User) *biz.User {
 	if u == nil {
 		return nil
 	}
 
-	return &biz.User{
+	user := &biz.User{
 		ID:        u.ID,
 		Username:  u.Username,
 		Role:      biz.UserRole(u.Role),
 		Status:    biz.UserStatus(u.Status),
 		Creator:   u.Creator,
+		Source:    u.Source,
 		CreatedAt: u.CreatedAt,
 		UpdatedBy: u.UpdatedBy,
 		UpdatedAt: u.UpdatedAt,
+		Email:     u.Email,
+		Phone:     u.Phone,
+		Version:   u.Version,
+	}
+	if u.IsDeleted.Valid {
+		deletedAt := u.IsDeleted.Time
+		user.DeletedAt = &deletedAt
 	}
+	return user
 }
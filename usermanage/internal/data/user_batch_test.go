@@ -0,0 +1,123 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"usermanage/gen/proto/conf"
+	"usermanage/internal/biz"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserUseCase_BatchUsers(t *testing.T) {
+	database := newTestDatabase(t)
+	repo := NewUserRepo(database, nil, log.DefaultLogger)
+	tokens := NewMemoryTokenRepo(&conf.Data{})
+	uc := biz.NewUserUseCase(NewTransaction(database), repo, tokens, NewEventRepo(database, log.DefaultLogger))
+	ctx := context.Background()
+
+	foo := createTestUser(t, repo, "foo")
+	bar := createTestUser(t, repo, "bar")
+	for _, username := range []string{"foo", "bar"} {
+		require.NoError(t, tokens.StoreToken(ctx, "token-"+username, username, time.Hour))
+	}
+	hasSession := func(username string) bool {
+		active, err := tokens.UserHasActiveSession(ctx, username)
+		require.NoError(t, err)
+		return active
+	}
+
+	disabled := int32(biz.UserStatusDisabled)
+	t.Run("all or nothing rolls back", func(t *testing.T) {
+		result, err := uc.BatchUpdateUsers(ctx, biz.UserBatchUpdateParams{
+			Selector: biz.UserBatchSelector{IDs: []string{foo.ID, "missing"}},
+			Status:   &disabled,
+			Mode:     biz.UserBatchModeAllOrNothing,
+		})
+		require.NoError(t, err)
+		assert.True(t, result.RolledBack)
+		assert.Equal(t, 0, result.Succeeded)
+		assert.Equal(t, 2, result.Failed)
+
+		user, err := repo.GetUserByID(ctx, foo.ID)
+		require.NoError(t, err)
+		assert.Equal(t, biz.UserStatusNormal, user.Status)
+		assert.True(t, hasSession("foo"), "the sessions are only revoked once committed")
+	})
+
+	t.Run("best effort disables and revokes sessions", func(t *testing.T) {
+		result, err := uc.BatchUpdateUsers(ctx, biz.UserBatchUpdateParams{
+			Selector: biz.UserBatchSelector{IDs: []string{foo.ID, "missing", foo.ID}},
+			Status:   &disabled,
+		})
+		require.NoError(t, err)
+		assert.False(t, result.RolledBack)
+		assert.Equal(t, 1, result.Succeeded)
+		assert.Equal(t, 1, result.Failed)
+		require.Len(t, result.Items, 2)
+		assert.Equal(t, "foo", result.Items[0].Username)
+		assert.Error(t, result.Items[1].Err)
+
+		user, err := repo.GetUserByID(ctx, foo.ID)
+		require.NoError(t, err)
+		assert.Equal(t, biz.UserStatusDisabled, user.Status)
+		assert.False(t, hasSession("foo"))
+		assert.True(t, hasSession("bar"))
+	})
+
+	t.Run("delete by filter", func(t *testing.T) {
+		result, err := uc.BatchDeleteUsers(ctx, biz.UserBatchDeleteParams{
+			Selector: biz.UserBatchSelector{Filter: &biz.UserListParams{Username: "bar"}},
+			Mode:     biz.UserBatchModeAllOrNothing,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Succeeded)
+		assert.Equal(t, bar.ID, result.Items[0].ID)
+
+		exists, err := repo.ExistsByID(ctx, bar.ID)
+		require.NoError(t, err)
+		assert.False(t, exists)
+		assert.False(t, hasSession("bar"))
+	})
+
+	t.Run("revocation failures are reported", func(t *testing.T) {
+		baz := createTestUser(t, repo, "baz")
+		failing := biz.NewUserUseCase(NewTransaction(database), repo, &failingTokenRepo{TokenRepo: tokens},
+			NewEventRepo(database, log.DefaultLogger))
+		result, err := failing.BatchUpdateUsers(ctx, biz.UserBatchUpdateParams{
+			Selector: biz.UserBatchSelector{IDs: []string{baz.ID}},
+			Status:   &disabled,
+			Mode:     biz.UserBatchModeAllOrNothing,
+		})
+		require.NoError(t, err)
+		assert.False(t, result.RolledBack)
+		assert.Equal(t, 1, result.Failed)
+		assert.ErrorContains(t, result.Items[0].Err, "failed to delete tokens")
+
+		user, err := repo.GetUserByID(ctx, baz.ID)
+		require.NoError(t, err)
+		assert.Equal(t, biz.UserStatusDisabled, user.Status, "the change is committed")
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := uc.BatchDeleteUsers(ctx, biz.UserBatchDeleteParams{
+			Selector: biz.UserBatchSelector{Filter: &biz.UserListParams{}},
+		})
+		assert.ErrorIs(t, err, biz.ErrInvalidUserBatch)
+		_, err = uc.BatchUpdateUsers(ctx, biz.UserBatchUpdateParams{Selector: biz.UserBatchSelector{IDs: []string{foo.ID}}})
+		assert.ErrorIs(t, err, biz.ErrInvalidUserBatch)
+	})
+}
+
+// failingTokenRepo is a token repository failing to revoke sessions.
+type failingTokenRepo struct {
+	biz.TokenRepo
+}
+
+func (r *failingTokenRepo) DeleteTokensByUsername(context.Context, string) error {
+	return errors.New("redis unavailable")
+}
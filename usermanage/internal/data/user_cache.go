@@ -0,0 +1,336 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+	"usermanage/gen/proto/conf"
+	"usermanage/internal/biz"
+	"usermanage/internal/pkg/cache"
+	"usermanage/internal/pkg/db"
+	"usermanage/internal/pkg/envelope"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	defaultUserCacheTTL       = 5 * time.Minute
+	defaultUserCacheLocalTTL  = 5 * time.Second
+	defaultUserCacheLocalSize = 10000
+
+	userCacheIDKeyPrefix       = "user_cache:id:"
+	userCacheUsernameKeyPrefix = "user_cache:username:"
+
+	// userCacheTombstone replaces an invalidated user for userCacheTombstoneTTL, so a load
+	// which read the user before the change cannot cache it afterwards.
+	userCacheTombstone    = "invalidated"
+	userCacheTombstoneTTL = 10 * time.Second
+)
+
+// storeUserScript caches a user unless a tombstone stands in its place.
+var storeUserScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[2] then
+  return 0
+end
+redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[3])
+return 1
+`)
+
+// userCacheMetrics counts the user cache lookups.
+type userCacheMetrics struct {
+	hits   metric.Int64Counter
+	misses metric.Int64Counter
+}
+
+func newUserCacheMetrics() *userCacheMetrics {
+	meter := otel.Meter("usermanage/internal/data")
+	hits, _ := meter.Int64Counter("user_cache.hits", metric.WithDescription("User cache hits, by tier"))
+	misses, _ := meter.Int64Counter("user_cache.misses", metric.WithDescription("User cache misses, loaded from the database"))
+	return &userCacheMetrics{hits: hits, misses: misses}
+}
+
+// cachedUserRepo is a read-through cache of users in front of a biz.UserRepo.
+//
+// Users are cached by ID in an in-process LRU, then in Redis. Usernames map to IDs, so a
+// user is invalidated by ID only: a username whose user was renamed or deleted no longer
+// matches and is reloaded. Every change of a user replaces it with a short-lived tombstone,
+// again once the transaction commits, and concurrent loads of the same user are collapsed
+// into one query on the primary.
+//
+// Other instances keep serving a changed user from their in-process tier until its TTL expires.
+// Users hold personal data encrypted at rest, so they are encrypted in Redis as well when
+// encryption is configured.
+type cachedUserRepo struct {
+	biz.UserRepo
+	rdb     redis.UniversalClient
+	env     *envelope.Envelope
+	prefix  string
+	local   *cache.LRU[string, *biz.User]
+	ttl     time.Duration
+	group   singleflight.Group
+	metrics *userCacheMetrics
+	logger  *log.Helper
+}
+
+// NewCachedUserRepo creates the user repository, behind a cache if it is enabled.
+func NewCachedUserRepo(c *conf.Data, db *db.Database, rdb redis.UniversalClient, env *envelope.Envelope, logger log.Logger) biz.UserRepo {
+	repo := NewUserRepo(db, env, logger)
+	cfg := c.GetUserCache()
+	if !cfg.GetEnabled() {
+		return repo
+	}
+	if rdb == nil {
+		log.NewHelper(logger).Warn("user cache disabled, no Redis is configured")
+		return repo
+	}
+	return newCachedUserRepo(repo, rdb, env, cfg, c.GetRedis().GetKeyPrefix(), logger)
+}
+
+func newCachedUserRepo(repo biz.UserRepo, rdb redis.UniversalClient, env *envelope.Envelope, cfg *conf.Data_UserCache, keyPrefix string, logger log.Logger) *cachedUserRepo {
+	ttl := defaultUserCacheTTL
+	if d := cfg.GetTtl(); d != nil && d.AsDuration() > 0 {
+		ttl = d.AsDuration()
+	}
+	localTTL := defaultUserCacheLocalTTL
+	if d := cfg.GetLocalTtl(); d != nil && d.AsDuration() > 0 {
+		localTTL = d.AsDuration()
+	}
+	localSize := defaultUserCacheLocalSize
+	if n := cfg.GetLocalSize(); n > 0 {
+		localSize = int(n)
+	}
+
+	return &cachedUserRepo{
+		UserRepo: repo,
+		rdb:      rdb,
+		env:      env,
+		prefix:   keyPrefix,
+		local:    cache.NewLRU[string, *biz.User](localSize, localTTL),
+		ttl:      ttl,
+		metrics:  newUserCacheMetrics(),
+		logger:   log.NewHelper(logger),
+	}
+}
+
+// GetUserByID implements biz.UserRepo.
+func (r *cachedUserRepo) GetUserByID(ctx context.Context, id string) (*biz.User, error) {
+	// A transaction must see its own changes
+	if _, ok := db.TxFromContext(ctx); ok {
+		return r.UserRepo.GetUserByID(ctx, id)
+	}
+	if user := r.cached(ctx, id); user != nil {
+		return user, nil
+	}
+
+	v, err, _ := r.group.Do(userCacheIDKeyPrefix+id, func() (any, error) {
+		r.metrics.misses.Add(ctx, 1)
+		user, err := r.UserRepo.GetUserByID(db.WithPrimary(ctx), id)
+		if err != nil {
+			return nil, err
+		}
+		r.store(ctx, user)
+		return user, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*biz.User), nil
+}
+
+// GetUserByUsername implements biz.UserRepo.
+func (r *cachedUserRepo) GetUserByUsername(ctx context.Context, username string) (*biz.User, error) {
+	if _, ok := db.TxFromContext(ctx); ok {
+		return r.UserRepo.GetUserByUsername(ctx, username)
+	}
+	if id := r.cachedID(ctx, username); id != "" {
+		if user := r.cached(ctx, id); user != nil && user.Username == username {
+			return user, nil
+		}
+	}
+
+	v, err, _ := r.group.Do(userCacheUsernameKeyPrefix+username, func() (any, error) {
+		r.metrics.misses.Add(ctx, 1)
+		user, err := r.UserRepo.GetUserByUsername(db.WithPrimary(ctx), username)
+		if err != nil {
+			return nil, err
+		}
+		r.store(ctx, user)
+		return user, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*biz.User), nil
+}
+
+// UpdateUser implements biz.UserRepo.
+func (r *cachedUserRepo) UpdateUser(ctx context.Context, id string, params biz.UserUpdateParams) (*biz.User, error) {
+	user, err := r.UserRepo.UpdateUser(ctx, id, params)
+	if err == nil {
+		r.invalidate(ctx, id)
+	}
+	return user, err
+}
+
+// ReplaceUser implements biz.UserRepo.
+func (r *cachedUserRepo) ReplaceUser(ctx context.Context, id string, params biz.UserReplaceParams) (*biz.User, error) {
+	user, err := r.UserRepo.ReplaceUser(ctx, id, params)
+	if err == nil {
+		r.invalidate(ctx, id)
+	}
+	return user, err
+}
+
+// DeleteUser implements biz.UserRepo.
+func (r *cachedUserRepo) DeleteUser(ctx context.Context, id string) error {
+	err := r.UserRepo.DeleteUser(ctx, id)
+	if err == nil {
+		r.invalidate(ctx, id)
+	}
+	return err
+}
+
+// ResetUserPassword implements biz.UserRepo.
+func (r *cachedUserRepo) ResetUserPassword(ctx context.Context, id, newPassword string) (*biz.User, error) {
+	user, err := r.UserRepo.ResetUserPassword(ctx, id, newPassword)
+	if err == nil {
+		r.invalidate(ctx, id)
+	}
+	return user, err
+}
+
+// RestoreUserRevision implements biz.UserRepo.
+func (r *cachedUserRepo) RestoreUserRevision(ctx context.Context, id string, revision int64, operator string) (*biz.User, error) {
+	user, err := r.UserRepo.RestoreUserRevision(ctx, id, revision, operator)
+	if err == nil {
+		r.invalidate(ctx, id)
+	}
+	return user, err
+}
+
+// UndeleteUser implements biz.UserRepo.
+func (r *cachedUserRepo) UndeleteUser(ctx context.Context, id string, operator string) (*biz.User, error) {
+	user, err := r.UserRepo.UndeleteUser(ctx, id, operator)
+	if err == nil {
+		r.invalidate(ctx, id)
+	}
+	return user, err
+}
+
+// PurgeUser implements biz.UserRepo.
+func (r *cachedUserRepo) PurgeUser(ctx context.Context, id string) error {
+	err := r.UserRepo.PurgeUser(ctx, id)
+	if err == nil {
+		r.invalidate(ctx, id)
+	}
+	return err
+}
+
+// Return the cached user, nil on a miss.
+//
+// Redis errors are logged and treated as misses, the database stays the source of truth.
+func (r *cachedUserRepo) cached(ctx context.Context, id string) *biz.User {
+	if user, ok := r.local.Get(id); ok {
+		r.metrics.hits.Add(ctx, 1, metric.WithAttributes(attribute.String("tier", "local")))
+		return user
+	}
+
+	data, err := r.rdb.Get(ctx, r.idKey(id)).Result()
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			r.logger.WithContext(ctx).Warnw("msg", "failed to get cached user", "id", id, "error", err)
+		}
+		return nil
+	}
+	if data == userCacheTombstone {
+		return nil
+	}
+	if r.env != nil {
+		if data, err = r.env.Decrypt(ctx, data, userCacheIDKeyPrefix+id); err != nil {
+			r.logger.WithContext(ctx).Warnw("msg", "failed to decrypt cached user", "id", id, "error", err)
+			return nil
+		}
+	}
+	var user biz.User
+	if err := json.Unmarshal([]byte(data), &user); err != nil {
+		r.logger.WithContext(ctx).Warnw("msg", "failed to decode cached user", "id", id, "error", err)
+		return nil
+	}
+	r.metrics.hits.Add(ctx, 1, metric.WithAttributes(attribute.String("tier", "redis")))
+	r.local.Set(id, &user)
+	return &user
+}
+
+// Return the cached ID of the username, empty on a miss.
+func (r *cachedUserRepo) cachedID(ctx context.Context, username string) string {
+	id, err := r.rdb.Get(ctx, r.usernameKey(username)).Result()
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			r.logger.WithContext(ctx).Warnw("msg", "failed to get cached user id", "username", username, "error", err)
+		}
+		return ""
+	}
+	return id
+}
+
+// Cache the user in both tiers, unless it was invalidated since it was loaded.
+func (r *cachedUserRepo) store(ctx context.Context, user *biz.User) {
+	encoded, err := json.Marshal(user)
+	if err != nil {
+		r.logger.WithContext(ctx).Warnw("msg", "failed to encode user", "id", user.ID, "error", err)
+		return
+	}
+	data := string(encoded)
+	if r.env != nil {
+		if data, err = r.env.Encrypt(ctx, data, userCacheIDKeyPrefix+user.ID); err != nil {
+			r.logger.WithContext(ctx).Warnw("msg", "failed to encrypt user", "id", user.ID, "error", err)
+			return
+		}
+	}
+	stored, err := storeUserScript.Run(ctx, r.rdb, []string{r.idKey(user.ID)}, data, userCacheTombstone, r.ttl.Milliseconds()).Int()
+	if err != nil {
+		r.logger.WithContext(ctx).Warnw("msg", "failed to cache user", "id", user.ID, "error", err)
+		return
+	}
+	if stored == 0 {
+		return
+	}
+	r.local.Set(user.ID, user)
+	if err := r.rdb.Set(ctx, r.usernameKey(user.Username), user.ID, r.ttl).Err(); err != nil {
+		r.logger.WithContext(ctx).Warnw("msg", "failed to cache user id", "username", user.Username, "error", err)
+	}
+}
+
+// Evict the user now and, when the change is part of a transaction, again once it commits
+// so a concurrent load cannot cache the state from before the commit.
+//
+// The user is replaced by a tombstone rather than deleted: a load that read the user before
+// the eviction is refused when caching it afterwards.
+func (r *cachedUserRepo) invalidate(ctx context.Context, id string) {
+	evict := func() {
+		r.local.Delete(id)
+		if err := r.rdb.Set(ctx, r.idKey(id), userCacheTombstone, userCacheTombstoneTTL).Err(); err != nil {
+			r.logger.WithContext(ctx).Errorw("msg", "failed to evict cached user", "id", id, "error", err)
+		}
+	}
+	if _, ok := db.TxFromContext(ctx); ok {
+		evict()
+	}
+	db.AfterCommit(ctx, evict)
+}
+
+// Return the Redis key of a cached user.
+func (r *cachedUserRepo) idKey(id string) string {
+	return r.prefix + userCacheIDKeyPrefix + id
+}
+
+// Return the Redis key mapping a username to the ID of its user.
+func (r *cachedUserRepo) usernameKey(username string) string {
+	return r.prefix + userCacheUsernameKeyPrefix + username
+}
@@ -0,0 +1,89 @@
+package data
+
+import (
+	"context"
+	"testing"
+	"time"
+	"usermanage/gen/proto/conf"
+	"usermanage/internal/biz"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestCachedUserRepo(t *testing.T) {
+	s := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: s.Addr()})
+	t.Cleanup(func() { client.Close() })
+	repo := newCachedUserRepo(newTestUserRepo(t), client, nil, &conf.Data_UserCache{
+		Enabled: true,
+		Ttl:     durationpb.New(time.Minute),
+	}, "", log.DefaultLogger)
+	ctx := context.Background()
+
+	user := createTestUser(t, repo, "foo")
+	idKey := userCacheIDKeyPrefix + user.ID
+
+	// Loaded from the database, then cached in both tiers
+	got, err := repo.GetUserByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "foo", got.Username)
+	assert.True(t, s.Exists(idKey))
+	assert.Equal(t, time.Minute, s.TTL(idKey))
+	id, err := s.Get(userCacheUsernameKeyPrefix + "foo")
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, id)
+
+	// Served from the in-process tier
+	s.Del(idKey)
+	got, err = repo.GetUserByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "foo", got.Username)
+
+	// Replaced by a tombstone on change
+	username := "bar"
+	_, err = repo.UpdateUser(ctx, user.ID, biz.UserUpdateParams{Username: &username})
+	require.NoError(t, err)
+	tombstone, err := s.Get(idKey)
+	require.NoError(t, err)
+	assert.Equal(t, userCacheTombstone, tombstone)
+
+	// The old username maps to a user with another name, so it is reloaded and not found
+	_, err = repo.GetUserByUsername(ctx, "foo")
+	assert.Error(t, err)
+
+	// The new username is loaded, but not cached over the tombstone
+	got, err = repo.GetUserByUsername(ctx, "bar")
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, got.ID)
+	tombstone, err = s.Get(idKey)
+	require.NoError(t, err)
+	assert.Equal(t, userCacheTombstone, tombstone)
+
+	// Cached again once the tombstone expires
+	s.FastForward(userCacheTombstoneTTL)
+	got, err = repo.GetUserByUsername(ctx, "bar")
+	require.NoError(t, err)
+	assert.Equal(t, "bar", got.Username)
+	cached, err := s.Get(idKey)
+	require.NoError(t, err)
+	assert.NotEqual(t, userCacheTombstone, cached)
+
+	t.Run("a load from before the change is not cached", func(t *testing.T) {
+		cached := newCachedUserRepo(newTestUserRepo(t), client, nil, &conf.Data_UserCache{Enabled: true}, "", log.DefaultLogger)
+		stale := &biz.User{ID: "u1", Username: "stale"}
+		cached.invalidate(ctx, stale.ID)
+		cached.store(ctx, stale)
+
+		_, ok := cached.local.Get(stale.ID)
+		assert.False(t, ok)
+		tombstone, err := s.Get(userCacheIDKeyPrefix + stale.ID)
+		require.NoError(t, err)
+		assert.Equal(t, userCacheTombstone, tombstone)
+		assert.Nil(t, cached.cached(ctx, stale.ID))
+	})
+}
@@ -0,0 +1,164 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"usermanage/internal/biz"
+	"usermanage/internal/data/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ListDeletedUsers implements biz.UserRepo.
+func (r *userRepo) ListDeletedUsers(ctx context.Context, params biz.DeletedUserListParams) (*biz.UserListResult, error) {
+	var totalCount int64
+	var users []model.User
+
+	query := r.db.Read(ctx).
+		Unscoped().
+		Model(&model.User{}).
+		Where("is_deleted IS NOT NULL")
+	if params.Username != "" {
+		query = query.Where("username = ?", params.Username)
+	}
+	if !params.DeletedBefore.IsZero() {
+		query = query.Where("is_deleted < ?", params.DeletedBefore)
+	}
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, fmt.Errorf("failed to count deleted users: %w", err)
+	}
+
+	page, pageSize := params.GetPage()
+	offset := (page - 1) * pageSize
+	if err := query.
+		Offset(int(offset)).
+		Limit(int(pageSize)).
+		Order("is_deleted DESC").
+		Find(&users).Error; err != nil {
+		return nil, fmt.Errorf("failed to find deleted users: %w", err)
+	}
+
+	bizUsers := make([]*biz.User, 0, len(users))
+	for _, user := range users {
+		bizUsers = append(bizUsers, r.toBizUser(&user))
+	}
+	return &biz.UserListResult{
+		TotalCount: totalCount,
+		Users:      bizUsers,
+	}, nil
+}
+
+// GetDeletedUserByID implements biz.UserRepo.
+func (r *userRepo) GetDeletedUserByID(ctx context.Context, id string) (*biz.User, error) {
+	var user model.User
+	err := r.db.Read(ctx).
+		Unscoped().
+		Where("id = ? AND is_deleted IS NOT NULL", id).
+		First(&user).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deleted user by id[%s]: %w", id, err)
+	}
+	return r.toBizUser(&user), nil
+}
+
+// UndeleteUser implements biz.UserRepo.
+func (r *userRepo) UndeleteUser(ctx context.Context, id string, operator string) (*biz.User, error) {
+	var user model.User
+	err := r.db.InTx(ctx, func(ctx context.Context) error {
+		tx := r.db.Conn(ctx)
+		if err := tx.Unscoped().
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND is_deleted IS NOT NULL", id).
+			First(&user).Error; err != nil {
+			return fmt.Errorf("failed to get deleted user by id[%s]: %w", id, err)
+		}
+		before := user
+
+		if err := checkUsernameAvailable(tx, user.Username, ""); err != nil {
+			return err
+		}
+
+		user.IsDeleted.Valid = false
+		user.UpdatedBy = operator
+		user.UpdatedAt = time.Now()
+		user.Version++
+		if err := tx.Unscoped().
+			Model(&model.User{}).
+			Where("id = ?", id).
+			Updates(map[string]any{
+				"is_deleted": nil,
+				"updated_by": user.UpdatedBy,
+				"updated_at": user.UpdatedAt,
+				"version":    user.Version,
+			}).Error; err != nil {
+			return fmt.Errorf("failed to undelete user by id[%s]: %w", id, usernameConflict(err, user.Username))
+		}
+
+		return recordUserRevision(tx, &before, &user, model.UserRevisionActionUndelete, operator)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.toBizUser(&user), nil
+}
+
+// PurgeUser implements biz.UserRepo.
+//
+// Every row keyed by the user goes with it: its revisions, the events and webhook deliveries
+// carrying its data, its external identities and OAuth grants.
+func (r *userRepo) PurgeUser(ctx context.Context, id string) error {
+	return r.db.InTx(ctx, func(ctx context.Context) error {
+		tx := r.db.Conn(ctx)
+		var user model.User
+		if err := tx.Unscoped().
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "username").
+			Where("id = ? AND is_deleted IS NOT NULL", id).
+			Take(&user).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("deleted user[id=%s] not found", id)
+			}
+			return fmt.Errorf("failed to get deleted user by id[%s]: %w", id, err)
+		}
+		if err := tx.Unscoped().Where("id = ?", id).Delete(&model.User{}).Error; err != nil {
+			return fmt.Errorf("failed to purge user by id[%s]: %w", id, err)
+		}
+
+		events := tx.Model(&model.OutboxEvent{}).Select("event_id").Where("aggregate_id = ?", id)
+		deliveries := tx.Model(&model.WebhookDelivery{}).Select("id").Where("event_id IN (?)", events)
+		purges := []struct {
+			name  string
+			model any
+			query string
+			arg   any
+		}{
+			{"revisions", &model.UserRevision{}, "user_id = ?", id},
+			{"webhook delivery attempts", &model.WebhookDeliveryAttempt{}, "delivery_id IN (?)", deliveries},
+			{"webhook deliveries", &model.WebhookDelivery{}, "event_id IN (?)", events},
+			{"events", &model.OutboxEvent{}, "aggregate_id = ?", id},
+			{"external identities", &model.ExternalIdentity{}, "user_id = ?", id},
+			{"oauth authorization codes", &model.OAuthAuthorizationCode{}, "user_id = ?", id},
+			{"oauth refresh tokens", &model.OAuthRefreshToken{}, "user_id = ?", id},
+		}
+		for _, p := range purges {
+			if err := tx.Where(p.query, p.arg).Delete(p.model).Error; err != nil {
+				return fmt.Errorf("failed to purge %s of user[id=%s]: %w", p.name, id, err)
+			}
+		}
+
+		// Consents are keyed by username, which may have been reused by a new user since
+		var reused int64
+		if err := tx.Model(&model.User{}).Where("username = ?", user.Username).Count(&reused).Error; err != nil {
+			return fmt.Errorf("failed to check username[%s]: %w", user.Username, err)
+		}
+		if reused == 0 {
+			if err := tx.Where("username = ?", user.Username).Delete(&model.OAuthConsent{}).Error; err != nil {
+				return fmt.Errorf("failed to purge oauth consents of user[id=%s]: %w", id, err)
+			}
+		}
+		return nil
+	})
+}
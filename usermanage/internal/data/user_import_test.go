@@ -0,0 +1,65 @@
+package data
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"usermanage/gen/proto/conf"
+	"usermanage/internal/biz"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserUseCase_ImportUsers(t *testing.T) {
+	database := newTestDatabase(t)
+	repo := NewUserRepo(database, newTestEnvelope(t, false), log.DefaultLogger)
+	uc := biz.NewUserUseCase(NewTransaction(database), repo, NewMemoryTokenRepo(&conf.Data{}), NewEventRepo(database, log.DefaultLogger))
+	ctx := context.Background()
+
+	foo, err := repo.CreateUser(ctx, biz.UserCreateParams{
+		Username: "foo",
+		Password: "P@ssw0rd",
+		Role:     int32(biz.UserRoleAdmin),
+		Status:   int32(biz.UserStatusLocked),
+		Email:    "foo@example.com",
+		Phone:    "+15550100",
+	})
+	require.NoError(t, err)
+	upsert := func(format biz.UserImportFormat, content string) *biz.UserImportResult {
+		t.Helper()
+		result, err := uc.ImportUsers(ctx, strings.NewReader(content), biz.UserImportParams{
+			Format:   format,
+			Mode:     biz.UserImportModeUpsert,
+			Operator: "admin",
+		})
+		require.NoError(t, err)
+		return result
+	}
+
+	t.Run("rows with the username only change nothing", func(t *testing.T) {
+		result := upsert(biz.UserImportFormatCSV, "username\nfoo\n")
+		assert.Equal(t, 1, result.Unchanged)
+		result = upsert(biz.UserImportFormatJSONLines, `{"username": "foo"}`+"\n")
+		assert.Equal(t, 1, result.Unchanged)
+
+		user, err := repo.GetUserByID(ctx, foo.ID)
+		require.NoError(t, err)
+		assert.Equal(t, foo.Version, user.Version)
+	})
+
+	t.Run("only the fields given are updated", func(t *testing.T) {
+		result := upsert(biz.UserImportFormatCSV, "username,role,email\nfoo,,bar@example.com\n")
+		assert.Equal(t, 1, result.Updated)
+		result = upsert(biz.UserImportFormatJSONLines, `{"username": "foo", "status": "normal", "phone": ""}`+"\n")
+		assert.Equal(t, 1, result.Updated)
+
+		user, err := repo.GetUserByID(ctx, foo.ID)
+		require.NoError(t, err)
+		assert.Equal(t, biz.UserRoleAdmin, user.Role)
+		assert.Equal(t, biz.UserStatusNormal, user.Status)
+		assert.Equal(t, "bar@example.com", user.Email)
+		assert.Empty(t, user.Phone)
+	})
+}
@@ -0,0 +1,247 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"usermanage/internal/biz"
+	"usermanage/internal/data/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ListUserRevisions implements biz.UserRepo.
+func (r *userRepo) ListUserRevisions(ctx context.Context, id string, params biz.UserRevisionListParams) (*biz.UserRevisionListResult, error) {
+	var totalCount int64
+	var revisions []model.UserRevision
+
+	query := r.db.Read(ctx).
+		Model(&model.UserRevision{}).
+		Where("user_id = ?", id)
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, fmt.Errorf("failed to count revisions of user[id=%s]: %w", id, err)
+	}
+
+	page, pageSize := params.GetPage()
+	offset := (page - 1) * pageSize
+	if err := query.
+		Offset(int(offset)).
+		Limit(int(pageSize)).
+		Order("revision DESC").
+		Find(&revisions).Error; err != nil {
+		return nil, fmt.Errorf("failed to find revisions of user[id=%s]: %w", id, err)
+	}
+
+	bizRevisions := make([]*biz.UserRevision, 0, len(revisions))
+	for _, revision := range revisions {
+		bizRevisions = append(bizRevisions, r.toBizUserRevision(&revision))
+	}
+	return &biz.UserRevisionListResult{
+		TotalCount: totalCount,
+		Revisions:  bizRevisions,
+	}, nil
+}
+
+// GetUserRevisionAt implements biz.UserRepo.
+func (r *userRepo) GetUserRevisionAt(ctx context.Context, id string, at time.Time) (*biz.UserRevision, error) {
+	var revision model.UserRevision
+	err := r.db.Read(ctx).
+		Where("user_id = ? AND created_at <= ?", id, at).
+		Order("revision DESC").
+		First(&revision).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get revision of user[id=%s] at %s: %w", id, at.Format(time.RFC3339), err)
+	}
+	return r.toBizUserRevision(&revision), nil
+}
+
+// RestoreUserRevision implements biz.UserRepo.
+func (r *userRepo) RestoreUserRevision(ctx context.Context, id string, revision int64, operator string) (*biz.User, error) {
+	var user model.User
+	err := r.db.InTx(ctx, func(ctx context.Context) error {
+		tx := r.db.Conn(ctx)
+		var target model.UserRevision
+		if err := tx.
+			Where("user_id = ? AND revision = ?", id, revision).
+			First(&target).Error; err != nil {
+			return fmt.Errorf("failed to get revision[%d] of user[id=%s]: %w", revision, id, err)
+		}
+		if target.Action == model.UserRevisionActionDelete {
+			return fmt.Errorf("revision[%d] records a deletion: %w", revision, biz.ErrRevisionNotRestorable)
+		}
+
+		locked, err := lockUser(tx, id)
+		if err != nil {
+			return err
+		}
+		user = *locked
+		before := user
+
+		// The email and phone are only recorded by their blind index, they cannot be
+		// restored, so the revision must not differ from the user on them.
+		snapshot := target.Snapshot
+		if snapshot.EmailIndex != user.EmailIndex || snapshot.PhoneIndex != user.PhoneIndex {
+			return fmt.Errorf("revision[%d] records other contacts than user[id=%s]: %w", revision, id, biz.ErrRevisionNotRestorable)
+		}
+		if snapshot.Username != user.Username {
+			if err := checkUsernameAvailable(tx, snapshot.Username, id); err != nil {
+				return err
+			}
+		}
+
+		user.Username = snapshot.Username
+		user.Role = snapshot.Role
+		user.Status = snapshot.Status
+		user.UpdatedBy = operator
+		user.UpdatedAt = time.Now()
+		user.Version++
+		if err := tx.Model(&model.User{}).
+			Where("id = ?", id).
+			Updates(map[string]any{
+				"username":   user.Username,
+				"role":       user.Role,
+				"status":     user.Status,
+				"updated_by": user.UpdatedBy,
+				"updated_at": user.UpdatedAt,
+				"version":    user.Version,
+			}).Error; err != nil {
+			return fmt.Errorf("failed to restore user by id[%s]: %w", id, usernameConflict(err, user.Username))
+		}
+		if err := revokeChangedOAuthGrants(tx, &before, &user); err != nil {
+			return err
+		}
+		return recordUserRevision(tx, &before, &user, model.UserRevisionActionRestore, operator)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.toBizUser(&user), nil
+}
+
+// Record a revision of the user inside the given transaction.
+//
+// `before` is nil when the user has just been created. The user row is locked first, so
+// concurrent changes to the user number their revisions one after the other instead of
+// colliding on the unique index; the row may be soft-deleted, by a deletion.
+func recordUserRevision(tx *gorm.DB, before, after *model.User, action model.UserRevisionAction, operator string) error {
+	var locked model.User
+	if err := tx.Unscoped().
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		Where("id = ?", after.ID).
+		Take(&locked).Error; err != nil {
+		return fmt.Errorf("failed to lock user[id=%s]: %w", after.ID, err)
+	}
+
+	var latest int64
+	if err := tx.Model(&model.UserRevision{}).
+		Select("COALESCE(MAX(revision), 0)").
+		Where("user_id = ?", after.ID).
+		Scan(&latest).Error; err != nil {
+		return fmt.Errorf("failed to get latest revision of user[id=%s]: %w", after.ID, err)
+	}
+
+	var previous model.UserSnapshot
+	if before != nil {
+		previous = before.Snapshot()
+	}
+	current := after.Snapshot()
+	revision := model.UserRevision{
+		UserID:   after.ID,
+		Revision: latest + 1,
+		Action:   action,
+		Snapshot: current,
+		Changes:  diffUserSnapshots(previous, current),
+		Operator: operator,
+	}
+	if err := tx.Create(&revision).Error; err != nil {
+		return fmt.Errorf("failed to record revision of user[id=%s]: %w", after.ID, err)
+	}
+	return nil
+}
+
+// Return the field-level changes between two snapshots of a user.
+func diffUserSnapshots(before, after model.UserSnapshot) []model.UserFieldChange {
+	changes := make([]model.UserFieldChange, 0)
+	if before.Username != after.Username {
+		changes = append(changes, model.UserFieldChange{
+			Field:    "username",
+			OldValue: before.Username,
+			NewValue: after.Username,
+		})
+	}
+	if before.Role != after.Role {
+		changes = append(changes, model.UserFieldChange{
+			Field:    "role",
+			OldValue: before.Role.String(),
+			NewValue: after.Role.String(),
+		})
+	}
+	if before.Status != after.Status {
+		changes = append(changes, model.UserFieldChange{
+			Field:    "status",
+			OldValue: before.Status.String(),
+			NewValue: after.Status.String(),
+		})
+	}
+	// Only the fact the personal data changed is recorded, not its value
+	if before.EmailIndex != after.EmailIndex {
+		changes = append(changes, model.UserFieldChange{
+			Field:    "email",
+			OldValue: redacted(before.EmailIndex),
+			NewValue: redacted(after.EmailIndex),
+		})
+	}
+	if before.PhoneIndex != after.PhoneIndex {
+		changes = append(changes, model.UserFieldChange{
+			Field:    "phone",
+			OldValue: redacted(before.PhoneIndex),
+			NewValue: redacted(after.PhoneIndex),
+		})
+	}
+	return changes
+}
+
+// Return the placeholder of a redacted value, empty if there is no value.
+func redacted(index string) string {
+	if index == "" {
+		return ""
+	}
+	return "[redacted]"
+}
+
+// Convert model UserRevision to biz UserRevision.
+func (r *userRepo) toBizUserRevision(rev *model.UserRevision) *biz.UserRevision {
+	if rev == nil {
+		return nil
+	}
+
+	changes := make([]biz.UserFieldChange, 0, len(rev.Changes))
+	for _, change := range rev.Changes {
+		changes = append(changes, biz.UserFieldChange{
+			Field:    change.Field,
+			OldValue: change.OldValue,
+			NewValue: change.NewValue,
+		})
+	}
+	snapshot := rev.Snapshot
+	return &biz.UserRevision{
+		UserID:   rev.UserID,
+		Revision: rev.Revision,
+		Action:   biz.UserRevisionAction(rev.Action),
+		Snapshot: &biz.User{
+			ID:        rev.UserID,
+			Username:  snapshot.Username,
+			Role:      biz.UserRole(snapshot.Role),
+			Status:    biz.UserStatus(snapshot.Status),
+			Creator:   snapshot.Creator,
+			CreatedAt: snapshot.CreatedAt,
+			UpdatedBy: snapshot.UpdatedBy,
+			UpdatedAt: snapshot.UpdatedAt,
+		},
+		Changes:   changes,
+		Operator:  rev.Operator,
+		CreatedAt: rev.CreatedAt,
+	}
+}
@@ -0,0 +1,144 @@
+package data
+
+import (
+	"context"
+	"testing"
+	"time"
+	"usermanage/internal/biz"
+	"usermanage/internal/data/model"
+	"usermanage/internal/pkg/constants"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserRepo_Revisions(t *testing.T) {
+	repo := newTestUserRepo(t)
+	ctx := context.Background()
+
+	user := createTestUser(t, repo, "foo")
+	// The timestamps are compared, the revisions must not be recorded in the same instant
+	time.Sleep(10 * time.Millisecond)
+	created := time.Now()
+	time.Sleep(10 * time.Millisecond)
+
+	admin := int32(biz.UserRoleAdmin)
+	locked := int32(biz.UserStatusLocked)
+	_, err := repo.UpdateUser(ctx, user.ID, biz.UserUpdateParams{Role: &admin, UpdatedBy: "admin"})
+	require.NoError(t, err)
+	_, err = repo.UpdateUser(ctx, user.ID, biz.UserUpdateParams{Status: &locked, UpdatedBy: "admin"})
+	require.NoError(t, err)
+
+	t.Run("record", func(t *testing.T) {
+		result, err := repo.ListUserRevisions(ctx, user.ID, biz.UserRevisionListParams{})
+		require.NoError(t, err)
+		require.EqualValues(t, 3, result.TotalCount)
+		latest := result.Revisions[0]
+		assert.EqualValues(t, 3, latest.Revision)
+		assert.Equal(t, biz.UserRevisionActionUpdate, latest.Action)
+		assert.Equal(t, "admin", latest.Operator)
+		assert.Equal(t, []biz.UserFieldChange{{Field: "status", OldValue: "normal", NewValue: "locked"}}, latest.Changes)
+		assert.Equal(t, biz.UserRevisionActionCreate, result.Revisions[2].Action)
+	})
+
+	t.Run("point in time", func(t *testing.T) {
+		revision, err := repo.GetUserRevisionAt(ctx, user.ID, created)
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, revision.Revision)
+		assert.Equal(t, biz.UserRoleUser, revision.Snapshot.Role)
+
+		revision, err = repo.GetUserRevisionAt(ctx, user.ID, time.Now())
+		require.NoError(t, err)
+		assert.EqualValues(t, 3, revision.Revision)
+
+		_, err = repo.GetUserRevisionAt(ctx, user.ID, created.Add(-time.Hour))
+		assert.Error(t, err)
+	})
+
+	t.Run("restore", func(t *testing.T) {
+		restored, err := repo.RestoreUserRevision(ctx, user.ID, 1, "admin")
+		require.NoError(t, err)
+		assert.Equal(t, biz.UserRoleUser, restored.Role)
+		assert.Equal(t, biz.UserStatusNormal, restored.Status)
+
+		result, err := repo.ListUserRevisions(ctx, user.ID, biz.UserRevisionListParams{})
+		require.NoError(t, err)
+		assert.EqualValues(t, 4, result.Revisions[0].Revision)
+		assert.Equal(t, biz.UserRevisionActionRestore, result.Revisions[0].Action)
+
+		_, err = repo.RestoreUserRevision(ctx, user.ID, 42, "admin")
+		assert.Error(t, err)
+	})
+
+	t.Run("deletion", func(t *testing.T) {
+		require.NoError(t, repo.DeleteUser(ctx, user.ID))
+		result, err := repo.ListUserRevisions(ctx, user.ID, biz.UserRevisionListParams{})
+		require.NoError(t, err)
+		assert.EqualValues(t, 5, result.Revisions[0].Revision)
+		assert.Equal(t, biz.UserRevisionActionDelete, result.Revisions[0].Action)
+
+		// A deletion is undone by undeleting the user, not by restoring its revision
+		_, err = repo.RestoreUserRevision(ctx, user.ID, 5, "admin")
+		assert.Error(t, err)
+	})
+}
+
+func TestData_InitializeAdminAccount(t *testing.T) {
+	database := newTestDatabase(t)
+	d, err := NewData(database, nil, nil, log.DefaultLogger)
+	require.NoError(t, err)
+	repo := NewUserRepo(database, nil, log.DefaultLogger)
+	ctx := context.Background()
+
+	require.NoError(t, d.InitializeAdminAccount(ctx))
+	admin, err := repo.GetUserByUsername(ctx, "admin")
+	require.NoError(t, err)
+	assert.Equal(t, biz.UserRoleAdmin, admin.Role)
+
+	result, err := repo.ListUserRevisions(ctx, admin.ID, biz.UserRevisionListParams{})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, result.TotalCount)
+	assert.Equal(t, biz.UserRevisionActionCreate, result.Revisions[0].Action)
+
+	// Idempotent, the admin is created once
+	require.NoError(t, d.InitializeAdminAccount(ctx))
+	result, err = repo.ListUserRevisions(ctx, admin.ID, biz.UserRevisionListParams{})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, result.TotalCount)
+}
+
+func TestDiffUserSnapshots(t *testing.T) {
+	before := model.UserSnapshot{
+		Username: "foo",
+		Role:     constants.UserRoleUser,
+		Status:   constants.UserStatusNormal,
+	}
+
+	t.Run("Created", func(t *testing.T) {
+		changes := diffUserSnapshots(model.UserSnapshot{}, before)
+		assert.Equal(t, []model.UserFieldChange{
+			{Field: "username", OldValue: "", NewValue: "foo"},
+			{Field: "role", OldValue: "unknown", NewValue: "user"},
+			{Field: "status", OldValue: "unknown", NewValue: "normal"},
+		}, changes)
+	})
+
+	t.Run("Changed", func(t *testing.T) {
+		after := before
+		after.Role = constants.UserRoleAdmin
+		after.Status = constants.UserStatusLocked
+		after.UpdatedBy = "admin"
+
+		changes := diffUserSnapshots(before, after)
+		assert.Equal(t, []model.UserFieldChange{
+			{Field: "role", OldValue: "user", NewValue: "admin"},
+			{Field: "status", OldValue: "normal", NewValue: "locked"},
+		}, changes)
+	})
+
+	t.Run("Unchanged", func(t *testing.T) {
+		changes := diffUserSnapshots(before, before)
+		assert.Empty(t, changes)
+	})
+}
@@ -0,0 +1,569 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"usermanage/internal/biz"
+	"usermanage/internal/data/model"
+	"usermanage/internal/pkg/db"
+	"usermanage/internal/pkg/envelope"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newTestUserRepo(t *testing.T) biz.UserRepo {
+	t.Helper()
+	return NewUserRepo(newTestDatabase(t), nil, log.DefaultLogger)
+}
+
+func createTestUser(t *testing.T, repo biz.UserRepo, username string) *biz.User {
+	t.Helper()
+	user, err := repo.CreateUser(context.Background(), biz.UserCreateParams{
+		Username: username,
+		Password: "P@ssw0rd",
+		Role:     int32(biz.UserRoleUser),
+		Status:   int32(biz.UserStatusNormal),
+		Creator:  "admin",
+	})
+	require.NoError(t, err)
+	return user
+}
+
+func TestUserRepo_CreateUser(t *testing.T) {
+	repo := newTestUserRepo(t)
+	ctx := context.Background()
+
+	user := createTestUser(t, repo, "foo")
+	assert.NotEmpty(t, user.ID)
+	assert.Equal(t, "foo", user.Username)
+	assert.Equal(t, "admin", user.Creator)
+
+	got, err := repo.GetUserByUsername(ctx, "foo")
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, got.ID)
+
+	exists, err := repo.ExistsByUsername(ctx, "foo")
+	require.NoError(t, err)
+	assert.True(t, exists)
+	exists, err = repo.ExistsByID(ctx, "unknown")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	_, err = repo.CreateUser(ctx, biz.UserCreateParams{Username: "foo", Password: "P@ssw0rd"})
+	assert.ErrorIs(t, err, biz.ErrUsernameExists)
+
+	_, err = repo.FindByCredentials(ctx, "foo", "P@ssw0rd")
+	assert.NoError(t, err)
+	_, err = repo.FindByCredentials(ctx, "foo", "wrong")
+	assert.ErrorIs(t, err, biz.ErrInvalidCredentials)
+	_, err = repo.FindByCredentials(ctx, "unknown", "P@ssw0rd")
+	assert.ErrorIs(t, err, biz.ErrUserNotFound)
+}
+
+func TestUserRepo_CreateUserConcurrently(t *testing.T) {
+	repo := newTestUserRepo(t)
+
+	const n = 8
+	var wg sync.WaitGroup
+	var created atomic.Int32
+	for range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := repo.CreateUser(context.Background(), biz.UserCreateParams{Username: "foo", Password: "P@ssw0rd"})
+			if err == nil {
+				created.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), created.Load())
+}
+
+func TestUsernameConflict(t *testing.T) {
+	database := newTestDatabase(t)
+	tx := database.Conn(context.Background())
+	require.NoError(t, tx.Create(&model.User{Username: "foo"}).Error)
+
+	// The unique index rejects a duplicate which got past the check
+	err := tx.Create(&model.User{Username: "foo"}).Error
+	require.ErrorIs(t, err, gorm.ErrDuplicatedKey)
+	assert.EqualError(t, usernameConflict(err, "foo"), "username[foo] already exists: "+err.Error())
+	assert.ErrorIs(t, usernameConflict(err, "foo"), biz.ErrUsernameExists)
+
+	// Deleted users do not hold their username
+	require.NoError(t, tx.Where("username = ?", "foo").Delete(&model.User{}).Error)
+	assert.NoError(t, tx.Create(&model.User{Username: "foo"}).Error)
+}
+
+func TestUserRepo_ListUsers(t *testing.T) {
+	repo := newTestUserRepo(t)
+	ctx := context.Background()
+	for _, username := range []string{"foo", "bar", "baz"} {
+		createTestUser(t, repo, username)
+	}
+
+	result, err := repo.ListUsers(ctx, biz.UserListParams{Page: 1, PageSize: 2})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), result.TotalCount)
+	assert.Len(t, result.Users, 2)
+
+	t.Run("filtered", func(t *testing.T) {
+		locked := int32(biz.UserStatusLocked)
+		bar, err := repo.GetUserByUsername(ctx, "bar")
+		require.NoError(t, err)
+		_, err = repo.UpdateUser(ctx, bar.ID, biz.UserUpdateParams{Status: &locked})
+		require.NoError(t, err)
+
+		result, err := repo.ListUsers(ctx, biz.UserListParams{Page: 1, PageSize: 10, Username: "baz"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), result.TotalCount)
+		require.Len(t, result.Users, 1)
+		assert.Equal(t, "baz", result.Users[0].Username)
+
+		result, err = repo.ListUsers(ctx, biz.UserListParams{Page: 1, PageSize: 10, Status: locked})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), result.TotalCount)
+		require.Len(t, result.Users, 1)
+		assert.Equal(t, "bar", result.Users[0].Username)
+
+		result, err = repo.ListUsers(ctx, biz.UserListParams{Page: 1, PageSize: 10, Username: "baz", Status: locked})
+		require.NoError(t, err)
+		assert.Zero(t, result.TotalCount)
+		assert.Empty(t, result.Users)
+	})
+}
+
+func TestUserRepo_IterateUsers(t *testing.T) {
+	repo := newTestUserRepo(t)
+	ctx := context.Background()
+	for _, username := range []string{"foo", "bar", "baz", "qux", "quux"} {
+		createTestUser(t, repo, username)
+	}
+	locked := int32(biz.UserStatusLocked)
+	_, err := repo.UpdateUser(ctx, createTestUser(t, repo, "corge").ID, biz.UserUpdateParams{Status: &locked})
+	require.NoError(t, err)
+
+	var batches [][]*biz.User
+	err = repo.IterateUsers(ctx, biz.UserListParams{}, 2, func(users []*biz.User) error {
+		batches = append(batches, users)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, batches, 3)
+	var ids []string
+	for _, batch := range batches {
+		assert.Len(t, batch, 2)
+		for _, user := range batch {
+			ids = append(ids, user.ID)
+		}
+	}
+	assert.IsIncreasing(t, ids)
+
+	var usernames []string
+	err = repo.IterateUsers(ctx, biz.UserListParams{Status: locked}, 2, func(users []*biz.User) error {
+		for _, user := range users {
+			usernames = append(usernames, user.Username)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"corge"}, usernames)
+
+	result, err := repo.ListUsers(ctx, biz.UserListParams{Username: "qux"})
+	require.NoError(t, err)
+	require.Len(t, result.Users, 1)
+	assert.Equal(t, "qux", result.Users[0].Username)
+
+	stop := errors.New("stop")
+	err = repo.IterateUsers(ctx, biz.UserListParams{}, 2, func([]*biz.User) error { return stop })
+	assert.ErrorIs(t, err, stop)
+}
+
+func TestUserRepo_UpdateUser(t *testing.T) {
+	repo := newTestUserRepo(t)
+	ctx := context.Background()
+	user := createTestUser(t, repo, "foo")
+	createTestUser(t, repo, "bar")
+
+	username := "qux"
+	role := int32(biz.UserRoleAdmin)
+	updated, err := repo.UpdateUser(ctx, user.ID, biz.UserUpdateParams{Username: &username, Role: &role, UpdatedBy: "admin"})
+	require.NoError(t, err)
+	assert.Equal(t, "qux", updated.Username)
+	assert.Equal(t, biz.UserRoleAdmin, updated.Role)
+
+	taken := "bar"
+	_, err = repo.UpdateUser(ctx, user.ID, biz.UserUpdateParams{Username: &taken})
+	assert.Error(t, err)
+
+	revisions, err := repo.ListUserRevisions(ctx, user.ID, biz.UserRevisionListParams{})
+	require.NoError(t, err)
+	require.Len(t, revisions.Revisions, 2)
+	assert.Equal(t, int64(2), revisions.Revisions[0].Revision)
+}
+
+func TestUserRepo_Version(t *testing.T) {
+	repo := newTestUserRepo(t)
+	ctx := context.Background()
+	user := createTestUser(t, repo, "foo")
+	assert.Equal(t, int64(1), user.Version)
+
+	// Every change increments the version
+	role := int32(biz.UserRoleAdmin)
+	updated, err := repo.UpdateUser(ctx, user.ID, biz.UserUpdateParams{Role: &role})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), updated.Version)
+
+	replaced, err := repo.ReplaceUser(ctx, user.ID, biz.UserReplaceParams{Username: "foo", Role: role, Status: int32(biz.UserStatusDisabled)})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), replaced.Version)
+
+	reset, err := repo.ResetUserPassword(ctx, user.ID, "N3wP@ssw0rd")
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), reset.Version)
+
+	restored, err := repo.RestoreUserRevision(ctx, user.ID, 1, "admin")
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), restored.Version)
+
+	require.NoError(t, repo.DeleteUser(ctx, user.ID))
+	undeleted, err := repo.UndeleteUser(ctx, user.ID, "admin")
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), undeleted.Version)
+
+	got, err := repo.GetUserByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), got.Version)
+}
+
+func TestUserRepo_DeleteUser(t *testing.T) {
+	repo := newTestUserRepo(t)
+	ctx := context.Background()
+	user := createTestUser(t, repo, "foo")
+
+	require.NoError(t, repo.DeleteUser(ctx, user.ID))
+	_, err := repo.GetUserByID(ctx, user.ID)
+	assert.ErrorIs(t, err, biz.ErrUserNotFound)
+
+	deleted, err := repo.GetDeletedUserByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, deleted.DeletedAt)
+
+	// The username of a deleted user can be reused
+	other := createTestUser(t, repo, "foo")
+	_, err = repo.UndeleteUser(ctx, user.ID, "admin")
+	assert.Error(t, err)
+
+	require.NoError(t, repo.DeleteUser(ctx, other.ID))
+	undeleted, err := repo.UndeleteUser(ctx, user.ID, "admin")
+	require.NoError(t, err)
+	assert.Nil(t, undeleted.DeletedAt)
+
+	require.NoError(t, repo.PurgeUser(ctx, other.ID))
+	_, err = repo.GetDeletedUserByID(ctx, other.ID)
+	assert.Error(t, err)
+}
+
+func TestUserRepo_PurgeUser(t *testing.T) {
+	database := newTestDatabase(t)
+	repo := NewUserRepo(database, nil, log.DefaultLogger)
+	events := NewEventRepo(database, log.DefaultLogger)
+	ctx := context.Background()
+
+	// Give the user a row in every table keyed by it
+	seed := func(user *biz.User) {
+		t.Helper()
+		event := biz.NewUserEvent(biz.EventTypeUserCreated, user, "admin")
+		require.NoError(t, events.Append(ctx, event))
+		delivery := model.WebhookDelivery{WebhookID: "w1", EventID: event.ID, Payload: "{}"}
+		for _, row := range []any{
+			&delivery,
+			&model.ExternalIdentity{Provider: "corp", Subject: user.ID, UserID: user.ID},
+			&model.OAuthAuthorizationCode{Code: "code-" + user.ID, UserID: user.ID, Username: user.Username},
+			&model.OAuthRefreshToken{Token: "token-" + user.ID, UserID: user.ID, Username: user.Username},
+			&model.OAuthConsent{Username: user.Username, ClientID: "c1"},
+		} {
+			require.NoError(t, database.DB.Create(row).Error)
+		}
+		require.NoError(t, database.DB.Create(&model.WebhookDeliveryAttempt{DeliveryID: delivery.ID, Attempt: 1}).Error)
+	}
+	count := func(m any) int64 {
+		t.Helper()
+		var n int64
+		require.NoError(t, database.DB.Model(m).Count(&n).Error)
+		return n
+	}
+
+	purged := createTestUser(t, repo, "foo")
+	kept := createTestUser(t, repo, "bar")
+	seed(purged)
+	seed(kept)
+
+	require.NoError(t, repo.DeleteUser(ctx, purged.ID))
+	require.NoError(t, repo.PurgeUser(ctx, purged.ID))
+	assert.Error(t, repo.PurgeUser(ctx, purged.ID))
+
+	for _, m := range []any{
+		&model.User{},
+		&model.OutboxEvent{},
+		&model.WebhookDelivery{},
+		&model.WebhookDeliveryAttempt{},
+		&model.ExternalIdentity{},
+		&model.OAuthAuthorizationCode{},
+		&model.OAuthRefreshToken{},
+		&model.OAuthConsent{},
+	} {
+		assert.EqualValues(t, 1, count(m), "%T of the other user only", m)
+	}
+	var revisions int64
+	require.NoError(t, database.DB.Model(&model.UserRevision{}).Where("user_id = ?", purged.ID).Count(&revisions).Error)
+	assert.Zero(t, revisions)
+
+	t.Run("consents of a reused username are kept", func(t *testing.T) {
+		require.NoError(t, repo.DeleteUser(ctx, kept.ID))
+		assert.Zero(t, count(&model.OAuthConsent{}), "deleted with the user")
+		reused := createTestUser(t, repo, "bar")
+		require.NoError(t, database.DB.Create(&model.OAuthConsent{Username: reused.Username, ClientID: "c1"}).Error)
+		require.NoError(t, repo.PurgeUser(ctx, kept.ID))
+		assert.EqualValues(t, 1, count(&model.OAuthConsent{}))
+		assert.Zero(t, count(&model.OAuthRefreshToken{}))
+	})
+}
+
+func TestUserRepo_OAuthGrants(t *testing.T) {
+	database := newTestDatabase(t)
+	repo := NewUserRepo(database, nil, log.DefaultLogger)
+	ctx := context.Background()
+
+	seed := func(user *biz.User) {
+		t.Helper()
+		for _, row := range []any{
+			&model.OAuthAuthorizationCode{Code: "code-" + user.ID, UserID: user.ID, Username: user.Username},
+			&model.OAuthRefreshToken{Token: "token-" + user.ID, UserID: user.ID, Username: user.Username},
+			&model.OAuthConsent{Username: user.Username, ClientID: "c1"},
+		} {
+			require.NoError(t, database.DB.Create(row).Error)
+		}
+	}
+	count := func(m any, user *biz.User) int64 {
+		t.Helper()
+		var n int64
+		require.NoError(t, database.DB.Model(m).Where("username = ?", user.Username).Count(&n).Error)
+		return n
+	}
+	assertGrants := func(user *biz.User, tokens, consents int64) {
+		t.Helper()
+		assert.Equal(t, tokens, count(&model.OAuthAuthorizationCode{}, user), "authorization codes")
+		assert.Equal(t, tokens, count(&model.OAuthRefreshToken{}, user), "refresh tokens")
+		assert.Equal(t, consents, count(&model.OAuthConsent{}, user), "consents")
+	}
+
+	t.Run("password reset", func(t *testing.T) {
+		user := createTestUser(t, repo, "reset")
+		seed(user)
+		_, err := repo.ResetUserPassword(ctx, user.ID, "N3wP@ssw0rd")
+		require.NoError(t, err)
+		assertGrants(user, 0, 1)
+	})
+
+	t.Run("status", func(t *testing.T) {
+		user := createTestUser(t, repo, "disabled")
+		seed(user)
+		role := int32(biz.UserRoleAdmin)
+		_, err := repo.UpdateUser(ctx, user.ID, biz.UserUpdateParams{Role: &role})
+		require.NoError(t, err)
+		assertGrants(user, 1, 1)
+
+		status := int32(biz.UserStatusDisabled)
+		_, err = repo.UpdateUser(ctx, user.ID, biz.UserUpdateParams{Status: &status})
+		require.NoError(t, err)
+		assertGrants(user, 0, 1)
+	})
+
+	t.Run("rename", func(t *testing.T) {
+		user := createTestUser(t, repo, "renamed")
+		seed(user)
+		username := "other"
+		_, err := repo.UpdateUser(ctx, user.ID, biz.UserUpdateParams{Username: &username})
+		require.NoError(t, err)
+		assertGrants(user, 1, 0)
+	})
+
+	t.Run("restore a locked revision", func(t *testing.T) {
+		user := createTestUser(t, repo, "restored-locked")
+		locked, normal := int32(biz.UserStatusLocked), int32(biz.UserStatusNormal)
+		_, err := repo.UpdateUser(ctx, user.ID, biz.UserUpdateParams{Status: &locked})
+		require.NoError(t, err)
+		_, err = repo.UpdateUser(ctx, user.ID, biz.UserUpdateParams{Status: &normal})
+		require.NoError(t, err)
+		seed(user)
+		_, err = repo.RestoreUserRevision(ctx, user.ID, 2, "admin")
+		require.NoError(t, err)
+		assertGrants(user, 0, 1)
+	})
+
+	t.Run("restore a renamed revision", func(t *testing.T) {
+		user := createTestUser(t, repo, "restored")
+		username := "restored-renamed"
+		renamed, err := repo.UpdateUser(ctx, user.ID, biz.UserUpdateParams{Username: &username})
+		require.NoError(t, err)
+		seed(renamed)
+		_, err = repo.RestoreUserRevision(ctx, user.ID, 1, "admin")
+		require.NoError(t, err)
+		assertGrants(renamed, 1, 0)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		user := createTestUser(t, repo, "deleted")
+		kept := createTestUser(t, repo, "kept")
+		seed(user)
+		seed(kept)
+		require.NoError(t, repo.DeleteUser(ctx, user.ID))
+		assertGrants(user, 0, 0)
+		assertGrants(kept, 1, 1)
+	})
+}
+
+// Create an envelope for the encrypted fields, with the keys `previous` and `current` and
+// `current` as the current key when `rotated`.
+func newTestEnvelope(t *testing.T, rotated bool) *envelope.Envelope {
+	t.Helper()
+
+	current := "previous"
+	if rotated {
+		current = "current"
+	}
+	provider, err := envelope.NewLocalKeyProvider(map[string][]byte{
+		"previous": bytes.Repeat([]byte("p"), 32),
+		"current":  bytes.Repeat([]byte("c"), 32),
+	}, current)
+	require.NoError(t, err)
+	env, err := envelope.New(provider, bytes.Repeat([]byte("i"), 32))
+	require.NoError(t, err)
+	db.SetFieldEnvelope(env)
+	t.Cleanup(func() { db.SetFieldEnvelope(nil) })
+	return env
+}
+
+func TestUserRepo_PersonalData(t *testing.T) {
+	database := newTestDatabase(t)
+	repo := NewUserRepo(database, newTestEnvelope(t, false), log.DefaultLogger)
+	ctx := context.Background()
+
+	user, err := repo.CreateUser(ctx, biz.UserCreateParams{
+		Username: "foo",
+		Password: "P@ssw0rd",
+		Role:     int32(biz.UserRoleUser),
+		Status:   int32(biz.UserStatusNormal),
+		Email:    "Foo@Example.com",
+		Phone:    "+15550100",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Foo@Example.com", user.Email)
+
+	// Stored encrypted, with a blind index
+	var row struct{ Email, EmailIndex, Phone, PhoneIndex string }
+	require.NoError(t, database.Table("users").Where("id = ?", user.ID).Take(&row).Error)
+	assert.True(t, envelope.IsEncrypted(row.Email))
+	assert.True(t, envelope.IsEncrypted(row.Phone))
+	assert.NotContains(t, row.Email, "Example")
+	assert.Len(t, row.EmailIndex, 64)
+	assert.Len(t, row.PhoneIndex, 64)
+
+	got, err := repo.GetUserByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Foo@Example.com", got.Email)
+	assert.Equal(t, "+15550100", got.Phone)
+
+	// Looked up by email, case-insensitively
+	createTestUser(t, repo, "bar")
+	result, err := repo.ListUsers(ctx, biz.UserListParams{Email: "foo@example.com"})
+	require.NoError(t, err)
+	require.Len(t, result.Users, 1)
+	assert.Equal(t, user.ID, result.Users[0].ID)
+
+	// Removing the phone clears its index, the revision does not record the values
+	empty := ""
+	updated, err := repo.UpdateUser(ctx, user.ID, biz.UserUpdateParams{Phone: &empty})
+	require.NoError(t, err)
+	assert.Empty(t, updated.Phone)
+	assert.Equal(t, "Foo@Example.com", updated.Email)
+	revisions, err := repo.ListUserRevisions(ctx, user.ID, biz.UserRevisionListParams{})
+	require.NoError(t, err)
+	assert.Equal(t, []biz.UserFieldChange{{Field: "phone", OldValue: "[redacted]"}}, revisions.Revisions[0].Changes)
+
+	// The revisions before cannot be restored, they do not record the phone
+	_, err = repo.RestoreUserRevision(ctx, user.ID, 1, "admin")
+	assert.ErrorIs(t, err, biz.ErrRevisionNotRestorable)
+
+	// Values are bound to their row, one copied to another row cannot be read
+	bar, err := repo.GetUserByUsername(ctx, "bar")
+	require.NoError(t, err)
+	require.NoError(t, database.Table("users").Where("id = ?", bar.ID).Update("email", row.Email).Error)
+	_, err = repo.GetUserByID(ctx, bar.ID)
+	assert.Error(t, err)
+
+	// Without keys, encrypted values can neither be read nor written
+	db.SetFieldEnvelope(nil)
+	_, err = repo.GetUserByID(ctx, user.ID)
+	assert.ErrorIs(t, err, db.ErrEncryptionNotConfigured)
+}
+
+func TestData_RotateKeys(t *testing.T) {
+	database := newTestDatabase(t)
+	repo := NewUserRepo(database, newTestEnvelope(t, false), log.DefaultLogger)
+	ctx := context.Background()
+
+	var ids []string
+	for _, username := range []string{"foo", "bar", "baz"} {
+		user, err := repo.CreateUser(ctx, biz.UserCreateParams{Username: username, Password: "P@ssw0rd", Email: username + "@example.com"})
+		require.NoError(t, err)
+		ids = append(ids, user.ID)
+	}
+	createTestUser(t, repo, "qux")
+	require.NoError(t, repo.DeleteUser(ctx, ids[2]))
+
+	env := newTestEnvelope(t, true)
+
+	// Written by earlier versions with the current key, but not bound to its row
+	legacy, err := env.Encrypt(ctx, "qux@example.com", "users.email")
+	require.NoError(t, err)
+	require.NoError(t, database.Table("users").Where("username = ?", "qux").Update("email", legacy).Error)
+	qux, err := repo.GetUserByUsername(ctx, "qux")
+	require.NoError(t, err)
+	assert.Equal(t, "qux@example.com", qux.Email)
+
+	d, err := NewData(database, nil, env, log.DefaultLogger)
+	require.NoError(t, err)
+	updated, err := d.RotateKeys(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 4, updated, "users without personal data are skipped, deleted users are not")
+
+	var emails []string
+	require.NoError(t, database.Table("users").Where("email <> ''").Pluck("email", &emails).Error)
+	require.Len(t, emails, 4)
+	assert.NotContains(t, emails, legacy)
+	for _, email := range emails {
+		assert.False(t, env.NeedsRotation(email))
+	}
+
+	// Nothing left to rotate
+	updated, err = d.RotateKeys(ctx, 2)
+	require.NoError(t, err)
+	assert.Zero(t, updated)
+
+	got, err := NewUserRepo(database, env, log.DefaultLogger).GetUserByID(ctx, ids[0])
+	require.NoError(t, err)
+	assert.Equal(t, "foo@example.com", got.Email)
+	got, err = NewUserRepo(database, env, log.DefaultLogger).GetUserByID(ctx, qux.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "qux@example.com", got.Email)
+}
@@ -0,0 +1,240 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"usermanage/internal/biz"
+	"usermanage/internal/data/model"
+	"usermanage/internal/pkg/db"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+type webhookRepo struct {
+	db     *db.Database
+	logger *log.Helper
+}
+
+// NewWebhookRepo creates a new webhook repository.
+func NewWebhookRepo(db *db.Database, logger log.Logger) biz.WebhookRepo {
+	return &webhookRepo{
+		db:     db,
+		logger: log.NewHelper(logger),
+	}
+}
+
+// ListWebhooks implements biz.WebhookRepo.
+func (r *webhookRepo) ListWebhooks(ctx context.Context, params biz.WebhookListParams) (*biz.WebhookListResult, error) {
+	var totalCount int64
+	var hooks []model.Webhook
+
+	query := r.db.Conn(ctx).Model(&model.Webhook{})
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, fmt.Errorf("failed to count webhooks: %w", err)
+	}
+
+	page, pageSize := params.GetPage()
+	offset := (page - 1) * pageSize
+	if err := query.
+		Offset(int(offset)).
+		Limit(int(pageSize)).
+		Order("created_at DESC").
+		Find(&hooks).Error; err != nil {
+		return nil, fmt.Errorf("failed to find webhooks: %w", err)
+	}
+
+	bizHooks := make([]*biz.Webhook, 0, len(hooks))
+	for _, hook := range hooks {
+		bizHooks = append(bizHooks, r.toBizWebhook(&hook))
+	}
+	return &biz.WebhookListResult{
+		TotalCount: totalCount,
+		Webhooks:   bizHooks,
+	}, nil
+}
+
+// GetWebhook implements biz.WebhookRepo.
+func (r *webhookRepo) GetWebhook(ctx context.Context, id string) (*biz.Webhook, error) {
+	var hook model.Webhook
+	if err := r.db.Conn(ctx).Where("id = ?", id).First(&hook).Error; err != nil {
+		return nil, fmt.Errorf("failed to get webhook by id[%s]: %w", id, err)
+	}
+	return r.toBizWebhook(&hook), nil
+}
+
+// CreateWebhook implements biz.WebhookRepo.
+func (r *webhookRepo) CreateWebhook(ctx context.Context, params biz.WebhookCreateParams) (*biz.Webhook, error) {
+	hook := model.Webhook{
+		URL:         params.URL,
+		EventTypes:  params.EventTypes,
+		Secret:      params.Secret,
+		Description: params.Description,
+		Active:      true,
+		Creator:     params.Creator,
+	}
+	if err := r.db.Conn(ctx).Create(&hook).Error; err != nil {
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+	return r.toBizWebhook(&hook), nil
+}
+
+// DeleteWebhook implements biz.WebhookRepo.
+func (r *webhookRepo) DeleteWebhook(ctx context.Context, id string) error {
+	return r.db.InTx(ctx, func(ctx context.Context) error {
+		tx := r.db.Conn(ctx)
+		var hook model.Webhook
+		if err := tx.Where("id = ?", id).First(&hook).Error; err != nil {
+			return fmt.Errorf("failed to get webhook by id[%s]: %w", id, err)
+		}
+		if err := tx.Delete(&hook).Error; err != nil {
+			return fmt.Errorf("failed to delete webhook by id[%s]: %w", id, err)
+		}
+		if err := tx.Model(&model.WebhookDelivery{}).
+			Where("webhook_id = ? AND status IN ?", id, []model.WebhookDeliveryStatus{
+				model.WebhookDeliveryStatusPending,
+				model.WebhookDeliveryStatusRetrying,
+			}).
+			Updates(map[string]any{
+				"status":     model.WebhookDeliveryStatusDead,
+				"last_error": "webhook deleted",
+			}).Error; err != nil {
+			return fmt.Errorf("failed to drop pending deliveries of webhook[id=%s]: %w", id, err)
+		}
+		return nil
+	})
+}
+
+// ListDeliveries implements biz.WebhookRepo.
+func (r *webhookRepo) ListDeliveries(ctx context.Context, params biz.WebhookDeliveryListParams) (*biz.WebhookDeliveryListResult, error) {
+	var totalCount int64
+	var deliveries []model.WebhookDelivery
+
+	query := r.db.Conn(ctx).
+		Model(&model.WebhookDelivery{}).
+		Where("webhook_id = ?", params.WebhookID)
+	if params.Status != biz.WebhookDeliveryStatusUnknown {
+		query = query.Where("status = ?", params.Status)
+	}
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, fmt.Errorf("failed to count deliveries of webhook[id=%s]: %w", params.WebhookID, err)
+	}
+
+	page, pageSize := params.GetPage()
+	offset := (page - 1) * pageSize
+	if err := query.
+		Offset(int(offset)).
+		Limit(int(pageSize)).
+		Order("created_at DESC").
+		Find(&deliveries).Error; err != nil {
+		return nil, fmt.Errorf("failed to find deliveries of webhook[id=%s]: %w", params.WebhookID, err)
+	}
+
+	bizDeliveries := make([]*biz.WebhookDelivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		bizDeliveries = append(bizDeliveries, r.toBizWebhookDelivery(&delivery, nil))
+	}
+	return &biz.WebhookDeliveryListResult{
+		TotalCount: totalCount,
+		Deliveries: bizDeliveries,
+	}, nil
+}
+
+// GetDelivery implements biz.WebhookRepo.
+func (r *webhookRepo) GetDelivery(ctx context.Context, id string) (*biz.WebhookDelivery, error) {
+	tx := r.db.Conn(ctx)
+	var delivery model.WebhookDelivery
+	if err := tx.Where("id = ?", id).First(&delivery).Error; err != nil {
+		return nil, fmt.Errorf("failed to get webhook delivery by id[%s]: %w", id, err)
+	}
+	var attempts []model.WebhookDeliveryAttempt
+	if err := tx.Where("delivery_id = ?", id).Order("id").Find(&attempts).Error; err != nil {
+		return nil, fmt.Errorf("failed to find attempts of webhook delivery[id=%s]: %w", id, err)
+	}
+	return r.toBizWebhookDelivery(&delivery, attempts), nil
+}
+
+// RedeliverDelivery implements biz.WebhookRepo.
+func (r *webhookRepo) RedeliverDelivery(ctx context.Context, id string) (*biz.WebhookDelivery, error) {
+	err := r.db.InTx(ctx, func(ctx context.Context) error {
+		tx := r.db.Conn(ctx)
+		var delivery model.WebhookDelivery
+		if err := tx.Where("id = ?", id).First(&delivery).Error; err != nil {
+			return fmt.Errorf("failed to get webhook delivery by id[%s]: %w", id, err)
+		}
+		var count int64
+		if err := tx.Model(&model.Webhook{}).Where("id = ?", delivery.WebhookID).Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to check webhook exists by id[%s]: %w", delivery.WebhookID, err)
+		}
+		if count == 0 {
+			return fmt.Errorf("webhook[id=%s] has been deleted", delivery.WebhookID)
+		}
+
+		if err := tx.Model(&model.WebhookDelivery{}).
+			Where("id = ?", id).
+			Updates(map[string]any{
+				"status":          model.WebhookDeliveryStatusPending,
+				"attempts":        0,
+				"next_attempt_at": time.Now(),
+				"last_error":      "",
+			}).Error; err != nil {
+			return fmt.Errorf("failed to reschedule webhook delivery by id[%s]: %w", id, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.GetDelivery(ctx, id)
+}
+
+// Convert model Webhook to biz Webhook.
+func (r *webhookRepo) toBizWebhook(w *model.Webhook) *biz.Webhook {
+	if w == nil {
+		return nil
+	}
+
+	return &biz.Webhook{
+		ID:          w.ID,
+		URL:         w.URL,
+		EventTypes:  w.EventTypes,
+		Secret:      w.Secret,
+		Description: w.Description,
+		Active:      w.Active,
+		Creator:     w.Creator,
+		CreatedAt:   w.CreatedAt,
+		UpdatedAt:   w.UpdatedAt,
+	}
+}
+
+// Convert model WebhookDelivery and its attempts to biz WebhookDelivery.
+func (r *webhookRepo) toBizWebhookDelivery(d *model.WebhookDelivery, attempts []model.WebhookDeliveryAttempt) *biz.WebhookDelivery {
+	if d == nil {
+		return nil
+	}
+
+	var attemptLog []*biz.WebhookDeliveryAttempt
+	for _, attempt := range attempts {
+		attemptLog = append(attemptLog, &biz.WebhookDeliveryAttempt{
+			Attempt:    attempt.Attempt,
+			StatusCode: attempt.StatusCode,
+			Error:      attempt.Error,
+			Duration:   time.Duration(attempt.DurationMs) * time.Millisecond,
+			CreatedAt:  attempt.CreatedAt,
+		})
+	}
+	return &biz.WebhookDelivery{
+		ID:             d.ID,
+		WebhookID:      d.WebhookID,
+		EventID:        d.EventID,
+		EventType:      d.EventType,
+		Status:         biz.WebhookDeliveryStatus(d.Status),
+		Attempts:       d.Attempts,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		NextAttemptAt:  d.NextAttemptAt,
+		DeliveredAt:    d.DeliveredAt,
+		CreatedAt:      d.CreatedAt,
+		AttemptLog:     attemptLog,
+	}
+}
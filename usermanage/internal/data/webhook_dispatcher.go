@@ -0,0 +1,367 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+	"usermanage/gen/proto/conf"
+	"usermanage/internal/data/model"
+	"usermanage/internal/pkg/broker"
+	"usermanage/internal/pkg/db"
+	"usermanage/internal/pkg/webhook"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	defaultWebhookPollInterval   = time.Second
+	defaultWebhookBatchSize      = 50
+	defaultWebhookMaxAttempts    = 8
+	defaultWebhookTimeout        = 10 * time.Second
+	defaultWebhookInitialBackoff = 10 * time.Second
+	defaultWebhookMaxBackoff     = time.Hour
+
+	// maxWebhookErrorLen is the size of the error columns of deliveries and attempts.
+	maxWebhookErrorLen = 512
+)
+
+// webhookEnqueuer is the broker turning published events into webhook deliveries.
+//
+// The outbox relay publishes outside any transaction, so an event may be published again
+// after a failure to mark it as published: the unique `idx_webhook_event` index and
+// `OnConflict{DoNothing}` keep one delivery per webhook and event.
+type webhookEnqueuer struct {
+	db *db.Database
+}
+
+// Publish implements broker.Broker.
+//
+// Events are published at-least-once, a delivery already created for the same
+// webhook and event is left untouched.
+func (e *webhookEnqueuer) Publish(ctx context.Context, msgs ...*broker.Message) error {
+	tx := e.db.Conn(ctx)
+	var hooks []model.Webhook
+	if err := tx.Where("active = ?", true).Find(&hooks).Error; err != nil {
+		return fmt.Errorf("failed to find active webhooks: %w", err)
+	}
+	if len(hooks) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	var deliveries []model.WebhookDelivery
+	for _, msg := range msgs {
+		for _, hook := range hooks {
+			if !hook.Subscribes(msg.Type) {
+				continue
+			}
+			deliveries = append(deliveries, model.WebhookDelivery{
+				WebhookID:     hook.ID,
+				EventID:       msg.ID,
+				EventType:     msg.Type,
+				Payload:       string(msg.Payload),
+				Status:        model.WebhookDeliveryStatusPending,
+				NextAttemptAt: now,
+			})
+		}
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&deliveries).Error; err != nil {
+		return fmt.Errorf("failed to enqueue webhook deliveries: %w", err)
+	}
+	return nil
+}
+
+// Close implements broker.Broker.
+func (e *webhookEnqueuer) Close() error {
+	return nil
+}
+
+// WebhookDispatcher sends the pending webhook deliveries.
+//
+// It implements the kratos `transport.Server` interface so it runs alongside the
+// HTTP and gRPC servers. Due deliveries are claimed with `FOR UPDATE SKIP LOCKED`
+// and leased by pushing back their next attempt, so several instances may run at
+// the same time and no transaction is held open during HTTP requests.
+type WebhookDispatcher struct {
+	db             *db.Database
+	client         *http.Client
+	interval       time.Duration
+	batchSize      int
+	maxAttempts    int32
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	lease          time.Duration
+	logger         *log.Helper
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewWebhookDispatcher creates a new webhook dispatcher.
+func NewWebhookDispatcher(c *conf.Data, db *db.Database, logger log.Logger) *WebhookDispatcher {
+	cfg := c.GetWebhook()
+	interval := defaultWebhookPollInterval
+	if d := cfg.GetPollInterval(); d != nil && d.AsDuration() > 0 {
+		interval = d.AsDuration()
+	}
+	batchSize := defaultWebhookBatchSize
+	if n := cfg.GetBatchSize(); n > 0 {
+		batchSize = int(n)
+	}
+	maxAttempts := int32(defaultWebhookMaxAttempts)
+	if n := cfg.GetMaxAttempts(); n > 0 {
+		maxAttempts = n
+	}
+	timeout := defaultWebhookTimeout
+	if d := cfg.GetTimeout(); d != nil && d.AsDuration() > 0 {
+		timeout = d.AsDuration()
+	}
+	initialBackoff := defaultWebhookInitialBackoff
+	if d := cfg.GetInitialBackoff(); d != nil && d.AsDuration() > 0 {
+		initialBackoff = d.AsDuration()
+	}
+	maxBackoff := defaultWebhookMaxBackoff
+	if d := cfg.GetMaxBackoff(); d != nil && d.AsDuration() > 0 {
+		maxBackoff = d.AsDuration()
+	}
+
+	return &WebhookDispatcher{
+		db:             db,
+		client:         &http.Client{Timeout: timeout},
+		interval:       interval,
+		batchSize:      batchSize,
+		maxAttempts:    maxAttempts,
+		initialBackoff: initialBackoff,
+		maxBackoff:     maxBackoff,
+		// A batch is sent sequentially, the lease must outlive it
+		lease:  timeout*time.Duration(batchSize) + time.Minute,
+		logger: log.NewHelper(logger),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+}
+
+// Start implements transport.Server.
+//
+// It blocks until `Stop` is called or the context is done.
+func (d *WebhookDispatcher) Start(ctx context.Context) error {
+	defer close(d.done)
+	d.logger.Infow("msg", "webhook dispatcher started", "interval", d.interval, "batch_size", d.batchSize)
+
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-d.stop:
+			return nil
+		case <-ticker.C:
+			for {
+				n, err := d.DispatchOnce(ctx)
+				if err != nil {
+					d.logger.Errorw("msg", "failed to dispatch webhook deliveries", "error", err)
+					break
+				}
+				if n < d.batchSize {
+					break
+				}
+			}
+		}
+	}
+}
+
+// Stop implements transport.Server.
+func (d *WebhookDispatcher) Stop(ctx context.Context) error {
+	close(d.stop)
+	select {
+	case <-d.done:
+	case <-ctx.Done():
+	}
+	d.logger.Info("webhook dispatcher stopped")
+	return nil
+}
+
+// DispatchOnce sends one batch of due deliveries and returns how many were attempted.
+func (d *WebhookDispatcher) DispatchOnce(ctx context.Context) (int, error) {
+	deliveries, err := d.claim(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if len(deliveries) == 0 {
+		return 0, nil
+	}
+
+	webhookIDs := make([]string, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		webhookIDs = append(webhookIDs, delivery.WebhookID)
+	}
+	var hooks []model.Webhook
+	if err := d.db.Conn(ctx).Where("id IN ?", webhookIDs).Find(&hooks).Error; err != nil {
+		return 0, fmt.Errorf("failed to find webhooks of deliveries: %w", err)
+	}
+	hookByID := make(map[string]*model.Webhook, len(hooks))
+	for i := range hooks {
+		hookByID[hooks[i].ID] = &hooks[i]
+	}
+
+	for i := range deliveries {
+		delivery := &deliveries[i]
+		hook, ok := hookByID[delivery.WebhookID]
+		if !ok || !hook.Active {
+			if err := d.abandon(ctx, delivery, "webhook deleted or inactive"); err != nil {
+				return i, err
+			}
+			continue
+		}
+		if err := d.deliver(ctx, hook, delivery); err != nil {
+			return i, err
+		}
+	}
+	return len(deliveries), nil
+}
+
+// Claim the due deliveries by moving their next attempt past the lease.
+func (d *WebhookDispatcher) claim(ctx context.Context) ([]model.WebhookDelivery, error) {
+	var deliveries []model.WebhookDelivery
+	err := d.db.InTx(ctx, func(ctx context.Context) error {
+		tx := d.db.Conn(ctx)
+		now := time.Now()
+		if err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status IN ? AND next_attempt_at <= ?", []model.WebhookDeliveryStatus{
+				model.WebhookDeliveryStatusPending,
+				model.WebhookDeliveryStatusRetrying,
+			}, now).
+			Order("next_attempt_at").
+			Limit(d.batchSize).
+			Find(&deliveries).Error; err != nil {
+			return fmt.Errorf("failed to fetch due webhook deliveries: %w", err)
+		}
+		if len(deliveries) == 0 {
+			return nil
+		}
+
+		ids := make([]string, 0, len(deliveries))
+		for _, delivery := range deliveries {
+			ids = append(ids, delivery.ID)
+		}
+		if err := tx.Model(&model.WebhookDelivery{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(d.lease)).Error; err != nil {
+			return fmt.Errorf("failed to lease webhook deliveries: %w", err)
+		}
+		return nil
+	})
+	return deliveries, err
+}
+
+// Send the delivery to the webhook and record the attempt.
+func (d *WebhookDispatcher) deliver(ctx context.Context, hook *model.Webhook, delivery *model.WebhookDelivery) error {
+	start := time.Now()
+	statusCode, sendErr := d.send(ctx, hook, delivery)
+	duration := time.Since(start)
+
+	now := time.Now()
+	attempt := model.WebhookDeliveryAttempt{
+		DeliveryID: delivery.ID,
+		Attempt:    delivery.Attempts + 1,
+		StatusCode: int32(statusCode),
+		DurationMs: duration.Milliseconds(),
+		CreatedAt:  now,
+	}
+	updates := map[string]any{
+		"attempts":         attempt.Attempt,
+		"last_status_code": attempt.StatusCode,
+	}
+	switch {
+	case sendErr == nil:
+		updates["status"] = model.WebhookDeliveryStatusSucceeded
+		updates["last_error"] = ""
+		updates["delivered_at"] = now
+	case attempt.Attempt >= d.maxAttempts:
+		attempt.Error = truncateWebhookError(sendErr.Error())
+		updates["status"] = model.WebhookDeliveryStatusDead
+		updates["last_error"] = attempt.Error
+		d.logger.Warnw("msg", "webhook delivery is dead", "delivery.id", delivery.ID, "webhook.id", hook.ID, "attempts", attempt.Attempt, "error", sendErr)
+	default:
+		attempt.Error = truncateWebhookError(sendErr.Error())
+		updates["status"] = model.WebhookDeliveryStatusRetrying
+		updates["last_error"] = attempt.Error
+		updates["next_attempt_at"] = now.Add(webhook.Backoff(attempt.Attempt, d.initialBackoff, d.maxBackoff))
+	}
+
+	return d.db.InTx(ctx, func(ctx context.Context) error {
+		tx := d.db.Conn(ctx)
+		if err := tx.Create(&attempt).Error; err != nil {
+			return fmt.Errorf("failed to record attempt of webhook delivery[id=%s]: %w", delivery.ID, err)
+		}
+		if err := tx.Model(&model.WebhookDelivery{}).
+			Where("id = ?", delivery.ID).
+			Updates(updates).Error; err != nil {
+			return fmt.Errorf("failed to update webhook delivery[id=%s]: %w", delivery.ID, err)
+		}
+		return nil
+	})
+}
+
+// Post the signed payload to the webhook and return the response status code.
+//
+// Any status code outside of 2xx is an error.
+func (d *WebhookDispatcher) send(ctx context.Context, hook *model.Webhook, delivery *model.WebhookDelivery) (int, error) {
+	payload := []byte(delivery.Payload)
+	timestamp := time.Now().Unix()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "usermanage-webhook")
+	req.Header.Set(webhook.HeaderDeliveryID, delivery.ID)
+	req.Header.Set(webhook.HeaderEvent, delivery.EventType)
+	req.Header.Set(webhook.HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(webhook.HeaderSignature, webhook.Sign(hook.Secret, timestamp, payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	// Drain a bounded part of the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// Move the delivery to the dead-letter state without attempting it.
+func (d *WebhookDispatcher) abandon(ctx context.Context, delivery *model.WebhookDelivery, reason string) error {
+	if err := d.db.Conn(ctx).
+		Model(&model.WebhookDelivery{}).
+		Where("id = ?", delivery.ID).
+		Updates(map[string]any{
+			"status":     model.WebhookDeliveryStatusDead,
+			"last_error": reason,
+		}).Error; err != nil {
+		return fmt.Errorf("failed to abandon webhook delivery[id=%s]: %w", delivery.ID, err)
+	}
+	return nil
+}
+
+// Return the error message cut to the size of the error columns.
+func truncateWebhookError(msg string) string {
+	if len(msg) > maxWebhookErrorLen {
+		return msg[:maxWebhookErrorLen]
+	}
+	return msg
+}
@@ -6,4 +6,18 @@ import (
 	"github.com/google/wire"
 )
 
-var ProviderSet = wire.NewSet(db.ProviderSet, NewUserRepo, NewRedisTokenRepo, NewData)
+var ProviderSet = wire.NewSet(
+	db.ProviderSet,
+	NewTransaction,
+	NewCachedUserRepo,
+	NewTokenRepo,
+	NewEventRepo,
+	NewWebhookRepo,
+	NewOAuthRepo,
+	NewFederationRepo,
+	NewEventBroker,
+	NewOutboxRelay,
+	NewWebhookDispatcher,
+	NewSessionSweeper,
+	NewData,
+)
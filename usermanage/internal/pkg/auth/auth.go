@@ -53,3 +53,22 @@ func CheckRole(ctx context.Context, roles ...constants.UserRole) error {
 	}
 	return nil
 }
+
+type sessionKey struct{}
+
+// WithSession returns a context carrying `verify`, which verifies the session of the current
+// user again, e.g. that its token was not revoked.
+func WithSession(ctx context.Context, verify func(ctx context.Context) error) context.Context {
+	return context.WithValue(ctx, sessionKey{}, verify)
+}
+
+// VerifySession verifies the session of the current user again, for the requests outliving
+// the check made when they started such as streams.
+//
+// It returns nil if the context carries no session.
+func VerifySession(ctx context.Context) error {
+	if verify, ok := ctx.Value(sessionKey{}).(func(context.Context) error); ok {
+		return verify(ctx)
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package broker
+
+import "context"
+
+// Message is a message published to a broker.
+type Message struct {
+	// ID uniquely identifies the message, consumers use it to drop duplicates.
+	ID string
+	// Topic is the destination of the message (e.g. a Redis stream).
+	Topic string
+	// Key groups related messages, e.g. all the events of a single user.
+	Key string
+	// Type describes the payload, e.g. `user.created`.
+	Type string
+	// Payload is the encoded message body.
+	Payload []byte
+}
+
+// Broker publishes messages to consumers.
+//
+// Delivery is at-least-once: a message may be published more than once, so
+// consumers are expected to be idempotent on `Message.ID`.
+type Broker interface {
+	// Publish publishes the messages in order.
+	Publish(ctx context.Context, msgs ...*Message) error
+
+	// Close releases the resources held by the broker.
+	Close() error
+}
@@ -0,0 +1,66 @@
+package broker
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/go-redis/redismock/v9"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemory_Publish(t *testing.T) {
+	b := NewMemory()
+	sub := b.Subscribe(1)
+
+	msg := &Message{ID: "1", Topic: "user", Key: "u1", Type: "user.created", Payload: []byte("{}")}
+	assert.NoError(t, b.Publish(context.Background(), msg))
+	assert.Equal(t, []*Message{msg}, b.Messages())
+	assert.Equal(t, msg, <-sub)
+
+	// A full subscriber does not block the publisher
+	assert.NoError(t, b.Publish(context.Background(), msg, msg))
+	assert.Len(t, b.Messages(), 3)
+
+	assert.NoError(t, b.Close())
+}
+
+func TestMemory_Retention(t *testing.T) {
+	b := NewMemory()
+	for i := range 2*memoryRetainedMessages + 5 {
+		assert.NoError(t, b.Publish(context.Background(), &Message{ID: strconv.Itoa(i)}))
+	}
+	messages := b.Messages()
+	assert.Len(t, messages, memoryRetainedMessages)
+	assert.Equal(t, strconv.Itoa(2*memoryRetainedMessages+4), messages[len(messages)-1].ID)
+	assert.LessOrEqual(t, len(b.messages), 2*memoryRetainedMessages)
+}
+
+func TestRedisStream_Publish(t *testing.T) {
+	client, mock := redismock.NewClientMock()
+	b := NewRedisStream(client, RedisStreamOption{StreamPrefix: "events:", MaxLen: 1000})
+
+	msg := &Message{ID: "1", Topic: "user", Key: "u1", Type: "user.created", Payload: []byte("{}")}
+	mock.ExpectXAdd(&redis.XAddArgs{
+		Stream: "events:user",
+		MaxLen: 1000,
+		Approx: true,
+		Values: []any{"id", "1", "type", "user.created", "key", "u1", "payload", "{}"},
+	}).SetVal("1-0")
+
+	assert.NoError(t, b.Publish(context.Background(), msg))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFanout_Publish(t *testing.T) {
+	first, second := NewMemory(), NewMemory()
+	b := NewFanout(first, second)
+
+	msg := &Message{ID: "1", Topic: "user", Key: "u1", Type: "user.created", Payload: []byte("{}")}
+	assert.NoError(t, b.Publish(context.Background(), msg))
+	assert.Equal(t, []*Message{msg}, first.Messages())
+	assert.Equal(t, []*Message{msg}, second.Messages())
+
+	assert.NoError(t, b.Close())
+}
@@ -0,0 +1,40 @@
+package broker
+
+import (
+	"context"
+	"errors"
+)
+
+// Fanout publishes every message to several brokers in order.
+type Fanout struct {
+	brokers []Broker
+}
+
+// NewFanout creates a broker publishing to all the given brokers.
+func NewFanout(brokers ...Broker) *Fanout {
+	return &Fanout{brokers: brokers}
+}
+
+// Publish implements Broker.
+//
+// It stops at the first failing broker. Since delivery is at-least-once the
+// whole batch is published again on retry, including to the brokers that succeeded.
+func (b *Fanout) Publish(ctx context.Context, msgs ...*Message) error {
+	for _, broker := range b.brokers {
+		if err := broker.Publish(ctx, msgs...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close implements Broker.
+func (b *Fanout) Close() error {
+	var errs []error
+	for _, broker := range b.brokers {
+		if err := broker.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,81 @@
+package broker
+
+import (
+	"context"
+	"sync"
+)
+
+// memoryRetainedMessages is how many of the latest messages the memory broker keeps.
+const memoryRetainedMessages = 10000
+
+// Memory is an in-process broker, intended for tests and local development.
+//
+// It keeps the latest memoryRetainedMessages messages, the older ones are dropped.
+type Memory struct {
+	mu          sync.Mutex
+	messages    []*Message
+	subscribers []chan *Message
+}
+
+// NewMemory creates an in-process broker.
+func NewMemory() *Memory {
+	return &Memory{}
+}
+
+// Publish implements Broker.
+//
+// Subscribers which are not keeping up do not block the publisher, the messages
+// they would miss are still available through `Messages`.
+func (b *Memory) Publish(_ context.Context, msgs ...*Message) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, msg := range msgs {
+		b.messages = append(b.messages, msg)
+		for _, ch := range b.subscribers {
+			select {
+			case ch <- msg:
+			default:
+			}
+		}
+	}
+	// Drop the older messages by halves, so publishing stays cheap
+	if len(b.messages) >= 2*memoryRetainedMessages {
+		b.messages = append([]*Message(nil), b.messages[len(b.messages)-memoryRetainedMessages:]...)
+	}
+	return nil
+}
+
+// Subscribe returns a channel receiving every message published from now on.
+func (b *Memory) Subscribe(buffer int) <-chan *Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ch := make(chan *Message, buffer)
+	b.subscribers = append(b.subscribers, ch)
+	return ch
+}
+
+// Messages returns the latest messages published, at most memoryRetainedMessages.
+func (b *Memory) Messages() []*Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	messages := b.messages
+	if len(messages) > memoryRetainedMessages {
+		messages = messages[len(messages)-memoryRetainedMessages:]
+	}
+	return append([]*Message(nil), messages...)
+}
+
+// Close implements Broker.
+func (b *Memory) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, ch := range b.subscribers {
+		close(ch)
+	}
+	b.subscribers = nil
+	return nil
+}
@@ -0,0 +1,65 @@
+package broker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisStreamOption configures the RedisStream broker.
+type RedisStreamOption struct {
+	// StreamPrefix is prepended to the topic to build the stream key.
+	// The default is `stream:`.
+	StreamPrefix string
+
+	// MaxLen caps the stream length approximately (`XADD ... MAXLEN ~`).
+	// Zero means the stream is not trimmed.
+	MaxLen int64
+}
+
+type redisStream struct {
+	client redis.UniversalClient
+	option RedisStreamOption
+}
+
+// NewRedisStream creates a broker publishing messages to Redis Streams.
+//
+// Every message is appended with `XADD` to the stream `<StreamPrefix><Topic>` with
+// the fields `id`, `type`, `key` and `payload`.
+func NewRedisStream(client redis.UniversalClient, option RedisStreamOption) Broker {
+	if option.StreamPrefix == "" {
+		option.StreamPrefix = "stream:"
+	}
+	return &redisStream{client: client, option: option}
+}
+
+// Publish implements Broker.
+func (b *redisStream) Publish(ctx context.Context, msgs ...*Message) error {
+	for _, msg := range msgs {
+		args := &redis.XAddArgs{
+			Stream: b.option.StreamPrefix + msg.Topic,
+			Values: []any{
+				"id", msg.ID,
+				"type", msg.Type,
+				"key", msg.Key,
+				"payload", string(msg.Payload),
+			},
+		}
+		if b.option.MaxLen > 0 {
+			args.MaxLen = b.option.MaxLen
+			args.Approx = true
+		}
+		if err := b.client.XAdd(ctx, args).Err(); err != nil {
+			return fmt.Errorf("failed to publish message[id=%s]: %w", msg.ID, err)
+		}
+	}
+	return nil
+}
+
+// Close implements Broker.
+//
+// The Redis client is shared, so it is left open.
+func (b *redisStream) Close() error {
+	return nil
+}
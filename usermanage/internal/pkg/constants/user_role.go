@@ -15,3 +15,15 @@ const (
 func (r UserRole) IsValid() bool {
 	return r == UserRoleUser || r == UserRoleAdmin
 }
+
+// String returns the string repetition of the user role.
+func (r UserRole) String() string {
+	switch r {
+	case UserRoleAdmin:
+		return "admin"
+	case UserRoleUser:
+		return "user"
+	default:
+		return "unknown"
+	}
+}
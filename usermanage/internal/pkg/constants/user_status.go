@@ -16,3 +16,17 @@ const (
 func (s UserStatus) IsValid() bool {
 	return s == UserStatusNormal || s == UserStatusDisabled || s == UserStatusLocked
 }
+
+// String returns the string repetition of the user status.
+func (s UserStatus) String() string {
+	switch s {
+	case UserStatusNormal:
+		return "normal"
+	case UserStatusDisabled:
+		return "disabled"
+	case UserStatusLocked:
+		return "locked"
+	default:
+		return "unknown"
+	}
+}
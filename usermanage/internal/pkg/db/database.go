@@ -9,14 +9,18 @@ import (
 	"time"
 	"usermanage/gen/proto/conf"
 
+	"github.com/glebarez/sqlite"
 	"gorm.io/driver/mysql"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
 // Database wraps the gorm.DB instance and provides custom methods
+//
+// The embedded gorm.DB is the primary, reads may be routed to replicas with `Read`.
 type Database struct {
 	*gorm.DB
+	replicas *replicaSet
 }
 
 // NewDatabase creates a new Database instance.
@@ -28,35 +32,20 @@ func NewDatabase(c *conf.Data) (*Database, error) {
 	// First connect to the server without specifying a database
 	serverDSN := removeDatabaseFromDSN(dsn, driver)
 
-	var serverDB *gorm.DB
-	var err error
-	switch driver {
-	case conf.DatabaseDriver_DATABASE_DRIVER_MYSQL:
-		serverDB, err = gorm.Open(mysql.Open(serverDSN), &gorm.Config{})
-	case conf.DatabaseDriver_DATABASE_DRIVER_POSTGRES:
-		serverDB, err = gorm.Open(postgres.Open(serverDSN), &gorm.Config{})
-	default:
-		return nil, fmt.Errorf("unknown database driver: %s", driver)
-	}
+	serverDB, err := open(driver, serverDSN, &gorm.Config{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database server: %w", err)
 	}
 
 	// Create the database if it doesn't exist
-	db := &Database{serverDB}
+	db := &Database{DB: serverDB}
 	if err := db.CreateDatabaseIfNotExists(context.Background(), dbName); err != nil {
 		return nil, fmt.Errorf("failed to create database: %w", err)
 	}
 
 	// Now connect to the specific database
-	var appDB *gorm.DB
-	switch driver {
-	case conf.DatabaseDriver_DATABASE_DRIVER_MYSQL:
-		appDB, err = gorm.Open(mysql.Open(dsn), &gorm.Config{})
-	case conf.DatabaseDriver_DATABASE_DRIVER_POSTGRES:
-		appDB, err = gorm.Open(postgres.Open(dsn), &gorm.Config{})
-	}
-
+	// Unique index violations are reported as `gorm.ErrDuplicatedKey`
+	appDB, err := open(driver, dsn, &gorm.Config{TranslateError: true})
 	if err != nil {
 		return nil, fmt.Errorf("failed to open application database: %w", err)
 	}
@@ -71,11 +60,41 @@ func NewDatabase(c *conf.Data) (*Database, error) {
 	sqlDB.SetMaxIdleConns(10)
 	sqlDB.SetMaxOpenConns(100)
 	sqlDB.SetConnMaxLifetime(time.Hour)
-	return &Database{appDB}, nil
+	if driver == conf.DatabaseDriver_DATABASE_DRIVER_SQLITE {
+		// SQLite allows a single writer, and every connection to `:memory:` opens
+		// a distinct database, so share one connection which is never recycled
+		sqlDB.SetMaxOpenConns(1)
+		sqlDB.SetConnMaxLifetime(0)
+	}
+
+	if err := registerWriteCallbacks(appDB); err != nil {
+		return nil, fmt.Errorf("failed to register write callbacks: %w", err)
+	}
+	return &Database{DB: appDB, replicas: newReplicaSet(c.Database)}, nil
+}
+
+// Open a connection with the dialector of the driver.
+func open(driver conf.DatabaseDriver, dsn string, config *gorm.Config) (*gorm.DB, error) {
+	switch driver {
+	case conf.DatabaseDriver_DATABASE_DRIVER_MYSQL:
+		return gorm.Open(mysql.Open(dsn), config)
+	case conf.DatabaseDriver_DATABASE_DRIVER_POSTGRES:
+		return gorm.Open(postgres.Open(dsn), config)
+	case conf.DatabaseDriver_DATABASE_DRIVER_SQLITE:
+		return gorm.Open(sqlite.Open(dsn), config)
+	default:
+		return nil, fmt.Errorf("unknown database driver: %s", driver)
+	}
 }
 
 // CreateDatabaseIfNotExists creates the specified database if it doesn't exist.
+//
+// SQLite creates the database file when it is opened, there is nothing to do.
 func (db *Database) CreateDatabaseIfNotExists(ctx context.Context, dbName string) error {
+	if db.Dialector.Name() == "sqlite" {
+		return nil
+	}
+
 	exists, err := db.databaseExists(ctx, dbName)
 	if err != nil {
 		return fmt.Errorf("failed to check if database exists: %w", err)
@@ -168,6 +187,9 @@ func removeDatabaseFromDSN(dsn string, driver conf.DatabaseDriver) string {
 			regex := regexp.MustCompile(`\bdbname=\w+\b`)
 			return regex.ReplaceAllString(dsn, "")
 		}
+	case conf.DatabaseDriver_DATABASE_DRIVER_SQLITE:
+		// SQLite has no server, the DSN is the database itself
+		return dsn
 	}
 	return dsn
 }
@@ -0,0 +1,252 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"usermanage/gen/proto/conf"
+	"usermanage/internal/pkg/envelope"
+
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// EncryptedSerializerName is the GORM serializer of the encrypted string fields,
+// e.g. `gorm:"serializer:encrypted"`.
+const EncryptedSerializerName = "encrypted"
+
+// ErrEncryptionNotConfigured is returned when an encrypted field is used without keys.
+var ErrEncryptionNotConfigured = errors.New("encryption is not configured, set `data.encryption.key_file`")
+
+// fieldEnvelope is the envelope of the encrypted fields, there is one per process
+// like the GORM serializers.
+var fieldEnvelope atomic.Pointer[envelope.Envelope]
+
+func init() {
+	schema.RegisterSerializer(EncryptedSerializerName, encryptedSerializer{})
+}
+
+// NewEnvelope creates the envelope of the encrypted fields from the configured key file.
+//
+// It returns nil when encryption is not configured, encrypted fields can then only be empty.
+func NewEnvelope(c *conf.Data) (*envelope.Envelope, error) {
+	keyFile := c.GetEncryption().GetKeyFile()
+	if keyFile == "" {
+		return nil, nil
+	}
+	provider, indexKey, err := envelope.LoadKeyFile(keyFile)
+	if err != nil {
+		return nil, err
+	}
+	env, err := envelope.New(provider, indexKey)
+	if err != nil {
+		return nil, err
+	}
+	SetFieldEnvelope(env)
+	return env, nil
+}
+
+// SetFieldEnvelope sets the envelope of the encrypted fields.
+func SetFieldEnvelope(env *envelope.Envelope) {
+	fieldEnvelope.Store(env)
+}
+
+// reencryptAttempts is how many times a row changed concurrently is read again to be
+// re-encrypted before giving up.
+const reencryptAttempts = 3
+
+// Return the additional data binding an encrypted value to its column and row, so a
+// value copied to another row or column fails to decrypt.
+func columnAAD(table, column, id string) string {
+	return table + "." + column + "#" + id
+}
+
+// Return the additional data of the values encrypted before they were bound to their row,
+// they are still decrypted until `ReencryptColumns` binds them.
+func legacyColumnAAD(table, column string) string {
+	return table + "." + column
+}
+
+// Decrypt a value of a column, reporting whether it is bound to its row.
+func decryptColumn(ctx context.Context, env *envelope.Envelope, ciphertext, table, column, id string) (string, bool, error) {
+	plaintext, err := env.Decrypt(ctx, ciphertext, columnAAD(table, column, id))
+	if err == nil {
+		return plaintext, true, nil
+	}
+	if plaintext, legacyErr := env.Decrypt(ctx, ciphertext, legacyColumnAAD(table, column)); legacyErr == nil {
+		return plaintext, false, nil
+	}
+	return "", false, err
+}
+
+// Return the primary key of the row a field belongs to.
+func rowID(ctx context.Context, field *schema.Field, row reflect.Value) string {
+	pk := field.Schema.PrioritizedPrimaryField
+	if pk == nil {
+		return ""
+	}
+	v, _ := pk.ValueOf(ctx, row)
+	return fmt.Sprint(v)
+}
+
+// encryptedSerializer stores string fields encrypted, empty strings are stored as is.
+//
+// The primary key of the model must be set, or selected before the field when reading.
+type encryptedSerializer struct{}
+
+// Scan implements schema.SerializerInterface.
+func (encryptedSerializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue any) error {
+	var ciphertext string
+	switch v := dbValue.(type) {
+	case nil:
+	case string:
+		ciphertext = v
+	case []byte:
+		ciphertext = string(v)
+	default:
+		return fmt.Errorf("unsupported encrypted value type %T of field[%s]", dbValue, field.Name)
+	}
+
+	var plaintext string
+	if ciphertext != "" {
+		env := fieldEnvelope.Load()
+		if env == nil {
+			return ErrEncryptionNotConfigured
+		}
+		var err error
+		if plaintext, _, err = decryptColumn(ctx, env, ciphertext, field.Schema.Table, field.DBName, rowID(ctx, field, dst)); err != nil {
+			return fmt.Errorf("failed to decrypt field[%s]: %w", field.Name, err)
+		}
+	}
+	field.ReflectValueOf(ctx, dst).SetString(plaintext)
+	return nil
+}
+
+// Value implements schema.SerializerInterface.
+func (encryptedSerializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue any) (any, error) {
+	plaintext, ok := fieldValue.(string)
+	if !ok {
+		return nil, fmt.Errorf("encrypted field[%s] must be a string", field.Name)
+	}
+	if plaintext == "" {
+		return "", nil
+	}
+	env := fieldEnvelope.Load()
+	if env == nil {
+		return nil, ErrEncryptionNotConfigured
+	}
+	id := rowID(ctx, field, dst)
+	if id == "" {
+		return nil, fmt.Errorf("encrypted field[%s] requires the primary key of its row", field.Name)
+	}
+	return env.Encrypt(ctx, plaintext, columnAAD(field.Schema.Table, field.DBName, id))
+}
+
+// ReencryptColumns re-encrypts the values of encrypted columns not encrypted under the
+// current key encryption key or not bound to their row yet, batch by batch, and returns
+// the number of updated rows.
+//
+// Rows are walked by primary key `id`, soft-deleted rows included. A row is only updated
+// if its values are still the ones re-encrypted, a row changed in between is read again.
+func (db *Database) ReencryptColumns(ctx context.Context, table string, columns []string, batchSize int) (int, error) {
+	env := fieldEnvelope.Load()
+	if env == nil {
+		return 0, ErrEncryptionNotConfigured
+	}
+
+	updated := 0
+	lastID := ""
+	for {
+		var rows []map[string]any
+		if err := db.WithContext(ctx).
+			Table(table).
+			Select(append([]string{"id"}, columns...)).
+			Where("id > ?", lastID).
+			Order("id").
+			Limit(batchSize).
+			Find(&rows).Error; err != nil {
+			return updated, fmt.Errorf("failed to read table[%s]: %w", table, err)
+		}
+
+		for _, row := range rows {
+			lastID = stringValue(row["id"])
+			ok, err := db.reencryptRow(ctx, env, table, columns, row)
+			if err != nil {
+				return updated, err
+			}
+			if ok {
+				updated++
+			}
+		}
+		if len(rows) < batchSize {
+			return updated, nil
+		}
+	}
+}
+
+// Re-encrypt the values of a row which need it and report whether the row was updated.
+//
+// The update compares the values read, a row changed in between is read again.
+func (db *Database) reencryptRow(ctx context.Context, env *envelope.Envelope, table string, columns []string, row map[string]any) (bool, error) {
+	id := stringValue(row["id"])
+	for range reencryptAttempts {
+		changes := make(map[string]any)
+		query := db.WithContext(ctx).Table(table).Where("id = ?", id)
+		for _, column := range columns {
+			ciphertext := stringValue(row[column])
+			if ciphertext == "" {
+				continue
+			}
+			plaintext, bound, err := decryptColumn(ctx, env, ciphertext, table, column, id)
+			if err != nil {
+				return false, fmt.Errorf("failed to decrypt %s.%s of row[id=%s]: %w", table, column, id, err)
+			}
+			if bound && !env.NeedsRotation(ciphertext) {
+				continue
+			}
+			if changes[column], err = env.Encrypt(ctx, plaintext, columnAAD(table, column, id)); err != nil {
+				return false, err
+			}
+			query = query.Where(clause.Eq{Column: clause.Column{Name: column}, Value: ciphertext})
+		}
+		if len(changes) == 0 {
+			return false, nil
+		}
+
+		result := query.Updates(changes)
+		if result.Error != nil {
+			return false, fmt.Errorf("failed to update row[id=%s] of table[%s]: %w", id, table, result.Error)
+		}
+		if result.RowsAffected > 0 {
+			return true, nil
+		}
+
+		// Changed in between, read it again
+		var rows []map[string]any
+		if err := db.WithContext(ctx).
+			Table(table).
+			Select(append([]string{"id"}, columns...)).
+			Where("id = ?", id).
+			Find(&rows).Error; err != nil {
+			return false, fmt.Errorf("failed to read row[id=%s] of table[%s]: %w", id, table, err)
+		}
+		if len(rows) == 0 {
+			return false, nil
+		}
+		row = rows[0]
+	}
+	return false, fmt.Errorf("row[id=%s] of table[%s] kept changing while re-encrypted", id, table)
+}
+
+// Return the string of a column value, drivers scan text as string or bytes.
+func stringValue(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	}
+	return ""
+}
@@ -1,6 +1,10 @@
 package db
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
 	"usermanage/gen/proto/conf"
 	"usermanage/internal/pkg/rdb"
 
@@ -8,14 +12,71 @@ import (
 )
 
 // NewRedis creates a new Redis client.
+//
+// The topology follows the configuration: Sentinel when a master name is set,
+// Cluster with several addresses or `cluster` enabled, standalone otherwise.
+// It returns a nil client when no address is configured, Redis is then not used.
 func NewRedis(c *conf.Data) (redis.UniversalClient, error) {
+	if len(c.GetRedis().GetAddrs()) == 0 {
+		return nil, nil
+	}
 	opts := &redis.UniversalOptions{
-		Addrs:        c.Redis.Addrs,
-		Password:     c.Redis.Password,
-		DB:           int(c.Redis.Db),
-		DialTimeout:  c.Redis.DialTimeout.AsDuration(),
-		ReadTimeout:  c.Redis.ReadTimeout.AsDuration(),
-		WriteTimeout: c.Redis.WriteTimeout.AsDuration(),
+		Addrs:            c.Redis.Addrs,
+		Username:         c.Redis.Username,
+		Password:         c.Redis.Password,
+		DB:               int(c.Redis.Db),
+		MasterName:       c.Redis.MasterName,
+		SentinelUsername: c.Redis.SentinelUsername,
+		SentinelPassword: c.Redis.SentinelPassword,
+		DialTimeout:      c.Redis.DialTimeout.AsDuration(),
+		ReadTimeout:      c.Redis.ReadTimeout.AsDuration(),
+		WriteTimeout:     c.Redis.WriteTimeout.AsDuration(),
+		PoolSize:         int(c.Redis.PoolSize),
+		MinIdleConns:     int(c.Redis.MinIdleConns),
+		MaxIdleConns:     int(c.Redis.MaxIdleConns),
+		PoolTimeout:      c.Redis.PoolTimeout.AsDuration(),
+		ConnMaxIdleTime:  c.Redis.ConnMaxIdleTime.AsDuration(),
+	}
+	if c.Redis.Tls.GetEnabled() {
+		tlsConfig, err := newTLSConfig(c.Redis.Tls)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure Redis TLS: %w", err)
+		}
+		opts.TLSConfig = tlsConfig
+	}
+
+	if c.Redis.Cluster {
+		return rdb.NewClusterClient(opts)
 	}
 	return rdb.NewClient(opts)
 }
+
+// Build the TLS configuration, loading the CA and the client certificate if any.
+func newTLSConfig(c *conf.Data_Redis_TLS) (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         c.ServerName,
+		InsecureSkipVerify: c.InsecureSkipVerify, //nolint:gosec // opt-in, for development only
+	}
+
+	if c.CaFile != "" {
+		ca, err := os.ReadFile(c.CaFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file[%s]: %w", c.CaFile, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no certificate found in CA file[%s]", c.CaFile)
+		}
+		config.RootCAs = pool
+	}
+
+	if c.CertFile != "" || c.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
@@ -0,0 +1,28 @@
+package db
+
+import (
+	"crypto/tls"
+	"os"
+	"path/filepath"
+	"testing"
+	"usermanage/gen/proto/conf"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTLSConfig(t *testing.T) {
+	config, err := newTLSConfig(&conf.Data_Redis_TLS{Enabled: true, ServerName: "redis.internal"})
+	require.NoError(t, err)
+	assert.Equal(t, "redis.internal", config.ServerName)
+	assert.Equal(t, uint16(tls.VersionTLS12), config.MinVersion)
+	assert.Nil(t, config.RootCAs)
+
+	_, err = newTLSConfig(&conf.Data_Redis_TLS{Enabled: true, CaFile: filepath.Join(t.TempDir(), "missing.pem")})
+	assert.ErrorContains(t, err, "failed to read CA file")
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, []byte("not a certificate"), 0o600))
+	_, err = newTLSConfig(&conf.Data_Redis_TLS{Enabled: true, CaFile: caFile})
+	assert.ErrorContains(t, err, "no certificate found")
+}
@@ -0,0 +1,268 @@
+package db
+
+import (
+	"context"
+	"math/rand/v2"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+	"usermanage/gen/proto/conf"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultReplicaHealthCheckInterval = 5 * time.Second
+	replicaPingTimeout                = 2 * time.Second
+)
+
+// replica is a read replica of the primary database.
+type replica struct {
+	name    string // `replica-<index>`, the DSN holds credentials
+	driver  conf.DatabaseDriver
+	dsn     string
+	db      atomic.Pointer[gorm.DB]
+	healthy atomic.Bool
+}
+
+// Ping the replica and update its health, returns whether the health changed.
+//
+// The replica is connected to lazily, so one which is down does not prevent
+// the service from starting.
+func (r *replica) check(ctx context.Context) (healthy, changed bool) {
+	ctx, cancel := context.WithTimeout(ctx, replicaPingTimeout)
+	defer cancel()
+
+	db := r.db.Load()
+	if db == nil {
+		if opened, err := open(r.driver, r.dsn, &gorm.Config{}); err == nil {
+			db = opened
+			r.db.Store(db)
+		}
+	}
+	if db != nil {
+		if sqlDB, err := db.DB(); err == nil {
+			healthy = sqlDB.PingContext(ctx) == nil
+		}
+	}
+	return healthy, r.healthy.Swap(healthy) != healthy
+}
+
+// replicaSet picks the replica serving a read according to the routing policy.
+type replicaSet struct {
+	replicas []*replica
+	policy   conf.ReplicaPolicy
+	next     atomic.Uint64
+}
+
+// Pick a healthy replica, nil if there is none.
+func (s *replicaSet) pick() *replica {
+	healthy := make([]*replica, 0, len(s.replicas))
+	for _, r := range s.replicas {
+		if r.healthy.Load() {
+			healthy = append(healthy, r)
+		}
+	}
+	if len(healthy) == 0 {
+		return nil
+	}
+	if s.policy == conf.ReplicaPolicy_REPLICA_POLICY_RANDOM {
+		return healthy[rand.IntN(len(healthy))]
+	}
+	return healthy[(s.next.Add(1)-1)%uint64(len(healthy))]
+}
+
+// Create the replicas of the configuration and check their health once.
+//
+// A replica which cannot be reached is kept out of rotation until a health check succeeds.
+func newReplicaSet(c *conf.Data_Database) *replicaSet {
+	if len(c.GetReplicaDsns()) == 0 {
+		return nil
+	}
+
+	set := &replicaSet{policy: c.GetReplicaPolicy()}
+	for i, dsn := range c.GetReplicaDsns() {
+		r := &replica{name: "replica-" + strconv.Itoa(i), driver: c.GetDriver(), dsn: dsn}
+		r.check(context.Background())
+		set.replicas = append(set.replicas, r)
+	}
+	return set
+}
+
+type contextReadStateKey struct{}
+
+// readState tracks the reads and writes made within a request.
+type readState struct {
+	mu      sync.Mutex
+	written bool
+	replica *replica
+}
+
+// WithReadYourWrites returns a new context tracking the writes made with it,
+// so it reads its own writes even if the replicas lag behind the primary.
+//
+// Reads made with the context go to the primary once it wrote anything, and
+// to the same replica otherwise, so they never go back in time.
+func WithReadYourWrites(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contextReadStateKey{}, &readState{})
+}
+
+// WithPrimary returns a new context whose reads go to the primary, for the reads
+// outliving the request such as the loads of a cache, which must not keep the state
+// of a lagging replica.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contextReadStateKey{}, &readState{written: true})
+}
+
+// Mark the context has written to the primary.
+func markWritten(ctx context.Context) {
+	if state, ok := ctx.Value(contextReadStateKey{}).(*readState); ok {
+		state.mu.Lock()
+		state.written = true
+		state.mu.Unlock()
+	}
+}
+
+// Read returns the connection to read with.
+//
+// It is the transaction carried by the context if there is one, the primary
+// if the request already wrote something or no replica is healthy, otherwise
+// a replica picked according to the routing policy.
+//
+// Reads which guard a write, such as uniqueness checks, should use `Conn` instead.
+func (db *Database) Read(ctx context.Context) *gorm.DB {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	if db.replicas == nil {
+		return db.WithContext(ctx)
+	}
+
+	state, ok := ctx.Value(contextReadStateKey{}).(*readState)
+	if !ok {
+		if r := db.replicas.pick(); r != nil {
+			return r.db.Load().WithContext(ctx)
+		}
+		return db.WithContext(ctx)
+	}
+
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	if state.written {
+		return db.WithContext(ctx)
+	}
+	if state.replica == nil || !state.replica.healthy.Load() {
+		state.replica = db.replicas.pick()
+	}
+	if state.replica == nil {
+		return db.WithContext(ctx)
+	}
+	return state.replica.db.Load().WithContext(ctx)
+}
+
+// Register the callbacks marking the contexts which wrote to the primary.
+func registerWriteCallbacks(db *gorm.DB) error {
+	mark := func(tx *gorm.DB) {
+		if tx.Error == nil && tx.Statement.Context != nil {
+			markWritten(tx.Statement.Context)
+		}
+	}
+	callbacks := db.Callback()
+	if err := callbacks.Create().After("gorm:create").Register("usermanage:mark_written", mark); err != nil {
+		return err
+	}
+	if err := callbacks.Update().After("gorm:update").Register("usermanage:mark_written", mark); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().After("gorm:delete").Register("usermanage:mark_written", mark); err != nil {
+		return err
+	}
+	return callbacks.Raw().After("gorm:raw").Register("usermanage:mark_written", mark)
+}
+
+// ReplicaHealthChecker periodically pings the read replicas, removing the failing
+// ones from rotation and bringing them back once they recover.
+//
+// It implements the kratos `transport.Server` interface so it runs alongside the
+// HTTP and gRPC servers. It does nothing when no replica is configured.
+type ReplicaHealthChecker struct {
+	db       *Database
+	interval time.Duration
+	logger   *log.Helper
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewReplicaHealthChecker creates a new replica health checker.
+func NewReplicaHealthChecker(c *conf.Data, db *Database, logger log.Logger) *ReplicaHealthChecker {
+	interval := defaultReplicaHealthCheckInterval
+	if d := c.GetDatabase().GetReplicaHealthCheckInterval(); d != nil && d.AsDuration() > 0 {
+		interval = d.AsDuration()
+	}
+	return &ReplicaHealthChecker{
+		db:       db,
+		interval: interval,
+		logger:   log.NewHelper(logger),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Start implements transport.Server.
+//
+// It blocks until `Stop` is called or the context is done.
+func (h *ReplicaHealthChecker) Start(ctx context.Context) error {
+	defer close(h.done)
+	if h.db.replicas == nil {
+		select {
+		case <-ctx.Done():
+		case <-h.stop:
+		}
+		return nil
+	}
+	h.logger.Infow("msg", "replica health checker started", "replicas", len(h.db.replicas.replicas), "interval", h.interval)
+
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+	for {
+		h.CheckOnce(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-h.stop:
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stop implements transport.Server.
+func (h *ReplicaHealthChecker) Stop(ctx context.Context) error {
+	close(h.stop)
+	select {
+	case <-h.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	h.logger.Info("replica health checker stopped")
+	return nil
+}
+
+// CheckOnce pings every replica once.
+func (h *ReplicaHealthChecker) CheckOnce(ctx context.Context) {
+	if h.db.replicas == nil {
+		return
+	}
+	for _, r := range h.db.replicas.replicas {
+		healthy, changed := r.check(ctx)
+		switch {
+		case changed && healthy:
+			h.logger.Infow("msg", "replica is back in rotation", "replica", r.name)
+		case changed:
+			h.logger.Warnw("msg", "replica is unhealthy, removed from rotation", "replica", r.name)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package db
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"usermanage/gen/proto/conf"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// Create a database whose primary and replicas are SQLite files holding their own name.
+func newTestReplicatedDatabase(t *testing.T, replicas ...string) *Database {
+	t.Helper()
+	dir := t.TempDir()
+
+	create := func(name string) string {
+		dsn := filepath.Join(dir, name+".db")
+		db, err := open(conf.DatabaseDriver_DATABASE_DRIVER_SQLITE, dsn, &gorm.Config{})
+		require.NoError(t, err)
+		require.NoError(t, db.Exec("CREATE TABLE node (name TEXT)").Error)
+		require.NoError(t, db.Exec("INSERT INTO node (name) VALUES (?)", name).Error)
+		sqlDB, _ := db.DB()
+		sqlDB.Close()
+		return dsn
+	}
+
+	c := &conf.Data{Database: &conf.Data_Database{
+		Driver: conf.DatabaseDriver_DATABASE_DRIVER_SQLITE,
+		Name:   "test",
+		Dsn:    create("primary"),
+	}}
+	for _, replica := range replicas {
+		dsn := filepath.Join(dir, "missing", replica+".db")
+		if replica != "down" {
+			dsn = create(replica)
+		}
+		c.Database.ReplicaDsns = append(c.Database.ReplicaDsns, dsn)
+	}
+	db, err := NewDatabase(c)
+	require.NoError(t, err)
+	return db
+}
+
+func readNode(t *testing.T, tx *gorm.DB) string {
+	t.Helper()
+	var name string
+	require.NoError(t, tx.Raw("SELECT name FROM node").Scan(&name).Error)
+	return name
+}
+
+func TestDatabase_Read(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("NoReplica", func(t *testing.T) {
+		db := newTestReplicatedDatabase(t)
+		assert.Equal(t, "primary", readNode(t, db.Read(ctx)))
+	})
+
+	t.Run("RoundRobin", func(t *testing.T) {
+		db := newTestReplicatedDatabase(t, "a", "b")
+		assert.Equal(t, "a", readNode(t, db.Read(ctx)))
+		assert.Equal(t, "b", readNode(t, db.Read(ctx)))
+		assert.Equal(t, "a", readNode(t, db.Read(ctx)))
+		assert.Equal(t, "primary", readNode(t, db.Conn(ctx)))
+	})
+
+	t.Run("UnhealthyReplica", func(t *testing.T) {
+		db := newTestReplicatedDatabase(t, "down", "b")
+		assert.Equal(t, "b", readNode(t, db.Read(ctx)))
+		assert.Equal(t, "b", readNode(t, db.Read(ctx)))
+
+		db = newTestReplicatedDatabase(t, "down")
+		assert.Equal(t, "primary", readNode(t, db.Read(ctx)))
+	})
+
+	t.Run("ReadYourWrites", func(t *testing.T) {
+		db := newTestReplicatedDatabase(t, "a", "b")
+		ctx := WithReadYourWrites(ctx)
+
+		// Reads stick to the same replica until the request writes
+		assert.Equal(t, "a", readNode(t, db.Read(ctx)))
+		assert.Equal(t, "a", readNode(t, db.Read(ctx)))
+		require.NoError(t, db.Conn(ctx).Exec("UPDATE node SET name = ?", "primary").Error)
+		assert.Equal(t, "primary", readNode(t, db.Read(ctx)))
+
+		// Other requests still read from the replicas
+		assert.Equal(t, "b", readNode(t, db.Read(WithReadYourWrites(context.Background()))))
+	})
+
+	t.Run("Primary", func(t *testing.T) {
+		db := newTestReplicatedDatabase(t, "a")
+		assert.Equal(t, "primary", readNode(t, db.Read(WithPrimary(ctx))))
+		assert.Equal(t, "a", readNode(t, db.Read(ctx)))
+	})
+
+	t.Run("Transaction", func(t *testing.T) {
+		db := newTestReplicatedDatabase(t, "a")
+		err := db.InTx(ctx, func(ctx context.Context) error {
+			assert.Equal(t, "primary", readNode(t, db.Read(ctx)))
+			return nil
+		})
+		require.NoError(t, err)
+	})
+}
+
+func TestReplicaHealthChecker_CheckOnce(t *testing.T) {
+	db := newTestReplicatedDatabase(t, "a")
+	checker := NewReplicaHealthChecker(&conf.Data{}, db, log.DefaultLogger)
+	ctx := context.Background()
+
+	replica := db.replicas.replicas[0]
+	sqlDB, err := replica.db.Load().DB()
+	require.NoError(t, err)
+	require.NoError(t, sqlDB.Close())
+	checker.CheckOnce(ctx)
+	assert.False(t, replica.healthy.Load())
+	assert.Equal(t, "primary", readNode(t, db.Read(ctx)))
+}
@@ -0,0 +1,68 @@
+package db
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type contextTxKey struct{}
+
+type contextAfterCommitKey struct{}
+
+// WithTx returns a new context carrying the given transaction.
+func WithTx(ctx context.Context, tx *gorm.DB) context.Context {
+	return context.WithValue(ctx, contextTxKey{}, tx)
+}
+
+// TxFromContext returns the transaction carried by the context, if any.
+func TxFromContext(ctx context.Context) (tx *gorm.DB, ok bool) {
+	tx, ok = ctx.Value(contextTxKey{}).(*gorm.DB)
+	return
+}
+
+// Conn returns the transaction carried by the context if there is one,
+// otherwise the database bound to the context.
+//
+// Repositories should always use `Conn` so they take part in an ongoing transaction.
+func (db *Database) Conn(ctx context.Context) *gorm.DB {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
+
+// InTx runs fn inside a transaction carried by the context passed to fn.
+//
+// If the context already carries a transaction, fn joins it instead of starting a new one,
+// so the outermost caller decides when to commit.
+func (db *Database) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := TxFromContext(ctx); ok {
+		return fn(ctx)
+	}
+	var afterCommit []func()
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		ctx := context.WithValue(WithTx(ctx, tx), contextAfterCommitKey{}, &afterCommit)
+		return fn(ctx)
+	})
+	if err != nil {
+		return err
+	}
+	markWritten(ctx)
+	for _, f := range afterCommit {
+		f()
+	}
+	return nil
+}
+
+// AfterCommit runs fn once the transaction carried by the context is committed,
+// it is dropped if the transaction is rolled back.
+//
+// fn runs right away if the context carries no transaction.
+func AfterCommit(ctx context.Context, fn func()) {
+	if afterCommit, ok := ctx.Value(contextAfterCommitKey{}).(*[]func()); ok {
+		*afterCommit = append(*afterCommit, fn)
+		return
+	}
+	fn()
+}
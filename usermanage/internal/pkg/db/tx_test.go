@@ -0,0 +1,37 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAfterCommit(t *testing.T) {
+	db := newTestReplicatedDatabase(t)
+	ctx := context.Background()
+
+	var calls []string
+	err := db.InTx(ctx, func(ctx context.Context) error {
+		AfterCommit(ctx, func() { calls = append(calls, "outer") })
+		return db.InTx(ctx, func(ctx context.Context) error {
+			AfterCommit(ctx, func() { calls = append(calls, "inner") })
+			assert.Empty(t, calls)
+			return nil
+		})
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"outer", "inner"}, calls)
+
+	calls = nil
+	err = db.InTx(ctx, func(ctx context.Context) error {
+		AfterCommit(ctx, func() { calls = append(calls, "rolled back") })
+		return errors.New("failed")
+	})
+	assert.Error(t, err)
+	assert.Empty(t, calls)
+
+	AfterCommit(ctx, func() { calls = append(calls, "no transaction") })
+	assert.Equal(t, []string{"no transaction"}, calls)
+}
@@ -2,4 +2,4 @@ package db
 
 import "github.com/google/wire"
 
-var ProviderSet = wire.NewSet(NewDatabase, NewRedis)
+var ProviderSet = wire.NewSet(NewDatabase, NewRedis, NewReplicaHealthChecker, NewEnvelope)
@@ -0,0 +1,186 @@
+// Package envelope implements envelope encryption of values stored at rest.
+//
+// Values are encrypted with AES-256-GCM by a data key, and the data key is stored
+// next to the value, wrapped by a key encryption key of a `KeyProvider`. Rotating
+// the key encryption key only requires re-wrapping, i.e. re-encrypting the values
+// with a data key wrapped by the new key.
+package envelope
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"usermanage/internal/pkg/cache"
+)
+
+const (
+	// prefix marks the values encrypted by this package, with the version of the format.
+	prefix    = "enc:v1:"
+	separator = ":"
+
+	dataKeyCacheSize = 1024
+)
+
+// dataKey is a data key, with the KEK wrapping it.
+type dataKey struct {
+	keyID   string
+	wrapped []byte
+	plain   []byte
+}
+
+// Envelope encrypts and decrypts values with data keys wrapped by a KeyProvider.
+//
+// Encrypted values are `enc:v1:<key id>:<wrapped data key>:<nonce and ciphertext>`,
+// base64 encoded. A data key is generated per key encryption key and reused, and the
+// unwrapped data keys are cached, so the provider is only called on key changes.
+type Envelope struct {
+	provider KeyProvider
+	indexKey []byte
+
+	mu        sync.Mutex
+	current   *dataKey
+	unwrapped *cache.LRU[string, []byte]
+}
+
+// New creates an Envelope.
+//
+// `indexKey` is the HMAC key of the blind indexes.
+func New(provider KeyProvider, indexKey []byte) (*Envelope, error) {
+	if len(indexKey) < 32 {
+		return nil, errors.New("index key must be at least 32 bytes")
+	}
+	return &Envelope{
+		provider:  provider,
+		indexKey:  indexKey,
+		unwrapped: cache.NewLRU[string, []byte](dataKeyCacheSize, 24*time.Hour),
+	}, nil
+}
+
+// Encrypt encrypts a value.
+//
+// `additionalData` is authenticated but not encrypted, it must be given again to
+// decrypt, e.g. the name of the column the value is stored in.
+func (e *Envelope) Encrypt(ctx context.Context, plaintext string, additionalData string) (string, error) {
+	key, err := e.currentKey(ctx)
+	if err != nil {
+		return "", err
+	}
+	aead, err := newAEAD(key.plain)
+	if err != nil {
+		return "", err
+	}
+	sealed, err := seal(aead, []byte(plaintext), []byte(additionalData))
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt: %w", err)
+	}
+	return prefix + key.keyID + separator +
+		base64.RawStdEncoding.EncodeToString(key.wrapped) + separator +
+		base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt decrypts a value produced by `Encrypt`.
+func (e *Envelope) Decrypt(ctx context.Context, ciphertext string, additionalData string) (string, error) {
+	keyID, wrapped, sealed, err := parse(ciphertext)
+	if err != nil {
+		return "", err
+	}
+	plainKey, err := e.unwrap(ctx, keyID, wrapped)
+	if err != nil {
+		return "", err
+	}
+	aead, err := newAEAD(plainKey)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := open(aead, sealed, []byte(additionalData))
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt: %w", err)
+	}
+	return string(plaintext), nil
+}
+
+// NeedsRotation reports whether a value is not encrypted under the current key encryption key.
+func (e *Envelope) NeedsRotation(ciphertext string) bool {
+	keyID, _, _, err := parse(ciphertext)
+	return err != nil || keyID != e.provider.CurrentKeyID()
+}
+
+// BlindIndex returns a keyed hash of a value, for equality lookups of encrypted values.
+//
+// The name of the indexed field is part of the hash, so equal values of different
+// fields have different indexes. Callers normalize the value first, e.g. lower case
+// emails.
+func (e *Envelope) BlindIndex(field, value string) string {
+	mac := hmac.New(sha256.New, e.indexKey)
+	mac.Write([]byte(field))
+	mac.Write([]byte{0})
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// IsEncrypted reports whether a value has been produced by `Encrypt`.
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, prefix)
+}
+
+// Return the data key of the current key encryption key, generating it if needed.
+func (e *Envelope) currentKey(ctx context.Context) (*dataKey, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.current != nil && e.current.keyID == e.provider.CurrentKeyID() {
+		return e.current, nil
+	}
+
+	plain := make([]byte, 32)
+	if _, err := rand.Read(plain); err != nil {
+		return nil, fmt.Errorf("failed to generate data key: %w", err)
+	}
+	keyID, wrapped, err := e.provider.WrapKey(ctx, plain)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap data key: %w", err)
+	}
+	e.current = &dataKey{keyID: keyID, wrapped: wrapped, plain: plain}
+	e.unwrapped.Set(keyID+separator+string(wrapped), plain)
+	return e.current, nil
+}
+
+// Return the plain data key, from the cache or the provider.
+func (e *Envelope) unwrap(ctx context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	cacheKey := keyID + separator + string(wrapped)
+	if plain, ok := e.unwrapped.Get(cacheKey); ok {
+		return plain, nil
+	}
+	plain, err := e.provider.UnwrapKey(ctx, keyID, wrapped)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+	e.unwrapped.Set(cacheKey, plain)
+	return plain, nil
+}
+
+// Split an encrypted value into its key ID, wrapped data key and sealed value.
+func parse(ciphertext string) (keyID string, wrapped, sealed []byte, err error) {
+	if !IsEncrypted(ciphertext) {
+		return "", nil, nil, errors.New("value is not encrypted")
+	}
+	parts := strings.Split(strings.TrimPrefix(ciphertext, prefix), separator)
+	if len(parts) != 3 {
+		return "", nil, nil, errors.New("malformed encrypted value")
+	}
+	if wrapped, err = base64.RawStdEncoding.DecodeString(parts[1]); err != nil {
+		return "", nil, nil, fmt.Errorf("malformed data key: %w", err)
+	}
+	if sealed, err = base64.RawStdEncoding.DecodeString(parts[2]); err != nil {
+		return "", nil, nil, fmt.Errorf("malformed encrypted value: %w", err)
+	}
+	return parts[0], wrapped, sealed, nil
+}
@@ -0,0 +1,129 @@
+package envelope
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Create an envelope of local keys, the key IDs are also the key bytes.
+func newTestEnvelope(t *testing.T, current string, ids ...string) *Envelope {
+	t.Helper()
+
+	keys := make(map[string][]byte, len(ids))
+	for _, id := range ids {
+		keys[id] = bytes.Repeat([]byte(id[:1]), 32)
+	}
+	provider, err := NewLocalKeyProvider(keys, current)
+	require.NoError(t, err)
+	env, err := New(provider, bytes.Repeat([]byte("i"), 32))
+	require.NoError(t, err)
+	return env
+}
+
+func TestEnvelope_EncryptDecrypt(t *testing.T) {
+	env := newTestEnvelope(t, "a", "a")
+	ctx := context.Background()
+
+	ciphertext, err := env.Encrypt(ctx, "alice@example.com", "users.email")
+	require.NoError(t, err)
+	assert.True(t, IsEncrypted(ciphertext))
+	assert.True(t, strings.HasPrefix(ciphertext, "enc:v1:a:"))
+	assert.NotContains(t, ciphertext, "alice")
+
+	plaintext, err := env.Decrypt(ctx, ciphertext, "users.email")
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", plaintext)
+
+	// The nonce is random, equal values are encrypted differently
+	other, err := env.Encrypt(ctx, "alice@example.com", "users.email")
+	require.NoError(t, err)
+	assert.NotEqual(t, ciphertext, other)
+
+	t.Run("Other additional data", func(t *testing.T) {
+		_, err := env.Decrypt(ctx, ciphertext, "users.phone")
+		assert.Error(t, err)
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		_, err := env.Decrypt(ctx, "alice@example.com", "users.email")
+		assert.ErrorContains(t, err, "not encrypted")
+		_, err = env.Decrypt(ctx, "enc:v1:a:xyz", "users.email")
+		assert.ErrorContains(t, err, "malformed")
+	})
+}
+
+func TestEnvelope_Rotation(t *testing.T) {
+	ctx := context.Background()
+	old := newTestEnvelope(t, "a", "a")
+	ciphertext, err := old.Encrypt(ctx, "+15550100", "users.phone")
+	require.NoError(t, err)
+	assert.False(t, old.NeedsRotation(ciphertext))
+
+	// After adding a current key, the values of the previous key can still be decrypted
+	env := newTestEnvelope(t, "b", "a", "b")
+	assert.True(t, env.NeedsRotation(ciphertext))
+	plaintext, err := env.Decrypt(ctx, ciphertext, "users.phone")
+	require.NoError(t, err)
+	assert.Equal(t, "+15550100", plaintext)
+
+	// Re-encrypted values use the current key
+	rotated, err := env.Encrypt(ctx, plaintext, "users.phone")
+	require.NoError(t, err)
+	assert.False(t, env.NeedsRotation(rotated))
+
+	// Without the previous key they cannot
+	_, err = newTestEnvelope(t, "b", "b").Decrypt(ctx, ciphertext, "users.phone")
+	assert.ErrorContains(t, err, "key[a] not found")
+}
+
+func TestEnvelope_BlindIndex(t *testing.T) {
+	env := newTestEnvelope(t, "a", "a")
+
+	index := env.BlindIndex("email", "alice@example.com")
+	assert.Len(t, index, 64)
+	assert.Equal(t, index, env.BlindIndex("email", "alice@example.com"))
+	assert.NotEqual(t, index, env.BlindIndex("email", "bob@example.com"))
+	assert.NotEqual(t, index, env.BlindIndex("phone", "alice@example.com"))
+
+	_, err := New(env.provider, []byte("short"))
+	assert.Error(t, err)
+}
+
+func TestLoadKeyFile(t *testing.T) {
+	key := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte("k"), 32))
+	write := func(t *testing.T, content string) string {
+		path := filepath.Join(t.TempDir(), "keys.json")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		provider, indexKey, err := LoadKeyFile(write(t, `{"current": "k1", "keys": {"k1": "`+key+`"}, "index_key": "`+key+`"}`))
+		require.NoError(t, err)
+		assert.Equal(t, "k1", provider.CurrentKeyID())
+		assert.Len(t, indexKey, 32)
+	})
+
+	t.Run("Unknown current key", func(t *testing.T) {
+		_, _, err := LoadKeyFile(write(t, `{"current": "k2", "keys": {"k1": "`+key+`"}, "index_key": "`+key+`"}`))
+		assert.ErrorContains(t, err, "current key[k2] not found")
+	})
+
+	t.Run("Short key", func(t *testing.T) {
+		_, _, err := LoadKeyFile(write(t, `{"current": "k1", "keys": {"k1": "c2hvcnQ="}, "index_key": "`+key+`"}`))
+		assert.ErrorContains(t, err, "key must be 32 bytes")
+	})
+
+	t.Run("Missing file", func(t *testing.T) {
+		_, _, err := LoadKeyFile(filepath.Join(t.TempDir(), "missing.json"))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,160 @@
+package envelope
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// KeyProvider wraps and unwraps the data keys with key encryption keys (KEK).
+//
+// The KEKs never leave the provider, so a KMS can implement it by calling its
+// encrypt and decrypt APIs.
+type KeyProvider interface {
+	// CurrentKeyID returns the ID of the KEK wrapping new data keys.
+	CurrentKeyID() string
+
+	// WrapKey encrypts a data key with the current KEK and returns the ID of that KEK.
+	WrapKey(ctx context.Context, dataKey []byte) (keyID string, wrapped []byte, err error)
+
+	// UnwrapKey decrypts a data key wrapped by the KEK of the given ID.
+	UnwrapKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error)
+}
+
+// KeyFile is the content of a local key file.
+//
+//	{
+//	  "current": "2026-10",
+//	  "keys": {"2026-10": "<base64 of 32 random bytes>"},
+//	  "index_key": "<base64 of 32 random bytes>"
+//	}
+//
+// Keys are rotated by adding a key and making it current, the previous keys are
+// kept until every value has been re-encrypted. The index key cannot be rotated
+// without recomputing the blind indexes.
+type KeyFile struct {
+	Current  string            `json:"current"`
+	Keys     map[string]string `json:"keys"`
+	IndexKey string            `json:"index_key"`
+}
+
+// LoadKeyFile reads a key file and returns the local key provider and the index key it holds.
+func LoadKeyFile(path string) (*LocalKeyProvider, []byte, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read key file[%s]: %w", path, err)
+	}
+	var kf KeyFile
+	if err := json.Unmarshal(content, &kf); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse key file[%s]: %w", path, err)
+	}
+
+	keys := make(map[string][]byte, len(kf.Keys))
+	for id, encoded := range kf.Keys {
+		key, err := decodeKey(encoded)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid key[%s] in key file[%s]: %w", id, path, err)
+		}
+		keys[id] = key
+	}
+	provider, err := NewLocalKeyProvider(keys, kf.Current)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid key file[%s]: %w", path, err)
+	}
+	indexKey, err := decodeKey(kf.IndexKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid index key in key file[%s]: %w", path, err)
+	}
+	return provider, indexKey, nil
+}
+
+// Decode a base64 AES-256 key.
+func decodeKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("key must be 32 bytes, got %d", len(key))
+	}
+	return key, nil
+}
+
+// LocalKeyProvider wraps the data keys with AES-256-GCM keys held in memory.
+type LocalKeyProvider struct {
+	current string
+	keys    map[string]cipher.AEAD
+}
+
+// NewLocalKeyProvider creates a provider from 32 bytes keys by ID.
+func NewLocalKeyProvider(keys map[string][]byte, current string) (*LocalKeyProvider, error) {
+	if _, ok := keys[current]; !ok {
+		return nil, fmt.Errorf("current key[%s] not found", current)
+	}
+
+	p := &LocalKeyProvider{current: current, keys: make(map[string]cipher.AEAD, len(keys))}
+	for id, key := range keys {
+		if id == "" || strings.Contains(id, separator) {
+			return nil, fmt.Errorf("invalid key id[%s]", id)
+		}
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key[%s]: %w", id, err)
+		}
+		p.keys[id] = aead
+	}
+	return p, nil
+}
+
+// CurrentKeyID implements KeyProvider.
+func (p *LocalKeyProvider) CurrentKeyID() string {
+	return p.current
+}
+
+// WrapKey implements KeyProvider.
+func (p *LocalKeyProvider) WrapKey(_ context.Context, dataKey []byte) (string, []byte, error) {
+	wrapped, err := seal(p.keys[p.current], dataKey, []byte(p.current))
+	return p.current, wrapped, err
+}
+
+// UnwrapKey implements KeyProvider.
+func (p *LocalKeyProvider) UnwrapKey(_ context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	aead, ok := p.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("key[%s] not found", keyID)
+	}
+	return open(aead, wrapped, []byte(keyID))
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Encrypt with a random nonce, prepended to the ciphertext.
+func seal(aead cipher.AEAD, plaintext, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+// Decrypt a ciphertext produced by `seal`.
+func open(aead cipher.AEAD, ciphertext, additionalData []byte) ([]byte, error) {
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	nonce, sealed := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	return aead.Open(nil, nonce, sealed, additionalData)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"strings"
 	"time"
+	"usermanage/internal/pkg/id"
 
 	"github.com/go-kratos/kratos/v2/transport"
 	"github.com/golang-jwt/jwt/v5"
@@ -55,12 +56,36 @@ func TokenExpireDuration() time.Duration {
 }
 
 // Claims represents the claims in a JWT token.
+//
+// Every token has a unique ID, the `jti` claim, so two tokens issued in the same
+// second to the same user are still different.
 type Claims struct {
 	Username string `json:"username"`
+	ClientID string `json:"client_id,omitempty"` // the OAuth client the token was issued to, if any
+	Scope    string `json:"scope,omitempty"`     // the space-separated OAuth scopes granted to the client
+	Grant    string `json:"grant_id,omitempty"`  // the OAuth authorization the token was issued from
 	role     int32
 	jwt.RegisteredClaims
 }
 
+// TokenOption customizes the claims of a generated token.
+type TokenOption func(*Claims)
+
+// WithClient issues the token to an OAuth client with the granted scopes.
+func WithClient(clientID, scope string) TokenOption {
+	return func(c *Claims) {
+		c.ClientID = clientID
+		c.Scope = scope
+	}
+}
+
+// WithGrant records the OAuth authorization the token is issued from, revoked along with it.
+func WithGrant(id string) TokenOption {
+	return func(c *Claims) {
+		c.Grant = id
+	}
+}
+
 // Role sets or returns the role of the user.
 func (c *Claims) Role(role ...int32) int32 {
 	if len(role) > 0 {
@@ -70,7 +95,7 @@ func (c *Claims) Role(role ...int32) int32 {
 }
 
 // GenerateToken generates a JWT token for a user.
-func GenerateToken(username string) (tokenString string, expiresAt time.Time, err error) {
+func GenerateToken(username string, opts ...TokenOption) (tokenString string, expiresAt time.Time, err error) {
 	if len(strings.TrimSpace(username)) == 0 {
 		return "", time.Time{}, errors.New("username is required")
 	}
@@ -80,11 +105,15 @@ func GenerateToken(username string) (tokenString string, expiresAt time.Time, er
 	claims := Claims{
 		Username: username,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        id.GenerateUUID(true),
 			ExpiresAt: jwt.NewNumericDate(expiresAt),
 			IssuedAt:  jwt.NewNumericDate(now),
 			NotBefore: jwt.NewNumericDate(now),
 		},
 	}
+	for _, opt := range opts {
+		opt(&claims)
+	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	tokenString, err = token.SignedString(jwtKey)
 	return tokenString, expiresAt, err
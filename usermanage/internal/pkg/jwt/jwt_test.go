@@ -23,3 +23,29 @@ func TestGenerateToken(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Greater(t, len(token), 0)
 }
+
+func TestGenerateTokenUniqueID(t *testing.T) {
+	Initialize([]byte("foo"), 2*time.Hour)
+	token1, _, err := GenerateToken("foo")
+	assert.Nil(t, err)
+	token2, _, err := GenerateToken("foo")
+	assert.Nil(t, err)
+	assert.NotEqual(t, token1, token2)
+
+	claims, err := ParseToken(token1)
+	assert.Nil(t, err)
+	assert.Len(t, claims.ID, 32)
+}
+
+func TestGenerateTokenWithClient(t *testing.T) {
+	Initialize([]byte("foo"), 2*time.Hour)
+	token, _, err := GenerateToken("foo", WithClient("app", "openid email"), WithGrant("g1"))
+	assert.Nil(t, err)
+
+	claims, err := ParseToken(token)
+	assert.Nil(t, err)
+	assert.Equal(t, "foo", claims.Username)
+	assert.Equal(t, "app", claims.ClientID)
+	assert.Equal(t, "openid email", claims.Scope)
+	assert.Equal(t, "g1", claims.Grant)
+}
@@ -0,0 +1,211 @@
+// Package ldap authenticates the users against an LDAP directory or Active Directory:
+// the user's entry is searched with a service account, then bound to with the user's password.
+package ldap
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	// DefaultUserFilter finds the entry of a user by its `uid`, `(sAMAccountName=%s)` for Active Directory.
+	DefaultUserFilter = "(uid=%s)"
+
+	defaultTimeout = 10 * time.Second
+)
+
+var (
+	// ErrInvalidCredentials is returned when the user does not exist in the directory or the password is wrong.
+	ErrInvalidCredentials = errors.New("invalid credentials")
+)
+
+// Config is the connection to the directory and where the users are searched.
+type Config struct {
+	URL                string // `ldaps://host:636` or `ldap://host:389`
+	StartTLS           bool   // upgrade an `ldap://` connection to TLS
+	InsecureSkipVerify bool
+	CAFile             string // PEM-encoded CA certificates of the server, the system pool if empty
+	BindDN             string // the service account searching the users, anonymous if empty
+	BindPassword       string
+	BaseDN             string
+	UserFilter         string   // with `%s` for the escaped username, DefaultUserFilter if empty
+	Attributes         []string // the attributes of the entry to return
+	Timeout            time.Duration
+}
+
+// Entry is the entry of an authenticated user.
+type Entry struct {
+	DN         string
+	Attributes map[string][]string
+}
+
+// Get returns the first value of an attribute, the names being case-insensitive.
+func (e *Entry) Get(name string) string {
+	if values := e.Values(name); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// Values returns the values of an attribute, the names being case-insensitive.
+func (e *Entry) Values(name string) []string {
+	for attr, values := range e.Attributes {
+		if strings.EqualFold(attr, name) {
+			return values
+		}
+	}
+	return nil
+}
+
+// conn is the part of the connection used, to be faked by the tests.
+type conn interface {
+	Bind(username, password string) error
+	Search(req *ldap.SearchRequest) (*ldap.SearchResult, error)
+	Close() error
+}
+
+// Client authenticates the users against a directory, a connection per login.
+type Client struct {
+	cfg  Config
+	dial func(ctx context.Context) (conn, error)
+}
+
+// NewClient creates a client of a directory.
+func NewClient(cfg Config) (*Client, error) {
+	u, err := url.Parse(cfg.URL)
+	if err != nil || (u.Scheme != "ldap" && u.Scheme != "ldaps") || u.Host == "" {
+		return nil, fmt.Errorf("invalid url[%s], `ldap://` or `ldaps://` is required", cfg.URL)
+	}
+	if cfg.StartTLS && u.Scheme == "ldaps" {
+		return nil, errors.New("start tls is for `ldap://` urls")
+	}
+	if cfg.BaseDN == "" {
+		return nil, errors.New("base dn is required")
+	}
+	if cfg.UserFilter == "" {
+		cfg.UserFilter = DefaultUserFilter
+	}
+	if strings.Count(cfg.UserFilter, "%s") != 1 {
+		return nil, fmt.Errorf("user filter[%s] must contain `%%s` once", cfg.UserFilter)
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+
+	tlsConfig := &tls.Config{
+		ServerName:         u.Hostname(),
+		InsecureSkipVerify: cfg.InsecureSkipVerify, //nolint:gosec // opt-in, for test directories only
+		MinVersion:         tls.VersionTLS12,
+	}
+	if cfg.CAFile != "" {
+		pem, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read ca file: %w", err)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificate in ca file[%s]", cfg.CAFile)
+		}
+	}
+
+	c := &Client{cfg: cfg}
+	c.dial = func(ctx context.Context) (conn, error) {
+		dialer := &net.Dialer{Timeout: cfg.Timeout}
+		if deadline, ok := ctx.Deadline(); ok {
+			dialer.Deadline = deadline
+		}
+		l, err := ldap.DialURL(cfg.URL, ldap.DialWithDialer(dialer), ldap.DialWithTLSConfig(tlsConfig))
+		if err != nil {
+			return nil, err
+		}
+		l.SetTimeout(cfg.Timeout)
+		if cfg.StartTLS {
+			if err := l.StartTLS(tlsConfig); err != nil {
+				l.Close()
+				return nil, fmt.Errorf("failed to start tls: %w", err)
+			}
+		}
+		return l, nil
+	}
+	return c, nil
+}
+
+// Authenticate verifies the password of a user and returns the user's entry.
+//
+// The connection is closed once the context is done, failing the pending request.
+func (c *Client) Authenticate(ctx context.Context, username, password string) (*Entry, error) {
+	// An empty password is an unauthenticated bind, which succeeds on most servers (RFC 4513, 5.1.2)
+	if username == "" || password == "" {
+		return nil, ErrInvalidCredentials
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	l, err := c.dial(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to directory: %w", err)
+	}
+	defer l.Close()
+	stop := context.AfterFunc(ctx, func() { l.Close() })
+	defer stop()
+
+	entry, err := c.authenticate(l, username, password)
+	if err != nil && ctx.Err() != nil {
+		return nil, fmt.Errorf("failed to authenticate user[%s]: %w", username, ctx.Err())
+	}
+	return entry, err
+}
+
+// Verify the password of a user over the connection and return the user's entry.
+func (c *Client) authenticate(l conn, username, password string) (*Entry, error) {
+	if c.cfg.BindDN != "" {
+		if err := l.Bind(c.cfg.BindDN, c.cfg.BindPassword); err != nil {
+			return nil, fmt.Errorf("failed to bind service account: %w", err)
+		}
+	}
+	result, err := l.Search(ldap.NewSearchRequest(
+		c.cfg.BaseDN,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		2, // more than one entry is an ambiguous filter
+		int(c.cfg.Timeout.Seconds()),
+		false,
+		fmt.Sprintf(c.cfg.UserFilter, ldap.EscapeFilter(username)),
+		c.cfg.Attributes,
+		nil,
+	))
+	if err != nil && !ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded) {
+		return nil, fmt.Errorf("failed to search user[%s]: %w", username, err)
+	}
+	switch {
+	case result == nil || len(result.Entries) == 0:
+		return nil, fmt.Errorf("%w: user[%s] not found", ErrInvalidCredentials, username)
+	case len(result.Entries) > 1:
+		return nil, fmt.Errorf("filter matches several entries for user[%s]", username)
+	}
+
+	found := result.Entries[0]
+	if err := l.Bind(found.DN, password); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return nil, fmt.Errorf("%w: wrong password of user[%s]", ErrInvalidCredentials, username)
+		}
+		return nil, fmt.Errorf("failed to bind user[%s]: %w", username, err)
+	}
+
+	entry := &Entry{DN: found.DN, Attributes: make(map[string][]string, len(found.Attributes))}
+	for _, attr := range found.Attributes {
+		entry.Attributes[attr.Name] = attr.Values
+	}
+	return entry, nil
+}
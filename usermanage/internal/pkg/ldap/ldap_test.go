@@ -0,0 +1,128 @@
+package ldap
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeConn is a directory of entries with their passwords.
+type fakeConn struct {
+	passwords map[string]string
+	entries   []*ldap.Entry
+	filters   []string
+	closed    chan struct{} // if not nil, binds hang until the connection is closed
+	once      sync.Once
+}
+
+func (c *fakeConn) Bind(dn, password string) error {
+	if c.closed != nil {
+		<-c.closed
+		return ldap.NewError(ldap.ErrorNetwork, errors.New("connection closed"))
+	}
+	if p, ok := c.passwords[dn]; !ok || p != password {
+		return ldap.NewError(ldap.LDAPResultInvalidCredentials, errors.New("invalid credentials"))
+	}
+	return nil
+}
+
+func (c *fakeConn) Search(req *ldap.SearchRequest) (*ldap.SearchResult, error) {
+	c.filters = append(c.filters, req.Filter)
+	// Only equality filters are faked, the escaped values match nothing
+	attr, value, _ := strings.Cut(strings.Trim(req.Filter, "()"), "=")
+	result := &ldap.SearchResult{}
+	for _, e := range c.entries {
+		if e.GetAttributeValue(attr) == value {
+			result.Entries = append(result.Entries, e)
+		}
+	}
+	return result, nil
+}
+
+func (c *fakeConn) Close() error {
+	if c.closed != nil {
+		c.once.Do(func() { close(c.closed) })
+	}
+	return nil
+}
+
+func TestClient_Authenticate(t *testing.T) {
+	directory := &fakeConn{
+		passwords: map[string]string{
+			"cn=svc,dc=example,dc=com":             "svc-secret",
+			"uid=jane,ou=people,dc=example,dc=com": "jane-secret",
+		},
+		entries: []*ldap.Entry{
+			ldap.NewEntry("uid=jane,ou=people,dc=example,dc=com", map[string][]string{
+				"uid":      {"jane"},
+				"mail":     {"jane@example.com"},
+				"memberOf": {"cn=admins,ou=groups,dc=example,dc=com", "cn=staff,ou=groups,dc=example,dc=com"},
+			}),
+			ldap.NewEntry("uid=twin,ou=a,dc=example,dc=com", map[string][]string{"uid": {"twin"}}),
+			ldap.NewEntry("uid=twin,ou=b,dc=example,dc=com", map[string][]string{"uid": {"twin"}}),
+		},
+	}
+	client, err := NewClient(Config{
+		URL:          "ldap://ldap.example.com",
+		BindDN:       "cn=svc,dc=example,dc=com",
+		BindPassword: "svc-secret",
+		BaseDN:       "dc=example,dc=com",
+	})
+	require.NoError(t, err)
+	client.dial = func(context.Context) (conn, error) { return directory, nil }
+	ctx := context.Background()
+
+	entry, err := client.Authenticate(ctx, "jane", "jane-secret")
+	require.NoError(t, err)
+	assert.Equal(t, "uid=jane,ou=people,dc=example,dc=com", entry.DN)
+	assert.Equal(t, "jane@example.com", entry.Get("MAIL"))
+	assert.Len(t, entry.Values("memberof"), 2)
+
+	_, err = client.Authenticate(ctx, "jane", "wrong")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+	_, err = client.Authenticate(ctx, "jane", "")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+	_, err = client.Authenticate(ctx, "nobody", "secret")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+
+	// An ambiguous filter is an error, not a login as whichever entry comes first
+	_, err = client.Authenticate(ctx, "twin", "secret")
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrInvalidCredentials)
+
+	_, err = client.Authenticate(ctx, "*)(uid=*", "secret")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+	assert.Equal(t, `(uid=\2a\29\28uid=\2a)`, directory.filters[len(directory.filters)-1])
+
+	t.Run("the context aborts a hanging directory", func(t *testing.T) {
+		hanging := &fakeConn{closed: make(chan struct{})}
+		client.dial = func(context.Context) (conn, error) { return hanging, nil }
+		ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+
+		_, err := client.Authenticate(ctx, "jane", "jane-secret")
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		_, err = client.Authenticate(ctx, "jane", "jane-secret")
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestNewClient(t *testing.T) {
+	for name, cfg := range map[string]Config{
+		"scheme":          {URL: "http://ldap.example.com", BaseDN: "dc=example,dc=com"},
+		"base dn":         {URL: "ldap://ldap.example.com"},
+		"filter":          {URL: "ldap://ldap.example.com", BaseDN: "dc=example,dc=com", UserFilter: "(uid=jane)"},
+		"start tls":       {URL: "ldaps://ldap.example.com", BaseDN: "dc=example,dc=com", StartTLS: true},
+		"missing ca file": {URL: "ldaps://ldap.example.com", BaseDN: "dc=example,dc=com", CAFile: "/nonexistent/ca.pem"},
+	} {
+		_, err := NewClient(cfg)
+		assert.Error(t, err, name)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 	"time"
 	"usermanage/internal/biz"
+	"usermanage/internal/pkg/auth"
 	"usermanage/internal/pkg/jwt"
 	"usermanage/internal/pkg/tracingx"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/go-kratos/kratos/v2/middleware"
 	"github.com/go-kratos/kratos/v2/transport"
+	"google.golang.org/grpc"
 )
 
 var skipAuthPaths = map[string]bool{
@@ -37,51 +39,10 @@ func JWTAuth(authUseCase *biz.AuthUseCase) middleware.Middleware {
 					return handler(ctx, req)
 				}
 
-				// Extract the JWT token from the request
-				token := tr.RequestHeader().Get("Authorization")
-				if token == "" {
-					logger.Log(log.LevelError, "msg", "missing Authorization header")
-					err := errors.Unauthorized("MISSING_TOKEN", "Missing token").
-						WithMetadata(md)
-					return nil, err
-				}
-
-				token = strings.TrimPrefix(token, "Bearer ")
-				claims, err := jwt.ParseToken(token)
+				ctx, claims, token, err := authenticate(ctx, authUseCase, tr.RequestHeader().Get("Authorization"))
 				if err != nil {
-					logger.Log(log.LevelError, "msg", "failed to parse token", "error", err)
-					err = errors.Unauthorized("INVALID_TOKEN", "Invalid or expired token").
-						WithMetadata(md)
-					return nil, err
-				}
-
-				// Check if the token exists
-				if exists, err := authUseCase.TokenExists(ctx, token); err != nil || !exists {
-					logger.Log(log.LevelError, "msg", "failed to check token", "error", err, "exists", exists)
-					err = errors.Unauthorized("INVALID_TOKEN", "Invalid or expired token").
-						WithMetadata(md)
-					return nil, err
-				}
-
-				user, err := authUseCase.GetUserByUsername(ctx, claims.Username)
-				if err != nil {
-					logger.Log(log.LevelError, "msg", "failed to get user by username", "error", err)
-					err = errors.InternalServer("GET_USER_BY_USERNAME", "Failed to get user by username").
-						WithMetadata(md)
-					return nil, err
-				}
-
-				// Verify user status
-				if !user.Status.IsNormal() {
-					logger.Log(log.LevelError, "msg", "invalid user status", "status", user.Status)
-					err = errors.Forbidden("INVALID_USER_STATUS", "Invalid user status").
-						WithMetadata(md)
 					return nil, err
 				}
-
-				// Set the user role in the token claims
-				claims.Role(int32(user.Role))
-				ctx = jwt.WithContext(ctx, claims)
 				resp, err := handler(ctx, req)
 				if shouldExtendToken(claims) {
 					if err := authUseCase.ExtendTokenExpiry(ctx, token); err != nil {
@@ -99,6 +60,112 @@ func JWTAuth(authUseCase *biz.AuthUseCase) middleware.Middleware {
 	}
 }
 
+// JWTAuthStream is a gRPC stream interceptor that authenticates the user using JWT.
+//
+// Kratos stream middlewares cannot pass a new context to the stream handler,
+// so streams are authenticated by an interceptor instead.
+// The token expiry is extended when the stream starts.
+func JWTAuthStream(authUseCase *biz.AuthUseCase) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := ss.Context()
+		logger := log.WithContext(ctx, log.GetLogger())
+		if skipAuthPaths[info.FullMethod] {
+			return handler(srv, ss)
+		}
+
+		var header string
+		if tr, ok := transport.FromServerContext(ctx); ok {
+			header = tr.RequestHeader().Get("Authorization")
+		}
+		ctx, claims, token, err := authenticate(ctx, authUseCase, header)
+		if err != nil {
+			return err
+		}
+		if shouldExtendToken(claims) {
+			if err := authUseCase.ExtendTokenExpiry(ctx, token); err != nil {
+				logger.Log(log.LevelError, "msg", "failed to extend token expiry", "error", err)
+			}
+		}
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authenticatedStream is a server stream carrying the context of the authenticated user.
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+// Verify the token of the `Authorization` header and the status of its user.
+//
+// Return the context carrying the token claims and the check of the session, the claims and
+// the raw token.
+func authenticate(ctx context.Context, authUseCase *biz.AuthUseCase, header string) (context.Context, *jwt.Claims, string, error) {
+	logger := log.WithContext(ctx, log.GetLogger())
+	md := map[string]string{"traceId": tracingx.GetTraceID(ctx)}
+
+	// Extract the JWT token from the request
+	if header == "" {
+		logger.Log(log.LevelError, "msg", "missing Authorization header")
+		err := errors.Unauthorized("MISSING_TOKEN", "Missing token").
+			WithMetadata(md)
+		return nil, nil, "", err
+	}
+
+	token := strings.TrimPrefix(header, "Bearer ")
+	claims, err := jwt.ParseToken(token)
+	if err != nil {
+		logger.Log(log.LevelError, "msg", "failed to parse token", "error", err)
+		err = errors.Unauthorized("INVALID_TOKEN", "Invalid or expired token").
+			WithMetadata(md)
+		return nil, nil, "", err
+	}
+
+	// The tokens of OAuth clients only grant their scopes, at the userinfo endpoint
+	if claims.ClientID != "" {
+		logger.Log(log.LevelError, "msg", "oauth client token used", "client.id", claims.ClientID)
+		err = errors.Unauthorized("CLIENT_TOKEN", "OAuth client tokens are only accepted by the userinfo endpoint").
+			WithMetadata(md)
+		return nil, nil, "", err
+	}
+
+	// Check if the token exists
+	if exists, err := authUseCase.TokenExists(ctx, token); err != nil || !exists {
+		logger.Log(log.LevelError, "msg", "failed to check token", "error", err, "exists", exists)
+		err = errors.Unauthorized("INVALID_TOKEN", "Invalid or expired token").
+			WithMetadata(md)
+		return nil, nil, "", err
+	}
+
+	user, err := authUseCase.GetUserByUsername(ctx, claims.Username)
+	if err != nil {
+		logger.Log(log.LevelError, "msg", "failed to get user by username", "error", err)
+		err = errors.InternalServer("GET_USER_BY_USERNAME", "Failed to get user by username").
+			WithMetadata(md)
+		return nil, nil, "", err
+	}
+
+	// Verify user status
+	if !user.Status.IsNormal() {
+		logger.Log(log.LevelError, "msg", "invalid user status", "status", user.Status)
+		err = errors.Forbidden("INVALID_USER_STATUS", "Invalid user status").
+			WithMetadata(md)
+		return nil, nil, "", err
+	}
+
+	// Set the user role in the token claims
+	claims.Role(int32(user.Role))
+	ctx = auth.WithSession(jwt.WithContext(ctx, claims), func(ctx context.Context) error {
+		_, _, _, err := authenticate(ctx, authUseCase, header)
+		return err
+	})
+	return ctx, claims, token, nil
+}
+
 // Check if the token should be extended.
 //
 // The token should be extended if the time until the token expires is less than 30% of the total lifetime.
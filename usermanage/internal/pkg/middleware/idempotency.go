@@ -0,0 +1,247 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+	"usermanage/internal/pkg/auth"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/go-kratos/kratos/v2/transport/http"
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+const (
+	// IdempotencyKeyHeader is the header, or gRPC metadata, carrying the idempotency key.
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader is set on the responses replayed from an earlier request.
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	idempotencyKeyMaxLen = 255
+	// How long a key is held by a request in progress past its deadline, so a crashed
+	// instance does not hold it forever.
+	idempotencyLockTTL = time.Minute
+)
+
+// finalizeIdempotencyScript stores the record of a response, if the key is still held by
+// the pending record of its request: it may have expired and been claimed by a retry since.
+var finalizeIdempotencyScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) ~= ARGV[1] then
+  return 0
+end
+redis.call('SET', KEYS[1], ARGV[2], 'PX', ARGV[3])
+return 1
+`)
+
+// releaseIdempotencyScript releases a key, if it is still held by the pending record of
+// the request releasing it.
+var releaseIdempotencyScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) ~= ARGV[1] then
+  return 0
+end
+return redis.call('DEL', KEYS[1])
+`)
+
+// Loader is a request read by its handler once the middlewares before it accepted it, e.g.
+// an upload not worth reading for unauthenticated callers. Middlewares needing the content
+// of the request load it first.
+type Loader interface {
+	Load() error
+}
+
+// idempotencyRecord is the state of an idempotency key stored in Redis.
+type idempotencyRecord struct {
+	Fingerprint string            `json:"fingerprint"`     // of the request
+	Claim       string            `json:"claim,omitempty"` // random, of the request holding the key
+	Done        bool              `json:"done"`
+	ReplyType   string            `json:"replyType,omitempty"`
+	Reply       []byte            `json:"reply,omitempty"`
+	Error       *idempotencyError `json:"error,omitempty"`
+}
+
+// idempotencyError is an error response kept for an idempotency key.
+type idempotencyError struct {
+	Code     int32             `json:"code"`
+	Reason   string            `json:"reason"`
+	Message  string            `json:"message"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// Idempotency processes the requests carrying an `Idempotency-Key` header once per key and caller.
+//
+// The response of the first request is kept in Redis for `window`, and replayed to the
+// retries with the `Idempotent-Replayed` header. Reusing a key for another request fails
+// with `IDEMPOTENCY_KEY_REUSED`, retrying while the first request is in progress fails with
+// `IDEMPOTENCY_KEY_IN_USE`. Server errors are not kept, so the request can be retried.
+//
+// A request holds its key until its deadline, plus a minute. A request completing after its
+// key expired, and may be claimed by a retry, neither keeps its response nor releases the key.
+//
+// It must run after the authentication, HTTP GET requests are never deduplicated. Without
+// Redis it does nothing.
+func Idempotency(rdb redis.UniversalClient, keyPrefix string, window time.Duration, logger log.Logger) middleware.Middleware {
+	helper := log.NewHelper(logger)
+	return func(handler middleware.Handler) middleware.Handler {
+		if rdb == nil {
+			return handler
+		}
+		return func(ctx context.Context, req any) (any, error) {
+			tr, ok := transport.FromServerContext(ctx)
+			if !ok || !isMutation(tr) {
+				return handler(ctx, req)
+			}
+			key := tr.RequestHeader().Get(IdempotencyKeyHeader)
+			if key == "" {
+				return handler(ctx, req)
+			}
+			if len(key) > idempotencyKeyMaxLen {
+				return nil, errors.BadRequest("INVALID_IDEMPOTENCY_KEY", "Idempotency key must be at most 255 characters")
+			}
+			if loader, ok := req.(Loader); ok {
+				if err := loader.Load(); err != nil {
+					return nil, err
+				}
+			}
+			message, ok := req.(proto.Message)
+			if !ok {
+				return handler(ctx, req)
+			}
+			fingerprint, err := requestFingerprint(tr.Operation(), message)
+			if err != nil {
+				return nil, err
+			}
+
+			sum := sha256.Sum256([]byte(auth.Username(ctx) + "\x00" + key))
+			redisKey := keyPrefix + "idempotency:" + hex.EncodeToString(sum[:])
+			claim := make([]byte, 16)
+			if _, err := rand.Read(claim); err != nil {
+				return nil, err
+			}
+			pending, _ := json.Marshal(idempotencyRecord{Fingerprint: fingerprint, Claim: hex.EncodeToString(claim)})
+			claimed, err := rdb.SetNX(ctx, redisKey, pending, lockTTL(ctx)).Result()
+			if err != nil {
+				helper.WithContext(ctx).Errorw("msg", "failed to claim idempotency key", "error", err)
+				return nil, errors.ServiceUnavailable("IDEMPOTENCY_UNAVAILABLE", "Idempotency keys are unavailable, retry later")
+			}
+			if !claimed {
+				return replay(ctx, rdb, tr, redisKey, fingerprint)
+			}
+
+			reply, err := handler(ctx, req)
+
+			// Keep or release the key even if the caller is gone
+			ctx = context.WithoutCancel(ctx)
+			if record, ok := newIdempotencyRecord(fingerprint, reply, err); ok {
+				data, _ := json.Marshal(record)
+				stored, err := finalizeIdempotencyScript.Run(ctx, rdb, []string{redisKey}, pending, data, window.Milliseconds()).Int()
+				if err != nil {
+					helper.WithContext(ctx).Errorw("msg", "failed to store idempotent response", "error", err)
+				} else if stored == 0 {
+					helper.WithContext(ctx).Warnw("msg", "idempotency key expired before the request completed")
+				}
+			} else if err := releaseIdempotencyScript.Run(ctx, rdb, []string{redisKey}, pending).Err(); err != nil {
+				helper.WithContext(ctx).Errorw("msg", "failed to release idempotency key", "error", err)
+			}
+			return reply, err
+		}
+	}
+}
+
+// Return how long a request holds its key: until its deadline, if any, plus idempotencyLockTTL.
+func lockTTL(ctx context.Context) time.Duration {
+	if deadline, ok := ctx.Deadline(); ok {
+		return max(time.Until(deadline), 0) + idempotencyLockTTL
+	}
+	return idempotencyLockTTL
+}
+
+// Return the response of the request which claimed the key first.
+func replay(ctx context.Context, rdb redis.UniversalClient, tr transport.Transporter, redisKey, fingerprint string) (any, error) {
+	inUse := errors.Conflict("IDEMPOTENCY_KEY_IN_USE", "A request with this idempotency key is in progress, retry later")
+
+	data, err := rdb.Get(ctx, redisKey).Bytes()
+	if err != nil {
+		// Released by a failed request in the meantime
+		return nil, inUse
+	}
+	var record idempotencyRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, inUse
+	}
+	if record.Fingerprint != fingerprint {
+		return nil, errors.BadRequest("IDEMPOTENCY_KEY_REUSED", "The idempotency key has been used for another request")
+	}
+	if !record.Done {
+		return nil, inUse
+	}
+
+	tr.ReplyHeader().Set(IdempotentReplayedHeader, "true")
+	if e := record.Error; e != nil {
+		return nil, errors.New(int(e.Code), e.Reason, e.Message).WithMetadata(e.Metadata)
+	}
+	mt, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(record.ReplyType))
+	if err != nil {
+		return nil, errors.InternalServer("IDEMPOTENT_REPLAY_FAILED", "Failed to replay the response")
+	}
+	reply := mt.New().Interface()
+	if err := proto.Unmarshal(record.Reply, reply); err != nil {
+		return nil, errors.InternalServer("IDEMPOTENT_REPLAY_FAILED", "Failed to replay the response")
+	}
+	return reply, nil
+}
+
+// Return the record of a response, false if it must not be kept: server errors and replies
+// which are not protobuf messages.
+func newIdempotencyRecord(fingerprint string, reply any, err error) (*idempotencyRecord, bool) {
+	record := &idempotencyRecord{Fingerprint: fingerprint, Done: true}
+	if err != nil {
+		se := errors.FromError(err)
+		if se.Code >= 500 {
+			return nil, false
+		}
+		record.Error = &idempotencyError{Code: se.Code, Reason: se.Reason, Message: se.Message, Metadata: se.Metadata}
+		return record, true
+	}
+
+	message, ok := reply.(proto.Message)
+	if !ok {
+		return nil, false
+	}
+	data, err := proto.Marshal(message)
+	if err != nil {
+		return nil, false
+	}
+	record.ReplyType = string(message.ProtoReflect().Descriptor().FullName())
+	record.Reply = data
+	return record, true
+}
+
+// Return the hash identifying a request, to detect the reuse of a key for another request.
+func requestFingerprint(operation string, req proto.Message) (string, error) {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return "", errors.BadRequest("INVALID_REQUEST", "Invalid request")
+	}
+	sum := sha256.Sum256(append([]byte(operation+"\x00"), data...))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Report whether the request may change anything, all gRPC requests may.
+func isMutation(tr transport.Transporter) bool {
+	if ht, ok := tr.(http.Transporter); ok {
+		switch ht.Request().Method {
+		case "GET", "HEAD", "OPTIONS":
+			return false
+		}
+	}
+	return true
+}
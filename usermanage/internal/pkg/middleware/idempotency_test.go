@@ -0,0 +1,181 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	nethttp "net/http"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// headerCarrier is a transport.Header of an http.Header.
+type headerCarrier nethttp.Header
+
+func (h headerCarrier) Get(key string) string      { return nethttp.Header(h).Get(key) }
+func (h headerCarrier) Set(key, value string)      { nethttp.Header(h).Set(key, value) }
+func (h headerCarrier) Add(key, value string)      { nethttp.Header(h).Add(key, value) }
+func (h headerCarrier) Values(key string) []string { return nethttp.Header(h).Values(key) }
+func (h headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// testTransport is a gRPC-like transport.Transporter.
+type testTransport struct {
+	request headerCarrier
+	reply   headerCarrier
+}
+
+func (t *testTransport) Kind() transport.Kind            { return transport.KindGRPC }
+func (t *testTransport) Endpoint() string                { return "" }
+func (t *testTransport) Operation() string               { return "/user.v1.UserService/CreateUser" }
+func (t *testTransport) RequestHeader() transport.Header { return t.request }
+func (t *testTransport) ReplyHeader() transport.Header   { return t.reply }
+
+func TestIdempotency(t *testing.T) {
+	s := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: s.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+
+	calls := 0
+	handler := Idempotency(rdb, "test:", time.Hour, log.DefaultLogger)(func(ctx context.Context, req any) (any, error) {
+		calls++
+		name := req.(*wrapperspb.StringValue).Value
+		switch name {
+		case "invalid":
+			return nil, errors.BadRequest("INVALID_REQUEST", "Invalid request")
+		case "failing":
+			return nil, errors.InternalServer("FAILED", "Failed")
+		}
+		return wrapperspb.String("created " + name), nil
+	})
+	call := func(key string, req string) (*testTransport, any, error) {
+		tr := &testTransport{request: headerCarrier{}, reply: headerCarrier{}}
+		if key != "" {
+			tr.request.Set(IdempotencyKeyHeader, key)
+		}
+		reply, err := handler(transport.NewServerContext(context.Background(), tr), wrapperspb.String(req))
+		return tr, reply, err
+	}
+
+	t.Run("Replayed", func(t *testing.T) {
+		calls = 0
+		_, first, err := call("key-1", "foo")
+		require.NoError(t, err)
+		tr, retry, err := call("key-1", "foo")
+		require.NoError(t, err)
+		assert.Equal(t, 1, calls)
+		assert.True(t, proto.Equal(first.(proto.Message), retry.(proto.Message)))
+		assert.Equal(t, "true", tr.reply.Get(IdempotentReplayedHeader))
+		assert.InDelta(t, time.Hour, s.TTL(s.Keys()[0]), float64(time.Second))
+	})
+
+	t.Run("Reused for another request", func(t *testing.T) {
+		_, _, err := call("key-1", "bar")
+		assert.Equal(t, "IDEMPOTENCY_KEY_REUSED", errors.Reason(err))
+	})
+
+	t.Run("Client errors are replayed", func(t *testing.T) {
+		calls = 0
+		_, _, err := call("key-2", "invalid")
+		assert.Equal(t, "INVALID_REQUEST", errors.Reason(err))
+		_, _, err = call("key-2", "invalid")
+		assert.Equal(t, "INVALID_REQUEST", errors.Reason(err))
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("Server errors are not kept", func(t *testing.T) {
+		calls = 0
+		_, _, err := call("key-3", "failing")
+		assert.Equal(t, "FAILED", errors.Reason(err))
+		_, _, err = call("key-3", "failing")
+		assert.Equal(t, "FAILED", errors.Reason(err))
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("In progress", func(t *testing.T) {
+		calls = 0
+		// Claimed by a request which has not completed, of an anonymous caller
+		fingerprint, err := requestFingerprint("/user.v1.UserService/CreateUser", wrapperspb.String("foo"))
+		require.NoError(t, err)
+		sum := sha256.Sum256([]byte("\x00key-4"))
+		require.NoError(t, s.Set("test:idempotency:"+hex.EncodeToString(sum[:]), `{"fingerprint":"`+fingerprint+`"}`))
+
+		_, _, err = call("key-4", "foo")
+		assert.Equal(t, "IDEMPOTENCY_KEY_IN_USE", errors.Reason(err))
+		assert.Zero(t, calls)
+	})
+
+	t.Run("Without key", func(t *testing.T) {
+		calls = 0
+		_, _, err := call("", "foo")
+		require.NoError(t, err)
+		_, _, err = call("", "foo")
+		require.NoError(t, err)
+		assert.Equal(t, 2, calls)
+	})
+}
+
+func TestIdempotency_Expired(t *testing.T) {
+	s := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: s.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+	sum := sha256.Sum256([]byte("\x00key-1"))
+	redisKey := "test:idempotency:" + hex.EncodeToString(sum[:])
+	retry := `{"fingerprint":"retry","claim":"other"}`
+
+	var held time.Duration
+	handler := Idempotency(rdb, "test:", time.Hour, log.DefaultLogger)(func(ctx context.Context, req any) (any, error) {
+		held = s.TTL(redisKey)
+		// Expired, then claimed by a retry
+		s.Del(redisKey)
+		require.NoError(t, s.Set(redisKey, retry))
+		if req.(*wrapperspb.StringValue).Value == "failing" {
+			return nil, errors.InternalServer("FAILED", "Failed")
+		}
+		return wrapperspb.String("created"), nil
+	})
+	call := func(req string) error {
+		tr := &testTransport{request: headerCarrier{}, reply: headerCarrier{}}
+		tr.request.Set(IdempotencyKeyHeader, "key-1")
+		ctx, cancel := context.WithTimeout(transport.NewServerContext(context.Background(), tr), 10*time.Minute)
+		defer cancel()
+		_, err := handler(ctx, wrapperspb.String(req))
+		return err
+	}
+
+	t.Run("Held until the deadline", func(t *testing.T) {
+		require.NoError(t, call("foo"))
+		assert.InDelta(t, 10*time.Minute+idempotencyLockTTL, held, float64(time.Second))
+	})
+
+	t.Run("Response not kept", func(t *testing.T) {
+		s.Del(redisKey)
+		require.NoError(t, call("foo"))
+		got, err := s.Get(redisKey)
+		require.NoError(t, err)
+		assert.Equal(t, retry, got)
+	})
+
+	t.Run("Key not released", func(t *testing.T) {
+		s.Del(redisKey)
+		assert.Equal(t, "FAILED", errors.Reason(call("failing")))
+		got, err := s.Get(redisKey)
+		require.NoError(t, err)
+		assert.Equal(t, retry, got)
+	})
+}
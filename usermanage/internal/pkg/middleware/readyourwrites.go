@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"context"
+	"usermanage/internal/pkg/db"
+
+	"github.com/go-kratos/kratos/v2/middleware"
+)
+
+// ReadYourWrites scopes the database read routing to the request.
+//
+// Reads go to the same replica for the whole request, and to the primary once the
+// request wrote anything, so a handler always sees its own changes.
+func ReadYourWrites() middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req any) (any, error) {
+			return handler(db.WithReadYourWrites(ctx), req)
+		}
+	}
+}
@@ -0,0 +1,415 @@
+// Package migrate applies versioned SQL schema migrations.
+//
+// Migrations are pairs of files named `<version>_<name>.up.sql` and
+// `<version>_<name>.down.sql`, applied in version order. Applied migrations are
+// recorded in the `schema_migrations` table along with the checksum of their up
+// script, so a migration edited after being applied is detected.
+//
+// Statements of a script are separated by a `;` at the end of a line.
+package migrate
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TableName is the table recording the applied migrations.
+const TableName = "schema_migrations"
+
+const (
+	defaultLockTimeout = time.Minute
+	lockName           = "schema_migrations"
+)
+
+var fileNameRegexp = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration is a versioned schema change.
+type Migration struct {
+	Version  int64
+	Name     string
+	Up       string
+	Down     string
+	Checksum string // SHA-256 of the up script
+}
+
+// Status is the state of a migration in the database.
+type Status struct {
+	Migration *Migration
+	Applied   bool
+	AppliedAt time.Time
+	// Modified reports the up script changed after the migration was applied.
+	Modified bool
+}
+
+// Load reads the migrations of a directory, sorted by version.
+func Load(fsys fs.FS, dir string) ([]*Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations directory[%s]: %w", dir, err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		matches := fileNameRegexp.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			return nil, fmt.Errorf("invalid migration file name[%s]", entry.Name())
+		}
+		version, err := strconv.ParseInt(matches[1], 10, 64)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("invalid migration version[%s]", entry.Name())
+		}
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration file[%s]: %w", entry.Name(), err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: matches[2]}
+			byVersion[version] = m
+		} else if m.Name != matches[2] {
+			return nil, fmt.Errorf("migration version[%d] is used by %s and %s", version, m.Name, matches[2])
+		}
+		if matches[3] == "up" {
+			m.Up = string(content)
+			sum := sha256.Sum256(content)
+			m.Checksum = hex.EncodeToString(sum[:])
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]*Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration[%d_%s] has no up script", m.Version, m.Name)
+		}
+		migrations = append(migrations, m)
+	}
+	slices.SortFunc(migrations, func(a, b *Migration) int {
+		return int(a.Version - b.Version)
+	})
+	return migrations, nil
+}
+
+// Split a script into statements, dropping the comment lines.
+func splitStatements(script string) []string {
+	var statements []string
+	var current strings.Builder
+	for _, line := range strings.Split(script, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+		current.WriteString(line)
+		current.WriteString("\n")
+		if strings.HasSuffix(trimmed, ";") {
+			statements = append(statements, strings.TrimSpace(current.String()))
+			current.Reset()
+		}
+	}
+	if rest := strings.TrimSpace(current.String()); rest != "" {
+		statements = append(statements, rest)
+	}
+	return statements
+}
+
+// Option configures a Migrator.
+type Option func(*Migrator)
+
+// WithLockTimeout sets how long to wait for another instance to finish migrating.
+func WithLockTimeout(timeout time.Duration) Option {
+	return func(m *Migrator) {
+		if timeout > 0 {
+			m.lockTimeout = timeout
+		}
+	}
+}
+
+// Migrator applies migrations to a database.
+//
+// Every operation holds a database-wide lock (`GET_LOCK` on MySQL, an advisory lock
+// on PostgreSQL), so several instances may migrate at the same time safely.
+type Migrator struct {
+	db          *gorm.DB
+	migrations  []*Migration
+	lockTimeout time.Duration
+}
+
+// New creates a migrator applying the given migrations.
+func New(db *gorm.DB, migrations []*Migration, opts ...Option) *Migrator {
+	m := &Migrator{
+		db:          db,
+		migrations:  migrations,
+		lockTimeout: defaultLockTimeout,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// schemaMigration is a row of the `schema_migrations` table.
+type schemaMigration struct {
+	Version   int64
+	Name      string
+	Checksum  string
+	AppliedAt time.Time
+}
+
+// Status returns the state of every known migration, in version order.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var statuses []Status
+	err := m.withLock(ctx, func(conn *gorm.DB) error {
+		applied, err := m.applied(conn)
+		if err != nil {
+			return err
+		}
+		for _, migration := range m.migrations {
+			status := Status{Migration: migration}
+			if row, ok := applied[migration.Version]; ok {
+				status.Applied = true
+				status.AppliedAt = row.AppliedAt
+				status.Modified = row.Checksum != migration.Checksum
+			}
+			statuses = append(statuses, status)
+		}
+		return nil
+	})
+	return statuses, err
+}
+
+// Up applies up to `steps` pending migrations, all of them if `steps` is 0,
+// and returns the applied migrations.
+//
+// It refuses to run if an applied migration was modified or is unknown, or if a
+// pending migration is older than the latest applied one.
+func (m *Migrator) Up(ctx context.Context, steps int) ([]*Migration, error) {
+	var done []*Migration
+	err := m.withLock(ctx, func(conn *gorm.DB) error {
+		applied, err := m.applied(conn)
+		if err != nil {
+			return err
+		}
+		if err := m.verify(applied); err != nil {
+			return err
+		}
+
+		var latest int64
+		for version := range applied {
+			latest = max(latest, version)
+		}
+		for _, migration := range m.migrations {
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+			if migration.Version < latest {
+				return fmt.Errorf("migration[%d_%s] is older than the latest applied migration[%d]", migration.Version, migration.Name, latest)
+			}
+			if steps > 0 && len(done) >= steps {
+				break
+			}
+			if err := m.apply(conn, migration, true); err != nil {
+				return err
+			}
+			done = append(done, migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Down reverts the latest `steps` applied migrations and returns them.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]*Migration, error) {
+	if steps <= 0 {
+		return nil, errors.New("steps must be positive")
+	}
+
+	var done []*Migration
+	err := m.withLock(ctx, func(conn *gorm.DB) error {
+		applied, err := m.applied(conn)
+		if err != nil {
+			return err
+		}
+		if err := m.verify(applied); err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+			if migration.Down == "" {
+				return fmt.Errorf("migration[%d_%s] has no down script", migration.Version, migration.Name)
+			}
+			if err := m.apply(conn, migration, false); err != nil {
+				return err
+			}
+			done = append(done, migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Run a migration script and record it in the same transaction.
+//
+// Note that MySQL commits DDL statements implicitly, a migration failing halfway
+// through must be fixed by hand there.
+func (m *Migrator) apply(conn *gorm.DB, migration *Migration, up bool) error {
+	script := migration.Down
+	if up {
+		script = migration.Up
+	}
+	return conn.Transaction(func(tx *gorm.DB) error {
+		for _, statement := range splitStatements(script) {
+			if err := tx.Exec(statement).Error; err != nil {
+				return fmt.Errorf("failed to run migration[%d_%s]: %w", migration.Version, migration.Name, err)
+			}
+		}
+		if !up {
+			if err := tx.Table(TableName).Where("version = ?", migration.Version).Delete(nil).Error; err != nil {
+				return fmt.Errorf("failed to unrecord migration[%d_%s]: %w", migration.Version, migration.Name, err)
+			}
+			return nil
+		}
+		row := schemaMigration{
+			Version:   migration.Version,
+			Name:      migration.Name,
+			Checksum:  migration.Checksum,
+			AppliedAt: time.Now(),
+		}
+		if err := tx.Table(TableName).Create(&row).Error; err != nil {
+			return fmt.Errorf("failed to record migration[%d_%s]: %w", migration.Version, migration.Name, err)
+		}
+		return nil
+	})
+}
+
+// Check the applied migrations are known and unmodified.
+func (m *Migrator) verify(applied map[int64]schemaMigration) error {
+	known := make(map[int64]*Migration, len(m.migrations))
+	for _, migration := range m.migrations {
+		known[migration.Version] = migration
+	}
+	for version, row := range applied {
+		migration, ok := known[version]
+		if !ok {
+			return fmt.Errorf("applied migration[%d_%s] is unknown", version, row.Name)
+		}
+		if row.Checksum != migration.Checksum {
+			return fmt.Errorf("migration[%d_%s] was modified after being applied", version, row.Name)
+		}
+	}
+	return nil
+}
+
+// Return the applied migrations by version, creating the table if needed.
+func (m *Migrator) applied(conn *gorm.DB) (map[int64]schemaMigration, error) {
+	if err := conn.Exec(createTableStatement(conn.Dialector.Name())).Error; err != nil {
+		return nil, fmt.Errorf("failed to create %s table: %w", TableName, err)
+	}
+
+	var rows []schemaMigration
+	if err := conn.Table(TableName).Order("version").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to read %s table: %w", TableName, err)
+	}
+	applied := make(map[int64]schemaMigration, len(rows))
+	for _, row := range rows {
+		applied[row.Version] = row
+	}
+	return applied, nil
+}
+
+// Return the statement creating the `schema_migrations` table.
+func createTableStatement(dialect string) string {
+	appliedAt := "TIMESTAMP"
+	switch dialect {
+	case "mysql":
+		appliedAt = "DATETIME(3)"
+	case "postgres":
+		appliedAt = "TIMESTAMPTZ"
+	}
+	return "CREATE TABLE IF NOT EXISTS " + TableName + " (" +
+		"version BIGINT NOT NULL PRIMARY KEY, " +
+		"name VARCHAR(255) NOT NULL, " +
+		"checksum CHAR(64) NOT NULL, " +
+		"applied_at " + appliedAt + " NOT NULL)"
+}
+
+// Run fn on a single connection holding the migration lock.
+//
+// The locks are bound to the database session, hence the dedicated connection.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *gorm.DB) error) error {
+	return m.db.WithContext(ctx).Connection(func(conn *gorm.DB) error {
+		unlock, err := m.lock(ctx, conn)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+		return fn(conn)
+	})
+}
+
+// Acquire the migration lock and return the function releasing it.
+func (m *Migrator) lock(ctx context.Context, conn *gorm.DB) (func(), error) {
+	switch conn.Dialector.Name() {
+	case "mysql":
+		var acquired int
+		seconds := int(m.lockTimeout.Seconds())
+		if err := conn.Raw("SELECT GET_LOCK(?, ?)", lockName, seconds).Scan(&acquired).Error; err != nil {
+			return nil, fmt.Errorf("failed to acquire migration lock: %w", err)
+		}
+		if acquired != 1 {
+			return nil, fmt.Errorf("timed out acquiring migration lock after %s", m.lockTimeout)
+		}
+		return func() { conn.Exec("SELECT RELEASE_LOCK(?)", lockName) }, nil
+	case "postgres":
+		key := advisoryLockKey(lockName)
+		deadline := time.Now().Add(m.lockTimeout)
+		for {
+			var acquired bool
+			if err := conn.Raw("SELECT pg_try_advisory_lock(?)", key).Scan(&acquired).Error; err != nil {
+				return nil, fmt.Errorf("failed to acquire migration lock: %w", err)
+			}
+			if acquired {
+				return func() { conn.Exec("SELECT pg_advisory_unlock(?)", key) }, nil
+			}
+			if time.Now().After(deadline) {
+				return nil, fmt.Errorf("timed out acquiring migration lock after %s", m.lockTimeout)
+			}
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(time.Second):
+			}
+		}
+	default:
+		// Other databases are embedded and used by a single process
+		return func() {}, nil
+	}
+}
+
+// Return the advisory lock key of a lock name.
+func advisoryLockKey(name string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(name))
+	return int64(h.Sum64())
+}
@@ -0,0 +1,61 @@
+package migrate
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		"sql/0002_add_index.up.sql":      {Data: []byte("CREATE INDEX idx ON t (c);\n")},
+		"sql/0002_add_index.down.sql":    {Data: []byte("DROP INDEX idx;\n")},
+		"sql/0001_create_table.up.sql":   {Data: []byte("CREATE TABLE t (c int);\n")},
+		"sql/0001_create_table.down.sql": {Data: []byte("DROP TABLE t;\n")},
+	}
+
+	migrations, err := Load(fsys, "sql")
+	require.NoError(t, err)
+	require.Len(t, migrations, 2)
+
+	assert.Equal(t, int64(1), migrations[0].Version)
+	assert.Equal(t, "create_table", migrations[0].Name)
+	assert.Equal(t, int64(2), migrations[1].Version)
+	assert.Equal(t, "DROP INDEX idx;\n", migrations[1].Down)
+	assert.Len(t, migrations[0].Checksum, 64)
+	assert.NotEqual(t, migrations[0].Checksum, migrations[1].Checksum)
+}
+
+func TestLoadInvalid(t *testing.T) {
+	tests := map[string]fstest.MapFS{
+		"bad name":       {"sql/create_table.up.sql": {Data: []byte("SELECT 1;")}},
+		"missing up":     {"sql/0001_create_table.down.sql": {Data: []byte("SELECT 1;")}},
+		"zero version":   {"sql/0000_create_table.up.sql": {Data: []byte("SELECT 1;")}},
+		"version reused": {"sql/0001_a.up.sql": {Data: []byte("SELECT 1;")}, "sql/0001_b.up.sql": {Data: []byte("SELECT 1;")}},
+	}
+	for name, fsys := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Load(fsys, "sql")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestSplitStatements(t *testing.T) {
+	script := `-- Create the table
+CREATE TABLE t (
+  c int, -- a column
+  d text
+);
+
+CREATE INDEX idx ON t (c);
+SELECT 1`
+
+	assert.Equal(t, []string{
+		"CREATE TABLE t (\n  c int, -- a column\n  d text\n);",
+		"CREATE INDEX idx ON t (c);",
+		"SELECT 1",
+	}, splitStatements(script))
+}
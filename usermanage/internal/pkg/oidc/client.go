@@ -0,0 +1,315 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// jwksRefreshInterval is how often the keys of an upstream provider may be fetched again
+	// for a token signed with an unknown key, the provider having rotated its keys.
+	jwksRefreshInterval = time.Minute
+	// maxResponseSize bounds the documents read from an upstream provider.
+	maxResponseSize = 1 << 20
+	// clockSkew is the leeway given to the time claims of the upstream ID tokens.
+	clockSkew = time.Minute
+)
+
+// ErrInvalidIDToken is returned when the ID token of an upstream provider fails verification.
+var ErrInvalidIDToken = errors.New("invalid id token")
+
+// ClientConfig is the registration of usermanage as a client of an upstream provider.
+type ClientConfig struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string       // the callback the provider redirects the users to
+	Scopes       []string     // `openid` is always requested
+	HTTPClient   *http.Client // http.DefaultClient if nil
+}
+
+// Metadata is the part of the discovery document of a provider the client uses.
+type Metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Client signs the users in with an upstream OpenID Connect provider, the authorization code
+// flow with PKCE.
+//
+// The provider is discovered on first use rather than at startup, so a provider being down
+// does not prevent the server from starting.
+type Client struct {
+	cfg  ClientConfig
+	http *http.Client
+
+	mu        sync.Mutex
+	metadata  *Metadata
+	keys      map[string]*rsa.PublicKey
+	fetchedAt time.Time
+}
+
+// NewClient creates a client of an upstream provider.
+func NewClient(cfg ClientConfig) (*Client, error) {
+	cfg.Issuer = strings.TrimSuffix(cfg.Issuer, "/")
+	if u, err := url.Parse(cfg.Issuer); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return nil, fmt.Errorf("invalid issuer[%s]", cfg.Issuer)
+	}
+	if cfg.ClientID == "" {
+		return nil, errors.New("client id is required")
+	}
+	if u, err := url.Parse(cfg.RedirectURL); err != nil || !u.IsAbs() {
+		return nil, fmt.Errorf("invalid redirect url[%s]", cfg.RedirectURL)
+	}
+	scopes := []string{"openid"}
+	for _, scope := range cfg.Scopes {
+		if scope != "openid" {
+			scopes = append(scopes, scope)
+		}
+	}
+	cfg.Scopes = scopes
+
+	client := cfg.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &Client{cfg: cfg, http: client}, nil
+}
+
+// AuthCodeURL returns the URL of the provider the user is redirected to, to sign in.
+func (c *Client) AuthCodeURL(ctx context.Context, state, nonce, codeVerifier string) (string, error) {
+	md, err := c.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	u, err := url.Parse(md.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid authorization endpoint[%s]: %w", md.AuthorizationEndpoint, err)
+	}
+	query := u.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", c.cfg.ClientID)
+	query.Set("redirect_uri", c.cfg.RedirectURL)
+	query.Set("scope", strings.Join(c.cfg.Scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", CodeChallenge(codeVerifier))
+	query.Set("code_challenge_method", CodeChallengeMethodS256)
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// Exchange exchanges the authorization code the provider redirected the user with for an ID
+// token, and returns its verified claims.
+func (c *Client) Exchange(ctx context.Context, code, codeVerifier, nonce string) (jwt.MapClaims, error) {
+	md, err := c.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {c.cfg.RedirectURL},
+		"code_verifier": {codeVerifier},
+	}
+	if c.cfg.ClientSecret == "" {
+		form.Set("client_id", c.cfg.ClientID)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, md.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if c.cfg.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(c.cfg.ClientID), url.QueryEscape(c.cfg.ClientSecret))
+	}
+
+	var token struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	status, err := c.do(req, &token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange code: %w", err)
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("failed to exchange code: status %d: %s %s", status, token.Error, token.ErrorDescription)
+	}
+	if token.IDToken == "" {
+		return nil, fmt.Errorf("%w: no id token in token response", ErrInvalidIDToken)
+	}
+	return c.VerifyIDToken(ctx, token.IDToken, nonce)
+}
+
+// VerifyIDToken verifies the signature, issuer, audience, expiry and nonce of an ID token, and
+// returns its claims.
+func (c *Client) VerifyIDToken(ctx context.Context, idToken, nonce string) (jwt.MapClaims, error) {
+	md, err := c.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(idToken, claims, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		return c.key(ctx, kid)
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}),
+		jwt.WithIssuer(md.Issuer),
+		jwt.WithAudience(c.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(clockSkew),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidIDToken, err)
+	}
+	if got, _ := claims["nonce"].(string); got != nonce {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrInvalidIDToken)
+	}
+	if sub, _ := claims["sub"].(string); sub == "" {
+		return nil, fmt.Errorf("%w: no subject", ErrInvalidIDToken)
+	}
+	// With several audiences, the token must have been issued to the client (OpenID Connect Core, 3.1.3.7)
+	if aud, err := claims.GetAudience(); err == nil && len(aud) > 1 {
+		if azp, _ := claims["azp"].(string); azp != c.cfg.ClientID {
+			return nil, fmt.Errorf("%w: issued to another party", ErrInvalidIDToken)
+		}
+	}
+	return claims, nil
+}
+
+// Return the discovery document of the provider, fetched once.
+func (c *Client) discover(ctx context.Context) (*Metadata, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.metadata != nil {
+		return c.metadata, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.cfg.Issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create discovery request: %w", err)
+	}
+	var md Metadata
+	status, err := c.do(req, &md)
+	if err == nil && status != http.StatusOK {
+		err = fmt.Errorf("status %d", status)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover provider[%s]: %w", c.cfg.Issuer, err)
+	}
+	// The document must be the issuer's own (OpenID Connect Discovery, 4.3)
+	if md.Issuer != c.cfg.Issuer {
+		return nil, fmt.Errorf("provider[%s] advertises another issuer[%s]", c.cfg.Issuer, md.Issuer)
+	}
+	if md.AuthorizationEndpoint == "" || md.TokenEndpoint == "" || md.JWKSURI == "" {
+		return nil, fmt.Errorf("provider[%s] discovery document is incomplete", c.cfg.Issuer)
+	}
+	c.metadata = &md
+	return c.metadata, nil
+}
+
+// Return the public key of the provider with the key ID, the keys are fetched again if it is
+// unknown and they were not fetched recently.
+func (c *Client) key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if key := c.lookupKey(kid); key != nil {
+		return key, nil
+	}
+	if time.Since(c.fetchedAt) < jwksRefreshInterval {
+		return nil, fmt.Errorf("unknown signing key[%s]", kid)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.metadata.JWKSURI, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create jwks request: %w", err)
+	}
+	var set JSONWebKeySet
+	status, err := c.do(req, &set)
+	if err == nil && status != http.StatusOK {
+		err = fmt.Errorf("status %d", status)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch jwks: %w", err)
+	}
+	c.fetchedAt = time.Now()
+	c.keys = make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+		if key, err := k.rsaPublicKey(); err == nil {
+			c.keys[k.Kid] = key
+		}
+	}
+
+	if key := c.lookupKey(kid); key != nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown signing key[%s]", kid)
+}
+
+// Return the key with the ID, or the only key if the token does not name one.
+func (c *Client) lookupKey(kid string) *rsa.PublicKey {
+	if kid == "" && len(c.keys) == 1 {
+		for _, key := range c.keys {
+			return key
+		}
+	}
+	return c.keys[kid]
+}
+
+// Send the request and decode its JSON response, whatever its status.
+func (c *Client) do(req *http.Request, v any) (int, error) {
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return resp.StatusCode, fmt.Errorf("failed to read response: %w", err)
+	}
+	if err := json.Unmarshal(body, v); err != nil && resp.StatusCode == http.StatusOK {
+		return resp.StatusCode, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return resp.StatusCode, nil
+}
+
+// Return the RSA public key of the JWK.
+func (k JSONWebKey) rsaPublicKey() (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, fmt.Errorf("invalid modulus: %w", err)
+	}
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exponent: %w", err)
+	}
+	exponent := new(big.Int).SetBytes(e)
+	if !exponent.IsInt64() || exponent.Int64() > 1<<31-1 || exponent.Int64() < 3 {
+		return nil, errors.New("invalid exponent")
+	}
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+}
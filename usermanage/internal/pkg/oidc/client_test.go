@@ -0,0 +1,116 @@
+package oidc_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+	"usermanage/internal/pkg/oidc"
+	"usermanage/internal/pkg/oidc/oidctest"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	idp, err := oidctest.NewIdP()
+	require.NoError(t, err)
+	t.Cleanup(idp.Close)
+	idp.SetUser(map[string]any{"sub": "248289761001", "preferred_username": "jane"})
+
+	client, err := oidc.NewClient(oidc.ClientConfig{
+		Issuer:       idp.Issuer(),
+		ClientID:     oidctest.ClientID,
+		ClientSecret: oidctest.ClientSecret,
+		RedirectURL:  "http://localhost:8000/v1/auth/federation/corp/callback",
+		Scopes:       []string{"profile"},
+	})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// Return the code the provider redirects back with
+	authorize := func(t *testing.T, verifier, nonce string) string {
+		t.Helper()
+		authURL, err := client.AuthCodeURL(ctx, "state", nonce, verifier)
+		require.NoError(t, err)
+		u, err := url.Parse(authURL)
+		require.NoError(t, err)
+		assert.Equal(t, "openid profile", u.Query().Get("scope"))
+
+		noRedirect := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		}}
+		resp, err := noRedirect.Get(authURL)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusFound, resp.StatusCode)
+		location, err := url.Parse(resp.Header.Get("Location"))
+		require.NoError(t, err)
+		assert.Equal(t, "state", location.Query().Get("state"))
+		return location.Query().Get("code")
+	}
+	const verifier = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+
+	t.Run("exchange", func(t *testing.T) {
+		claims, err := client.Exchange(ctx, authorize(t, verifier, "n1"), verifier, "n1")
+		require.NoError(t, err)
+		assert.Equal(t, "248289761001", claims["sub"])
+		assert.Equal(t, "jane", claims["preferred_username"])
+	})
+
+	t.Run("wrong verifier", func(t *testing.T) {
+		_, err := client.Exchange(ctx, authorize(t, verifier, "n1"), verifier[1:]+"x", "n1")
+		assert.Error(t, err)
+	})
+
+	t.Run("wrong nonce", func(t *testing.T) {
+		_, err := client.Exchange(ctx, authorize(t, verifier, "n1"), verifier, "n2")
+		assert.ErrorIs(t, err, oidc.ErrInvalidIDToken)
+	})
+
+	t.Run("foreign tokens", func(t *testing.T) {
+		other, err := oidc.GenerateSigner()
+		require.NoError(t, err)
+		claims := jwt.MapClaims{
+			"iss": idp.Issuer(), "aud": oidctest.ClientID, "sub": "1", "nonce": "n1",
+			"exp": time.Now().Add(time.Hour).Unix(),
+		}
+		forged, err := other.Sign(claims)
+		require.NoError(t, err)
+		_, err = client.VerifyIDToken(ctx, forged, "n1")
+		assert.ErrorIs(t, err, oidc.ErrInvalidIDToken)
+
+		claims["aud"] = "another-client"
+		token, err := idp.Signer.Sign(claims)
+		require.NoError(t, err)
+		_, err = client.VerifyIDToken(ctx, token, "n1")
+		assert.ErrorIs(t, err, oidc.ErrInvalidIDToken)
+	})
+}
+
+func TestClient_IssuerMismatch(t *testing.T) {
+	idp, err := oidctest.NewIdP()
+	require.NoError(t, err)
+	t.Cleanup(idp.Close)
+
+	// The discovery document of the provider names its own URL, not the configured one
+	client, err := oidc.NewClient(oidc.ClientConfig{
+		Issuer:      idp.Issuer() + "/",
+		ClientID:    oidctest.ClientID,
+		RedirectURL: "http://localhost:8000/callback",
+	})
+	require.NoError(t, err)
+	_, err = client.AuthCodeURL(context.Background(), "s", "n", "v")
+	assert.NoError(t, err)
+
+	client, err = oidc.NewClient(oidc.ClientConfig{
+		Issuer:      "http://" + idp.Listener.Addr().String() + "/tenant",
+		ClientID:    oidctest.ClientID,
+		RedirectURL: "http://localhost:8000/callback",
+	})
+	require.NoError(t, err)
+	_, err = client.AuthCodeURL(context.Background(), "s", "n", "v")
+	assert.Error(t, err)
+}
@@ -0,0 +1,153 @@
+// Package oidc provides the signing keys, PKCE and opaque tokens of the OpenID Connect provider,
+// and the client signing the users in with upstream providers.
+package oidc
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// signingKeyBits is the size of the generated RSA keys.
+const signingKeyBits = 2048
+
+// Signer signs the ID tokens with an RSA key, RS256 being the algorithm every relying party supports.
+type Signer struct {
+	key *rsa.PrivateKey
+	kid string
+}
+
+// NewSigner creates a signer from an RSA private key.
+//
+// The key ID is derived from the public key, so it changes along with the key.
+func NewSigner(key *rsa.PrivateKey) (*Signer, error) {
+	if key == nil {
+		return nil, errors.New("signing key is required")
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal public key: %w", err)
+	}
+	sum := sha256.Sum256(der)
+	return &Signer{key: key, kid: base64.RawURLEncoding.EncodeToString(sum[:12])}, nil
+}
+
+// LoadSigner creates a signer from a PEM-encoded RSA private key file, in the PKCS #1 or PKCS #8 format.
+func LoadSigner(path string) (*Signer, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signing key: %w", err)
+	}
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data in signing key file[%s]", path)
+	}
+
+	var key *rsa.PrivateKey
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		var parsed any
+		if parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+			var ok bool
+			if key, ok = parsed.(*rsa.PrivateKey); !ok {
+				err = errors.New("not an RSA key")
+			}
+		}
+	default:
+		err = fmt.Errorf("unsupported PEM block type[%s]", block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signing key: %w", err)
+	}
+	return NewSigner(key)
+}
+
+// GenerateSigner creates a signer with a random key.
+func GenerateSigner() (*Signer, error) {
+	key, err := rsa.GenerateKey(rand.Reader, signingKeyBits)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate signing key: %w", err)
+	}
+	return NewSigner(key)
+}
+
+// KeyID returns the ID of the key, the `kid` header of the signed tokens.
+func (s *Signer) KeyID() string {
+	return s.kid
+}
+
+// PublicKey returns the public key verifying the signed tokens.
+func (s *Signer) PublicKey() crypto.PublicKey {
+	return &s.key.PublicKey
+}
+
+// Sign returns the signed JWT of the claims.
+func (s *Signer) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = s.kid
+	return token.SignedString(s.key)
+}
+
+// JSONWebKey is the public part of a signing key, as published in the JWK set.
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// JSONWebKeySet is the document of the `jwks_uri` endpoint.
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// JWKS returns the JWK set relying parties verify the ID tokens with.
+func (s *Signer) JWKS() JSONWebKeySet {
+	pub := s.key.PublicKey
+	return JSONWebKeySet{Keys: []JSONWebKey{{
+		Kty: "RSA",
+		Use: "sig",
+		Alg: jwt.SigningMethodRS256.Alg(),
+		Kid: s.kid,
+		N:   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+	}}}
+}
+
+// GenerateToken generates an opaque token, such as an authorization code or a refresh token.
+func GenerateToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashToken returns the hex-encoded SHA-256 of an opaque token or a client secret,
+// what is stored in their place.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// AccessTokenHash returns the `at_hash` claim of an ID token issued along with an access token,
+// the base64url-encoded left half of its SHA-256.
+func AccessTokenHash(accessToken string) string {
+	sum := sha256.Sum256([]byte(accessToken))
+	return base64.RawURLEncoding.EncodeToString(sum[:len(sum)/2])
+}
@@ -0,0 +1,122 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigner(t *testing.T) {
+	signer, err := GenerateSigner()
+	require.NoError(t, err)
+
+	signed, err := signer.Sign(jwt.RegisteredClaims{Subject: "u1", Audience: jwt.ClaimStrings{"app"}})
+	require.NoError(t, err)
+
+	var claims jwt.RegisteredClaims
+	token, err := jwt.ParseWithClaims(signed, &claims, func(token *jwt.Token) (any, error) {
+		assert.Equal(t, signer.KeyID(), token.Header["kid"])
+		return signer.PublicKey(), nil
+	}, jwt.WithValidMethods([]string{"RS256"}))
+	require.NoError(t, err)
+	assert.True(t, token.Valid)
+	assert.Equal(t, "u1", claims.Subject)
+
+	other, err := GenerateSigner()
+	require.NoError(t, err)
+	assert.NotEqual(t, signer.KeyID(), other.KeyID())
+	_, err = jwt.Parse(signed, func(*jwt.Token) (any, error) { return other.PublicKey(), nil })
+	assert.Error(t, err)
+}
+
+func TestSigner_JWKS(t *testing.T) {
+	signer, err := GenerateSigner()
+	require.NoError(t, err)
+
+	keys := signer.JWKS().Keys
+	require.Len(t, keys, 1)
+	assert.Equal(t, "RSA", keys[0].Kty)
+	assert.Equal(t, "RS256", keys[0].Alg)
+	assert.Equal(t, signer.KeyID(), keys[0].Kid)
+
+	n, err := base64.RawURLEncoding.DecodeString(keys[0].N)
+	require.NoError(t, err)
+	e, err := base64.RawURLEncoding.DecodeString(keys[0].E)
+	require.NoError(t, err)
+	pub := signer.PublicKey().(*rsa.PublicKey)
+	assert.Equal(t, pub.N, new(big.Int).SetBytes(n))
+	assert.Equal(t, pub.E, int(new(big.Int).SetBytes(e).Int64()))
+}
+
+func TestLoadSigner(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	files := map[string][]byte{
+		"pkcs1.pem": pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}),
+		"pkcs8.pem": pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}),
+	}
+	expected, err := NewSigner(key)
+	require.NoError(t, err)
+	for name, b := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, b, 0o600))
+		signer, err := LoadSigner(path)
+		require.NoError(t, err, name)
+		assert.Equal(t, expected.KeyID(), signer.KeyID(), name)
+	}
+
+	path := filepath.Join(dir, "invalid.pem")
+	require.NoError(t, os.WriteFile(path, []byte("not a key"), 0o600))
+	_, err = LoadSigner(path)
+	assert.Error(t, err)
+	_, err = LoadSigner(filepath.Join(dir, "missing.pem"))
+	assert.Error(t, err)
+}
+
+func TestHashToken(t *testing.T) {
+	a, err := GenerateToken()
+	require.NoError(t, err)
+	b, err := GenerateToken()
+	require.NoError(t, err)
+
+	assert.Len(t, a, 43)
+	assert.NotEqual(t, a, b)
+	assert.Len(t, HashToken(a), 64)
+	assert.Equal(t, HashToken(a), HashToken(a))
+	assert.NotEqual(t, HashToken(a), HashToken(b))
+}
+
+func TestVerifyCodeChallenge(t *testing.T) {
+	// The example of RFC 7636, appendix B
+	verifier := "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	challenge := "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+
+	assert.Equal(t, challenge, CodeChallenge(verifier))
+	assert.True(t, VerifyCodeChallenge(challenge, verifier))
+	assert.False(t, VerifyCodeChallenge(challenge, verifier[1:]+"a"))
+	assert.False(t, VerifyCodeChallenge(challenge, ""))
+	assert.False(t, VerifyCodeChallenge(verifier, verifier))
+}
+
+func TestValidCodeVerifier(t *testing.T) {
+	assert.True(t, ValidCodeVerifier("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"))
+	assert.True(t, ValidCodeVerifier("abc.DEF~123_abcdefghijklmnopqrstuvwxyz01234"))
+	assert.False(t, ValidCodeVerifier("short"))
+	assert.False(t, ValidCodeVerifier("dBjftJeZ4CVP+mB92K27uhbUJU1p1r/wW1gFWFOEjXk"))
+	assert.False(t, ValidCodeVerifier(strings.Repeat("a", 129)))
+}
@@ -0,0 +1,151 @@
+// Package oidctest provides a mock upstream OpenID Connect provider for the tests of federated login.
+package oidctest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+	"usermanage/internal/pkg/oidc"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	ClientID     = "usermanage"
+	ClientSecret = "secret"
+)
+
+// IdP is a mock identity provider: its authorization endpoint signs in whoever `SetUser`
+// set, without asking, and redirects straight back with a code.
+type IdP struct {
+	*httptest.Server
+	Signer *oidc.Signer
+
+	mu     sync.Mutex
+	claims map[string]any
+	codes  map[string]grant
+}
+
+type grant struct {
+	claims        map[string]any
+	nonce         string
+	redirectURI   string
+	codeChallenge string
+}
+
+// NewIdP starts a mock identity provider, to be closed by the caller.
+func NewIdP() (*IdP, error) {
+	signer, err := oidc.GenerateSigner()
+	if err != nil {
+		return nil, err
+	}
+	idp := &IdP{Signer: signer, codes: map[string]grant{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", idp.discovery)
+	mux.HandleFunc("GET /jwks", idp.jwks)
+	mux.HandleFunc("GET /authorize", idp.authorize)
+	mux.HandleFunc("POST /token", idp.token)
+	idp.Server = httptest.NewServer(mux)
+	return idp, nil
+}
+
+// Issuer returns the issuer of the provider.
+func (p *IdP) Issuer() string {
+	return p.URL
+}
+
+// SetUser sets the claims of the user signed in by the next authorizations, `sub` included.
+func (p *IdP) SetUser(claims map[string]any) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.claims = claims
+}
+
+func (p *IdP) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"issuer":                 p.URL,
+		"authorization_endpoint": p.URL + "/authorize",
+		"token_endpoint":         p.URL + "/token",
+		"jwks_uri":               p.URL + "/jwks",
+	})
+}
+
+func (p *IdP) jwks(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, p.Signer.JWKS())
+}
+
+func (p *IdP) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("client_id") != ClientID || query.Get("code_challenge_method") != oidc.CodeChallengeMethodS256 {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	code, _ := oidc.GenerateToken()
+
+	p.mu.Lock()
+	p.codes[code] = grant{
+		claims:        p.claims,
+		nonce:         query.Get("nonce"),
+		redirectURI:   query.Get("redirect_uri"),
+		codeChallenge: query.Get("code_challenge"),
+	}
+	p.mu.Unlock()
+
+	u, _ := url.Parse(query.Get("redirect_uri"))
+	values := u.Query()
+	values.Set("code", code)
+	values.Set("state", query.Get("state"))
+	u.RawQuery = values.Encode()
+	http.Redirect(w, r, u.String(), http.StatusFound)
+}
+
+func (p *IdP) token(w http.ResponseWriter, r *http.Request) {
+	if id, secret, ok := r.BasicAuth(); !ok || id != ClientID || secret != ClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	code := r.PostFormValue("code")
+	p.mu.Lock()
+	g, ok := p.codes[code]
+	delete(p.codes, code)
+	p.mu.Unlock()
+	if !ok || g.redirectURI != r.PostFormValue("redirect_uri") ||
+		!oidc.VerifyCodeChallenge(g.codeChallenge, r.PostFormValue("code_verifier")) {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":   p.URL,
+		"aud":   ClientID,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+		"nonce": g.nonce,
+	}
+	for name, value := range g.claims {
+		claims[name] = value
+	}
+	idToken, err := p.Signer.Sign(claims)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": "mock",
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     idToken,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
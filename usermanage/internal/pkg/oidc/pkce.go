@@ -0,0 +1,42 @@
+package oidc
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+)
+
+// CodeChallengeMethodS256 is the only PKCE method supported, `plain` offers no protection
+// against a leaked authorization request.
+const CodeChallengeMethodS256 = "S256"
+
+// ValidCodeVerifier reports whether a PKCE code verifier, or an S256 challenge, is well-formed:
+// 43 to 128 characters among `A-Z`, `a-z`, `0-9`, `-`, `.`, `_` and `~` (RFC 7636).
+func ValidCodeVerifier(verifier string) bool {
+	if len(verifier) < 43 || len(verifier) > 128 {
+		return false
+	}
+	for _, c := range verifier {
+		switch {
+		case c >= 'A' && c <= 'Z', c >= 'a' && c <= 'z', c >= '0' && c <= '9':
+		case c == '-' || c == '.' || c == '_' || c == '~':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// CodeChallenge returns the S256 challenge of a code verifier.
+func CodeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// VerifyCodeChallenge checks in constant time that the code verifier matches the S256 challenge.
+func VerifyCodeChallenge(challenge, verifier string) bool {
+	if !ValidCodeVerifier(verifier) {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(CodeChallenge(verifier)), []byte(challenge)) == 1
+}
@@ -14,7 +14,22 @@ func NewClient(opts *redis.UniversalOptions) (redis.UniversalClient, error) {
         return nil, fmt.Errorf("redis options cannot be nil")
     }
 
-	client := redis.NewUniversalClient(opts)
+	return connect(redis.NewUniversalClient(opts))
+}
+
+// NewClusterClient creates a new Redis Cluster client and returns it.
+//
+// Unlike `NewClient`, the cluster mode is used even with a single address,
+// e.g. the configuration endpoint of a managed cluster.
+func NewClusterClient(opts *redis.UniversalOptions) (redis.UniversalClient, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("redis options cannot be nil")
+	}
+	return connect(redis.NewClusterClient(opts.Cluster()))
+}
+
+// Check the client can reach Redis, closing it otherwise.
+func connect(client redis.UniversalClient) (redis.UniversalClient, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
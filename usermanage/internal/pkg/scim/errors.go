@@ -0,0 +1,68 @@
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// ErrorSchema is the schema of the error responses.
+const ErrorSchema = "urn:ietf:params:scim:api:messages:2.0:Error"
+
+// ErrorType is the `scimType` of an error, detailing a bad request.
+type ErrorType string
+
+const (
+	ErrInvalidFilter ErrorType = "invalidFilter"
+	ErrInvalidPath   ErrorType = "invalidPath"
+	ErrInvalidSyntax ErrorType = "invalidSyntax"
+	ErrInvalidValue  ErrorType = "invalidValue"
+	ErrNoTarget      ErrorType = "noTarget"
+	ErrMutability    ErrorType = "mutability"
+	ErrUniqueness    ErrorType = "uniqueness"
+	ErrTooMany       ErrorType = "tooMany"
+)
+
+// Error is an error response.
+type Error struct {
+	Status   int
+	ScimType ErrorType
+	Detail   string
+}
+
+// NewError returns an error of an HTTP status, without `scimType`.
+func NewError(status int, detail string) *Error {
+	return &Error{Status: status, Detail: detail}
+}
+
+// Errorf returns an error of a type, with the status of the type: 409 for uniqueness, else 400.
+func Errorf(typ ErrorType, format string, args ...any) *Error {
+	status := http.StatusBadRequest
+	if typ == ErrUniqueness {
+		status = http.StatusConflict
+	}
+	return &Error{Status: status, ScimType: typ, Detail: fmt.Sprintf(format, args...)}
+}
+
+func (e *Error) Error() string {
+	if e.ScimType != "" {
+		return fmt.Sprintf("scim: %d %s: %s", e.Status, e.ScimType, e.Detail)
+	}
+	return fmt.Sprintf("scim: %d: %s", e.Status, e.Detail)
+}
+
+// MarshalJSON encodes the error as a response, whose status is a string.
+func (e *Error) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Schemas  []string  `json:"schemas"`
+		Status   string    `json:"status"`
+		ScimType ErrorType `json:"scimType,omitempty"`
+		Detail   string    `json:"detail,omitempty"`
+	}{
+		Schemas:  []string{ErrorSchema},
+		Status:   strconv.Itoa(e.Status),
+		ScimType: e.ScimType,
+		Detail:   e.Detail,
+	})
+}
@@ -0,0 +1,571 @@
+// Package scim implements the protocol parts of SCIM 2.0 (RFC 7643, RFC 7644) which do
+// not depend on the resources: errors, filters and PATCH operations.
+//
+// Resources are handled as JSON objects, i.e. `map[string]any`, whose attribute names are
+// matched case-insensitively as required by the RFC.
+package scim
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Filter is a parsed filter expression, e.g. `userName eq "alice" and active eq true`.
+type Filter interface {
+	// Match reports whether a resource matches the filter.
+	Match(resource map[string]any) bool
+}
+
+// AttrPath is an attribute path, e.g. `name.givenName` or
+// `urn:ietf:params:scim:schemas:core:2.0:User:userName`.
+type AttrPath struct {
+	URN  string // schema URN, empty if omitted
+	Name string
+	Sub  string // sub-attribute, empty if none
+}
+
+// String returns the path as written in filters.
+func (p AttrPath) String() string {
+	s := p.Name
+	if p.URN != "" {
+		s = p.URN + ":" + s
+	}
+	if p.Sub != "" {
+		s += "." + p.Sub
+	}
+	return s
+}
+
+// attrExpr compares an attribute to a value, or tests its presence with `pr`.
+type attrExpr struct {
+	path      AttrPath
+	op        string
+	value     any  // string, float64, bool or nil
+	caseExact bool // strings are compared case-sensitively
+}
+
+// logicalExpr combines two filters with `and` or `or`.
+type logicalExpr struct {
+	op          string
+	left, right Filter
+}
+
+// notExpr negates a filter.
+type notExpr struct {
+	filter Filter
+}
+
+// valuePathExpr matches the resources with an element of a multi-valued attribute matching
+// a filter, e.g. `emails[type eq "work" and value co "@example.com"]`.
+type valuePathExpr struct {
+	path   AttrPath
+	filter Filter
+}
+
+// caseExactAttributes are compared case-sensitively, the others are not unless given to ParseFilter.
+var caseExactAttributes = map[string]bool{"id": true, "externalid": true}
+
+// Equality returns the attribute and the value of a filter comparing a string attribute
+// case-sensitively with `eq`, e.g. `userName eq "alice"`, so the resources can be looked up
+// by an index instead of being matched one by one.
+func Equality(f Filter) (path AttrPath, value string, ok bool) {
+	e, ok := f.(*attrExpr)
+	if !ok || e.op != "eq" || !e.caseExact {
+		return AttrPath{}, "", false
+	}
+	value, ok = e.value.(string)
+	return e.path, value, ok
+}
+
+// ParseFilter parses a filter expression. The strings of the attributes of caseExact, e.g.
+// `userName` or `emails.value`, are compared case-sensitively, as are `id` and `externalId`.
+func ParseFilter(filter string, caseExact ...string) (Filter, error) {
+	p := &parser{input: filter, caseExact: make(map[string]bool, len(caseExact))}
+	for _, name := range caseExact {
+		p.caseExact[strings.ToLower(name)] = true
+	}
+	if err := p.tokenize(); err != nil {
+		return nil, err
+	}
+	f, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, p.errorf("unexpected %q", p.peek().text)
+	}
+	return f, nil
+}
+
+func (e *attrExpr) Match(resource map[string]any) bool {
+	values := lookup(resource, e.path)
+	if e.op == "pr" {
+		for _, v := range values {
+			if !isEmpty(v) {
+				return true
+			}
+		}
+		return false
+	}
+	if e.op == "ne" {
+		// Absent attributes are not equal to anything
+		for _, v := range values {
+			if compare(v, "eq", e.value, e.caseExact) {
+				return false
+			}
+		}
+		return true
+	}
+	for _, v := range values {
+		if compare(v, e.op, e.value, e.caseExact) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *logicalExpr) Match(resource map[string]any) bool {
+	if e.op == "and" {
+		return e.left.Match(resource) && e.right.Match(resource)
+	}
+	return e.left.Match(resource) || e.right.Match(resource)
+}
+
+func (e *notExpr) Match(resource map[string]any) bool {
+	return !e.filter.Match(resource)
+}
+
+func (e *valuePathExpr) Match(resource map[string]any) bool {
+	for _, element := range elements(resource, e.path) {
+		if e.filter.Match(element) {
+			return true
+		}
+	}
+	return false
+}
+
+// Return the values of an attribute path, flattening the multi-valued attributes. The
+// value of a multi-valued complex attribute without sub-attribute is its `value`.
+func lookup(resource map[string]any, path AttrPath) []any {
+	resource = container(resource, path.URN, false)
+	if resource == nil {
+		return nil
+	}
+	v, ok := Get(resource, path.Name)
+	if !ok {
+		return nil
+	}
+	var values []any
+	collect := func(v any) {
+		if m, ok := v.(map[string]any); ok {
+			sub := path.Sub
+			if sub == "" {
+				sub = "value"
+			}
+			if sv, ok := Get(m, sub); ok {
+				values = append(values, sv)
+			}
+			return
+		}
+		if path.Sub == "" {
+			values = append(values, v)
+		}
+	}
+	if list, ok := v.([]any); ok {
+		for _, item := range list {
+			collect(item)
+		}
+	} else {
+		collect(v)
+	}
+	return values
+}
+
+// Return the complex values of a multi-valued attribute.
+func elements(resource map[string]any, path AttrPath) []map[string]any {
+	resource = container(resource, path.URN, false)
+	if resource == nil {
+		return nil
+	}
+	v, ok := Get(resource, path.Name)
+	if !ok {
+		return nil
+	}
+	list, ok := v.([]any)
+	if !ok {
+		list = []any{v}
+	}
+	result := make([]map[string]any, 0, len(list))
+	for _, item := range list {
+		if m, ok := item.(map[string]any); ok {
+			result = append(result, m)
+		}
+	}
+	return result
+}
+
+// Get returns an attribute of a JSON object, matching its name case-insensitively.
+func Get(resource map[string]any, name string) (any, bool) {
+	if v, ok := resource[name]; ok {
+		return v, true
+	}
+	for key, v := range resource {
+		if strings.EqualFold(key, name) {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+func isEmpty(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case []any:
+		return len(v) == 0
+	case map[string]any:
+		return len(v) == 0
+	default:
+		return false
+	}
+}
+
+// Compare an attribute value to a filter value.
+func compare(actual any, op string, expected any, caseExact bool) bool {
+	switch a := actual.(type) {
+	case string:
+		e, ok := expected.(string)
+		if !ok {
+			return false
+		}
+		if at, err := time.Parse(time.RFC3339, a); err == nil {
+			if et, err := time.Parse(time.RFC3339, e); err == nil {
+				return compareOrdered(at.Compare(et), op)
+			}
+		}
+		if !caseExact {
+			a, e = strings.ToLower(a), strings.ToLower(e)
+		}
+		switch op {
+		case "co":
+			return strings.Contains(a, e)
+		case "sw":
+			return strings.HasPrefix(a, e)
+		case "ew":
+			return strings.HasSuffix(a, e)
+		default:
+			return compareOrdered(strings.Compare(a, e), op)
+		}
+	case float64:
+		e, ok := expected.(float64)
+		if !ok {
+			return false
+		}
+		switch {
+		case a < e:
+			return compareOrdered(-1, op)
+		case a > e:
+			return compareOrdered(1, op)
+		default:
+			return compareOrdered(0, op)
+		}
+	case bool:
+		e, ok := expected.(bool)
+		return ok && op == "eq" && a == e
+	default:
+		return false
+	}
+}
+
+// Report whether the result of a comparison satisfies an operator.
+func compareOrdered(c int, op string) bool {
+	switch op {
+	case "eq":
+		return c == 0
+	case "gt":
+		return c > 0
+	case "ge":
+		return c >= 0
+	case "lt":
+		return c < 0
+	case "le":
+		return c <= 0
+	default:
+		return false
+	}
+}
+
+// tokenKind is the kind of a filter token.
+type tokenKind int
+
+const (
+	tokenWord   tokenKind = iota // attribute path, operator or literal
+	tokenString                  // quoted string, unquoted in text
+	tokenOpen                    // (
+	tokenClose                   // )
+	tokenOpenBracket
+	tokenCloseBracket
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// parser is a recursive descent parser of filters, `not` binds tighter than `and`,
+// which binds tighter than `or`.
+type parser struct {
+	input     string
+	tokens    []token
+	next      int
+	caseExact map[string]bool
+	parent    string // attribute of the value path being parsed, e.g. `emails` in `emails[value eq "a"]`
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return Errorf(ErrInvalidFilter, "invalid filter: "+format, args...)
+}
+
+// Split the input into tokens.
+func (p *parser) tokenize() error {
+	s := p.input
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			p.tokens = append(p.tokens, token{kind: tokenOpen, text: "(", pos: i})
+			i++
+		case c == ')':
+			p.tokens = append(p.tokens, token{kind: tokenClose, text: ")", pos: i})
+			i++
+		case c == '[':
+			p.tokens = append(p.tokens, token{kind: tokenOpenBracket, text: "[", pos: i})
+			i++
+		case c == ']':
+			p.tokens = append(p.tokens, token{kind: tokenCloseBracket, text: "]", pos: i})
+			i++
+		case c == '"':
+			// A JSON string, with its escapes
+			j := i + 1
+			for ; j < len(s) && s[j] != '"'; j++ {
+				if s[j] == '\\' {
+					j++
+				}
+			}
+			if j >= len(s) {
+				return p.errorf("unterminated string at %d", i)
+			}
+			text, err := strconv.Unquote(s[i : j+1])
+			if err != nil {
+				return p.errorf("invalid string at %d", i)
+			}
+			p.tokens = append(p.tokens, token{kind: tokenString, text: text, pos: i})
+			i = j + 1
+		default:
+			j := i
+			for j < len(s) && !strings.ContainsRune(" \t\n\r()[]\"", rune(s[j])) {
+				j++
+			}
+			p.tokens = append(p.tokens, token{kind: tokenWord, text: s[i:j], pos: i})
+			i = j
+		}
+	}
+	return nil
+}
+
+func (p *parser) done() bool {
+	return p.next >= len(p.tokens)
+}
+
+func (p *parser) peek() token {
+	if p.done() {
+		return token{kind: -1, pos: len(p.input)}
+	}
+	return p.tokens[p.next]
+}
+
+// Report whether the next token is the given keyword, consuming it if so.
+func (p *parser) accept(keyword string) bool {
+	if t := p.peek(); t.kind == tokenWord && strings.EqualFold(t.text, keyword) {
+		p.next++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(kind tokenKind, text string) error {
+	if p.peek().kind != kind {
+		return p.errorf("expected %q at %d", text, p.peek().pos)
+	}
+	p.next++
+	return nil
+}
+
+func (p *parser) parseOr() (Filter, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("or") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalExpr{op: "or", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Filter, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("and") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalExpr{op: "and", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (Filter, error) {
+	if p.accept("not") {
+		if err := p.expect(tokenOpen, "("); err != nil {
+			return nil, err
+		}
+		f, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokenClose, ")"); err != nil {
+			return nil, err
+		}
+		return &notExpr{filter: f}, nil
+	}
+	if p.peek().kind == tokenOpen {
+		p.next++
+		f, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokenClose, ")"); err != nil {
+			return nil, err
+		}
+		return f, nil
+	}
+	return p.parseAttrExpr()
+}
+
+func (p *parser) parseAttrExpr() (Filter, error) {
+	t := p.peek()
+	if t.kind != tokenWord {
+		return nil, p.errorf("expected an attribute at %d", t.pos)
+	}
+	p.next++
+	path, err := ParseAttrPath(t.text)
+	if err != nil {
+		return nil, p.errorf("invalid attribute %q at %d", t.text, t.pos)
+	}
+
+	if p.peek().kind == tokenOpenBracket {
+		if p.parent != "" || path.Sub != "" {
+			return nil, p.errorf("nested value path at %d", t.pos)
+		}
+		p.next++
+		p.parent = path.Name
+		inner, err := p.parseOr()
+		p.parent = ""
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokenCloseBracket, "]"); err != nil {
+			return nil, err
+		}
+		return &valuePathExpr{path: path, filter: inner}, nil
+	}
+
+	opToken := p.peek()
+	if opToken.kind != tokenWord {
+		return nil, p.errorf("expected an operator at %d", opToken.pos)
+	}
+	p.next++
+	op := strings.ToLower(opToken.text)
+	switch op {
+	case "pr":
+		return &attrExpr{path: path, op: op}, nil
+	case "eq", "ne", "co", "sw", "ew", "gt", "ge", "lt", "le":
+	default:
+		return nil, p.errorf("unknown operator %q at %d", opToken.text, opToken.pos)
+	}
+
+	v := p.peek()
+	p.next++
+	switch {
+	case v.kind == tokenString:
+		return &attrExpr{path: path, op: op, value: v.text, caseExact: p.isCaseExact(path)}, nil
+	case v.kind != tokenWord:
+		return nil, p.errorf("expected a value at %d", v.pos)
+	}
+	switch strings.ToLower(v.text) {
+	case "true":
+		return &attrExpr{path: path, op: op, value: true}, nil
+	case "false":
+		return &attrExpr{path: path, op: op, value: false}, nil
+	case "null":
+		return &attrExpr{path: path, op: op, value: nil}, nil
+	}
+	n, err := strconv.ParseFloat(v.text, 64)
+	if err != nil {
+		return nil, p.errorf("invalid value %q at %d", v.text, v.pos)
+	}
+	return &attrExpr{path: path, op: op, value: n}, nil
+}
+
+// Report whether the strings of an attribute are compared case-sensitively.
+func (p *parser) isCaseExact(path AttrPath) bool {
+	name := strings.ToLower(path.Name)
+	if p.parent != "" {
+		name = strings.ToLower(p.parent) + "." + name
+	} else if path.Sub != "" {
+		name += "." + strings.ToLower(path.Sub)
+	}
+	return caseExactAttributes[name] || p.caseExact[name]
+}
+
+// ParseAttrPath parses an attribute path, with an optional schema URN and sub-attribute.
+func ParseAttrPath(s string) (AttrPath, error) {
+	var path AttrPath
+	if strings.HasPrefix(strings.ToLower(s), "urn:") {
+		i := strings.LastIndex(s, ":")
+		path.URN, s = s[:i], s[i+1:]
+	}
+	name, sub, _ := strings.Cut(s, ".")
+	if !isAttrName(name) || (sub != "" && !isAttrName(sub)) {
+		return AttrPath{}, Errorf(ErrInvalidPath, "invalid attribute path %q", s)
+	}
+	path.Name, path.Sub = name, sub
+	return path, nil
+}
+
+// Report whether s is an attribute name: a letter followed by letters, digits, `_` or `-`, or `$ref`.
+func isAttrName(s string) bool {
+	if s == "$ref" {
+		return true
+	}
+	for i, r := range s {
+		if !unicode.IsLetter(r) && (i == 0 || (!unicode.IsDigit(r) && r != '_' && r != '-')) {
+			return false
+		}
+	}
+	return s != ""
+}
@@ -0,0 +1,124 @@
+package scim
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testResource(t *testing.T) map[string]any {
+	var resource map[string]any
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"schemas": ["urn:ietf:params:scim:schemas:core:2.0:User"],
+		"id": "Abc123",
+		"userName": "Alice",
+		"active": true,
+		"emails": [{"type": "work", "value": "alice@example.com"}, {"type": "home", "value": "alice@home.org"}],
+		"meta": {"created": "2024-01-02T03:04:05Z", "version": "W/\"3\""},
+		"score": 42
+	}`), &resource))
+	return resource
+}
+
+func TestParseFilter(t *testing.T) {
+	resource := testResource(t)
+	tests := []struct {
+		filter string
+		match  bool
+	}{
+		{`userName eq "alice"`, true},
+		{`USERNAME Eq "ALICE"`, true},
+		{`urn:ietf:params:scim:schemas:core:2.0:User:userName eq "alice"`, true},
+		{`id eq "abc123"`, false},
+		{`id eq "Abc123"`, true},
+		{`userName ne "alice"`, false},
+		{`externalId ne "x"`, true},
+		{`userName co "lic"`, true},
+		{`userName sw "al" and userName ew "ce"`, true},
+		{`active eq true`, true},
+		{`active eq false`, false},
+		{`score gt 41 and score le 42`, true},
+		{`score lt 42`, false},
+		{`meta.created gt "2024-01-01T00:00:00Z"`, true},
+		{`meta.created lt "2024-01-02T03:04:05+01:00"`, false},
+		{`emails.value ew "@home.org"`, true},
+		{`emails co "example"`, true},
+		{`emails[type eq "work" and value co "example"]`, true},
+		{`emails[type eq "work" and value co "home"]`, false},
+		{`title pr`, false},
+		{`emails pr`, true},
+		{`not (userName eq "bob")`, true},
+		{`userName eq "bob" or active eq true and score eq 42`, true},
+		{`(userName eq "bob" or active eq true) and score eq 0`, false},
+		{`userName eq "a\"b"`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			f, err := ParseFilter(tt.filter)
+			require.NoError(t, err)
+			assert.Equal(t, tt.match, f.Match(resource))
+		})
+	}
+}
+
+func TestParseFilter_CaseExact(t *testing.T) {
+	resource := testResource(t)
+	f, err := ParseFilter(`userName eq "alice"`, "userName")
+	require.NoError(t, err)
+	assert.False(t, f.Match(resource))
+
+	f, err = ParseFilter(`emails[value sw "Alice"] or emails.value eq "ALICE@HOME.ORG"`, "emails.value")
+	require.NoError(t, err)
+	assert.False(t, f.Match(resource))
+
+	f, err = ParseFilter(`emails[type eq "WORK"]`, "emails.value")
+	require.NoError(t, err)
+	assert.True(t, f.Match(resource))
+}
+
+func TestEquality(t *testing.T) {
+	f, err := ParseFilter(`userName eq "Alice"`, "userName")
+	require.NoError(t, err)
+	path, value, ok := Equality(f)
+	assert.True(t, ok)
+	assert.Equal(t, AttrPath{Name: "userName"}, path)
+	assert.Equal(t, "Alice", value)
+
+	for _, filter := range []string{
+		`userName eq "alice" and active eq true`,
+		`userName sw "alice"`,
+		`emails.value eq "alice@example.com"`, // not case-sensitive
+		`active eq true`,
+	} {
+		f, err := ParseFilter(filter, "userName")
+		require.NoError(t, err)
+		_, _, ok := Equality(f)
+		assert.False(t, ok, filter)
+	}
+}
+
+func TestParseFilter_Invalid(t *testing.T) {
+	for _, filter := range []string{
+		``,
+		`userName`,
+		`userName eq`,
+		`userName like "a"`,
+		`userName eq "a`,
+		`userName eq alice`,
+		`(userName eq "a"`,
+		`userName eq "a" userName eq "b"`,
+		`emails[type eq "work"`,
+		`not userName eq "a"`,
+		`1name eq "a"`,
+		`emails[value[type eq "a"]]`,
+	} {
+		_, err := ParseFilter(filter)
+		var scimErr *Error
+		if assert.ErrorAs(t, err, &scimErr, filter) {
+			assert.Equal(t, ErrInvalidFilter, scimErr.ScimType)
+			assert.Equal(t, 400, scimErr.Status)
+		}
+	}
+}
@@ -0,0 +1,355 @@
+package scim
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// PatchSchema is the schema of the PATCH requests.
+const PatchSchema = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+
+// PatchRequest is the body of a PATCH request.
+type PatchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []PatchOperation `json:"Operations"`
+}
+
+// PatchOperation is an operation of a PATCH request. The operation name is matched
+// case-insensitively, as some identity providers send `Replace` or `Add`.
+type PatchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path,omitempty"`
+	Value any    `json:"value,omitempty"`
+}
+
+// PatchPath is the target of a PATCH operation, e.g. `emails[type eq "work"].value`.
+type PatchPath struct {
+	Attr   AttrPath
+	Filter Filter // selects the elements of a multi-valued attribute, nil for the whole attribute
+	Sub    string // sub-attribute of the selected elements, with a filter only
+}
+
+// ParsePatchPath parses the path of a PATCH operation.
+func ParsePatchPath(s string) (*PatchPath, error) {
+	s = strings.TrimSpace(s)
+	open := strings.IndexByte(s, '[')
+	if open < 0 {
+		attr, err := ParseAttrPath(s)
+		if err != nil {
+			return nil, err
+		}
+		return &PatchPath{Attr: attr}, nil
+	}
+
+	closing := strings.LastIndexByte(s, ']')
+	if closing < open {
+		return nil, Errorf(ErrInvalidPath, "invalid path %q", s)
+	}
+	attr, err := ParseAttrPath(s[:open])
+	if err != nil {
+		return nil, err
+	}
+	if attr.Sub != "" {
+		return nil, Errorf(ErrInvalidPath, "invalid path %q", s)
+	}
+	filter, err := ParseFilter(s[open+1 : closing])
+	if err != nil {
+		return nil, err
+	}
+	path := &PatchPath{Attr: attr, Filter: filter}
+	if rest := s[closing+1:]; rest != "" {
+		if !strings.HasPrefix(rest, ".") || !isAttrName(rest[1:]) {
+			return nil, Errorf(ErrInvalidPath, "invalid path %q", s)
+		}
+		path.Sub = rest[1:]
+	}
+	return path, nil
+}
+
+// Apply applies PATCH operations to a resource, in order. The resource is changed even
+// if an operation fails, so it should be a copy.
+func Apply(resource map[string]any, operations []PatchOperation) error {
+	for _, operation := range operations {
+		if err := applyOperation(resource, operation); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func applyOperation(resource map[string]any, operation PatchOperation) error {
+	op := strings.ToLower(operation.Op)
+	if op != "add" && op != "remove" && op != "replace" {
+		return Errorf(ErrInvalidSyntax, "invalid operation %q", operation.Op)
+	}
+
+	if operation.Path == "" {
+		if op == "remove" {
+			return Errorf(ErrNoTarget, "a path is required to remove")
+		}
+		values, ok := operation.Value.(map[string]any)
+		if !ok {
+			return Errorf(ErrInvalidValue, "an object is required without path")
+		}
+		for name, value := range values {
+			target := resource
+			// Extension attributes are given by schema
+			if strings.HasPrefix(strings.ToLower(name), "urn:") {
+				if _, isObject := value.(map[string]any); !isObject {
+					path, err := ParseAttrPath(name)
+					if err != nil {
+						return err
+					}
+					target, name = container(resource, path.URN, true), path.Name
+				}
+			}
+			if op == "add" {
+				addValue(target, name, value)
+			} else {
+				Set(target, name, value)
+			}
+		}
+		return nil
+	}
+
+	path, err := ParsePatchPath(operation.Path)
+	if err != nil {
+		return err
+	}
+	target := container(resource, path.Attr.URN, op != "remove")
+	if target == nil {
+		return nil
+	}
+	if path.Filter != nil {
+		return applyFiltered(target, op, path, operation.Value)
+	}
+
+	name := path.Attr.Name
+	if path.Attr.Sub != "" {
+		current, _ := Get(target, name)
+		parent, ok := current.(map[string]any)
+		if !ok {
+			if op == "remove" {
+				return nil
+			}
+			parent = map[string]any{}
+			Set(target, name, parent)
+		}
+		target, name = parent, path.Attr.Sub
+	}
+
+	switch op {
+	case "add":
+		addValue(target, name, operation.Value)
+	case "replace":
+		Set(target, name, operation.Value)
+	case "remove":
+		current, _ := Get(target, name)
+		list, isList := current.([]any)
+		if operation.Value == nil || !isList {
+			Delete(target, name)
+			return nil
+		}
+		// Removing the given values of a multi-valued attribute
+		remove := toList(operation.Value)
+		kept := list[:0:0]
+		for _, item := range list {
+			if !containsValue(remove, item) {
+				kept = append(kept, item)
+			}
+		}
+		Set(target, name, kept)
+	}
+	return nil
+}
+
+// Apply an operation to the elements of a multi-valued attribute matching the filter.
+//
+// Adding or replacing a value of an element which does not exist creates it when the filter
+// only has equalities, e.g. `emails[type eq "work"].value`.
+func applyFiltered(target map[string]any, op string, path *PatchPath, value any) error {
+	current, _ := Get(target, path.Attr.Name)
+	list := toList(current)
+
+	matched := false
+	kept := list[:0:0]
+	for _, item := range list {
+		element, ok := item.(map[string]any)
+		if !ok || !path.Filter.Match(element) {
+			kept = append(kept, item)
+			continue
+		}
+		matched = true
+		switch {
+		case op == "remove" && path.Sub == "":
+			continue
+		case op == "remove":
+			Delete(element, path.Sub)
+		case path.Sub != "":
+			Set(element, path.Sub, value)
+		case op == "replace":
+			replacement, ok := value.(map[string]any)
+			if !ok {
+				return Errorf(ErrInvalidValue, "an object is required for %q", path.Attr.Name)
+			}
+			element = replacement
+		default:
+			values, ok := value.(map[string]any)
+			if !ok {
+				return Errorf(ErrInvalidValue, "an object is required for %q", path.Attr.Name)
+			}
+			for name, v := range values {
+				Set(element, name, v)
+			}
+		}
+		kept = append(kept, element)
+	}
+
+	if !matched {
+		if op == "remove" {
+			return nil
+		}
+		element, ok := equalities(path.Filter)
+		if !ok {
+			return Errorf(ErrNoTarget, "no value of %q matches the filter", path.Attr.Name)
+		}
+		if path.Sub != "" {
+			Set(element, path.Sub, value)
+		} else if values, ok := value.(map[string]any); ok {
+			for name, v := range values {
+				Set(element, name, v)
+			}
+		} else {
+			return Errorf(ErrInvalidValue, "an object is required for %q", path.Attr.Name)
+		}
+		kept = append(kept, element)
+	}
+	Set(target, path.Attr.Name, kept)
+	return nil
+}
+
+// Return the attributes of an element matching a filter of equalities joined by `and`.
+func equalities(filter Filter) (map[string]any, bool) {
+	switch f := filter.(type) {
+	case *attrExpr:
+		if f.op != "eq" || f.path.Sub != "" || f.value == nil {
+			return nil, false
+		}
+		return map[string]any{f.path.Name: f.value}, true
+	case *logicalExpr:
+		if f.op != "and" {
+			return nil, false
+		}
+		left, ok := equalities(f.left)
+		if !ok {
+			return nil, false
+		}
+		right, ok := equalities(f.right)
+		if !ok {
+			return nil, false
+		}
+		for name, v := range right {
+			left[name] = v
+		}
+		return left, true
+	default:
+		return nil, false
+	}
+}
+
+// Add a value to an attribute: appended if multi-valued, set otherwise.
+func addValue(target map[string]any, name string, value any) {
+	current, exists := Get(target, name)
+	list, isList := current.([]any)
+	if !exists || !isList {
+		if values, ok := value.(map[string]any); ok {
+			if object, isObject := current.(map[string]any); isObject {
+				for n, v := range values {
+					Set(object, n, v)
+				}
+				return
+			}
+		}
+		Set(target, name, value)
+		return
+	}
+	for _, item := range toList(value) {
+		if !containsValue(list, item) {
+			list = append(list, item)
+		}
+	}
+	Set(target, name, list)
+}
+
+// Return the object holding the attributes of a schema: the resource for the core schema
+// or an omitted one, else the object named by the schema, created if required.
+func container(resource map[string]any, urn string, create bool) map[string]any {
+	if urn == "" {
+		return resource
+	}
+	v, ok := Get(resource, urn)
+	if object, isObject := v.(map[string]any); ok && isObject {
+		return object
+	}
+	schemas, _ := Get(resource, "schemas")
+	if list, ok := schemas.([]any); ok && len(list) > 0 && strings.EqualFold(fmt.Sprint(list[0]), urn) {
+		return resource
+	}
+	if !create {
+		return nil
+	}
+	object := map[string]any{}
+	Set(resource, urn, object)
+	return object
+}
+
+// Set sets an attribute of a JSON object, replacing the attribute of the same name in
+// another case if any.
+func Set(resource map[string]any, name string, value any) {
+	for key := range resource {
+		if key != name && strings.EqualFold(key, name) {
+			delete(resource, key)
+		}
+	}
+	resource[name] = value
+}
+
+// Delete removes an attribute of a JSON object, matching its name case-insensitively.
+func Delete(resource map[string]any, name string) {
+	for key := range resource {
+		if strings.EqualFold(key, name) {
+			delete(resource, key)
+		}
+	}
+}
+
+func toList(v any) []any {
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case []any:
+		return v
+	default:
+		return []any{v}
+	}
+}
+
+// Report whether a list has a value, comparing the `value` of complex values.
+func containsValue(list []any, v any) bool {
+	for _, item := range list {
+		if reflect.DeepEqual(valueOf(item), valueOf(v)) {
+			return true
+		}
+	}
+	return false
+}
+
+func valueOf(v any) any {
+	if m, ok := v.(map[string]any); ok {
+		if value, ok := Get(m, "value"); ok {
+			return value
+		}
+	}
+	return v
+}
@@ -0,0 +1,101 @@
+package scim
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePatchPath(t *testing.T) {
+	path, err := ParsePatchPath(`emails[type eq "work"].value`)
+	require.NoError(t, err)
+	assert.Equal(t, AttrPath{Name: "emails"}, path.Attr)
+	assert.Equal(t, "value", path.Sub)
+	assert.NotNil(t, path.Filter)
+
+	path, err = ParsePatchPath(`urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:manager.value`)
+	require.NoError(t, err)
+	assert.Equal(t, AttrPath{URN: "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User", Name: "manager", Sub: "value"}, path.Attr)
+	assert.Nil(t, path.Filter)
+
+	for _, invalid := range []string{`emails[type eq "work"`, `emails[type eq "work"]value`, `name.given.name`, `emails.value[type eq "work"]`} {
+		_, err := ParsePatchPath(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestApply(t *testing.T) {
+	resource := testResource(t)
+	var operations []PatchOperation
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{"op": "Replace", "path": "active", "value": false},
+		{"op": "replace", "value": {"USERNAME": "alice2", "displayName": "Alice"}},
+		{"op": "add", "path": "emails", "value": [{"type": "other", "value": "a@other.net"}]},
+		{"op": "replace", "path": "emails[type eq \"work\"].value", "value": "alice@work.com"},
+		{"op": "remove", "path": "emails[type eq \"home\"]"},
+		{"op": "add", "path": "phoneNumbers[type eq \"work\"].value", "value": "+33123456789"},
+		{"op": "add", "path": "name.givenName", "value": "Alice"},
+		{"op": "remove", "path": "score"},
+		{"op": "add", "path": "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:department", "value": "R&D"}
+	]`), &operations))
+	require.NoError(t, Apply(resource, operations))
+
+	assert.Equal(t, false, resource["active"])
+	assert.Equal(t, "alice2", resource["USERNAME"])
+	assert.NotContains(t, resource, "userName")
+	assert.Equal(t, "Alice", resource["displayName"])
+	assert.Equal(t, []any{
+		map[string]any{"type": "work", "value": "alice@work.com"},
+		map[string]any{"type": "other", "value": "a@other.net"},
+	}, resource["emails"])
+	assert.Equal(t, []any{map[string]any{"type": "work", "value": "+33123456789"}}, resource["phoneNumbers"])
+	assert.Equal(t, map[string]any{"givenName": "Alice"}, resource["name"])
+	assert.NotContains(t, resource, "score")
+	assert.Equal(t, map[string]any{"department": "R&D"},
+		resource["urn:ietf:params:scim:schemas:extension:enterprise:2.0:User"])
+}
+
+func TestApply_RemoveMembers(t *testing.T) {
+	resource := map[string]any{"members": []any{
+		map[string]any{"value": "1"}, map[string]any{"value": "2"}, map[string]any{"value": "3"},
+	}}
+	require.NoError(t, Apply(resource, []PatchOperation{
+		{Op: "remove", Path: "members", Value: []any{map[string]any{"value": "2"}}},
+		{Op: "remove", Path: `members[value eq "3"]`},
+	}))
+	assert.Equal(t, []any{map[string]any{"value": "1"}}, resource["members"])
+}
+
+func TestApply_Invalid(t *testing.T) {
+	tests := []struct {
+		operation PatchOperation
+		scimType  ErrorType
+	}{
+		{PatchOperation{Op: "move", Path: "active"}, ErrInvalidSyntax},
+		{PatchOperation{Op: "remove"}, ErrNoTarget},
+		{PatchOperation{Op: "replace", Value: "x"}, ErrInvalidValue},
+		{PatchOperation{Op: "replace", Path: "emails[", Value: "x"}, ErrInvalidPath},
+		{PatchOperation{Op: "replace", Path: "emails[type]", Value: "x"}, ErrInvalidFilter},
+		{PatchOperation{Op: "replace", Path: `emails[value co "zz"].value`, Value: "x"}, ErrNoTarget},
+	}
+	for _, tt := range tests {
+		err := Apply(testResource(t), []PatchOperation{tt.operation})
+		var scimErr *Error
+		if assert.ErrorAs(t, err, &scimErr) {
+			assert.Equal(t, tt.scimType, scimErr.ScimType)
+		}
+	}
+}
+
+func TestError_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal(Errorf(ErrUniqueness, "userName %q is taken", "alice"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"schemas": ["urn:ietf:params:scim:api:messages:2.0:Error"],
+		"status": "409",
+		"scimType": "uniqueness",
+		"detail": "userName \"alice\" is taken"
+	}`, string(data))
+}
@@ -0,0 +1,72 @@
+// Package webhook provides the signing and retry policy of outgoing webhooks.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Headers set on every webhook request.
+const (
+	HeaderDeliveryID = "X-Webhook-Delivery"
+	HeaderEvent      = "X-Webhook-Event"
+	HeaderTimestamp  = "X-Webhook-Timestamp"
+	HeaderSignature  = "X-Webhook-Signature"
+)
+
+// signaturePrefix identifies the algorithm used to compute the signature.
+const signaturePrefix = "sha256="
+
+// Sign returns the signature of the payload sent at the given unix timestamp.
+//
+// The signature is the hex encoded HMAC-SHA256 of `<timestamp>.<payload>` keyed
+// with the webhook secret, prefixed with `sha256=`. Including the timestamp lets
+// receivers reject replayed requests.
+func Sign(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature of a payload in constant time.
+func Verify(secret string, timestamp int64, payload []byte, signature string) bool {
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return false
+	}
+	expected := Sign(secret, timestamp, payload)
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
+
+// GenerateSecret generates a random signing secret.
+func GenerateSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Backoff returns the delay before retrying after the given number of failed attempts.
+//
+// The delay doubles after every attempt, starting at `initial` and capped at `max`.
+func Backoff(attempts int32, initial, max time.Duration) time.Duration {
+	if attempts <= 1 {
+		return min(initial, max)
+	}
+
+	delay := initial
+	for i := int32(1); i < attempts; i++ {
+		delay *= 2
+		if delay >= max || delay <= 0 {
+			return max
+		}
+	}
+	return delay
+}
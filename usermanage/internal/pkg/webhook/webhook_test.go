@@ -0,0 +1,50 @@
+package webhook
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSign(t *testing.T) {
+	payload := []byte(`{"type":"user.created"}`)
+	signature := Sign("secret", 1700000000, payload)
+
+	assert.Equal(t, "sha256=", signature[:7])
+	assert.Len(t, signature, 7+64)
+	assert.True(t, Verify("secret", 1700000000, payload, signature))
+	assert.False(t, Verify("other", 1700000000, payload, signature))
+	assert.False(t, Verify("secret", 1700000001, payload, signature))
+	assert.False(t, Verify("secret", 1700000000, []byte(`{}`), signature))
+	assert.False(t, Verify("secret", 1700000000, payload, signature[7:]))
+}
+
+func TestGenerateSecret(t *testing.T) {
+	a, err := GenerateSecret()
+	require.NoError(t, err)
+	b, err := GenerateSecret()
+	require.NoError(t, err)
+
+	assert.Len(t, a, 64)
+	assert.NotEqual(t, a, b)
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int32
+		expected time.Duration
+	}{
+		{0, 10 * time.Second},
+		{1, 10 * time.Second},
+		{2, 20 * time.Second},
+		{3, 40 * time.Second},
+		{6, 5 * time.Minute},
+		{100, 5 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, Backoff(tt.attempts, 10*time.Second, 5*time.Minute), "attempts=%d", tt.attempts)
+	}
+}
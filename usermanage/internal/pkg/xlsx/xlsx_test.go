@@ -0,0 +1,46 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, "Users")
+	require.NoError(t, err)
+	require.NoError(t, w.WriteRow([]string{"username", "email"}))
+	require.NoError(t, w.WriteRow([]string{"<alice> & co", ""}))
+	require.NoError(t, w.Close())
+	assert.Error(t, w.WriteRow([]string{"bob"}))
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	parts := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		parts[f.Name] = string(content)
+	}
+
+	assert.Contains(t, parts, "[Content_Types].xml")
+	assert.Contains(t, parts["xl/workbook.xml"], `<sheet name="Users"`)
+	assert.Contains(t, parts["xl/worksheets/sheet1.xml"],
+		`<row><c t="inlineStr"><is><t xml:space="preserve">&lt;alice&gt; &amp; co</t></is></c><c/></row>`)
+	assert.Contains(t, parts["xl/worksheets/sheet1.xml"], "</sheetData></worksheet>")
+}
+
+func TestNewWriter_InvalidSheetName(t *testing.T) {
+	for _, name := range []string{"", "a/b", "a name longer than thirty-one characters"} {
+		_, err := NewWriter(io.Discard, name)
+		assert.Error(t, err, name)
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	authv1 "usermanage/gen/proto/api/auth/v1"
 	healthv1 "usermanage/gen/proto/api/health/v1"
+	oauthv1 "usermanage/gen/proto/api/oauth/v1"
 	userv1 "usermanage/gen/proto/api/user/v1"
+	webhookv1 "usermanage/gen/proto/api/webhook/v1"
 	"usermanage/gen/proto/conf"
 	"usermanage/internal/biz"
 	"usermanage/internal/pkg/middleware"
@@ -15,16 +17,21 @@ import (
 	"github.com/go-kratos/kratos/v2/middleware/recovery"
 	"github.com/go-kratos/kratos/v2/middleware/tracing"
 	"github.com/go-kratos/kratos/v2/transport/grpc"
+	"github.com/redis/go-redis/v9"
 )
 
 // NewGRPCServer new a gRPC server.
 func NewGRPCServer(
 	ctx context.Context,
 	c *conf.Server,
+	d *conf.Data,
 	health *service.HealthService,
 	user *service.UserService,
 	auth *service.AuthService,
+	webhook *service.WebhookService,
+	oauth *service.OAuthClientService,
 	authUseCase *biz.AuthUseCase,
+	rdb redis.UniversalClient,
 	logger log.Logger,
 ) *grpc.Server {
 	if err := initTracer(ctx, c); err != nil {
@@ -36,8 +43,11 @@ func NewGRPCServer(
 			recovery.Recovery(),
 			tracing.Server(),
 			middleware.Logging(logger, generateMaskedOperations(c)...),
+			middleware.ReadYourWrites(),
 			middleware.JWTAuth(authUseCase),
+			middleware.Idempotency(rdb, d.GetRedis().GetKeyPrefix(), idempotencyWindow(c), logger),
 		),
+		grpc.StreamInterceptor(middleware.JWTAuthStream(authUseCase)),
 	}
 	if c.Grpc.Network != "" {
 		opts = append(opts, grpc.Network(c.Grpc.Network))
@@ -52,5 +62,7 @@ func NewGRPCServer(
 	healthv1.RegisterHealthServiceServer(srv, health)
 	userv1.RegisterUserServiceServer(srv, user)
 	authv1.RegisterAuthServiceServer(srv, auth)
+	webhookv1.RegisterWebhookServiceServer(srv, webhook)
+	oauthv1.RegisterOAuthClientServiceServer(srv, oauth)
 	return srv
 }
@@ -4,7 +4,9 @@ import (
 	"context"
 	authv1 "usermanage/gen/proto/api/auth/v1"
 	healthv1 "usermanage/gen/proto/api/health/v1"
+	oauthv1 "usermanage/gen/proto/api/oauth/v1"
 	userv1 "usermanage/gen/proto/api/user/v1"
+	webhookv1 "usermanage/gen/proto/api/webhook/v1"
 	"usermanage/gen/proto/conf"
 	"usermanage/internal/biz"
 	"usermanage/internal/pkg/middleware"
@@ -14,16 +16,24 @@ import (
 	"github.com/go-kratos/kratos/v2/middleware/recovery"
 	"github.com/go-kratos/kratos/v2/middleware/tracing"
 	"github.com/go-kratos/kratos/v2/transport/http"
+	"github.com/redis/go-redis/v9"
 )
 
 // NewHTTPServer new an HTTP server.
 func NewHTTPServer(
 	ctx context.Context,
 	c *conf.Server,
+	d *conf.Data,
 	health *service.HealthService,
 	user *service.UserService,
 	auth *service.AuthService,
+	webhook *service.WebhookService,
+	scim *service.ScimService,
+	oauth *service.OAuthClientService,
+	oidc *service.OidcService,
+	federation *service.FederationService,
 	authUseCase *biz.AuthUseCase,
+	rdb redis.UniversalClient,
 	logger log.Logger,
 ) *http.Server {
 	if err := initTracer(ctx, c); err != nil {
@@ -35,7 +45,9 @@ func NewHTTPServer(
 			recovery.Recovery(),
 			tracing.Server(),
 			middleware.Logging(logger, generateMaskedOperations(c)...),
+			middleware.ReadYourWrites(),
 			middleware.JWTAuth(authUseCase),
+			middleware.Idempotency(rdb, d.GetRedis().GetKeyPrefix(), idempotencyWindow(c), logger),
 		),
 	}
 	if c.Http.Network != "" {
@@ -49,7 +61,16 @@ func NewHTTPServer(
 	}
 	srv := http.NewServer(opts...)
 	healthv1.RegisterHealthServiceHTTPServer(srv, health)
+	// Registered first, `/v1/admin/users/{id}` would match them otherwise
+	srv.Route("/").POST("/v1/admin/users/import", user.ImportUsersHTTP)
+	srv.Route("/").GET("/v1/admin/users/export", user.ExportUsersHTTP)
 	userv1.RegisterUserServiceHTTPServer(srv, user)
+	srv.Route("/").GET("/v1/users/watch", user.WatchUsersSSE)
 	authv1.RegisterAuthServiceHTTPServer(srv, auth)
+	webhookv1.RegisterWebhookServiceHTTPServer(srv, webhook)
+	scim.RegisterRoutes(srv)
+	oauthv1.RegisterOAuthClientServiceHTTPServer(srv, oauth)
+	oidc.RegisterRoutes(srv)
+	federation.RegisterRoutes(srv)
 	return srv
 }
@@ -0,0 +1,115 @@
+package server
+
+import (
+	"context"
+	"time"
+	"usermanage/gen/proto/conf"
+	"usermanage/internal/biz"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+const (
+	defaultPurgeInterval  = time.Hour
+	defaultPurgeBatchSize = 100
+)
+
+// UserPurger permanently removes the users deleted for longer than the retention period.
+//
+// It implements the kratos `transport.Server` interface so it runs alongside the
+// HTTP and gRPC servers. It does nothing when no retention period is configured.
+type UserPurger struct {
+	uc        *biz.UserUseCase
+	retention time.Duration
+	interval  time.Duration
+	batchSize int32
+	logger    *log.Helper
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewUserPurger creates a new user purger.
+func NewUserPurger(c *conf.Data, uc *biz.UserUseCase, logger log.Logger) *UserPurger {
+	cfg := c.GetDeletedUser()
+	var retention time.Duration
+	if d := cfg.GetRetention(); d != nil {
+		retention = d.AsDuration()
+	}
+	interval := defaultPurgeInterval
+	if d := cfg.GetPurgeInterval(); d != nil && d.AsDuration() > 0 {
+		interval = d.AsDuration()
+	}
+	batchSize := int32(defaultPurgeBatchSize)
+	if n := cfg.GetPurgeBatchSize(); n > 0 {
+		batchSize = n
+	}
+
+	return &UserPurger{
+		uc:        uc,
+		retention: retention,
+		interval:  interval,
+		batchSize: batchSize,
+		logger:    log.NewHelper(logger),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+}
+
+// Start implements transport.Server.
+//
+// It blocks until `Stop` is called or the context is done.
+func (p *UserPurger) Start(ctx context.Context) error {
+	defer close(p.done)
+	if p.retention <= 0 {
+		p.logger.Info("user purger disabled, deleted users are kept forever")
+		select {
+		case <-ctx.Done():
+		case <-p.stop:
+		}
+		return nil
+	}
+	p.logger.Infow("msg", "user purger started", "retention", p.retention, "interval", p.interval)
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		p.purge(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-p.stop:
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stop implements transport.Server.
+func (p *UserPurger) Stop(ctx context.Context) error {
+	close(p.stop)
+	select {
+	case <-p.done:
+	case <-ctx.Done():
+	}
+	p.logger.Info("user purger stopped")
+	return nil
+}
+
+// Purge the expired users batch by batch.
+func (p *UserPurger) purge(ctx context.Context) {
+	deletedBefore := time.Now().Add(-p.retention)
+	for {
+		n, err := p.uc.PurgeDeletedUsers(ctx, deletedBefore, p.batchSize)
+		if n > 0 {
+			p.logger.Infow("msg", "purged deleted users", "count", n, "deleted_before", deletedBefore)
+		}
+		if err != nil {
+			p.logger.Errorw("msg", "failed to purge deleted users", "error", err)
+			return
+		}
+		if n < int(p.batchSize) {
+			return
+		}
+	}
+}
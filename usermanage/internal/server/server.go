@@ -1,9 +1,12 @@
 package server
 
 import (
+	"time"
 	"usermanage/gen/proto/conf"
 )
 
+const defaultIdempotencyWindow = 24 * time.Hour
+
 var maskedOperations = []string{"/auth.v1.AuthService/Login"}
 
 func generateMaskedOperations(c *conf.Server) []string {
@@ -14,5 +17,15 @@ func generateMaskedOperations(c *conf.Server) []string {
 		"/auth.v1.AuthService/Login",
 		"/auth.v1.AuthService/RegisterRequest",
 		"/auth.v1.AuthService/ChangePasswordRequest",
+		"/webhook.v1.WebhookService/CreateWebhook",
+		"/oauth.v1.OAuthClientService/CreateOAuthClient",
+	}
+}
+
+// Return how long the responses of the requests with an idempotency key are kept.
+func idempotencyWindow(c *conf.Server) time.Duration {
+	if d := c.GetIdempotency().GetWindow(); d != nil && d.AsDuration() > 0 {
+		return d.AsDuration()
 	}
+	return defaultIdempotencyWindow
 }
@@ -2,11 +2,15 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"slices"
 	authv1 "usermanage/gen/proto/api/auth/v1"
 	userv1 "usermanage/gen/proto/api/user/v1"
+	"usermanage/gen/proto/conf"
 	"usermanage/internal/biz"
 	"usermanage/internal/pkg/auth"
 	"usermanage/internal/pkg/jwt"
+	"usermanage/internal/pkg/ldap"
 	"usermanage/internal/pkg/tracingx"
 
 	"github.com/go-kratos/kratos/v2/errors"
@@ -15,6 +19,73 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// NewAuthOptions creates the backends verifying the passwords at login from the configuration.
+func NewAuthOptions(c *conf.Server, d *conf.Data) (*biz.AuthOptions, error) {
+	ac := c.GetAuth()
+	opts := &biz.AuthOptions{Backends: ac.GetBackends()}
+	for i, backend := range opts.Backends {
+		if backend != biz.AuthBackendLocal && backend != biz.AuthBackendLDAP {
+			return nil, fmt.Errorf("auth: unknown backend[%s], `local` or `ldap` is required", backend)
+		}
+		if slices.Contains(opts.Backends[:i], backend) {
+			return nil, fmt.Errorf("auth: duplicate backend[%s]", backend)
+		}
+	}
+	if !slices.Contains(opts.Backends, biz.AuthBackendLDAP) {
+		return opts, nil
+	}
+
+	lc := ac.GetLdap()
+	if lc == nil {
+		return nil, fmt.Errorf("auth: the ldap backend requires the ldap configuration")
+	}
+	// The email and phone are stored encrypted, the login would fail on every sync
+	if (lc.GetEmailAttribute() != "" || lc.GetPhoneAttribute() != "") && d.GetEncryption().GetKeyFile() == "" {
+		return nil, fmt.Errorf("auth: syncing the ldap email or phone requires the encryption at rest")
+	}
+	groupAttribute := lc.GetGroupAttribute()
+	if groupAttribute == "" {
+		groupAttribute = biz.DefaultLDAPGroupAttribute
+	}
+	var attributes []string
+	for _, attribute := range []string{lc.GetEmailAttribute(), lc.GetPhoneAttribute()} {
+		if attribute != "" {
+			attributes = append(attributes, attribute)
+		}
+	}
+	if len(lc.GetAdminGroups()) > 0 {
+		attributes = append(attributes, groupAttribute)
+	}
+	if len(attributes) == 0 {
+		// An empty list returns every attribute, `1.1` none (RFC 4511, 4.5.1.8)
+		attributes = []string{"1.1"}
+	}
+	client, err := ldap.NewClient(ldap.Config{
+		URL:                lc.GetUrl(),
+		StartTLS:           lc.GetStartTls(),
+		InsecureSkipVerify: lc.GetInsecureSkipVerify(),
+		CAFile:             lc.GetCaFile(),
+		BindDN:             lc.GetBindDn(),
+		BindPassword:       lc.GetBindPassword(),
+		BaseDN:             lc.GetBaseDn(),
+		UserFilter:         lc.GetUserFilter(),
+		Attributes:         attributes,
+		Timeout:            lc.GetTimeout().AsDuration(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("auth: ldap: %w", err)
+	}
+	opts.LDAP = &biz.LDAPOptions{
+		Directory:      client,
+		EmailAttribute: lc.GetEmailAttribute(),
+		PhoneAttribute: lc.GetPhoneAttribute(),
+		GroupAttribute: groupAttribute,
+		AdminGroups:    lc.GetAdminGroups(),
+		AutoProvision:  lc.GetAutoProvision(),
+	}
+	return opts, nil
+}
+
 // AtuhService is a service for authentication.
 type AuthService struct {
 	authv1.UnimplementedAuthServiceServer
@@ -84,6 +155,12 @@ func (s *AuthService) GetUserInfo(ctx context.Context, req *authv1.UserInfoReque
 	}
 
 	logger.Info("auth get user info")
+	// The tokens of OAuth clients only grant their scopes, at the userinfo endpoint
+	if claims, err := jwt.ParseToken(req.Token); err == nil && claims.ClientID != "" {
+		logger.Errorw("msg", "oauth client token used", "client.id", claims.ClientID)
+		err = errors.Unauthorized("CLIENT_TOKEN", "OAuth client tokens are only accepted by the userinfo endpoint").WithMetadata(md)
+		return nil, err
+	}
 	user, err := s.uc.VerifyToken(ctx, req.Token)
 	if err != nil {
 		logger.Errorw("msg", "failed to verify token", "error", err)
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	nethttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	authv1 "usermanage/gen/proto/api/auth/v1"
+	userv1 "usermanage/gen/proto/api/user/v1"
+	"usermanage/internal/biz"
+	"usermanage/internal/pkg/middleware"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJWTAuth_ClientToken(t *testing.T) {
+	uc, authUseCase, oauth := newTestOAuthUseCase(t)
+	ctx := context.Background()
+
+	admin, err := uc.CreateUser(ctx, biz.UserCreateParams{
+		Username: "root",
+		Role:     int32(biz.UserRoleAdmin),
+		Status:   int32(biz.UserStatusNormal),
+		Creator:  "admin",
+	})
+	require.NoError(t, err)
+	_, loginToken, _, err := authUseCase.Login(ctx, "root", biz.DefaultUserPassword)
+	require.NoError(t, err)
+
+	// An access token of the admin, issued to a client
+	client, err := oauth.CreateClient(ctx, biz.OAuthClientCreateParams{
+		Name:         "App",
+		RedirectURIs: []string{"https://app.example.com/callback"},
+		SkipConsent:  true,
+	})
+	require.NoError(t, err)
+	clientToken := issueTestOAuthTokens(t, oauth, client, admin).AccessToken
+
+	srv := http.NewServer(http.Middleware(middleware.JWTAuth(authUseCase)))
+	userv1.RegisterUserServiceHTTPServer(srv, NewUserService(uc, log.DefaultLogger))
+	authv1.RegisterAuthServiceHTTPServer(srv, NewAuthService(authUseCase, log.DefaultLogger))
+	// Return the status and the body of a request
+	call := func(method, path, token, body string) (int, string) {
+		t.Helper()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rw := httptest.NewRecorder()
+		srv.ServeHTTP(rw, req)
+		return rw.Code, rw.Body.String()
+	}
+
+	t.Run("the API refuses client tokens", func(t *testing.T) {
+		status, _ := call(nethttp.MethodGet, "/v1/admin/users", loginToken, "")
+		assert.Equal(t, nethttp.StatusOK, status)
+
+		status, body := call(nethttp.MethodGet, "/v1/admin/users", clientToken, "")
+		assert.Equal(t, nethttp.StatusUnauthorized, status)
+		assert.Contains(t, body, "CLIENT_TOKEN")
+	})
+
+	t.Run("the user info refuses client tokens", func(t *testing.T) {
+		status, _ := call(nethttp.MethodPost, "/v1/auth/userinfo", "", `{"token": "`+loginToken+`"}`)
+		assert.Equal(t, nethttp.StatusOK, status)
+
+		status, body := call(nethttp.MethodPost, "/v1/auth/userinfo", "", `{"token": "`+clientToken+`"}`)
+		assert.Equal(t, nethttp.StatusUnauthorized, status)
+		assert.Contains(t, body, "CLIENT_TOKEN")
+
+		// Still accepted by the userinfo endpoint of OpenID Connect
+		claims, err := oauth.UserInfo(ctx, clientToken)
+		require.NoError(t, err)
+		assert.Equal(t, admin.ID, claims["sub"])
+	})
+}
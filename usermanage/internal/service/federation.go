@@ -0,0 +1,214 @@
+package service
+
+import (
+	"crypto/subtle"
+	"fmt"
+	nethttp "net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	authv1 "usermanage/gen/proto/api/auth/v1"
+	"usermanage/gen/proto/conf"
+	"usermanage/internal/biz"
+	"usermanage/internal/pkg/oidc"
+	"usermanage/internal/pkg/tracingx"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport/http"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	federationPath = "/v1/auth/federation"
+	// federationLoginCookie keeps the state, nonce and PKCE verifier of a login until the provider redirects back.
+	federationLoginCookie = "federation_login"
+	federationLoginTTL    = 10 * time.Minute
+	// federationHTTPTimeout bounds the requests to the upstream providers.
+	federationHTTPTimeout = 10 * time.Second
+)
+
+// federationProviderName matches the provider names, part of the URLs.
+var federationProviderName = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// Scopes requested to the upstream providers by default.
+var defaultFederationScopes = []string{"openid", "profile", "email"}
+
+// NewFederationOptions creates the upstream providers from the configuration.
+func NewFederationOptions(c *conf.Server) (*biz.FederationOptions, error) {
+	fc := c.GetFederation()
+	opts := &biz.FederationOptions{Providers: map[string]*biz.FederatedProvider{}}
+	if len(fc.GetProviders()) == 0 {
+		return opts, nil
+	}
+
+	baseURL := strings.TrimSuffix(fc.GetBaseUrl(), "/")
+	if u, err := url.Parse(baseURL); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return nil, fmt.Errorf("federation: invalid base url[%s], an http(s) URL is required", fc.GetBaseUrl())
+	}
+	if redirect := fc.GetSuccessRedirectUrl(); redirect != "" {
+		if u, err := url.Parse(redirect); err != nil || !u.IsAbs() || u.Fragment != "" {
+			return nil, fmt.Errorf("federation: invalid success redirect url[%s], an absolute URL without fragment is required", redirect)
+		}
+	}
+	httpClient := &nethttp.Client{Timeout: federationHTTPTimeout}
+	for _, pc := range fc.GetProviders() {
+		name := pc.GetName()
+		if !federationProviderName.MatchString(name) {
+			return nil, fmt.Errorf("federation: invalid provider name[%s]", name)
+		}
+		if _, ok := opts.Providers[name]; ok {
+			return nil, fmt.Errorf("federation: duplicate provider[%s]", name)
+		}
+		scopes := pc.GetScopes()
+		if len(scopes) == 0 {
+			scopes = defaultFederationScopes
+		}
+		client, err := oidc.NewClient(oidc.ClientConfig{
+			Issuer:       pc.GetIssuer(),
+			ClientID:     pc.GetClientId(),
+			ClientSecret: pc.GetClientSecret(),
+			RedirectURL:  baseURL + federationPath + "/" + name + "/callback",
+			Scopes:       scopes,
+			HTTPClient:   httpClient,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("federation: provider[%s]: %w", name, err)
+		}
+		opts.Providers[name] = &biz.FederatedProvider{
+			Name:           name,
+			Client:         client,
+			UsernameClaim:  pc.GetUsernameClaim(),
+			AutoProvision:  pc.GetAutoProvision(),
+			LinkByUsername: pc.GetLinkByUsername(),
+			RoleClaim:      pc.GetRoleClaim(),
+			AdminValues:    pc.GetAdminValues(),
+		}
+	}
+	return opts, nil
+}
+
+// FederationService logs the users in with upstream OpenID Connect providers: the login
+// endpoint redirects to the provider, which redirects back to the callback endpoint.
+//
+// The handlers are plain HTTP routes, driven by browser redirects rather than API calls.
+type FederationService struct {
+	uc              *biz.FederationUseCase
+	secure          bool   // the cookies are only sent over https
+	successRedirect string // where the browser is sent with the token, the token is returned as JSON if empty
+	log             *log.Helper
+}
+
+// NewFederationService creates a new federation service.
+func NewFederationService(c *conf.Server, uc *biz.FederationUseCase, logger log.Logger) *FederationService {
+	return &FederationService{
+		uc:              uc,
+		secure:          strings.HasPrefix(c.GetFederation().GetBaseUrl(), "https://"),
+		successRedirect: c.GetFederation().GetSuccessRedirectUrl(),
+		log:             log.NewHelper(logger),
+	}
+}
+
+// RegisterRoutes registers the login and callback endpoints, if providers are configured.
+func (s *FederationService) RegisterRoutes(srv *http.Server) {
+	if len(s.uc.Providers()) == 0 {
+		return
+	}
+	r := srv.Route(federationPath)
+	r.GET("/{provider}/login", s.login)
+	r.GET("/{provider}/callback", s.callback)
+}
+
+// Redirect the user to the provider to sign in.
+func (s *FederationService) login(ctx http.Context) error {
+	logger := s.log.WithContext(ctx)
+	md := map[string]string{"traceId": tracingx.GetTraceID(ctx)}
+	provider := ctx.Vars().Get("provider")
+
+	authURL, login, err := s.uc.StartLogin(ctx, provider)
+	if err != nil {
+		if errors.Is(err, biz.ErrFederationProviderNotFound) {
+			return errors.NotFound("FEDERATION_PROVIDER_NOT_FOUND", "Identity provider not found").
+				WithMetadata(md)
+		}
+		logger.Errorw("msg", "failed to start federated login", "provider", provider, "error", err)
+		return errors.InternalServer("FEDERATED_LOGIN_FAILED", "Failed to start login").
+			WithMetadata(md)
+	}
+	logger.Infow("msg", "federated login started", "provider", provider)
+
+	nethttp.SetCookie(ctx.Response(), &nethttp.Cookie{
+		Name:     federationLoginCookie,
+		Value:    strings.Join([]string{login.State, login.Nonce, login.CodeVerifier}, "."),
+		Path:     federationPath + "/" + provider,
+		MaxAge:   int(federationLoginTTL.Seconds()),
+		Secure:   s.secure,
+		HttpOnly: true,
+		// Lax, the cookie must come along with the redirect of the provider
+		SameSite: nethttp.SameSiteLaxMode,
+	})
+	ctx.Response().Header().Set("Cache-Control", "no-store")
+	nethttp.Redirect(ctx.Response(), ctx.Request(), authURL, nethttp.StatusFound)
+	return nil
+}
+
+// Finish the login the provider redirected the user back from.
+func (s *FederationService) callback(ctx http.Context) error {
+	logger := s.log.WithContext(ctx)
+	md := map[string]string{"traceId": tracingx.GetTraceID(ctx)}
+	provider := ctx.Vars().Get("provider")
+	query := ctx.Request().URL.Query()
+
+	// The login can only be finished once, by the browser it was started in
+	nethttp.SetCookie(ctx.Response(), &nethttp.Cookie{
+		Name:   federationLoginCookie,
+		Path:   federationPath + "/" + provider,
+		MaxAge: -1,
+	})
+	var login *biz.FederatedLogin
+	if cookie, err := ctx.Request().Cookie(federationLoginCookie); err == nil {
+		if parts := strings.Split(cookie.Value, "."); len(parts) == 3 {
+			login = &biz.FederatedLogin{State: parts[0], Nonce: parts[1], CodeVerifier: parts[2]}
+		}
+	}
+	if login == nil || subtle.ConstantTimeCompare([]byte(login.State), []byte(query.Get("state"))) != 1 {
+		logger.Warnw("msg", "invalid federated login state", "provider", provider)
+		return errors.BadRequest("INVALID_FEDERATED_LOGIN", "Login expired or started in another browser").
+			WithMetadata(md)
+	}
+	if code := query.Get("error"); code != "" {
+		logger.Warnw("msg", "federated login refused", "provider", provider, "error", code, "description", query.Get("error_description"))
+		return errors.Unauthorized("FEDERATED_LOGIN_FAILED", "Login refused by the identity provider").
+			WithMetadata(md)
+	}
+
+	user, token, expiresAt, err := s.uc.FinishLogin(ctx, provider, login, query.Get("code"))
+	if err != nil {
+		logger.Errorw("msg", "failed to finish federated login", "provider", provider, "error", err)
+		switch {
+		case errors.Is(err, biz.ErrFederationProviderNotFound):
+			return errors.NotFound("FEDERATION_PROVIDER_NOT_FOUND", "Identity provider not found").
+				WithMetadata(md)
+		case errors.Is(err, biz.ErrFederatedLoginDenied):
+			return errors.Forbidden("FEDERATED_LOGIN_DENIED", "No active user for this account").
+				WithMetadata(md)
+		case errors.Is(err, oidc.ErrInvalidIDToken):
+			return errors.Unauthorized("FEDERATED_LOGIN_FAILED", "Invalid identity token").
+				WithMetadata(md)
+		default:
+			return errors.InternalServer("FEDERATED_LOGIN_FAILED", "Failed to login").
+				WithMetadata(md)
+		}
+	}
+	logger.Infow("msg", "token generated", "user.name", user.Username, "provider", provider)
+
+	ctx.Response().Header().Set("Cache-Control", "no-store")
+	if s.successRedirect == "" {
+		return ctx.Result(nethttp.StatusOK, &authv1.LoginResponse{Token: token, ExpiresAt: timestamppb.New(expiresAt)})
+	}
+	// In the fragment, the token is not sent to the server of the page nor in the Referer
+	fragment := url.Values{"token": {token}, "expires_at": {expiresAt.UTC().Format(time.RFC3339)}}
+	nethttp.Redirect(ctx.Response(), ctx.Request(), s.successRedirect+"#"+fragment.Encode(), nethttp.StatusFound)
+	return nil
+}
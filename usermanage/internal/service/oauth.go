@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	commonv1 "usermanage/gen/proto/api/common/v1"
+	oauthv1 "usermanage/gen/proto/api/oauth/v1"
+	"usermanage/internal/biz"
+	"usermanage/internal/pkg/auth"
+	"usermanage/internal/pkg/constants"
+	"usermanage/internal/pkg/tracingx"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type OAuthClientService struct {
+	oauthv1.UnimplementedOAuthClientServiceServer
+	uc  *biz.OAuthUseCase
+	log *log.Helper
+}
+
+// NewOAuthClientService creates a new OAuth client service.
+func NewOAuthClientService(uc *biz.OAuthUseCase, logger log.Logger) *OAuthClientService {
+	return &OAuthClientService{uc: uc, log: log.NewHelper(logger)}
+}
+
+// ListOAuthClients lists OAuth clients.
+func (s *OAuthClientService) ListOAuthClients(ctx context.Context, req *oauthv1.OAuthClientListRequest) (*oauthv1.OAuthClientListResponse, error) {
+	logger := s.log.WithContext(ctx)
+	md := map[string]string{"traceId": tracingx.GetTraceID(ctx)}
+
+	if err := s.validateAdminAndRequest(ctx, req); err != nil {
+		return nil, err
+	}
+
+	page := func() int32 {
+		if req.Page == 0 {
+			return constants.DefaultPage
+		}
+		return req.Page
+	}()
+	pageSize := func() int32 {
+		if req.PageSize == 0 {
+			return constants.DefaultPageSize
+		}
+		return req.PageSize
+	}()
+	params := biz.OAuthClientListParams{
+		Page:     page,
+		PageSize: pageSize,
+	}
+	logger.Info("list oauth clients")
+	result, err := s.uc.ListClients(ctx, params)
+	if err != nil {
+		logger.Errorw("msg", "failed to list oauth clients", "error", err)
+		err = errors.InternalServer("LIST_OAUTH_CLIENTS_FAILED", "Failed to list OAuth clients").
+			WithMetadata(md)
+		return nil, err
+	}
+
+	pagination := commonv1.PageResponse{
+		Page:       page,
+		PageSize:   pageSize,
+		TotalCount: result.TotalCount,
+	}
+	data := make([]*oauthv1.OAuthClient, 0, len(result.Clients))
+	for _, client := range result.Clients {
+		data = append(data, s.toOAuthClient(client))
+	}
+	return &oauthv1.OAuthClientListResponse{Data: data, Pagination: &pagination}, nil
+}
+
+// GetOAuthClient gets an OAuth client by ID.
+func (s *OAuthClientService) GetOAuthClient(ctx context.Context, req *oauthv1.OAuthClientRequest) (*oauthv1.OAuthClientResponse, error) {
+	logger := s.log.WithContext(ctx)
+
+	if err := s.validateAdminAndRequest(ctx, req); err != nil {
+		return nil, err
+	}
+
+	logger.Infow("msg", "get oauth client", "client.id", req.Id)
+	client, err := s.uc.GetClient(ctx, req.Id)
+	if err != nil {
+		return nil, s.oauthClientError(ctx, "GET_OAUTH_CLIENT_FAILED", "Failed to get OAuth client", err)
+	}
+	return &oauthv1.OAuthClientResponse{Data: s.toOAuthClient(client)}, nil
+}
+
+// CreateOAuthClient registers an OAuth client.
+func (s *OAuthClientService) CreateOAuthClient(ctx context.Context, req *oauthv1.OAuthClientCreateRequest) (*oauthv1.OAuthClientResponse, error) {
+	logger := s.log.WithContext(ctx)
+
+	if err := s.validateAdminAndRequest(ctx, req); err != nil {
+		return nil, err
+	}
+
+	params := biz.OAuthClientCreateParams{
+		Name:           req.Name,
+		RedirectURIs:   req.RedirectUris,
+		Public:         req.Public,
+		SkipConsent:    req.SkipConsent,
+		ResourceServer: req.ResourceServer,
+		Creator:        auth.Username(ctx),
+	}
+	logger.Infow("msg", "create oauth client", "name", req.Name, "public", req.Public)
+	client, err := s.uc.CreateClient(ctx, params)
+	if err != nil {
+		return nil, s.oauthClientError(ctx, "CREATE_OAUTH_CLIENT_FAILED", "Failed to create OAuth client", err)
+	}
+	logger.Infow("msg", "successfully create oauth client", "client.id", client.ID)
+
+	// The secret is only handed out once, at creation
+	data := s.toOAuthClient(client)
+	data.Secret = client.Secret
+	return &oauthv1.OAuthClientResponse{Data: data}, nil
+}
+
+// DeleteOAuthClient deletes an OAuth client by ID.
+func (s *OAuthClientService) DeleteOAuthClient(ctx context.Context, req *oauthv1.OAuthClientDeleteRequest) (*emptypb.Empty, error) {
+	logger := s.log.WithContext(ctx)
+
+	if err := s.validateAdminAndRequest(ctx, req); err != nil {
+		return nil, err
+	}
+
+	logger.Infow("msg", "delete oauth client", "client.id", req.Id)
+	if err := s.uc.DeleteClient(ctx, req.Id); err != nil {
+		return nil, s.oauthClientError(ctx, "DELETE_OAUTH_CLIENT_FAILED", "Failed to delete OAuth client", err)
+	}
+	logger.Infow("msg", "successfully delete oauth client", "client.id", req.Id)
+	return &emptypb.Empty{}, nil
+}
+
+// Log and translate the error of an OAuth client operation.
+func (s *OAuthClientService) oauthClientError(ctx context.Context, reason, message string, err error) error {
+	md := map[string]string{"traceId": tracingx.GetTraceID(ctx)}
+
+	s.log.WithContext(ctx).Errorw("msg", message, "error", err)
+	switch {
+	case errors.Is(err, biz.ErrOAuthClientNotFound):
+		return errors.NotFound("OAUTH_CLIENT_NOT_FOUND", "OAuth client not found").
+			WithMetadata(md)
+	case errors.Is(err, biz.ErrInvalidOAuthClient):
+		return errors.BadRequest("INVALID_OAUTH_CLIENT", err.Error()).
+			WithMetadata(md)
+	default:
+		return errors.InternalServer(reason, message).
+			WithMetadata(md)
+	}
+}
+
+func (s *OAuthClientService) validateAdminAndRequest(ctx context.Context, req interface{ Validate() error }) error {
+	md := map[string]string{"traceId": tracingx.GetTraceID(ctx)}
+	logger := s.log.WithContext(ctx)
+
+	if err := auth.IsAdmin(ctx); err != nil {
+		logger.Errorw("msg", "insufficient permissions", "error", err)
+		return errors.Forbidden("INSUFFICIENT_PERMISSIONS", "Insufficient permissions").
+			WithMetadata(md)
+	}
+
+	if err := req.Validate(); err != nil {
+		logger.Errorw("msg", "invalid request", "error", err)
+		return errors.BadRequest("INVALID_REQUEST", "Invalid request").
+			WithMetadata(md)
+	}
+
+	return nil
+}
+
+// Convert biz OAuth client to OAuth client, without its secret.
+func (s *OAuthClientService) toOAuthClient(c *biz.OAuthClient) *oauthv1.OAuthClient {
+	if c == nil {
+		return nil
+	}
+
+	return &oauthv1.OAuthClient{
+		Id:             c.ID,
+		Name:           c.Name,
+		RedirectUris:   c.RedirectURIs,
+		Public:         c.Public,
+		SkipConsent:    c.SkipConsent,
+		ResourceServer: c.ResourceServer,
+		Creator:        c.Creator,
+		CreatedAt:      timestamppb.New(c.CreatedAt),
+		UpdatedAt:      timestamppb.New(c.UpdatedAt),
+	}
+}
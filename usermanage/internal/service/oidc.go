@@ -0,0 +1,666 @@
+package service
+
+import (
+	"bytes"
+	"crypto/subtle"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	nethttp "net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+	"usermanage/gen/proto/conf"
+	"usermanage/internal/biz"
+	"usermanage/internal/pkg/jwt"
+	"usermanage/internal/pkg/oidc"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport/http"
+)
+
+const (
+	defaultOAuthCodeTTL         = time.Minute
+	defaultOAuthIDTokenTTL      = time.Hour
+	defaultOAuthRefreshTokenTTL = 30 * 24 * time.Hour
+
+	// oidcSessionCookie holds the login token of the user on the provider pages.
+	oidcSessionCookie = "oidc_session"
+	// oidcCSRFCookie holds the token the forms of the provider pages are submitted with.
+	oidcCSRFCookie = "oidc_csrf"
+	oidcCookiePath = "/oauth2"
+)
+
+//go:embed templates/oidc.html
+var oidcPageTemplate string
+
+var oidcPage = template.Must(template.New("oidc").Parse(oidcPageTemplate))
+
+// oidcScopeDescriptions are shown to the user asked to consent.
+var oidcScopeDescriptions = map[string]string{
+	biz.OAuthScopeOpenID:        "Confirm your identity",
+	biz.OAuthScopeProfile:       "Read your username and role",
+	biz.OAuthScopeEmail:         "Read your email address",
+	biz.OAuthScopePhone:         "Read your phone number",
+	biz.OAuthScopeOfflineAccess: "Keep access while you are away",
+}
+
+// The parameters of an authorization request, carried by the forms of the login and consent pages.
+var oidcAuthorizationParams = []string{
+	"client_id", "redirect_uri", "response_type", "scope", "state", "nonce", "code_challenge", "code_challenge_method",
+}
+
+// NewOAuthOptions creates the options of the OpenID Connect provider from the configuration.
+//
+// Without a configured signing key, a key is generated: the ID tokens it signed can no
+// longer be verified once the server restarts, and every instance has its own.
+func NewOAuthOptions(c *conf.Server, logger log.Logger) (*biz.OAuthOptions, error) {
+	oc := c.GetOidc()
+	opts := &biz.OAuthOptions{
+		Issuer:          strings.TrimSuffix(oc.GetIssuer(), "/"),
+		CodeTTL:         defaultOAuthCodeTTL,
+		IDTokenTTL:      defaultOAuthIDTokenTTL,
+		RefreshTokenTTL: defaultOAuthRefreshTokenTTL,
+	}
+	if d := oc.GetCodeTtl(); d != nil && d.AsDuration() > 0 {
+		opts.CodeTTL = d.AsDuration()
+	}
+	if d := oc.GetIdTokenTtl(); d != nil && d.AsDuration() > 0 {
+		opts.IDTokenTTL = d.AsDuration()
+	}
+	if d := oc.GetRefreshTokenTtl(); d != nil && d.AsDuration() > 0 {
+		opts.RefreshTokenTTL = d.AsDuration()
+	}
+	if !oc.GetEnabled() {
+		return opts, nil
+	}
+
+	u, err := url.Parse(opts.Issuer)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" || u.RawQuery != "" || u.Fragment != "" {
+		return nil, fmt.Errorf("oidc: invalid issuer[%s], an http(s) URL without query is required", oc.GetIssuer())
+	}
+	if path := oc.GetSigningKeyFile(); path != "" {
+		opts.Signer, err = oidc.LoadSigner(path)
+	} else {
+		log.NewHelper(logger).Warn("oidc: no signing key file configured, using a key generated for this process")
+		opts.Signer, err = oidc.GenerateSigner()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("oidc: %w", err)
+	}
+	return opts, nil
+}
+
+// OidcService serves the OpenID Connect provider: the discovery document, the login and
+// consent pages of the authorization endpoint, the token and userinfo endpoints.
+//
+// The handlers are plain HTTP routes rather than Kratos operations: the clients authenticate
+// with their secret, the users with the session cookie of the pages, and OAuth has its own errors.
+type OidcService struct {
+	enabled bool
+	secure  bool // the cookies are only sent over https
+	uc      *biz.OAuthUseCase
+	auth    *biz.AuthUseCase
+	log     *log.Helper
+}
+
+// oidcAuthorization is a valid authorization request.
+type oidcAuthorization struct {
+	req    *biz.OAuthAuthorizationRequest
+	client *biz.OAuthClient
+	scopes []string
+}
+
+// oidcPageData is rendered by the page template.
+type oidcPageData struct {
+	Kind     string // login, consent or error
+	Title    string
+	Error    string
+	Client   string
+	Username string
+	Scopes   []string
+	CSRF     string
+	Params   []oidcParam
+}
+
+type oidcParam struct {
+	Name  string
+	Value string
+}
+
+// NewOidcService creates a new OpenID Connect provider service.
+func NewOidcService(c *conf.Server, uc *biz.OAuthUseCase, auth *biz.AuthUseCase, logger log.Logger) *OidcService {
+	return &OidcService{
+		enabled: c.GetOidc().GetEnabled(),
+		secure:  strings.HasPrefix(c.GetOidc().GetIssuer(), "https://"),
+		uc:      uc,
+		auth:    auth,
+		log:     log.NewHelper(logger),
+	}
+}
+
+// RegisterRoutes registers the endpoints under `/oauth2` and the discovery document, if the provider is enabled.
+func (s *OidcService) RegisterRoutes(srv *http.Server) {
+	if !s.enabled {
+		return
+	}
+	srv.Route("/").GET("/.well-known/openid-configuration", s.discovery)
+
+	r := srv.Route(oidcCookiePath)
+	r.GET("/jwks", s.jwks)
+	r.GET("/authorize", s.authorize)
+	r.POST("/authorize", s.authorize)
+	r.POST("/token", s.token)
+	r.OPTIONS("/token", s.preflight)
+	r.GET("/userinfo", s.userinfo)
+	r.POST("/userinfo", s.userinfo)
+	r.OPTIONS("/userinfo", s.preflight)
+	r.POST("/introspect", s.introspect)
+	r.POST("/revoke", s.revoke)
+	r.OPTIONS("/revoke", s.preflight)
+}
+
+func (s *OidcService) discovery(ctx http.Context) error {
+	issuer := s.uc.Issuer()
+	w := ctx.Response()
+	allowCORS(w)
+	return writeOidcJSON(w, nethttp.StatusOK, map[string]any{
+		"issuer":                                issuer,
+		"authorization_endpoint":                issuer + oidcCookiePath + "/authorize",
+		"token_endpoint":                        issuer + oidcCookiePath + "/token",
+		"userinfo_endpoint":                     issuer + oidcCookiePath + "/userinfo",
+		"jwks_uri":                              issuer + oidcCookiePath + "/jwks",
+		"introspection_endpoint":                issuer + oidcCookiePath + "/introspect",
+		"revocation_endpoint":                   issuer + oidcCookiePath + "/revoke",
+		"scopes_supported":                      biz.OAuthScopes,
+		"response_types_supported":              []string{biz.OAuthResponseTypeCode},
+		"response_modes_supported":              []string{"query"},
+		"grant_types_supported":                 []string{biz.OAuthGrantTypeAuthorizationCode, biz.OAuthGrantTypeRefreshToken},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"token_endpoint_auth_methods_supported": []string{"client_secret_basic", "client_secret_post", "none"},
+		// Public clients cannot introspect, anyone can claim their ID
+		"introspection_endpoint_auth_methods_supported": []string{"client_secret_basic", "client_secret_post"},
+		"revocation_endpoint_auth_methods_supported":    []string{"client_secret_basic", "client_secret_post", "none"},
+		"code_challenge_methods_supported":              []string{oidc.CodeChallengeMethodS256},
+		"prompt_values_supported":                       []string{"none", "login", "consent"},
+		"claims_supported": []string{
+			"sub", "iss", "aud", "exp", "iat", "auth_time", "nonce", "at_hash",
+			"preferred_username", "role", "updated_at", "email", "email_verified", "phone_number", "phone_number_verified",
+		},
+		"authorization_response_iss_parameter_supported": true,
+	})
+}
+
+func (s *OidcService) jwks(ctx http.Context) error {
+	w := ctx.Response()
+	allowCORS(w)
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	return writeOidcJSON(w, nethttp.StatusOK, s.uc.JWKS())
+}
+
+// Serve the authorization endpoint: GET starts the authorization, the login and consent
+// forms are posted back to it.
+func (s *OidcService) authorize(ctx http.Context) error {
+	r := ctx.Request()
+	logger := s.log.WithContext(ctx)
+
+	if err := r.ParseForm(); err != nil {
+		return s.renderError(ctx, nethttp.StatusBadRequest, "The request is invalid.")
+	}
+	req := &biz.OAuthAuthorizationRequest{
+		ClientID:            r.Form.Get("client_id"),
+		RedirectURI:         r.Form.Get("redirect_uri"),
+		ResponseType:        r.Form.Get("response_type"),
+		Scope:               r.Form.Get("scope"),
+		State:               r.Form.Get("state"),
+		Nonce:               r.Form.Get("nonce"),
+		CodeChallenge:       r.Form.Get("code_challenge"),
+		CodeChallengeMethod: r.Form.Get("code_challenge_method"),
+	}
+	client, scopes, err := s.uc.CheckAuthorizationRequest(ctx, req)
+	if err != nil {
+		var oauthErr *biz.OAuthError
+		switch {
+		case client == nil && errors.As(err, &oauthErr):
+			logger.Warnw("msg", "invalid authorization request", "client.id", req.ClientID, "error", err)
+			return s.renderError(ctx, nethttp.StatusBadRequest, "The application sent an invalid request: "+oauthErr.Description+".")
+		case client == nil:
+			logger.Errorw("msg", "failed to check authorization request", "client.id", req.ClientID, "error", err)
+			return s.renderError(ctx, nethttp.StatusInternalServerError, "Something went wrong, please try again later.")
+		default:
+			return s.redirect(ctx, &oidcAuthorization{req: req, client: client}, oauthErrorParams(err))
+		}
+	}
+	a := &oidcAuthorization{req: req, client: client, scopes: scopes}
+
+	if r.Method == nethttp.MethodPost {
+		if !s.checkCSRF(r) {
+			logger.Warnw("msg", "invalid csrf token", "client.id", client.ID)
+			return s.renderError(ctx, nethttp.StatusForbidden, "The form expired, please go back to the application and try again.")
+		}
+		switch r.PostForm.Get("action") {
+		case "login":
+			return s.login(ctx, a)
+		case "consent":
+			return s.consent(ctx, a)
+		default:
+			return s.renderError(ctx, nethttp.StatusBadRequest, "The request is invalid.")
+		}
+	}
+
+	prompt := strings.Fields(r.Form.Get("prompt"))
+	user, authTime := s.session(ctx)
+	if slices.Contains(prompt, "none") {
+		switch {
+		case len(prompt) > 1:
+			return s.redirectError(ctx, a, biz.OAuthErrInvalidRequest, "prompt=none cannot be combined with other values")
+		case user == nil:
+			return s.redirectError(ctx, a, biz.OAuthErrLoginRequired, "the user is not logged in")
+		}
+		needed, err := s.uc.NeedsConsent(ctx, client, user.Username, scopes)
+		if err != nil {
+			logger.Errorw("msg", "failed to check consent", "client.id", client.ID, "error", err)
+			return s.renderError(ctx, nethttp.StatusInternalServerError, "Something went wrong, please try again later.")
+		}
+		if needed {
+			return s.redirectError(ctx, a, biz.OAuthErrConsentRequired, "the user has not consented")
+		}
+		return s.issueCode(ctx, a, user, authTime, false)
+	}
+	if user == nil || slices.Contains(prompt, "login") {
+		return s.renderLogin(ctx, a, nethttp.StatusOK, "", "")
+	}
+	return s.continueAuthorization(ctx, a, user, authTime, slices.Contains(prompt, "consent"))
+}
+
+// Log the user in with the posted credentials and continue the authorization.
+func (s *OidcService) login(ctx http.Context, a *oidcAuthorization) error {
+	r := ctx.Request()
+	logger := s.log.WithContext(ctx)
+
+	username := r.PostForm.Get("username")
+	user, token, expiresAt, err := s.auth.Login(ctx, username, r.PostForm.Get("password"))
+	if err != nil {
+		logger.Warnw("msg", "failed to login", "username", username, "client.id", a.client.ID, "error", err)
+		return s.renderLogin(ctx, a, nethttp.StatusUnauthorized, username, "Invalid username or password.")
+	}
+	if !user.Status.IsNormal() {
+		logger.Warnw("msg", "invalid user status", "username", username, "status", user.Status.String())
+		if err := s.auth.DeleteToken(ctx, token); err != nil {
+			logger.Errorw("msg", "failed to delete token", "error", err)
+		}
+		return s.renderLogin(ctx, a, nethttp.StatusForbidden, username, fmt.Sprintf("Your account is %s.", user.Status))
+	}
+	logger.Infow("msg", "user logged in", "username", username, "client.id", a.client.ID)
+
+	nethttp.SetCookie(ctx.Response(), &nethttp.Cookie{
+		Name:     oidcSessionCookie,
+		Value:    token,
+		Path:     oidcCookiePath,
+		Expires:  expiresAt,
+		Secure:   s.secure,
+		HttpOnly: true,
+		SameSite: nethttp.SameSiteLaxMode,
+	})
+	return s.continueAuthorization(ctx, a, user, time.Now(), false)
+}
+
+// Issue the code or deny the authorization as the logged in user decided.
+func (s *OidcService) consent(ctx http.Context, a *oidcAuthorization) error {
+	user, authTime := s.session(ctx)
+	if user == nil {
+		return s.renderLogin(ctx, a, nethttp.StatusUnauthorized, "", "Your session expired, please sign in again.")
+	}
+	if ctx.Request().PostForm.Get("decision") != "allow" {
+		s.log.WithContext(ctx).Infow("msg", "user denied authorization", "username", user.Username, "client.id", a.client.ID)
+		return s.redirectError(ctx, a, biz.OAuthErrAccessDenied, "the user denied the request")
+	}
+	return s.issueCode(ctx, a, user, authTime, true)
+}
+
+// Ask the logged in user to consent if needed, otherwise issue the code.
+func (s *OidcService) continueAuthorization(ctx http.Context, a *oidcAuthorization, user *biz.User, authTime time.Time, forceConsent bool) error {
+	needed := forceConsent && !a.client.SkipConsent
+	if !needed {
+		var err error
+		if needed, err = s.uc.NeedsConsent(ctx, a.client, user.Username, a.scopes); err != nil {
+			s.log.WithContext(ctx).Errorw("msg", "failed to check consent", "client.id", a.client.ID, "error", err)
+			return s.renderError(ctx, nethttp.StatusInternalServerError, "Something went wrong, please try again later.")
+		}
+	}
+	if !needed {
+		return s.issueCode(ctx, a, user, authTime, false)
+	}
+
+	descriptions := make([]string, 0, len(a.scopes))
+	for _, scope := range a.scopes {
+		descriptions = append(descriptions, oidcScopeDescriptions[scope])
+	}
+	return s.render(ctx, nethttp.StatusOK, &oidcPageData{
+		Kind:     "consent",
+		Title:    "Authorize " + a.client.Name,
+		Client:   a.client.Name,
+		Username: user.Username,
+		Scopes:   descriptions,
+		CSRF:     s.csrfToken(ctx),
+		Params:   oidcParams(a),
+	})
+}
+
+// Issue an authorization code and redirect the user back to the client with it.
+func (s *OidcService) issueCode(ctx http.Context, a *oidcAuthorization, user *biz.User, authTime time.Time, consented bool) error {
+	code, err := s.uc.Authorize(ctx, a.client, a.req, a.scopes, user, authTime, consented)
+	if err != nil {
+		var oauthErr *biz.OAuthError
+		if errors.As(err, &oauthErr) {
+			return s.redirect(ctx, a, oauthErrorParams(err))
+		}
+		s.log.WithContext(ctx).Errorw("msg", "failed to authorize", "username", user.Username, "client.id", a.client.ID, "error", err)
+		return s.renderError(ctx, nethttp.StatusInternalServerError, "Something went wrong, please try again later.")
+	}
+	s.log.WithContext(ctx).Infow("msg", "issued authorization code", "username", user.Username, "client.id", a.client.ID)
+	return s.redirect(ctx, a, url.Values{"code": {code}})
+}
+
+// Return the user logged in on the provider pages and the time they logged in, nil if none.
+func (s *OidcService) session(ctx http.Context) (*biz.User, time.Time) {
+	cookie, err := ctx.Request().Cookie(oidcSessionCookie)
+	if err != nil {
+		return nil, time.Time{}
+	}
+	// The access tokens issued to the clients are not sessions of the pages
+	claims, err := jwt.ParseToken(cookie.Value)
+	if err != nil || claims.ClientID != "" || claims.IssuedAt == nil {
+		return nil, time.Time{}
+	}
+	user, err := s.auth.VerifyToken(ctx, cookie.Value)
+	if err != nil {
+		return nil, time.Time{}
+	}
+	return user, claims.IssuedAt.Time
+}
+
+// Return the CSRF token of the forms, set as a cookie on first use.
+func (s *OidcService) csrfToken(ctx http.Context) string {
+	if cookie, err := ctx.Request().Cookie(oidcCSRFCookie); err == nil && cookie.Value != "" {
+		return cookie.Value
+	}
+	token, err := oidc.GenerateToken()
+	if err != nil {
+		return ""
+	}
+	nethttp.SetCookie(ctx.Response(), &nethttp.Cookie{
+		Name:     oidcCSRFCookie,
+		Value:    token,
+		Path:     oidcCookiePath,
+		Secure:   s.secure,
+		HttpOnly: true,
+		SameSite: nethttp.SameSiteStrictMode,
+	})
+	return token
+}
+
+// Check the CSRF token of a posted form against its cookie.
+func (s *OidcService) checkCSRF(r *nethttp.Request) bool {
+	cookie, err := r.Cookie(oidcCSRFCookie)
+	if err != nil || cookie.Value == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(r.PostForm.Get("csrf"))) == 1
+}
+
+func (s *OidcService) renderLogin(ctx http.Context, a *oidcAuthorization, status int, username, message string) error {
+	return s.render(ctx, status, &oidcPageData{
+		Kind:     "login",
+		Title:    "Sign in",
+		Error:    message,
+		Client:   a.client.Name,
+		Username: username,
+		CSRF:     s.csrfToken(ctx),
+		Params:   oidcParams(a),
+	})
+}
+
+func (s *OidcService) renderError(ctx http.Context, status int, message string) error {
+	return s.render(ctx, status, &oidcPageData{Kind: "error", Title: "Sign in failed", Error: message})
+}
+
+func (s *OidcService) render(ctx http.Context, status int, data *oidcPageData) error {
+	var b bytes.Buffer
+	if err := oidcPage.Execute(&b, data); err != nil {
+		return fmt.Errorf("failed to render oidc page: %w", err)
+	}
+	h := ctx.Response().Header()
+	h.Set("Content-Type", "text/html; charset=utf-8")
+	h.Set("Cache-Control", "no-store")
+	h.Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; frame-ancestors 'none'")
+	h.Set("X-Frame-Options", "DENY")
+	h.Set("Referrer-Policy", "no-referrer")
+	ctx.Response().WriteHeader(status)
+	_, err := ctx.Response().Write(b.Bytes())
+	return err
+}
+
+func (s *OidcService) redirectError(ctx http.Context, a *oidcAuthorization, code, description string) error {
+	return s.redirect(ctx, a, url.Values{"error": {code}, "error_description": {description}})
+}
+
+// Redirect the user back to the client with the response parameters, the state and the issuer.
+func (s *OidcService) redirect(ctx http.Context, a *oidcAuthorization, params url.Values) error {
+	u, err := url.Parse(a.req.RedirectURI)
+	if err != nil {
+		return fmt.Errorf("failed to parse redirect uri: %w", err)
+	}
+	query := u.Query()
+	for name, values := range params {
+		query[name] = values
+	}
+	if a.req.State != "" {
+		query.Set("state", a.req.State)
+	}
+	query.Set("iss", s.uc.Issuer())
+	u.RawQuery = query.Encode()
+
+	ctx.Response().Header().Set("Cache-Control", "no-store")
+	nethttp.Redirect(ctx.Response(), ctx.Request(), u.String(), nethttp.StatusFound)
+	return nil
+}
+
+// Serve the token endpoint, the clients authenticate with HTTP Basic or the form parameters.
+func (s *OidcService) token(ctx http.Context) error {
+	r := ctx.Request()
+	w := ctx.Response()
+	logger := s.log.WithContext(ctx)
+	allowCORS(w)
+
+	if err := r.ParseForm(); err != nil {
+		return s.writeOAuthError(ctx, &biz.OAuthError{Code: biz.OAuthErrInvalidRequest, Description: "invalid form body"})
+	}
+	req := &biz.OAuthTokenRequest{
+		GrantType:    r.PostForm.Get("grant_type"),
+		Code:         r.PostForm.Get("code"),
+		RedirectURI:  r.PostForm.Get("redirect_uri"),
+		CodeVerifier: r.PostForm.Get("code_verifier"),
+		RefreshToken: r.PostForm.Get("refresh_token"),
+		Scope:        r.PostForm.Get("scope"),
+	}
+	req.ClientID, req.ClientSecret = clientCredentials(r)
+
+	resp, err := s.uc.Exchange(ctx, req)
+	if err != nil {
+		return s.writeOAuthError(ctx, err)
+	}
+	logger.Infow("msg", "issued tokens", "client.id", req.ClientID, "grant_type", req.GrantType)
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Pragma", "no-cache")
+	return writeOidcJSON(w, nethttp.StatusOK, resp)
+}
+
+// Serve the userinfo endpoint, authenticated with an access token.
+func (s *OidcService) userinfo(ctx http.Context) error {
+	w := ctx.Response()
+	allowCORS(w)
+
+	token, ok := strings.CutPrefix(ctx.Request().Header.Get(jwt.AuthorizationHeader), jwt.BearerPrefix)
+	if !ok || token == "" {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="oauth2"`)
+		return writeOidcJSON(w, nethttp.StatusUnauthorized, map[string]string{"error": biz.OAuthErrInvalidToken})
+	}
+	claims, err := s.uc.UserInfo(ctx, token)
+	if err != nil {
+		return s.writeOAuthError(ctx, err)
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	return writeOidcJSON(w, nethttp.StatusOK, claims)
+}
+
+// Serve the introspection endpoint (RFC 7662), for resource servers such as an API gateway
+// registered as confidential clients.
+func (s *OidcService) introspect(ctx http.Context) error {
+	r := ctx.Request()
+	w := ctx.Response()
+	logger := s.log.WithContext(ctx)
+
+	if err := r.ParseForm(); err != nil {
+		return s.writeOAuthError(ctx, &biz.OAuthError{Code: biz.OAuthErrInvalidRequest, Description: "invalid form body"})
+	}
+	params := &biz.OAuthTokenParams{
+		Token:         r.PostForm.Get("token"),
+		TokenTypeHint: r.PostForm.Get("token_type_hint"),
+	}
+	params.ClientID, params.ClientSecret = clientCredentials(r)
+
+	introspection, err := s.uc.Introspect(ctx, params)
+	if err != nil {
+		return s.writeOAuthError(ctx, err)
+	}
+	logger.Debugw("msg", "introspected token", "client.id", params.ClientID, "active", introspection.Active)
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Pragma", "no-cache")
+	return writeOidcJSON(w, nethttp.StatusOK, introspection)
+}
+
+// Serve the revocation endpoint (RFC 7009), unknown tokens are ignored.
+func (s *OidcService) revoke(ctx http.Context) error {
+	r := ctx.Request()
+	w := ctx.Response()
+	logger := s.log.WithContext(ctx)
+	allowCORS(w)
+
+	if err := r.ParseForm(); err != nil {
+		return s.writeOAuthError(ctx, &biz.OAuthError{Code: biz.OAuthErrInvalidRequest, Description: "invalid form body"})
+	}
+	params := &biz.OAuthTokenParams{
+		Token:         r.PostForm.Get("token"),
+		TokenTypeHint: r.PostForm.Get("token_type_hint"),
+	}
+	params.ClientID, params.ClientSecret = clientCredentials(r)
+
+	if err := s.uc.Revoke(ctx, params); err != nil {
+		return s.writeOAuthError(ctx, err)
+	}
+	logger.Infow("msg", "revoked token", "client.id", params.ClientID, "token_type_hint", params.TokenTypeHint)
+	w.WriteHeader(nethttp.StatusOK)
+	return nil
+}
+
+func (s *OidcService) preflight(ctx http.Context) error {
+	w := ctx.Response()
+	allowCORS(w)
+	w.Header().Set("Access-Control-Allow-Methods", "GET, POST")
+	w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type")
+	w.Header().Set("Access-Control-Max-Age", "86400")
+	w.WriteHeader(nethttp.StatusNoContent)
+	return nil
+}
+
+// Write the error of the token, userinfo, introspection or revocation endpoint.
+func (s *OidcService) writeOAuthError(ctx http.Context, err error) error {
+	w := ctx.Response()
+	logger := s.log.WithContext(ctx)
+
+	var oauthErr *biz.OAuthError
+	if !errors.As(err, &oauthErr) {
+		logger.Errorw("msg", "failed to serve oauth request", "path", ctx.Request().URL.Path, "error", err)
+		return writeOidcJSON(w, nethttp.StatusInternalServerError, map[string]string{"error": "server_error"})
+	}
+	logger.Warnw("msg", "invalid oauth request", "path", ctx.Request().URL.Path, "error", err)
+
+	status := nethttp.StatusBadRequest
+	switch oauthErr.Code {
+	case biz.OAuthErrInvalidClient:
+		status = nethttp.StatusUnauthorized
+		w.Header().Set("WWW-Authenticate", `Basic realm="oauth2"`)
+	case biz.OAuthErrInvalidToken, biz.OAuthErrInsufficientScope:
+		status = nethttp.StatusUnauthorized
+		if oauthErr.Code == biz.OAuthErrInsufficientScope {
+			status = nethttp.StatusForbidden
+		}
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="oauth2", error=%q, error_description=%q`, oauthErr.Code, oauthErr.Description))
+	}
+	return writeOidcJSON(w, status, oauthErrorParams(oauthErr))
+}
+
+// Return the credentials of a client, from HTTP Basic or the form parameters.
+func clientCredentials(r *nethttp.Request) (id, secret string) {
+	if id, secret, ok := r.BasicAuth(); ok {
+		// The credentials are form-encoded before being put in the header (RFC 6749, section 2.3.1)
+		id, _ = url.QueryUnescape(id)
+		secret, _ = url.QueryUnescape(secret)
+		return id, secret
+	}
+	return r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+}
+
+// Return the parameters of an error sent to the client.
+func oauthErrorParams(err error) url.Values {
+	var oauthErr *biz.OAuthError
+	if !errors.As(err, &oauthErr) {
+		return url.Values{"error": {"server_error"}}
+	}
+	return url.Values{"error": {oauthErr.Code}, "error_description": {oauthErr.Description}}
+}
+
+// Return the parameters of the authorization request as hidden fields of the forms.
+func oidcParams(a *oidcAuthorization) []oidcParam {
+	values := map[string]string{
+		"client_id":             a.req.ClientID,
+		"redirect_uri":          a.req.RedirectURI,
+		"response_type":         a.req.ResponseType,
+		"scope":                 a.req.Scope,
+		"state":                 a.req.State,
+		"nonce":                 a.req.Nonce,
+		"code_challenge":        a.req.CodeChallenge,
+		"code_challenge_method": a.req.CodeChallengeMethod,
+	}
+	params := make([]oidcParam, 0, len(oidcAuthorizationParams))
+	for _, name := range oidcAuthorizationParams {
+		if values[name] != "" {
+			params = append(params, oidcParam{Name: name, Value: values[name]})
+		}
+	}
+	return params
+}
+
+// Allow the browser apps of other origins, such as single-page apps, to call the endpoint.
+// The endpoints do not rely on cookies, any origin is allowed.
+func allowCORS(w nethttp.ResponseWriter) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+}
+
+func writeOidcJSON(w nethttp.ResponseWriter, status int, v any) error {
+	if values, ok := v.(url.Values); ok {
+		body := make(map[string]string, len(values))
+		for name := range values {
+			body[name] = values.Get(name)
+		}
+		v = body
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	return json.NewEncoder(w).Encode(v)
+}
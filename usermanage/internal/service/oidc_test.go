@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	nethttp "net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+	"usermanage/gen/proto/conf"
+	"usermanage/internal/biz"
+	"usermanage/internal/data"
+	"usermanage/internal/pkg/jwt"
+	"usermanage/internal/pkg/oidc"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Create the user, auth and OAuth use cases over an in-memory database.
+func newTestOAuthUseCase(t *testing.T) (*biz.UserUseCase, *biz.AuthUseCase, *biz.OAuthUseCase) {
+	t.Helper()
+	require.NoError(t, jwt.Initialize([]byte("secret"), time.Hour))
+
+	uc, database := newTestUserUseCase(t)
+	authUseCase := biz.NewAuthUseCase(data.NewTransaction(database), data.NewUserRepo(database, nil, log.DefaultLogger),
+		data.NewMemoryTokenRepo(&conf.Data{}), data.NewEventRepo(database, log.DefaultLogger), nil)
+	signer, err := oidc.GenerateSigner()
+	require.NoError(t, err)
+	oauth := biz.NewOAuthUseCase(data.NewTransaction(database), data.NewOAuthRepo(database, log.DefaultLogger), authUseCase, &biz.OAuthOptions{
+		Issuer:          "https://login.example.com",
+		Signer:          signer,
+		CodeTTL:         time.Minute,
+		IDTokenTTL:      time.Hour,
+		RefreshTokenTTL: time.Hour,
+	})
+	return uc, authUseCase, oauth
+}
+
+// Issue the tokens of the user to the client, with the openid scope.
+func issueTestOAuthTokens(t *testing.T, oauth *biz.OAuthUseCase, client *biz.OAuthClient, user *biz.User) *biz.OAuthTokenResponse {
+	t.Helper()
+	ctx := context.Background()
+
+	const verifier = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	req := &biz.OAuthAuthorizationRequest{
+		ClientID:            client.ID,
+		RedirectURI:         client.RedirectURIs[0],
+		ResponseType:        "code",
+		Scope:               "openid",
+		CodeChallenge:       oidc.CodeChallenge(verifier),
+		CodeChallengeMethod: "S256",
+	}
+	_, scopes, err := oauth.CheckAuthorizationRequest(ctx, req)
+	require.NoError(t, err)
+	code, err := oauth.Authorize(ctx, client, req, scopes, user, time.Now(), true)
+	require.NoError(t, err)
+	resp, err := oauth.Exchange(ctx, &biz.OAuthTokenRequest{
+		GrantType:    "authorization_code",
+		ClientID:     client.ID,
+		ClientSecret: client.Secret,
+		Code:         code,
+		RedirectURI:  req.RedirectURI,
+		CodeVerifier: verifier,
+	})
+	require.NoError(t, err)
+	return resp
+}
+
+func TestOidcService_Tokens(t *testing.T) {
+	uc, authUseCase, oauth := newTestOAuthUseCase(t)
+	ctx := context.Background()
+	svc := NewOidcService(&conf.Server{Oidc: &conf.Server_Oidc{Enabled: true, Issuer: "https://login.example.com"}},
+		oauth, authUseCase, log.DefaultLogger)
+	srv := http.NewServer()
+	svc.RegisterRoutes(srv)
+
+	user, err := uc.CreateUser(ctx, biz.UserCreateParams{
+		Username: "jane",
+		Role:     int32(biz.UserRoleUser),
+		Status:   int32(biz.UserStatusNormal),
+		Creator:  "admin",
+	})
+	require.NoError(t, err)
+	app, err := oauth.CreateClient(ctx, biz.OAuthClientCreateParams{
+		Name:         "App",
+		RedirectURIs: []string{"https://app.example.com/callback"},
+	})
+	require.NoError(t, err)
+	gateway, err := oauth.CreateClient(ctx, biz.OAuthClientCreateParams{
+		Name:           "Gateway",
+		RedirectURIs:   []string{"https://gateway.example.com/callback"},
+		ResourceServer: true,
+	})
+	require.NoError(t, err)
+
+	// Post the form to an endpoint with the credentials of the client in HTTP Basic, return the
+	// response and its decoded body
+	post := func(path string, c *biz.OAuthClient, secret string, form url.Values) (*httptest.ResponseRecorder, map[string]any) {
+		t.Helper()
+		req := httptest.NewRequest(nethttp.MethodPost, "/oauth2/"+path, strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		if c != nil {
+			req.SetBasicAuth(url.QueryEscape(c.ID), url.QueryEscape(secret))
+		}
+		rw := httptest.NewRecorder()
+		srv.ServeHTTP(rw, req)
+		var body map[string]any
+		if rw.Body.Len() > 0 {
+			require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &body), rw.Body.String())
+		}
+		return rw, body
+	}
+	introspect := func(token string) map[string]any {
+		t.Helper()
+		rw, body := post("introspect", gateway, gateway.Secret, url.Values{"token": {token}})
+		require.Equal(t, nethttp.StatusOK, rw.Code, body)
+		assert.Equal(t, "no-store", rw.Header().Get("Cache-Control"))
+		return body
+	}
+
+	t.Run("token", func(t *testing.T) {
+		tokens := issueTestOAuthTokens(t, oauth, app, user)
+		refresh := url.Values{"grant_type": {"refresh_token"}, "refresh_token": {tokens.RefreshToken}}
+
+		rw, body := post("token", app, "wrong", refresh)
+		assert.Equal(t, nethttp.StatusUnauthorized, rw.Code)
+		assert.Equal(t, biz.OAuthErrInvalidClient, body["error"])
+		assert.Equal(t, `Basic realm="oauth2"`, rw.Header().Get("WWW-Authenticate"))
+
+		rw, body = post("token", app, app.Secret, refresh)
+		require.Equal(t, nethttp.StatusOK, rw.Code, body)
+		assert.Equal(t, "no-store", rw.Header().Get("Cache-Control"))
+		assert.Equal(t, "Bearer", body["token_type"])
+		assert.NotEmpty(t, body["access_token"])
+
+		// The credentials may be form parameters instead
+		form := url.Values{
+			"grant_type":    {"refresh_token"},
+			"refresh_token": {body["refresh_token"].(string)},
+			"client_id":     {app.ID},
+			"client_secret": {app.Secret},
+		}
+		rw, body = post("token", nil, "", form)
+		require.Equal(t, nethttp.StatusOK, rw.Code, body)
+		assert.NotEmpty(t, body["refresh_token"])
+	})
+
+	t.Run("introspect", func(t *testing.T) {
+		tokens := issueTestOAuthTokens(t, oauth, app, user)
+
+		body := introspect(tokens.AccessToken)
+		assert.Equal(t, true, body["active"])
+		assert.Equal(t, user.ID, body["sub"])
+		assert.Equal(t, app.ID, body["client_id"])
+		assert.Equal(t, false, introspect("unknown")["active"])
+
+		// Only resource servers introspect the tokens
+		rw, body := post("introspect", app, app.Secret, url.Values{"token": {tokens.AccessToken}})
+		assert.Equal(t, nethttp.StatusUnauthorized, rw.Code)
+		assert.Equal(t, biz.OAuthErrInvalidClient, body["error"])
+		rw, _ = post("introspect", nil, "", url.Values{"token": {tokens.AccessToken}})
+		assert.Equal(t, nethttp.StatusUnauthorized, rw.Code)
+	})
+
+	t.Run("revoke", func(t *testing.T) {
+		tokens := issueTestOAuthTokens(t, oauth, app, user)
+
+		// The tokens of other clients are ignored
+		rw, _ := post("revoke", gateway, gateway.Secret, url.Values{"token": {tokens.RefreshToken}})
+		assert.Equal(t, nethttp.StatusOK, rw.Code)
+		assert.Equal(t, true, introspect(tokens.AccessToken)["active"])
+
+		// Revoking the refresh token revokes the access token issued with it
+		rw, _ = post("revoke", app, app.Secret, url.Values{"token": {tokens.RefreshToken}, "token_type_hint": {"refresh_token"}})
+		assert.Equal(t, nethttp.StatusOK, rw.Code)
+		assert.Equal(t, false, introspect(tokens.AccessToken)["active"])
+
+		rw, _ = post("revoke", app, app.Secret, url.Values{"token": {"unknown"}})
+		assert.Equal(t, nethttp.StatusOK, rw.Code)
+		rw, body := post("revoke", app, "wrong", url.Values{"token": {tokens.AccessToken}})
+		assert.Equal(t, nethttp.StatusUnauthorized, rw.Code)
+		assert.Equal(t, biz.OAuthErrInvalidClient, body["error"])
+	})
+}
+
+func TestClientCredentials(t *testing.T) {
+	// HTTP Basic, form-encoded first (RFC 6749, section 2.3.1)
+	req := httptest.NewRequest(nethttp.MethodPost, "/oauth2/token", nil)
+	req.SetBasicAuth("my%20app", "s%2Bcret%3A")
+	id, secret := clientCredentials(req)
+	assert.Equal(t, "my app", id)
+	assert.Equal(t, "s+cret:", secret)
+
+	// The form parameters otherwise
+	req = httptest.NewRequest(nethttp.MethodPost, "/oauth2/token", strings.NewReader("client_id=app&client_secret=s%2Bcret"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	require.NoError(t, req.ParseForm())
+	id, secret = clientCredentials(req)
+	assert.Equal(t, "app", id)
+	assert.Equal(t, "s+cret", secret)
+}
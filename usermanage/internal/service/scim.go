@@ -0,0 +1,714 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	nethttp "net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"usermanage/gen/proto/conf"
+	"usermanage/internal/biz"
+	"usermanage/internal/pkg/constants"
+	"usermanage/internal/pkg/jwt"
+	"usermanage/internal/pkg/scim"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport/http"
+)
+
+const (
+	scimContentType = "application/scim+json"
+
+	scimUserSchema         = "urn:ietf:params:scim:schemas:core:2.0:User"
+	scimGroupSchema        = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	scimListResponseSchema = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+
+	// scimOperator is the username recorded as the creator or updater of the provisioned users.
+	scimOperator = biz.ScimActor
+
+	scimMaxBodySize     = 1 << 20
+	scimDefaultCount    = 100
+	scimMaxResults      = int(constants.MaxPageSize)
+	scimConflictRetries = 3
+)
+
+// ScimService serves the SCIM 2.0 provisioning endpoints, mapped onto `UserUseCase`.
+//
+// The handlers are plain HTTP routes rather than Kratos operations: SCIM has its own
+// authentication, a bearer token shared with the identity provider, and its own errors.
+//
+// The bearer token only reaches the users who are not administrators: administrators are
+// neither listed nor changed through SCIM, and the administrators group is read-only.
+type ScimService struct {
+	token string // empty if SCIM is disabled
+	uc    *biz.UserUseCase
+	log   *log.Helper
+}
+
+// scimRequest is a SCIM request, authenticated.
+type scimRequest struct {
+	*nethttp.Request
+	id      string // `{id}` of the path, if any
+	baseURL string // e.g. `https://example.com/scim/v2`
+}
+
+// scimResponse is the response of a SCIM handler, written as `application/scim+json`.
+type scimResponse struct {
+	status   int
+	body     any
+	etag     string
+	location string
+}
+
+type scimHandler func(ctx context.Context, req *scimRequest) (*scimResponse, error)
+
+// NewScimService creates a new SCIM service.
+func NewScimService(c *conf.Server, uc *biz.UserUseCase, logger log.Logger) (*ScimService, error) {
+	s := &ScimService{uc: uc, log: log.NewHelper(logger)}
+	if c.GetScim().GetEnabled() {
+		if s.token = c.GetScim().GetBearerToken(); s.token == "" {
+			return nil, errors.New("scim: a bearer token is required")
+		}
+	}
+	return s, nil
+}
+
+// RegisterRoutes registers the endpoints under `/scim/v2`, if SCIM is enabled.
+func (s *ScimService) RegisterRoutes(srv *http.Server) {
+	if s.token == "" {
+		return
+	}
+	r := srv.Route("/scim/v2")
+	r.GET("/ServiceProviderConfig", s.serve(s.getServiceProviderConfig))
+	r.GET("/Schemas", s.serve(s.listSchemas))
+	r.GET("/Schemas/{id}", s.serve(s.getSchema))
+	r.GET("/ResourceTypes", s.serve(s.listResourceTypes))
+	r.GET("/ResourceTypes/{id}", s.serve(s.getResourceType))
+
+	r.GET("/Users", s.serve(s.listUsers))
+	r.POST("/Users", s.serve(s.createUser))
+	r.GET("/Users/{id}", s.serve(s.getUser))
+	r.PUT("/Users/{id}", s.serve(s.replaceUser))
+	r.PATCH("/Users/{id}", s.serve(s.patchUser))
+	r.DELETE("/Users/{id}", s.serve(s.deleteUser))
+
+	r.GET("/Groups", s.serve(s.listGroups))
+	r.POST("/Groups", s.serve(s.createGroup))
+	r.GET("/Groups/{id}", s.serve(s.getGroup))
+	r.PUT("/Groups/{id}", s.serve(s.replaceGroup))
+	r.PATCH("/Groups/{id}", s.serve(s.patchGroup))
+	r.DELETE("/Groups/{id}", s.serve(s.deleteGroup))
+}
+
+// Authenticate the request, run the handler as an admin named `scim` and write its
+// response or error.
+func (s *ScimService) serve(h scimHandler) http.HandlerFunc {
+	return func(ctx http.Context) error {
+		r := ctx.Request()
+		logger := s.log.WithContext(ctx)
+
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+			logger.Errorw("msg", "invalid scim bearer token", "method", r.Method, "path", r.URL.Path)
+			ctx.Response().Header().Set("WWW-Authenticate", `Bearer realm="scim"`)
+			return writeScimError(ctx, scim.NewError(nethttp.StatusUnauthorized, "Invalid or missing bearer token"))
+		}
+
+		claims := &jwt.Claims{Username: scimOperator}
+		claims.Role(int32(biz.UserRoleAdmin))
+		c := jwt.WithContext(ctx, claims)
+
+		req := &scimRequest{Request: r, id: ctx.Vars().Get("id"), baseURL: scimBaseURL(r)}
+		logger.Infow("msg", "scim request", "method", r.Method, "path", r.URL.Path)
+		resp, err := h(c, req)
+		if err != nil {
+			var se *scim.Error
+			if !errors.As(err, &se) {
+				logger.Errorw("msg", "failed to serve scim request", "method", r.Method, "path", r.URL.Path, "error", err)
+				se = scim.NewError(nethttp.StatusInternalServerError, "Internal server error")
+			} else if se.Status == nethttp.StatusInternalServerError {
+				logger.Errorw("msg", "failed to serve scim request", "method", r.Method, "path", r.URL.Path, "error", err)
+			}
+			return writeScimError(ctx, se)
+		}
+
+		w := ctx.Response()
+		w.Header().Set("Content-Type", scimContentType)
+		if resp.etag != "" {
+			w.Header().Set("ETag", resp.etag)
+		}
+		if resp.location != "" {
+			w.Header().Set("Location", resp.location)
+		}
+		w.WriteHeader(resp.status)
+		if resp.body == nil {
+			return nil
+		}
+		return json.NewEncoder(w).Encode(resp.body)
+	}
+}
+
+func writeScimError(ctx http.Context, se *scim.Error) error {
+	w := ctx.Response()
+	w.Header().Set("Content-Type", scimContentType)
+	w.WriteHeader(se.Status)
+	return json.NewEncoder(w).Encode(se)
+}
+
+// Return the URL of the SCIM endpoints, as seen by the client.
+func scimBaseURL(r *nethttp.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+	return scheme + "://" + r.Host + "/scim/v2"
+}
+
+// Decode a JSON request body.
+func decodeScimBody(req *scimRequest, v any) error {
+	body, err := io.ReadAll(io.LimitReader(req.Body, scimMaxBodySize+1))
+	if err != nil {
+		return err
+	}
+	if len(body) > scimMaxBodySize {
+		return scim.NewError(nethttp.StatusRequestEntityTooLarge, "Request body is too large")
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return scim.Errorf(scim.ErrInvalidSyntax, "invalid JSON body: %v", err)
+	}
+	return nil
+}
+
+// Return the version of the `If-Match` header, 0 if none.
+func scimIfMatch(req *scimRequest) (int64, error) {
+	etag := req.Header.Get("If-Match")
+	if etag == "" || etag == "*" {
+		return 0, nil
+	}
+	version, err := biz.ParseETag(etag)
+	if err != nil {
+		return 0, scim.Errorf(scim.ErrInvalidValue, "invalid If-Match header")
+	}
+	return version, nil
+}
+
+// Translate the errors of the user use case.
+func scimUserError(id string, err error) error {
+	switch {
+	case errors.Is(err, biz.ErrUserNotFound):
+		return scim.NewError(nethttp.StatusNotFound, fmt.Sprintf("User %s not found", id))
+	case errors.Is(err, biz.ErrUsernameExists):
+		return scim.Errorf(scim.ErrUniqueness, "userName is already taken")
+	case errors.Is(err, biz.ErrVersionConflict):
+		return scim.NewError(nethttp.StatusPreconditionFailed, "The user has been changed, get it again and retry")
+	default:
+		return err
+	}
+}
+
+// scimListResponse is the response of a query.
+type scimListResponse struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int      `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    []any    `json:"Resources"`
+}
+
+// scimQuery is the filter, pagination and projection of a query.
+type scimQuery struct {
+	filter     scim.Filter
+	startIndex int // 1-based
+	count      int
+	attributes []string // returned attributes, all if empty
+	excluded   []string // attributes not returned
+}
+
+func parseScimQuery(req *scimRequest) (*scimQuery, error) {
+	values := req.URL.Query()
+	q := &scimQuery{startIndex: 1, count: scimDefaultCount}
+	if filter := values.Get("filter"); filter != "" {
+		// Usernames are case-sensitive
+		f, err := scim.ParseFilter(filter, "userName", "members.value")
+		if err != nil {
+			return nil, err
+		}
+		q.filter = f
+	}
+	if v := values.Get("startIndex"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, scim.Errorf(scim.ErrInvalidValue, "invalid startIndex")
+		}
+		q.startIndex = max(n, 1)
+	}
+	if v := values.Get("count"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, scim.Errorf(scim.ErrInvalidValue, "invalid count")
+		}
+		q.count = min(max(n, 0), scimMaxResults)
+	}
+	q.attributes = splitScimAttributes(values.Get("attributes"))
+	q.excluded = splitScimAttributes(values.Get("excludedAttributes"))
+	return q, nil
+}
+
+func splitScimAttributes(value string) []string {
+	var attributes []string
+	for _, attribute := range strings.Split(value, ",") {
+		if attribute = strings.TrimSpace(attribute); attribute != "" {
+			attributes = append(attributes, strings.ToLower(attribute))
+		}
+	}
+	return attributes
+}
+
+// Keep the requested attributes of a resource, `schemas`, `id` and `meta` are always returned.
+func (q *scimQuery) project(resource map[string]any) map[string]any {
+	for name := range resource {
+		key := strings.ToLower(name)
+		if key == "schemas" || key == "id" || key == "meta" {
+			continue
+		}
+		if (len(q.attributes) > 0 && !slices.Contains(q.attributes, key)) || slices.Contains(q.excluded, key) {
+			delete(resource, name)
+		}
+	}
+	return resource
+}
+
+// scimPage collects the page of the resources matching a query.
+type scimPage struct {
+	query *scimQuery
+	list  scimListResponse
+}
+
+func newScimPage(query *scimQuery) *scimPage {
+	return &scimPage{query: query, list: scimListResponse{
+		Schemas:    []string{scimListResponseSchema},
+		StartIndex: query.startIndex,
+		Resources:  []any{},
+	}}
+}
+
+// Add a resource, counted if it matches the filter and kept if it is in the page.
+func (p *scimPage) add(resource map[string]any) {
+	if p.query.filter != nil && !p.query.filter.Match(resource) {
+		return
+	}
+	p.list.TotalResults++
+	if p.list.TotalResults >= p.query.startIndex && len(p.list.Resources) < p.query.count {
+		p.list.Resources = append(p.list.Resources, p.query.project(resource))
+		p.list.ItemsPerPage++
+	}
+}
+
+// scimMultiValue is a value of a multi-valued attribute, e.g. an email.
+type scimMultiValue struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+type scimMeta struct {
+	ResourceType string `json:"resourceType"`
+	Created      string `json:"created,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	Version      string `json:"version,omitempty"`
+	Location     string `json:"location"`
+}
+
+type scimUser struct {
+	Schemas      []string         `json:"schemas"`
+	ID           string           `json:"id"`
+	UserName     string           `json:"userName"`
+	Active       bool             `json:"active"`
+	Emails       []scimMultiValue `json:"emails,omitempty"`
+	PhoneNumbers []scimMultiValue `json:"phoneNumbers,omitempty"`
+	Groups       []scimMultiValue `json:"groups,omitempty"`
+	Meta         scimMeta         `json:"meta"`
+}
+
+// Return the SCIM version of a user, a weak etag.
+func scimVersion(u *biz.User) string {
+	return "W/" + u.ETag()
+}
+
+// Convert a user to a SCIM resource.
+func toScimUser(u *biz.User, baseURL string) map[string]any {
+	user := scimUser{
+		Schemas:  []string{scimUserSchema},
+		ID:       u.ID,
+		UserName: u.Username,
+		Active:   u.Status.IsNormal(),
+		Meta: scimMeta{
+			ResourceType: "User",
+			Created:      u.CreatedAt.UTC().Format(time.RFC3339),
+			LastModified: u.UpdatedAt.UTC().Format(time.RFC3339),
+			Version:      scimVersion(u),
+			Location:     baseURL + "/Users/" + u.ID,
+		},
+	}
+	if u.Email != "" {
+		user.Emails = []scimMultiValue{{Value: u.Email, Type: "work", Primary: true}}
+	}
+	if u.Phone != "" {
+		user.PhoneNumbers = []scimMultiValue{{Value: u.Phone, Type: "work", Primary: true}}
+	}
+	if u.Role == biz.UserRoleAdmin {
+		user.Groups = []scimMultiValue{{Value: scimAdminGroupID, Display: scimAdminGroupName, Ref: baseURL + "/Groups/" + scimAdminGroupID}}
+	}
+	return toScimResource(user)
+}
+
+// Convert a value to a JSON object, as used by filters and PATCH operations.
+func toScimResource(v any) map[string]any {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	var resource map[string]any
+	if err := json.Unmarshal(data, &resource); err != nil {
+		panic(err)
+	}
+	return resource
+}
+
+// scimUserInput is the writable part of a user resource, the other attributes are ignored.
+type scimUserInput struct {
+	username string
+	active   *bool
+	email    string
+	phone    string
+}
+
+func parseScimUser(resource map[string]any) (*scimUserInput, error) {
+	var input scimUserInput
+	if v, _ := scim.Get(resource, "userName"); v != nil {
+		username, ok := v.(string)
+		if !ok {
+			return nil, scim.Errorf(scim.ErrInvalidValue, "userName must be a string")
+		}
+		input.username = username
+	}
+	if input.username == "" {
+		return nil, scim.Errorf(scim.ErrInvalidValue, "userName is required")
+	}
+
+	if v, _ := scim.Get(resource, "active"); v != nil {
+		// Some identity providers send booleans as strings
+		var active bool
+		switch v := v.(type) {
+		case bool:
+			active = v
+		case string:
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return nil, scim.Errorf(scim.ErrInvalidValue, "active must be a boolean")
+			}
+			active = b
+		default:
+			return nil, scim.Errorf(scim.ErrInvalidValue, "active must be a boolean")
+		}
+		input.active = &active
+	}
+
+	var err error
+	if input.email, err = primaryScimValue(resource, "emails"); err != nil {
+		return nil, err
+	}
+	if input.phone, err = primaryScimValue(resource, "phoneNumbers"); err != nil {
+		return nil, err
+	}
+	return &input, nil
+}
+
+// Return the primary value of a multi-valued attribute, else the first one.
+func primaryScimValue(resource map[string]any, name string) (string, error) {
+	v, _ := scim.Get(resource, name)
+	if v == nil {
+		return "", nil
+	}
+	list, ok := v.([]any)
+	if !ok {
+		return "", scim.Errorf(scim.ErrInvalidValue, "%s must be an array", name)
+	}
+	var value any
+	for i, item := range list {
+		element, ok := item.(map[string]any)
+		if !ok {
+			return "", scim.Errorf(scim.ErrInvalidValue, "%s must be an array of objects", name)
+		}
+		primary, _ := scim.Get(element, "primary")
+		if i == 0 || primary == true {
+			value, _ = scim.Get(element, "value")
+		}
+	}
+	if value == nil {
+		return "", nil
+	}
+	s, ok := value.(string)
+	if !ok {
+		return "", scim.Errorf(scim.ErrInvalidValue, "the value of %s must be a string", name)
+	}
+	return s, nil
+}
+
+// Return the status of a user from `active`: an inactive user is disabled unless locked.
+func (in *scimUserInput) status(current biz.UserStatus) int32 {
+	switch {
+	case in.active == nil:
+		return int32(current)
+	case *in.active:
+		return int32(biz.UserStatusNormal)
+	case current.IsNormal():
+		return int32(biz.UserStatusDisabled)
+	default:
+		return int32(current)
+	}
+}
+
+// Return a user in the scope of SCIM, administrators are not found.
+func (s *ScimService) scopedUser(ctx context.Context, id string) (*biz.User, error) {
+	user, err := s.uc.GetUser(ctx, id)
+	if err == nil && user.Role == biz.UserRoleAdmin {
+		err = biz.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, scimUserError(id, err)
+	}
+	return user, nil
+}
+
+// Return the username a filter looks up, if it is `userName eq "<username>"`.
+func scimFilterUsername(filter scim.Filter) (string, bool) {
+	path, username, ok := scim.Equality(filter)
+	if !ok || !strings.EqualFold(path.Name, "userName") || path.Sub != "" ||
+		(path.URN != "" && !strings.EqualFold(path.URN, scimUserSchema)) {
+		return "", false
+	}
+	return username, username != ""
+}
+
+func (s *ScimService) listUsers(ctx context.Context, req *scimRequest) (*scimResponse, error) {
+	query, err := parseScimQuery(req)
+	if err != nil {
+		return nil, err
+	}
+	params := biz.UserListParams{Role: int32(biz.UserRoleUser)}
+
+	// Lists and lookups by username are paginated by the database, other filters are
+	// matched user by user
+	username, ok := scimFilterUsername(query.filter)
+	if query.filter == nil || ok {
+		params.Username = username
+		params.Page, params.PageSize, params.Offset = 1, int32(max(query.count, 1)), int32(query.startIndex-1)
+		result, err := s.uc.ListUsers(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list users: %w", err)
+		}
+		page := newScimPage(query)
+		page.list.TotalResults = int(result.TotalCount)
+		for _, user := range result.Users[:min(len(result.Users), query.count)] {
+			page.list.Resources = append(page.list.Resources, query.project(toScimUser(user, req.baseURL)))
+		}
+		page.list.ItemsPerPage = len(page.list.Resources)
+		return &scimResponse{status: nethttp.StatusOK, body: page.list}, nil
+	}
+
+	page := newScimPage(query)
+	err = s.uc.IterateUsers(ctx, params, 500, func(users []*biz.User) error {
+		for _, user := range users {
+			page.add(toScimUser(user, req.baseURL))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+	return &scimResponse{status: nethttp.StatusOK, body: page.list}, nil
+}
+
+func (s *ScimService) getUser(ctx context.Context, req *scimRequest) (*scimResponse, error) {
+	user, err := s.scopedUser(ctx, req.id)
+	if err != nil {
+		return nil, err
+	}
+	query, err := parseScimQuery(req)
+	if err != nil {
+		return nil, err
+	}
+	return &scimResponse{
+		status: nethttp.StatusOK,
+		body:   query.project(toScimUser(user, req.baseURL)),
+		etag:   scimVersion(user),
+	}, nil
+}
+
+func (s *ScimService) createUser(ctx context.Context, req *scimRequest) (*scimResponse, error) {
+	var resource map[string]any
+	if err := decodeScimBody(req, &resource); err != nil {
+		return nil, err
+	}
+	input, err := parseScimUser(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	params := biz.UserCreateParams{
+		Username: input.username,
+		Role:     int32(biz.DefaultUserRole),
+		Status:   input.status(biz.DefaultUserStatus),
+		Creator:  scimOperator,
+		UpdateBy: scimOperator,
+		Source:   scimOperator,
+		Email:    input.email,
+		Phone:    input.phone,
+	}
+	check := params
+	check.Password = biz.DefaultUserPassword
+	if err := check.Validate(); err != nil {
+		return nil, scim.Errorf(scim.ErrInvalidValue, "%v", err)
+	}
+
+	user, err := s.uc.CreateUser(ctx, params)
+	if err != nil {
+		return nil, scimUserError("", err)
+	}
+	s.log.WithContext(ctx).Infow("msg", "scim created user", "user.id", user.ID, "user.name", user.Username)
+	location := req.baseURL + "/Users/" + user.ID
+	return &scimResponse{
+		status:   nethttp.StatusCreated,
+		body:     toScimUser(user, req.baseURL),
+		etag:     scimVersion(user),
+		location: location,
+	}, nil
+}
+
+func (s *ScimService) replaceUser(ctx context.Context, req *scimRequest) (*scimResponse, error) {
+	var resource map[string]any
+	if err := decodeScimBody(req, &resource); err != nil {
+		return nil, err
+	}
+	input, err := parseScimUser(resource)
+	if err != nil {
+		return nil, err
+	}
+	return s.changeUser(ctx, req, func(*biz.User) (*scimUserInput, error) {
+		return input, nil
+	})
+}
+
+func (s *ScimService) patchUser(ctx context.Context, req *scimRequest) (*scimResponse, error) {
+	var patch scim.PatchRequest
+	if err := decodeScimBody(req, &patch); err != nil {
+		return nil, err
+	}
+	if len(patch.Operations) == 0 {
+		return nil, scim.Errorf(scim.ErrInvalidSyntax, "Operations is required")
+	}
+	return s.changeUser(ctx, req, func(user *biz.User) (*scimUserInput, error) {
+		resource := toScimUser(user, req.baseURL)
+		if err := scim.Apply(resource, patch.Operations); err != nil {
+			return nil, err
+		}
+		return parseScimUser(resource)
+	})
+}
+
+// Replace a user with the input of its current state.
+//
+// The change is based on the version the user is read at, so an administrator is never
+// changed even if promoted in between. Without `If-Match`, the change is applied again to
+// a user changed concurrently.
+func (s *ScimService) changeUser(ctx context.Context, req *scimRequest, change func(user *biz.User) (*scimUserInput, error)) (*scimResponse, error) {
+	version, err := scimIfMatch(req)
+	if err != nil {
+		return nil, err
+	}
+
+	for attempt := 1; ; attempt++ {
+		user, err := s.scopedUser(ctx, req.id)
+		if err != nil {
+			return nil, err
+		}
+		if version != 0 && version != user.Version {
+			return nil, scimUserError(req.id, biz.ErrVersionConflict)
+		}
+		input, err := change(user)
+		if err != nil {
+			return nil, err
+		}
+
+		user, err = s.saveUser(ctx, user, input, user.Version)
+		if version == 0 && attempt < scimConflictRetries && errors.Is(err, biz.ErrVersionConflict) {
+			continue
+		}
+		if err != nil {
+			return nil, scimUserError(req.id, err)
+		}
+		return &scimResponse{status: nethttp.StatusOK, body: toScimUser(user, req.baseURL), etag: scimVersion(user)}, nil
+	}
+}
+
+// Replace the user with the input, keeping its role. The errors of the use case are returned as is.
+func (s *ScimService) saveUser(ctx context.Context, user *biz.User, input *scimUserInput, version int64) (*biz.User, error) {
+	params := biz.UserReplaceParams{
+		Username:  input.username,
+		Role:      int32(user.Role),
+		Status:    input.status(user.Status),
+		Email:     input.email,
+		Phone:     input.phone,
+		UpdatedBy: scimOperator,
+		Version:   version,
+	}
+	if err := params.Validate(); err != nil {
+		return nil, scim.Errorf(scim.ErrInvalidValue, "%v", err)
+	}
+
+	updated, err := s.uc.ReplaceUser(ctx, user.ID, params)
+	if err != nil {
+		return nil, err
+	}
+	s.log.WithContext(ctx).Infow("msg", "scim replaced user", "user.id", updated.ID, "user.name", updated.Username)
+	return updated, nil
+}
+
+func (s *ScimService) deleteUser(ctx context.Context, req *scimRequest) (*scimResponse, error) {
+	version, err := scimIfMatch(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Deleted at the version checked to be in scope, see `changeUser`
+	for attempt := 1; ; attempt++ {
+		user, err := s.scopedUser(ctx, req.id)
+		if err != nil {
+			return nil, err
+		}
+		if version != 0 && version != user.Version {
+			return nil, scimUserError(req.id, biz.ErrVersionConflict)
+		}
+		err = s.uc.DeleteUser(ctx, user.ID, user.Version)
+		if version == 0 && attempt < scimConflictRetries && errors.Is(err, biz.ErrVersionConflict) {
+			continue
+		}
+		if err != nil {
+			return nil, scimUserError(req.id, err)
+		}
+		s.log.WithContext(ctx).Infow("msg", "scim deleted user", "user.id", req.id)
+		return &scimResponse{status: nethttp.StatusNoContent}, nil
+	}
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	nethttp "net/http"
+	"usermanage/internal/biz"
+	"usermanage/internal/pkg/scim"
+)
+
+// The roles are exposed as a single group, the administrators: its members are the users
+// with the admin role. It is read-only, the role is granted and revoked by the admin API.
+const (
+	scimAdminGroupID   = "admin"
+	scimAdminGroupName = "Administrators"
+)
+
+// errScimGroupReadOnly rejects the changes of the administrators group, which would reach
+// administrators, out of the scope of SCIM.
+var errScimGroupReadOnly = scim.Errorf(scim.ErrMutability, "The administrators are not managed by SCIM")
+
+type scimGroup struct {
+	Schemas     []string         `json:"schemas"`
+	ID          string           `json:"id"`
+	DisplayName string           `json:"displayName"`
+	Members     []scimMultiValue `json:"members"`
+	Meta        scimMeta         `json:"meta"`
+}
+
+// Return the administrators group.
+func (s *ScimService) adminGroup(ctx context.Context, baseURL string) (map[string]any, error) {
+	group := scimGroup{
+		Schemas:     []string{scimGroupSchema},
+		ID:          scimAdminGroupID,
+		DisplayName: scimAdminGroupName,
+		Members:     []scimMultiValue{},
+		Meta:        scimMeta{ResourceType: "Group", Location: baseURL + "/Groups/" + scimAdminGroupID},
+	}
+	err := s.uc.IterateUsers(ctx, biz.UserListParams{}, 500, func(users []*biz.User) error {
+		for _, user := range users {
+			if user.Role == biz.UserRoleAdmin {
+				group.Members = append(group.Members, scimMultiValue{
+					Value:   user.ID,
+					Display: user.Username,
+					Ref:     baseURL + "/Users/" + user.ID,
+				})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list administrators: %w", err)
+	}
+	return toScimResource(group), nil
+}
+
+func (s *ScimService) listGroups(ctx context.Context, req *scimRequest) (*scimResponse, error) {
+	query, err := parseScimQuery(req)
+	if err != nil {
+		return nil, err
+	}
+	group, err := s.adminGroup(ctx, req.baseURL)
+	if err != nil {
+		return nil, err
+	}
+	page := newScimPage(query)
+	page.add(group)
+	return &scimResponse{status: nethttp.StatusOK, body: page.list}, nil
+}
+
+func (s *ScimService) getGroup(ctx context.Context, req *scimRequest) (*scimResponse, error) {
+	if req.id != scimAdminGroupID {
+		return nil, scim.NewError(nethttp.StatusNotFound, fmt.Sprintf("Group %s not found", req.id))
+	}
+	query, err := parseScimQuery(req)
+	if err != nil {
+		return nil, err
+	}
+	group, err := s.adminGroup(ctx, req.baseURL)
+	if err != nil {
+		return nil, err
+	}
+	return &scimResponse{status: nethttp.StatusOK, body: query.project(group)}, nil
+}
+
+func (s *ScimService) createGroup(context.Context, *scimRequest) (*scimResponse, error) {
+	return nil, scim.NewError(nethttp.StatusNotImplemented, "Groups are the roles, they cannot be created")
+}
+
+func (s *ScimService) deleteGroup(context.Context, *scimRequest) (*scimResponse, error) {
+	return nil, scim.NewError(nethttp.StatusNotImplemented, "Groups are the roles, they cannot be deleted")
+}
+
+func (s *ScimService) replaceGroup(context.Context, *scimRequest) (*scimResponse, error) {
+	return nil, errScimGroupReadOnly
+}
+
+func (s *ScimService) patchGroup(context.Context, *scimRequest) (*scimResponse, error) {
+	return nil, errScimGroupReadOnly
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	nethttp "net/http"
+	"usermanage/internal/pkg/scim"
+)
+
+const (
+	scimServiceProviderConfigSchema = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	scimResourceTypeSchema          = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+	scimSchemaSchema                = "urn:ietf:params:scim:schemas:core:2.0:Schema"
+)
+
+// scimAttribute is the definition of an attribute in a schema.
+type scimAttribute struct {
+	Name          string          `json:"name"`
+	Type          string          `json:"type"`
+	MultiValued   bool            `json:"multiValued"`
+	Description   string          `json:"description,omitempty"`
+	Required      bool            `json:"required"`
+	CaseExact     bool            `json:"caseExact"`
+	Mutability    string          `json:"mutability"`
+	Returned      string          `json:"returned"`
+	Uniqueness    string          `json:"uniqueness"`
+	SubAttributes []scimAttribute `json:"subAttributes,omitempty"`
+}
+
+// Return a single-valued, optional, case-insensitive attribute, read-write and always returned.
+func newScimAttribute(name, typ, description string) scimAttribute {
+	return scimAttribute{
+		Name:        name,
+		Type:        typ,
+		Description: description,
+		Mutability:  "readWrite",
+		Returned:    "default",
+		Uniqueness:  "none",
+	}
+}
+
+func (a scimAttribute) required() scimAttribute    { a.Required = true; return a }
+func (a scimAttribute) multiValued() scimAttribute { a.MultiValued = true; return a }
+func (a scimAttribute) readOnly() scimAttribute    { a.Mutability = "readOnly"; return a }
+func (a scimAttribute) unique() scimAttribute      { a.Uniqueness = "server"; return a }
+func (a scimAttribute) caseExact() scimAttribute   { a.CaseExact = true; return a }
+func (a scimAttribute) sub(subs ...scimAttribute) scimAttribute {
+	a.SubAttributes = subs
+	return a
+}
+
+// Return the sub-attributes of a multi-valued value, such as an email.
+func scimMultiValueAttributes(description string) []scimAttribute {
+	return []scimAttribute{
+		newScimAttribute("value", "string", description),
+		newScimAttribute("type", "string", "A label indicating the function, e.g. 'work'."),
+		newScimAttribute("primary", "boolean", "Indicates the preferred value, only the primary value is kept."),
+	}
+}
+
+var scimSchemas = []map[string]any{
+	{
+		"schemas":     []string{scimSchemaSchema},
+		"id":          scimUserSchema,
+		"name":        "User",
+		"description": "User Account",
+		"attributes": []scimAttribute{
+			newScimAttribute("userName", "string", "Unique identifier for the User, case-sensitive.").required().unique().caseExact(),
+			newScimAttribute("active", "boolean", "The user's status: active users are normal, the others are disabled or locked."),
+			newScimAttribute("emails", "complex", "Email address of the User.").multiValued().
+				sub(scimMultiValueAttributes("Email address, e.g. 'bjensen@example.com'.")...),
+			newScimAttribute("phoneNumbers", "complex", "Phone number of the User.").multiValued().
+				sub(scimMultiValueAttributes("Phone number in the E.164 format, e.g. '+15550100'.")...),
+			newScimAttribute("groups", "complex", "The groups of the User, i.e. its role.").multiValued().readOnly().sub(
+				newScimAttribute("value", "string", "The identifier of the group.").readOnly().caseExact(),
+				newScimAttribute("display", "string", "The name of the group.").readOnly(),
+				newScimAttribute("$ref", "reference", "The URI of the group.").readOnly().caseExact(),
+			),
+		},
+		"meta": map[string]any{"resourceType": "Schema"},
+	},
+	{
+		"schemas":     []string{scimSchemaSchema},
+		"id":          scimGroupSchema,
+		"name":        "Group",
+		"description": "Group, the administrators only, read-only",
+		"attributes": []scimAttribute{
+			newScimAttribute("displayName", "string", "A human-readable name for the Group.").required().readOnly(),
+			newScimAttribute("members", "complex", "The members of the Group, granted its role.").multiValued().readOnly().sub(
+				newScimAttribute("value", "string", "The identifier of the member.").readOnly().caseExact(),
+				newScimAttribute("display", "string", "The name of the member.").readOnly(),
+				newScimAttribute("$ref", "reference", "The URI of the member.").readOnly().caseExact(),
+			),
+		},
+		"meta": map[string]any{"resourceType": "Schema"},
+	},
+}
+
+var scimResourceTypes = []map[string]any{
+	{
+		"schemas":     []string{scimResourceTypeSchema},
+		"id":          "User",
+		"name":        "User",
+		"endpoint":    "/Users",
+		"description": "User Account",
+		"schema":      scimUserSchema,
+		"meta":        map[string]any{"resourceType": "ResourceType"},
+	},
+	{
+		"schemas":     []string{scimResourceTypeSchema},
+		"id":          "Group",
+		"name":        "Group",
+		"endpoint":    "/Groups",
+		"description": "Group",
+		"schema":      scimGroupSchema,
+		"meta":        map[string]any{"resourceType": "ResourceType"},
+	},
+}
+
+func (s *ScimService) getServiceProviderConfig(_ context.Context, req *scimRequest) (*scimResponse, error) {
+	supported := func(ok bool) map[string]any { return map[string]any{"supported": ok} }
+	config := map[string]any{
+		"schemas":        []string{scimServiceProviderConfigSchema},
+		"patch":          supported(true),
+		"bulk":           map[string]any{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         map[string]any{"supported": true, "maxResults": scimMaxResults},
+		"changePassword": supported(false),
+		"sort":           supported(false),
+		"etag":           supported(true),
+		"authenticationSchemes": []map[string]any{{
+			"type":        "oauthbearertoken",
+			"name":        "OAuth Bearer Token",
+			"description": "Authentication with the bearer token configured in the server",
+			"primary":     true,
+		}},
+		"meta": map[string]any{
+			"resourceType": "ServiceProviderConfig",
+			"location":     req.baseURL + "/ServiceProviderConfig",
+		},
+	}
+	return &scimResponse{status: nethttp.StatusOK, body: config}, nil
+}
+
+func (s *ScimService) listSchemas(_ context.Context, req *scimRequest) (*scimResponse, error) {
+	return scimDiscoveryList(req, scimSchemas, "/Schemas/")
+}
+
+func (s *ScimService) getSchema(_ context.Context, req *scimRequest) (*scimResponse, error) {
+	return scimDiscoveryResource(req, scimSchemas, "/Schemas/", "Schema")
+}
+
+func (s *ScimService) listResourceTypes(_ context.Context, req *scimRequest) (*scimResponse, error) {
+	return scimDiscoveryList(req, scimResourceTypes, "/ResourceTypes/")
+}
+
+func (s *ScimService) getResourceType(_ context.Context, req *scimRequest) (*scimResponse, error) {
+	return scimDiscoveryResource(req, scimResourceTypes, "/ResourceTypes/", "ResourceType")
+}
+
+// List static resources, filters and pagination are ignored as allowed for them.
+func scimDiscoveryList(req *scimRequest, resources []map[string]any, endpoint string) (*scimResponse, error) {
+	list := scimListResponse{
+		Schemas:      []string{scimListResponseSchema},
+		TotalResults: len(resources),
+		StartIndex:   1,
+		ItemsPerPage: len(resources),
+	}
+	for _, resource := range resources {
+		list.Resources = append(list.Resources, withScimLocation(resource, req.baseURL+endpoint))
+	}
+	return &scimResponse{status: nethttp.StatusOK, body: list}, nil
+}
+
+func scimDiscoveryResource(req *scimRequest, resources []map[string]any, endpoint, kind string) (*scimResponse, error) {
+	for _, resource := range resources {
+		if resource["id"] == req.id {
+			return &scimResponse{status: nethttp.StatusOK, body: withScimLocation(resource, req.baseURL+endpoint)}, nil
+		}
+	}
+	return nil, scim.NewError(nethttp.StatusNotFound, fmt.Sprintf("%s %s not found", kind, req.id))
+}
+
+// Return a copy of a static resource with its location.
+func withScimLocation(resource map[string]any, endpoint string) map[string]any {
+	resource = toScimResource(resource)
+	meta := resource["meta"].(map[string]any)
+	meta["location"] = endpoint + resource["id"].(string)
+	return resource
+}
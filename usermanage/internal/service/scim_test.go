@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	nethttp "net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"usermanage/gen/proto/conf"
+	"usermanage/internal/biz"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScimService_Users(t *testing.T) {
+	uc, _ := newTestUserUseCase(t)
+	ctx := context.Background()
+	svc, err := NewScimService(&conf.Server{Scim: &conf.Server_Scim{Enabled: true, BearerToken: "token"}}, uc, log.DefaultLogger)
+	require.NoError(t, err)
+	srv := http.NewServer()
+	svc.RegisterRoutes(srv)
+
+	// Send a request with the bearer token, return the status and the decoded body
+	call := func(method, path, body string) (int, map[string]any) {
+		t.Helper()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer token")
+		req.Header.Set("Content-Type", scimContentType)
+		rw := httptest.NewRecorder()
+		srv.ServeHTTP(rw, req)
+		var resp map[string]any
+		if rw.Body.Len() > 0 {
+			require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &resp), rw.Body.String())
+		}
+		return rw.Code, resp
+	}
+	list := func(query url.Values) map[string]any {
+		t.Helper()
+		status, resp := call(nethttp.MethodGet, "/scim/v2/Users?"+query.Encode(), "")
+		require.Equal(t, nethttp.StatusOK, status, resp)
+		return resp
+	}
+	usernames := func(resp map[string]any) []string {
+		var names []string
+		for _, resource := range resp["Resources"].([]any) {
+			names = append(names, resource.(map[string]any)["userName"].(string))
+		}
+		return names
+	}
+
+	for _, username := range []string{"alice", "bob", "carol"} {
+		status, resp := call(nethttp.MethodPost, "/scim/v2/Users", `{"userName": "`+username+`"}`)
+		require.Equal(t, nethttp.StatusCreated, status, resp)
+		user, err := uc.GetUser(ctx, resp["id"].(string))
+		require.NoError(t, err)
+		assert.Equal(t, biz.ScimActor, user.Source)
+	}
+	admin, err := uc.CreateUser(ctx, biz.UserCreateParams{
+		Username: "root",
+		Role:     int32(biz.UserRoleAdmin),
+		Status:   int32(biz.UserStatusNormal),
+		Creator:  "admin",
+	})
+	require.NoError(t, err)
+
+	t.Run("unauthorized", func(t *testing.T) {
+		rw := httptest.NewRecorder()
+		srv.ServeHTTP(rw, httptest.NewRequest(nethttp.MethodGet, "/scim/v2/Users", nil))
+		assert.Equal(t, nethttp.StatusUnauthorized, rw.Code)
+	})
+
+	t.Run("administrators are not listed", func(t *testing.T) {
+		resp := list(url.Values{})
+		assert.EqualValues(t, 3, resp["totalResults"])
+		assert.NotContains(t, usernames(resp), "root")
+
+		resp = list(url.Values{"filter": {`userName eq "root"`}})
+		assert.EqualValues(t, 0, resp["totalResults"])
+		resp = list(url.Values{"filter": {`userName sw "r"`}})
+		assert.EqualValues(t, 0, resp["totalResults"])
+	})
+
+	t.Run("looked up by username", func(t *testing.T) {
+		resp := list(url.Values{"filter": {`userName eq "bob"`}})
+		assert.EqualValues(t, 1, resp["totalResults"])
+		assert.Equal(t, []string{"bob"}, usernames(resp))
+
+		resp = list(url.Values{"filter": {`userName eq "BOB"`}})
+		assert.EqualValues(t, 0, resp["totalResults"], "usernames are case-sensitive")
+	})
+
+	t.Run("paginated", func(t *testing.T) {
+		all := usernames(list(url.Values{}))
+		require.Len(t, all, 3)
+
+		resp := list(url.Values{"startIndex": {"2"}, "count": {"1"}})
+		assert.EqualValues(t, 3, resp["totalResults"])
+		assert.EqualValues(t, 2, resp["startIndex"])
+		assert.EqualValues(t, 1, resp["itemsPerPage"])
+		assert.Equal(t, all[1:2], usernames(resp))
+
+		resp = list(url.Values{"count": {"0"}})
+		assert.EqualValues(t, 3, resp["totalResults"])
+		assert.Empty(t, resp["Resources"])
+	})
+
+	t.Run("other filters", func(t *testing.T) {
+		resp := list(url.Values{"filter": {`userName sw "c" or userName ew "ce"`}})
+		assert.ElementsMatch(t, []string{"alice", "carol"}, usernames(resp))
+	})
+
+	t.Run("administrators cannot be changed", func(t *testing.T) {
+		path := "/scim/v2/Users/" + admin.ID
+		status, _ := call(nethttp.MethodGet, path, "")
+		assert.Equal(t, nethttp.StatusNotFound, status)
+		status, _ = call(nethttp.MethodPut, path, `{"userName": "root", "active": false}`)
+		assert.Equal(t, nethttp.StatusNotFound, status)
+		status, _ = call(nethttp.MethodPatch, path, `{"Operations": [{"op": "replace", "path": "active", "value": false}]}`)
+		assert.Equal(t, nethttp.StatusNotFound, status)
+		status, _ = call(nethttp.MethodDelete, path, "")
+		assert.Equal(t, nethttp.StatusNotFound, status)
+
+		user, err := uc.GetUser(ctx, admin.ID)
+		require.NoError(t, err)
+		assert.Equal(t, admin.Version, user.Version)
+	})
+
+	t.Run("the administrators group is read-only", func(t *testing.T) {
+		status, resp := call(nethttp.MethodGet, "/scim/v2/Groups/admin", "")
+		require.Equal(t, nethttp.StatusOK, status)
+		require.Len(t, resp["members"], 1)
+
+		bob := list(url.Values{"filter": {`userName eq "bob"`}})["Resources"].([]any)[0].(map[string]any)
+		status, resp = call(nethttp.MethodPatch, "/scim/v2/Groups/admin",
+			`{"Operations": [{"op": "add", "path": "members", "value": [{"value": "`+bob["id"].(string)+`"}]}]}`)
+		assert.Equal(t, nethttp.StatusBadRequest, status)
+		assert.Equal(t, "mutability", resp["scimType"])
+	})
+
+	t.Run("users are changed and deleted", func(t *testing.T) {
+		carol := list(url.Values{"filter": {`userName eq "carol"`}})["Resources"].([]any)[0].(map[string]any)
+		path := "/scim/v2/Users/" + carol["id"].(string)
+		status, resp := call(nethttp.MethodPatch, path, `{"Operations": [{"op": "replace", "path": "active", "value": false}]}`)
+		require.Equal(t, nethttp.StatusOK, status, resp)
+		assert.Equal(t, false, resp["active"])
+
+		status, _ = call(nethttp.MethodDelete, path, "")
+		assert.Equal(t, nethttp.StatusNoContent, status)
+		status, _ = call(nethttp.MethodGet, path, "")
+		assert.Equal(t, nethttp.StatusNotFound, status)
+	})
+}
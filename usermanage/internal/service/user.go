@@ -11,6 +11,7 @@ import (
 
 	"github.com/go-kratos/kratos/v2/errors"
 	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -53,6 +54,7 @@ func (s *UserService) ListUsers(ctx context.Context, req *userv1.UserListRequest
 		PageSize:  pageSize,
 		Username:  req.Username,
 		Status:    int32(req.Status),
+		Email:     req.Email,
 		SortBy:    req.SortBy,
 		SortOrder: req.SortOrder,
 	}
@@ -75,7 +77,7 @@ func (s *UserService) ListUsers(ctx context.Context, req *userv1.UserListRequest
 	for _, user := range result.Users {
 		data = append(data, s.toUserPublic(user))
 	}
-	return &userv1.UserListResponse{Data: data, Pagination: &pagination}, nil
+	return &userv1.UserListResponse{Data: data, Pagination: &pagination, ResourceVersion: result.ResourceVersion}, nil
 }
 
 // GetUser gets a user by ID.
@@ -96,6 +98,7 @@ func (s *UserService) GetUser(ctx context.Context, req *userv1.UserRequest) (*us
 			WithMetadata(md)
 		return nil, err
 	}
+	setETagHeader(ctx, user)
 	return &userv1.UserResponse{Data: s.toUserPublic(user)}, nil
 }
 
@@ -114,6 +117,8 @@ func (s *UserService) CreateUser(ctx context.Context, req *userv1.UserCreateRequ
 		Status:   int32(req.Status),
 		Creator:  auth.Username(ctx),
 		UpdateBy: auth.Username(ctx),
+		Email:    req.Email,
+		Phone:    req.Phone,
 	}
 	logger.Infow("msg", "create user", "params", params.String())
 	user, err := s.uc.CreateUser(ctx, params)
@@ -124,6 +129,7 @@ func (s *UserService) CreateUser(ctx context.Context, req *userv1.UserCreateRequ
 		return nil, err
 	}
 	logger.Info("successfully create user")
+	setETagHeader(ctx, user)
 	return &userv1.UserResponse{Data: s.toUserPublic(user)}, nil
 }
 
@@ -147,8 +153,13 @@ func (s *UserService) UpdateUser(ctx context.Context, req *userv1.UserUpdateRequ
 		return nil, err
 	}
 
+	version, err := s.expectedVersion(ctx, req.Etag)
+	if err != nil {
+		return nil, err
+	}
 	params := biz.UserUpdateParams{
 		UpdatedBy: auth.Username(ctx),
+		Version:   version,
 	}
 	for _, field := range req.UpdateMask.Paths {
 		switch field {
@@ -158,6 +169,10 @@ func (s *UserService) UpdateUser(ctx context.Context, req *userv1.UserUpdateRequ
 			params.Role = (*int32)(&req.Role)
 		case "status":
 			params.Status = (*int32)(&req.Status)
+		case "email":
+			params.Email = &req.Email
+		case "phone":
+			params.Phone = &req.Phone
 		default:
 			logger.Errorw("msg", "invalid update field", "field", field, "target_user.id", targetUserID)
 			err := errors.BadRequest("INVALID_UPDATE_FIELD", "Invalid update field").
@@ -170,11 +185,15 @@ func (s *UserService) UpdateUser(ctx context.Context, req *userv1.UserUpdateRequ
 	updatedUser, err := s.uc.UpdateUser(ctx, targetUserID, params)
 	if err != nil {
 		logger.Errorw("msg", "failed to update user", "error", err)
+		if errors.Is(err, biz.ErrVersionConflict) {
+			return nil, versionConflict(md)
+		}
 		err = errors.InternalServer("UPDATE_USER_FAILED", "Failed to update user").
 			WithMetadata(md)
 		return nil, err
 	}
 	logger.Info("successfully update user")
+	setETagHeader(ctx, updatedUser)
 	return &userv1.UserResponse{Data: s.toUserPublic(updatedUser)}, nil
 }
 
@@ -198,21 +217,32 @@ func (s *UserService) ReplaceUser(ctx context.Context, req *userv1.UserReplaceRe
 		return nil, err
 	}
 
+	version, err := s.expectedVersion(ctx, req.Etag)
+	if err != nil {
+		return nil, err
+	}
 	params := biz.UserReplaceParams{
 		Username:  req.Username,
 		Role:      int32(req.Role),
 		Status:    int32(req.Status),
+		Email:     req.Email,
+		Phone:     req.Phone,
 		UpdatedBy: auth.Username(ctx),
+		Version:   version,
 	}
 	logger.Infow("msg", "replace user", "target_user.id", targetUserID, "params", params.String())
 	replacedUser, err := s.uc.ReplaceUser(ctx, targetUserID, params)
 	if err != nil {
 		logger.Errorw("msg", "failed to replace user", "error", err)
+		if errors.Is(err, biz.ErrVersionConflict) {
+			return nil, versionConflict(md)
+		}
 		err = errors.InternalServer("REPLACE_USER_FAILED", "Failed to replace user").
 			WithMetadata(md)
 		return nil, err
 	}
 	logger.Info("successfully replace user")
+	setETagHeader(ctx, replacedUser)
 	return &userv1.UserResponse{Data: s.toUserPublic(replacedUser)}, nil
 }
 
@@ -225,10 +255,17 @@ func (s *UserService) DeleteUser(ctx context.Context, req *userv1.UserDeleteRequ
 		return nil, err
 	}
 
+	version, err := s.expectedVersion(ctx, req.Etag)
+	if err != nil {
+		return nil, err
+	}
 	targetUserID := req.Id
 	logger.Infow("msg", "delete user", "target_user.id", targetUserID)
-	if err := s.uc.DeleteUser(ctx, targetUserID); err != nil {
+	if err := s.uc.DeleteUser(ctx, targetUserID, version); err != nil {
 		logger.Errorw("msg", "failed to delete user", "error", err)
+		if errors.Is(err, biz.ErrVersionConflict) {
+			return nil, versionConflict(md)
+		}
 		err = errors.InternalServer("DELETE_USER_FAILED", "Failed to delete user").
 			WithMetadata(md)
 		return nil, err
@@ -279,13 +316,47 @@ func (s *UserService) validateAdminAndRequest(ctx context.Context, req interface
 	return nil
 }
 
+// Return the version a change is based on, from the etag of the request or else its
+// `If-Match` header. It is 0, skipping the check, if there is none or it is `*`.
+func (s *UserService) expectedVersion(ctx context.Context, etag string) (int64, error) {
+	if etag == "" {
+		if tr, ok := transport.FromServerContext(ctx); ok {
+			etag = tr.RequestHeader().Get("If-Match")
+		}
+	}
+	if etag == "" || etag == "*" {
+		return 0, nil
+	}
+
+	version, err := biz.ParseETag(etag)
+	if err != nil {
+		s.log.WithContext(ctx).Errorw("msg", "invalid etag", "error", err)
+		return 0, errors.BadRequest("INVALID_ETAG", "Invalid etag").
+			WithMetadata(map[string]string{"traceId": tracingx.GetTraceID(ctx)})
+	}
+	return version, nil
+}
+
+// Return the error of a change based on an outdated version of the user.
+func versionConflict(md map[string]string) error {
+	return errors.Conflict("VERSION_CONFLICT", "The user has been changed, get it again and retry").
+		WithMetadata(md)
+}
+
+// Set the `ETag` header of the response to the version of the user.
+func setETagHeader(ctx context.Context, u *biz.User) {
+	if tr, ok := transport.FromServerContext(ctx); ok {
+		tr.ReplyHeader().Set("ETag", u.ETag())
+	}
+}
+
 // Convert biz user to user public.
 func (s *UserService) toUserPublic(u *biz.User) *userv1.UserPublic {
 	if u == nil {
 		return nil
 	}
 
-	return &userv1.UserPublic{
+	user := &userv1.UserPublic{
 		Id:        u.ID,
 		Username:  u.Username,
 		Role:      userv1.UserRole(u.Role),
@@ -294,5 +365,12 @@ func (s *UserService) toUserPublic(u *biz.User) *userv1.UserPublic {
 		CreatedAt: timestamppb.New(u.CreatedAt),
 		UpdatedBy: u.UpdatedBy,
 		UpdatedAt: timestamppb.New(u.UpdatedAt),
+		Email:     u.Email,
+		Phone:     u.Phone,
+		Etag:      u.ETag(),
+	}
+	if u.DeletedAt != nil {
+		user.DeletedAt = timestamppb.New(*u.DeletedAt)
 	}
+	return user
 }
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	userv1 "usermanage/gen/proto/api/user/v1"
+	"usermanage/internal/biz"
+	"usermanage/internal/pkg/db"
+	"usermanage/internal/pkg/tracingx"
+
+	"github.com/go-kratos/kratos/v2/errors"
+)
+
+// BatchUpdateUsers changes the role or the status of many users.
+func (s *UserService) BatchUpdateUsers(ctx context.Context, req *userv1.UserBatchUpdateRequest) (*userv1.UserBatchResponse, error) {
+	logger := s.log.WithContext(ctx)
+
+	if err := s.validateAdminAndRequest(ctx, req); err != nil {
+		return nil, err
+	}
+
+	params := biz.UserBatchUpdateParams{
+		Selector: toUserBatchSelector(req.Ids, req.Filter),
+		Mode:     biz.UserBatchMode(req.Mode),
+	}
+	if req.Role != userv1.UserRole_ROLE_UNSPECIFIED {
+		role := int32(req.Role)
+		params.Role = &role
+	}
+	if req.Status != userv1.UserStatus_STATUS_UNSPECIFIED {
+		status := int32(req.Status)
+		params.Status = &status
+	}
+	logger.Infow("msg", "batch update users", "ids", len(req.Ids), "filter", req.Filter != nil,
+		"role", req.Role, "status", req.Status, "mode", req.Mode)
+	result, err := s.uc.BatchUpdateUsers(ctx, params)
+	if err != nil {
+		return nil, s.userBatchError(ctx, "BATCH_UPDATE_USERS_FAILED", "Failed to update users", err)
+	}
+	logger.Infow("msg", "successfully batch update users", "succeeded", result.Succeeded, "failed", result.Failed)
+	return toUserBatchResponse(result), nil
+}
+
+// BatchDeleteUsers deletes many users.
+func (s *UserService) BatchDeleteUsers(ctx context.Context, req *userv1.UserBatchDeleteRequest) (*userv1.UserBatchResponse, error) {
+	logger := s.log.WithContext(ctx)
+
+	if err := s.validateAdminAndRequest(ctx, req); err != nil {
+		return nil, err
+	}
+
+	params := biz.UserBatchDeleteParams{
+		Selector: toUserBatchSelector(req.Ids, req.Filter),
+		Mode:     biz.UserBatchMode(req.Mode),
+	}
+	logger.Infow("msg", "batch delete users", "ids", len(req.Ids), "filter", req.Filter != nil, "mode", req.Mode)
+	result, err := s.uc.BatchDeleteUsers(ctx, params)
+	if err != nil {
+		return nil, s.userBatchError(ctx, "BATCH_DELETE_USERS_FAILED", "Failed to delete users", err)
+	}
+	logger.Infow("msg", "successfully batch delete users", "succeeded", result.Succeeded, "failed", result.Failed)
+	return toUserBatchResponse(result), nil
+}
+
+// Log and translate the error of a batch operation.
+func (s *UserService) userBatchError(ctx context.Context, reason, message string, err error) error {
+	md := map[string]string{"traceId": tracingx.GetTraceID(ctx)}
+
+	s.log.WithContext(ctx).Errorw("msg", message, "error", err)
+	switch {
+	case errors.Is(err, biz.ErrInvalidUserBatch):
+		return errors.BadRequest("INVALID_BATCH", err.Error()).
+			WithMetadata(md)
+	case errors.Is(err, db.ErrEncryptionNotConfigured):
+		return errors.BadRequest("ENCRYPTION_NOT_CONFIGURED", "Filtering by email requires encryption at rest").
+			WithMetadata(md)
+	default:
+		return errors.InternalServer(reason, message).
+			WithMetadata(md)
+	}
+}
+
+func toUserBatchSelector(ids []string, filter *userv1.UserBatchFilter) biz.UserBatchSelector {
+	selector := biz.UserBatchSelector{IDs: ids}
+	if filter != nil {
+		selector.Filter = &biz.UserListParams{
+			Username: filter.Username,
+			Status:   int32(filter.Status),
+			Email:    filter.Email,
+		}
+	}
+	return selector
+}
+
+func toUserBatchResponse(result *biz.UserBatchResult) *userv1.UserBatchResponse {
+	resp := &userv1.UserBatchResponse{
+		Total:      int32(len(result.Items)),
+		Succeeded:  int32(result.Succeeded),
+		Failed:     int32(result.Failed),
+		RolledBack: result.RolledBack,
+		Results:    make([]*userv1.UserBatchItemResult, 0, len(result.Items)),
+	}
+	for _, item := range result.Items {
+		itemResult := &userv1.UserBatchItemResult{Id: item.ID, Username: item.Username, Success: item.Err == nil}
+		if item.Err != nil {
+			itemResult.Error = item.Err.Error()
+		}
+		resp.Results = append(resp.Results, itemResult)
+	}
+	return resp
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	commonv1 "usermanage/gen/proto/api/common/v1"
+	userv1 "usermanage/gen/proto/api/user/v1"
+	"usermanage/internal/biz"
+	"usermanage/internal/pkg/auth"
+	"usermanage/internal/pkg/constants"
+	"usermanage/internal/pkg/tracingx"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// ListDeletedUsers lists the soft-deleted users which have not been purged yet.
+func (s *UserService) ListDeletedUsers(ctx context.Context, req *userv1.DeletedUserListRequest) (*userv1.UserListResponse, error) {
+	logger := s.log.WithContext(ctx)
+	md := map[string]string{"traceId": tracingx.GetTraceID(ctx)}
+
+	if err := s.validateAdminAndRequest(ctx, req); err != nil {
+		return nil, err
+	}
+
+	page := func() int32 {
+		if req.Page == 0 {
+			return constants.DefaultPage
+		}
+		return req.Page
+	}()
+	pageSize := func() int32 {
+		if req.PageSize == 0 {
+			return constants.DefaultPageSize
+		}
+		return req.PageSize
+	}()
+	params := biz.DeletedUserListParams{
+		Page:     page,
+		PageSize: pageSize,
+		Username: req.Username,
+	}
+	logger.Infow("msg", "list deleted users", "username", req.Username)
+	result, err := s.uc.ListDeletedUsers(ctx, params)
+	if err != nil {
+		logger.Errorw("msg", "failed to list deleted users", "error", err)
+		err = errors.InternalServer("LIST_DELETED_USERS_FAILED", "Failed to list deleted users").
+			WithMetadata(md)
+		return nil, err
+	}
+
+	pagination := commonv1.PageResponse{
+		Page:       page,
+		PageSize:   pageSize,
+		TotalCount: result.TotalCount,
+	}
+	data := make([]*userv1.UserPublic, 0, len(result.Users))
+	for _, user := range result.Users {
+		data = append(data, s.toUserPublic(user))
+	}
+	return &userv1.UserListResponse{Data: data, Pagination: &pagination}, nil
+}
+
+// UndeleteUser brings a soft-deleted user back.
+func (s *UserService) UndeleteUser(ctx context.Context, req *userv1.UserUndeleteRequest) (*userv1.UserResponse, error) {
+	logger := s.log.WithContext(ctx)
+	md := map[string]string{"traceId": tracingx.GetTraceID(ctx)}
+
+	if err := s.validateAdminAndRequest(ctx, req); err != nil {
+		return nil, err
+	}
+
+	targetUserID := req.Id
+	logger.Infow("msg", "undelete user", "target_user.id", targetUserID)
+	user, err := s.uc.UndeleteUser(ctx, targetUserID, auth.Username(ctx))
+	if err != nil {
+		logger.Errorw("msg", "failed to undelete user", "error", err)
+		err = errors.InternalServer("UNDELETE_USER_FAILED", "Failed to undelete user").
+			WithMetadata(md)
+		return nil, err
+	}
+	logger.Infow("msg", "successfully undelete user", "target_user.id", targetUserID)
+	return &userv1.UserResponse{Data: s.toUserPublic(user)}, nil
+}
+
+// PurgeUser permanently removes a soft-deleted user.
+func (s *UserService) PurgeUser(ctx context.Context, req *userv1.UserPurgeRequest) (*emptypb.Empty, error) {
+	logger := s.log.WithContext(ctx)
+	md := map[string]string{"traceId": tracingx.GetTraceID(ctx)}
+
+	if err := s.validateAdminAndRequest(ctx, req); err != nil {
+		return nil, err
+	}
+
+	targetUserID := req.Id
+	logger.Infow("msg", "purge user", "target_user.id", targetUserID)
+	if err := s.uc.PurgeUser(ctx, targetUserID, auth.Username(ctx)); err != nil {
+		logger.Errorw("msg", "failed to purge user", "error", err)
+		err = errors.InternalServer("PURGE_USER_FAILED", "Failed to purge user").
+			WithMetadata(md)
+		return nil, err
+	}
+	logger.Infow("msg", "successfully purge user", "target_user.id", targetUserID)
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	nethttp "net/http"
+	"strings"
+	"time"
+	userv1 "usermanage/gen/proto/api/user/v1"
+	"usermanage/internal/biz"
+	"usermanage/internal/pkg/db"
+	"usermanage/internal/pkg/tracingx"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/transport/http"
+	"github.com/go-kratos/kratos/v2/transport/http/binding"
+	"google.golang.org/grpc"
+)
+
+// ExportUsers streams the users matching the filters of `ListUsers` as a file, in chunks.
+func (s *UserService) ExportUsers(req *userv1.UserExportRequest, stream grpc.ServerStreamingServer[userv1.UserExportChunk]) error {
+	ctx := stream.Context()
+
+	params, err := s.userExportParams(ctx, req)
+	if err != nil {
+		return err
+	}
+	w := chunkWriter(func(p []byte) error {
+		return stream.Send(&userv1.UserExportChunk{Data: p})
+	})
+	return s.exportUsers(ctx, params, w)
+}
+
+// ExportUsersHTTP serves `ExportUsers` as a file download.
+//
+// The format is read from the `format` query parameter (`csv`, `jsonl` or `xlsx`), the
+// columns from `columns`, repeated or comma separated, and the filters as in `ListUsers`.
+func (s *UserService) ExportUsersHTTP(ctx http.Context) error {
+	query := ctx.Query()
+	format := query.Get("format")
+	query.Del("format")
+	columns := query["columns"]
+	query.Del("columns")
+
+	var in userv1.UserExportRequest
+	if err := binding.BindQuery(query, &in); err != nil {
+		return errors.BadRequest("INVALID_REQUEST", "Invalid query")
+	}
+	in.Format = exportFormatFromQuery(format)
+	for _, value := range columns {
+		for _, column := range strings.Split(value, ",") {
+			if column = strings.TrimSpace(column); column != "" {
+				in.Columns = append(in.Columns, column)
+			}
+		}
+	}
+
+	http.SetOperation(ctx, userv1.UserService_ExportUsers_FullMethodName)
+	h := ctx.Middleware(func(c context.Context, req any) (any, error) {
+		params, err := s.userExportParams(c, req.(*userv1.UserExportRequest))
+		if err != nil {
+			return nil, err
+		}
+		return nil, s.exportUsersHTTP(c, params, ctx.Response())
+	})
+	_, err := h(ctx, &in)
+	return err
+}
+
+// Write the export to the response, once it has started errors can only be logged.
+func (s *UserService) exportUsersHTTP(ctx context.Context, params biz.UserExportParams, w nethttp.ResponseWriter) error {
+	// The server timeout is meant for unary calls, the download lasts until it completes or writing fails
+	ctx = context.WithoutCancel(ctx)
+
+	started := false
+	out := chunkWriter(func(p []byte) error {
+		if !started {
+			started = true
+			filename := fmt.Sprintf("users-%s.%s", time.Now().UTC().Format("20060102"), params.Format.Extension())
+			w.Header().Set("Content-Type", params.Format.ContentType())
+			w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+			w.Header().Set("Cache-Control", "no-store")
+			w.WriteHeader(nethttp.StatusOK)
+		}
+		_, err := w.Write(p)
+		return err
+	})
+	err := s.exportUsers(ctx, params, out)
+	if started {
+		return nil
+	}
+	return err
+}
+
+// Export the users to w, logging and translating the errors.
+func (s *UserService) exportUsers(ctx context.Context, params biz.UserExportParams, w io.Writer) error {
+	logger := s.log.WithContext(ctx)
+	md := map[string]string{"traceId": tracingx.GetTraceID(ctx)}
+
+	logger.Infow("msg", "export users", "format", params.Format.Extension(), "columns", strings.Join(params.Columns, ","),
+		"filter", params.Filter.String())
+	if err := s.uc.ExportUsers(ctx, params, w); err != nil {
+		logger.Errorw("msg", "failed to export users", "error", err)
+		switch {
+		case errors.Is(err, biz.ErrInvalidUserExport):
+			return errors.BadRequest("INVALID_EXPORT", err.Error()).
+				WithMetadata(md)
+		case errors.Is(err, db.ErrEncryptionNotConfigured):
+			return errors.BadRequest("ENCRYPTION_NOT_CONFIGURED", "Filtering by email requires encryption at rest").
+				WithMetadata(md)
+		default:
+			return errors.InternalServer("EXPORT_USERS_FAILED", "Failed to export users").
+				WithMetadata(md)
+		}
+	}
+	logger.Info("successfully export users")
+	return nil
+}
+
+// Validate the export request and convert it to the export params.
+func (s *UserService) userExportParams(ctx context.Context, req *userv1.UserExportRequest) (biz.UserExportParams, error) {
+	if err := s.validateAdminAndRequest(ctx, req); err != nil {
+		return biz.UserExportParams{}, err
+	}
+	return biz.UserExportParams{
+		Filter: biz.UserListParams{
+			Username: req.Username,
+			Status:   int32(req.Status),
+			Email:    req.Email,
+		},
+		Format:  biz.UserExportFormat(req.Format),
+		Columns: req.Columns,
+	}, nil
+}
+
+// Return the export format of a query parameter, CSV if empty.
+func exportFormatFromQuery(value string) userv1.UserExportFormat {
+	switch strings.ToLower(value) {
+	case "", "csv":
+		return userv1.UserExportFormat_USER_EXPORT_FORMAT_CSV
+	case "jsonl", "ndjson", "json_lines":
+		return userv1.UserExportFormat_USER_EXPORT_FORMAT_JSON_LINES
+	case "xlsx":
+		return userv1.UserExportFormat_USER_EXPORT_FORMAT_XLSX
+	default:
+		return userv1.UserExportFormat_USER_EXPORT_FORMAT_UNSPECIFIED
+	}
+}
+
+// chunkWriter is an io.Writer passing every write to a function, which must not keep the slice.
+type chunkWriter func(p []byte) error
+
+func (f chunkWriter) Write(p []byte) (int, error) {
+	if err := f(p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,230 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"io"
+	nethttp "net/http"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	userv1 "usermanage/gen/proto/api/user/v1"
+	"usermanage/internal/biz"
+	"usermanage/internal/pkg/auth"
+	"usermanage/internal/pkg/tracingx"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/transport/http"
+	"google.golang.org/grpc"
+)
+
+// maxUserImportSize is the maximum size of an import file.
+const maxUserImportSize = 10 << 20
+
+// ImportUsers creates, or upserts by username, users from a CSV or JSON Lines file.
+func (s *UserService) ImportUsers(stream grpc.ClientStreamingServer[userv1.UserImportRequest, userv1.UserImportResponse]) error {
+	ctx := stream.Context()
+	md := map[string]string{"traceId": tracingx.GetTraceID(ctx)}
+
+	first, err := stream.Recv()
+	if err == io.EOF {
+		return errors.BadRequest("INVALID_REQUEST", "Missing import options").
+			WithMetadata(md)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Feed the chunks to the parser as they arrive
+	pr, pw := io.Pipe()
+	defer pr.Close()
+	go func() {
+		size := 0
+		for req := first; ; {
+			var err error
+			size += len(req.Chunk)
+			if size > maxUserImportSize {
+				pw.CloseWithError(errors.New(nethttp.StatusRequestEntityTooLarge, "IMPORT_TOO_LARGE", "Import file is too large"))
+				return
+			}
+			if _, err = pw.Write(req.Chunk); err != nil {
+				return
+			}
+			if req, err = stream.Recv(); err != nil {
+				if err == io.EOF {
+					err = nil
+				}
+				pw.CloseWithError(err)
+				return
+			}
+		}
+	}()
+
+	resp, err := s.importUsers(ctx, first.GetOptions(), pr)
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(resp)
+}
+
+// importUsersHTTPRequest is the import request of an upload, read from the form once the
+// caller is authenticated.
+type importUsersHTTPRequest struct {
+	*userv1.UserImportRequest
+	read func() (*userv1.UserImportRequest, error)
+	once sync.Once
+	err  error
+}
+
+// Load implements middleware.Loader.
+func (r *importUsersHTTPRequest) Load() error {
+	r.once.Do(func() {
+		var in *userv1.UserImportRequest
+		if in, r.err = r.read(); r.err == nil {
+			r.UserImportRequest = in
+		}
+	})
+	return r.err
+}
+
+// ImportUsersHTTP serves `ImportUsers` as a `multipart/form-data` upload.
+//
+// The file is sent in the `file` field, the options in the `format` (`csv` or `jsonl`,
+// guessed from the file name if empty), `mode` (`create` or `upsert`) and `dry_run` fields.
+func (s *UserService) ImportUsersHTTP(ctx http.Context) error {
+	// The content is part of the request, so an idempotency key cannot be reused for another
+	// file, it is only read once the caller is authenticated
+	http.SetOperation(ctx, userv1.UserService_ImportUsers_FullMethodName)
+	h := ctx.Middleware(func(c context.Context, req any) (any, error) {
+		in := req.(*importUsersHTTPRequest)
+		if err := in.Load(); err != nil {
+			return nil, err
+		}
+		return s.importUsers(c, in.Options, bytes.NewReader(in.Chunk))
+	})
+	out, err := h(ctx, &importUsersHTTPRequest{
+		UserImportRequest: &userv1.UserImportRequest{},
+		read:              func() (*userv1.UserImportRequest, error) { return readUserImportForm(ctx) },
+	})
+	if err != nil {
+		return err
+	}
+	return ctx.Result(nethttp.StatusOK, out)
+}
+
+// Read the file and the options of an import upload.
+func readUserImportForm(ctx http.Context) (*userv1.UserImportRequest, error) {
+	r := ctx.Request()
+	r.Body = nethttp.MaxBytesReader(ctx.Response(), r.Body, maxUserImportSize+1<<20)
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		return nil, errors.BadRequest("INVALID_REQUEST", "Missing or invalid file")
+	}
+	defer file.Close()
+	content, err := io.ReadAll(io.LimitReader(file, maxUserImportSize+1))
+	if err != nil {
+		return nil, errors.BadRequest("INVALID_REQUEST", "Failed to read file")
+	}
+	if len(content) > maxUserImportSize {
+		return nil, errors.New(nethttp.StatusRequestEntityTooLarge, "IMPORT_TOO_LARGE", "Import file is too large")
+	}
+
+	options := &userv1.UserImportOptions{
+		Format: importFormatFromForm(r.FormValue("format"), header.Filename),
+		Mode:   importModeFromForm(r.FormValue("mode")),
+	}
+	if dryRun := r.FormValue("dry_run"); dryRun != "" {
+		if options.DryRun, err = strconv.ParseBool(dryRun); err != nil {
+			return nil, errors.BadRequest("INVALID_REQUEST", "Invalid dry_run")
+		}
+	}
+
+	return &userv1.UserImportRequest{Options: options, Chunk: content}, nil
+}
+
+// Import the users of a file and report every row.
+func (s *UserService) importUsers(ctx context.Context, options *userv1.UserImportOptions, r io.Reader) (*userv1.UserImportResponse, error) {
+	logger := s.log.WithContext(ctx)
+	md := map[string]string{"traceId": tracingx.GetTraceID(ctx)}
+
+	if options == nil {
+		return nil, errors.BadRequest("INVALID_REQUEST", "Missing import options").
+			WithMetadata(md)
+	}
+	if err := s.validateAdminAndRequest(ctx, options); err != nil {
+		return nil, err
+	}
+
+	params := biz.UserImportParams{
+		Format:   biz.UserImportFormat(options.Format),
+		Mode:     biz.UserImportMode(options.Mode),
+		DryRun:   options.DryRun,
+		Operator: auth.Username(ctx),
+	}
+	logger.Infow("msg", "import users", "format", options.Format, "mode", options.Mode, "dry_run", options.DryRun)
+	result, err := s.uc.ImportUsers(ctx, r, params)
+	if err != nil {
+		var se *errors.Error
+		if errors.As(err, &se) {
+			return nil, se.WithMetadata(md)
+		}
+		if errors.Is(err, biz.ErrInvalidUserImport) {
+			logger.Errorw("msg", "invalid import file", "error", err)
+			return nil, errors.BadRequest("INVALID_IMPORT", err.Error()).
+				WithMetadata(md)
+		}
+		logger.Errorw("msg", "failed to import users", "error", err)
+		return nil, errors.InternalServer("IMPORT_USERS_FAILED", "Failed to import users").
+			WithMetadata(md)
+	}
+	logger.Infow("msg", "successfully import users", "total", result.Total,
+		"created", result.Created, "updated", result.Updated, "failed", result.Failed)
+
+	resp := &userv1.UserImportResponse{
+		Total:     int32(result.Total),
+		Created:   int32(result.Created),
+		Updated:   int32(result.Updated),
+		Unchanged: int32(result.Unchanged),
+		Failed:    int32(result.Failed),
+		DryRun:    result.DryRun,
+		Rows:      make([]*userv1.UserImportRowResult, 0, len(result.Rows)),
+	}
+	for _, row := range result.Rows {
+		resp.Rows = append(resp.Rows, &userv1.UserImportRowResult{
+			Row:      int32(row.Line),
+			Username: row.Username,
+			Status:   userv1.UserImportRowStatus(row.Status),
+			UserId:   row.UserID,
+			Error:    row.Error,
+		})
+	}
+	return resp, nil
+}
+
+// Return the import format of a form value, or else of the extension of the file name.
+func importFormatFromForm(value, filename string) userv1.UserImportFormat {
+	if value == "" {
+		value = strings.TrimPrefix(path.Ext(filename), ".")
+	}
+	switch strings.ToLower(value) {
+	case "csv":
+		return userv1.UserImportFormat_USER_IMPORT_FORMAT_CSV
+	case "jsonl", "ndjson", "json_lines":
+		return userv1.UserImportFormat_USER_IMPORT_FORMAT_JSON_LINES
+	default:
+		return userv1.UserImportFormat_USER_IMPORT_FORMAT_UNSPECIFIED
+	}
+}
+
+// Return the import mode of a form value, create if empty.
+func importModeFromForm(value string) userv1.UserImportMode {
+	switch strings.ToLower(value) {
+	case "", "create":
+		return userv1.UserImportMode_USER_IMPORT_MODE_CREATE
+	case "upsert":
+		return userv1.UserImportMode_USER_IMPORT_MODE_UPSERT
+	default:
+		return userv1.UserImportMode(-1)
+	}
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	nethttp "net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"usermanage/internal/pkg/middleware"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	kmiddleware "github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/go-kratos/kratos/v2/transport/http"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readRecorder is a request body recording whether it was read.
+type readRecorder struct {
+	io.Reader
+	read bool
+}
+
+func (r *readRecorder) Read(p []byte) (int, error) {
+	r.read = true
+	return r.Reader.Read(p)
+}
+
+func TestUserService_ImportUsersHTTP(t *testing.T) {
+	uc, _ := newTestUserUseCase(t)
+	svc := NewUserService(uc, log.DefaultLogger)
+	s := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: s.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+
+	// Callers with a token are admins
+	authenticate := func(handler kmiddleware.Handler) kmiddleware.Handler {
+		return func(ctx context.Context, req any) (any, error) {
+			tr, _ := transport.FromServerContext(ctx)
+			if tr.RequestHeader().Get("Authorization") == "" {
+				return nil, errors.Unauthorized("MISSING_TOKEN", "Missing token")
+			}
+			return handler(adminSession(ctx, new(atomic.Bool)), req)
+		}
+	}
+	srv := http.NewServer(http.Middleware(authenticate, middleware.Idempotency(rdb, "test:", 0, log.DefaultLogger)))
+	srv.Route("/").POST("/v1/admin/users/import", svc.ImportUsersHTTP)
+
+	upload := func(token, key, content string) (*httptest.ResponseRecorder, *readRecorder) {
+		var body bytes.Buffer
+		form := multipart.NewWriter(&body)
+		file, err := form.CreateFormFile("file", "users.csv")
+		require.NoError(t, err)
+		_, err = file.Write([]byte(content))
+		require.NoError(t, err)
+		require.NoError(t, form.Close())
+
+		recorder := &readRecorder{Reader: &body}
+		req := httptest.NewRequest(nethttp.MethodPost, "/v1/admin/users/import", recorder)
+		req.Header.Set("Content-Type", form.FormDataContentType())
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		if key != "" {
+			req.Header.Set(middleware.IdempotencyKeyHeader, key)
+		}
+		rw := httptest.NewRecorder()
+		srv.ServeHTTP(rw, req)
+		return rw, recorder
+	}
+
+	t.Run("the upload of an anonymous caller is not read", func(t *testing.T) {
+		rw, body := upload("", "", "username\nfoo\n")
+		assert.Equal(t, nethttp.StatusUnauthorized, rw.Code)
+		assert.False(t, body.read)
+	})
+
+	t.Run("imported", func(t *testing.T) {
+		rw, _ := upload("token", "", "username\nfoo\n")
+		require.Equal(t, nethttp.StatusOK, rw.Code, rw.Body.String())
+		assert.Contains(t, rw.Body.String(), `"created":1`)
+	})
+
+	t.Run("an idempotency key cannot be reused for another file", func(t *testing.T) {
+		rw, _ := upload("token", "key-1", "username\nbar\n")
+		require.Equal(t, nethttp.StatusOK, rw.Code, rw.Body.String())
+		rw, _ = upload("token", "key-1", "username\nbaz\n")
+		assert.Equal(t, nethttp.StatusBadRequest, rw.Code)
+		assert.Contains(t, rw.Body.String(), "IDEMPOTENCY_KEY_REUSED")
+	})
+}
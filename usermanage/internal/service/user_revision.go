@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	commonv1 "usermanage/gen/proto/api/common/v1"
+	userv1 "usermanage/gen/proto/api/user/v1"
+	"usermanage/internal/biz"
+	"usermanage/internal/pkg/auth"
+	"usermanage/internal/pkg/constants"
+	"usermanage/internal/pkg/tracingx"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ListUserRevisions lists the change history of a user, newest first.
+func (s *UserService) ListUserRevisions(ctx context.Context, req *userv1.UserRevisionListRequest) (*userv1.UserRevisionListResponse, error) {
+	logger := s.log.WithContext(ctx)
+	md := map[string]string{"traceId": tracingx.GetTraceID(ctx)}
+
+	if err := s.validateAdminAndRequest(ctx, req); err != nil {
+		return nil, err
+	}
+
+	targetUserID := req.Id
+	page := func() int32 {
+		if req.Page == 0 {
+			return constants.DefaultPage
+		}
+		return req.Page
+	}()
+	pageSize := func() int32 {
+		if req.PageSize == 0 {
+			return constants.DefaultPageSize
+		}
+		return req.PageSize
+	}()
+	params := biz.UserRevisionListParams{
+		Page:     page,
+		PageSize: pageSize,
+	}
+	logger.Infow("msg", "list user revisions", "target_user.id", targetUserID)
+	result, err := s.uc.ListUserRevisions(ctx, targetUserID, params)
+	if err != nil {
+		logger.Errorw("msg", "failed to list user revisions", "error", err)
+		err = errors.InternalServer("LIST_USER_REVISIONS_FAILED", "Failed to list user revisions").
+			WithMetadata(md)
+		return nil, err
+	}
+
+	pagination := commonv1.PageResponse{
+		Page:       page,
+		PageSize:   pageSize,
+		TotalCount: result.TotalCount,
+	}
+	data := make([]*userv1.UserRevision, 0, len(result.Revisions))
+	for _, revision := range result.Revisions {
+		data = append(data, s.toUserRevision(revision))
+	}
+	return &userv1.UserRevisionListResponse{Data: data, Pagination: &pagination}, nil
+}
+
+// GetUserAtTime returns the state of a user as it was at the given point in time.
+func (s *UserService) GetUserAtTime(ctx context.Context, req *userv1.UserAtTimeRequest) (*userv1.UserResponse, error) {
+	logger := s.log.WithContext(ctx)
+	md := map[string]string{"traceId": tracingx.GetTraceID(ctx)}
+
+	if err := s.validateAdminAndRequest(ctx, req); err != nil {
+		return nil, err
+	}
+
+	targetUserID := req.Id
+	at := req.Time.AsTime()
+	logger.Infow("msg", "get user at time", "target_user.id", targetUserID, "at", at)
+	user, err := s.uc.GetUserAtTime(ctx, targetUserID, at)
+	if err != nil {
+		logger.Errorw("msg", "failed to get user at time", "error", err)
+		err = errors.NotFound("USER_AT_TIME_NOT_FOUND", "User not found at the given time").
+			WithMetadata(md)
+		return nil, err
+	}
+	return &userv1.UserResponse{Data: s.toUserPublic(user)}, nil
+}
+
+// RestoreUserRevision rolls a user back to the state recorded by a previous revision.
+func (s *UserService) RestoreUserRevision(ctx context.Context, req *userv1.UserRevisionRestoreRequest) (*userv1.UserResponse, error) {
+	logger := s.log.WithContext(ctx)
+	md := map[string]string{"traceId": tracingx.GetTraceID(ctx)}
+
+	if err := s.validateAdminAndRequest(ctx, req); err != nil {
+		return nil, err
+	}
+
+	targetUserID := req.Id
+	logger.Infow("msg", "restore user revision", "target_user.id", targetUserID, "revision", req.Revision)
+	user, err := s.uc.RestoreUserRevision(ctx, targetUserID, req.Revision, auth.Username(ctx))
+	if err != nil {
+		logger.Errorw("msg", "failed to restore user revision", "error", err)
+		if errors.Is(err, biz.ErrRevisionNotRestorable) {
+			return nil, errors.Conflict("REVISION_NOT_RESTORABLE", "The revision cannot be restored").
+				WithMetadata(md)
+		}
+		err = errors.InternalServer("RESTORE_USER_REVISION_FAILED", "Failed to restore user revision").
+			WithMetadata(md)
+		return nil, err
+	}
+	logger.Infow("msg", "successfully restore user revision", "target_user.id", targetUserID, "revision", req.Revision)
+	return &userv1.UserResponse{Data: s.toUserPublic(user)}, nil
+}
+
+// Convert biz user revision to user revision.
+func (s *UserService) toUserRevision(r *biz.UserRevision) *userv1.UserRevision {
+	if r == nil {
+		return nil
+	}
+
+	changes := make([]*userv1.UserFieldChange, 0, len(r.Changes))
+	for _, change := range r.Changes {
+		changes = append(changes, &userv1.UserFieldChange{
+			Field:    change.Field,
+			OldValue: change.OldValue,
+			NewValue: change.NewValue,
+		})
+	}
+	return &userv1.UserRevision{
+		UserId:    r.UserID,
+		Revision:  r.Revision,
+		Action:    userv1.UserRevisionAction(r.Action),
+		Snapshot:  s.toUserPublic(r.Snapshot),
+		Changes:   changes,
+		Operator:  r.Operator,
+		CreatedAt: timestamppb.New(r.CreatedAt),
+	}
+}
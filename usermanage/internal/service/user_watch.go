@@ -0,0 +1,238 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	nethttp "net/http"
+	"strconv"
+	"sync"
+	"time"
+	userv1 "usermanage/gen/proto/api/user/v1"
+	"usermanage/internal/biz"
+	"usermanage/internal/pkg/auth"
+	"usermanage/internal/pkg/tracingx"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/transport/http"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// sseHeartbeatInterval is how often a comment is sent on idle SSE streams,
+// which keeps proxies from closing them and detects the clients gone away.
+const sseHeartbeatInterval = 15 * time.Second
+
+// watchSessionInterval is how often the session of a watcher is verified again, the watch
+// ends once its token is revoked or expires, or its user is disabled.
+var watchSessionInterval = 30 * time.Second
+
+// errWatchSessionEnded ends a watch whose session is no longer valid.
+var errWatchSessionEnded = errors.Unauthorized("SESSION_ENDED", "The session has ended")
+
+// WatchUsers streams the changes of users as they happen.
+func (s *UserService) WatchUsers(req *userv1.UserWatchRequest, stream grpc.ServerStreamingServer[userv1.UserWatchEvent]) error {
+	ctx := stream.Context()
+	logger := s.log.WithContext(ctx)
+
+	params, err := s.userWatchParams(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	logger.Infow("msg", "watch users", "resource_version", req.ResourceVersion)
+	return s.watchUsers(ctx, params, stream.Send)
+}
+
+// WatchUsersSSE serves `WatchUsers` as Server-Sent Events.
+//
+// The version to resume from is read from the `resource_version` query parameter
+// or the `Last-Event-ID` header sent by browsers when reconnecting.
+func (s *UserService) WatchUsersSSE(ctx http.Context) error {
+	var in userv1.UserWatchRequest
+	if err := ctx.BindQuery(&in); err != nil {
+		return err
+	}
+	if lastEventID := ctx.Header().Get("Last-Event-ID"); lastEventID != "" {
+		version, err := strconv.ParseUint(lastEventID, 10, 64)
+		if err != nil {
+			return errors.BadRequest("INVALID_REQUEST", "Invalid Last-Event-ID header")
+		}
+		in.ResourceVersion = version
+	}
+
+	http.SetOperation(ctx, userv1.UserService_WatchUsers_FullMethodName)
+	h := ctx.Middleware(func(c context.Context, req any) (any, error) {
+		return nil, s.watchUsersSSE(c, req.(*userv1.UserWatchRequest), ctx.Response())
+	})
+	_, err := h(ctx, &in)
+	return err
+}
+
+// Stream the changes of users to the response writer as Server-Sent Events.
+func (s *UserService) watchUsersSSE(ctx context.Context, req *userv1.UserWatchRequest, w nethttp.ResponseWriter) error {
+	logger := s.log.WithContext(ctx)
+
+	params, err := s.userWatchParams(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	// The server timeout is meant for unary calls, the stream lasts until writing to the client
+	// fails or its session ends
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancel()
+
+	rc := nethttp.NewResponseController(w)
+	var mu sync.Mutex
+	write := func(b []byte) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if _, err := w.Write(b); err != nil {
+			cancel()
+			return err
+		}
+		if err := rc.Flush(); err != nil {
+			cancel()
+			return err
+		}
+		return nil
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(nethttp.StatusOK)
+	if err := write([]byte(": watching\n\n")); err != nil {
+		return nil
+	}
+
+	go func() {
+		ticker := time.NewTicker(sseHeartbeatInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				_ = write([]byte(": heartbeat\n\n"))
+			}
+		}
+	}()
+
+	logger.Infow("msg", "watch users over sse", "resource_version", req.ResourceVersion)
+	// The response has already been sent, errors are only logged by `watchUsers`
+	err = s.watchUsers(ctx, params, func(event *userv1.UserWatchEvent) error {
+		data, err := protojson.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to marshal user watch event: %w", err)
+		}
+		var buf bytes.Buffer
+		fmt.Fprintf(&buf, "id: %d\nevent: %s\ndata: %s\n\n", event.ResourceVersion, event.Type, data)
+		return write(buf.Bytes())
+	})
+	if errors.Is(err, errWatchSessionEnded) {
+		// Tell the client not to reconnect with the same token
+		_ = write([]byte("event: session_ended\ndata: {}\n\n"))
+	}
+	return nil
+}
+
+// Validate the watch request and restrict non-admin users to their own changes.
+func (s *UserService) userWatchParams(ctx context.Context, req *userv1.UserWatchRequest) (biz.UserWatchParams, error) {
+	logger := s.log.WithContext(ctx)
+	md := map[string]string{"traceId": tracingx.GetTraceID(ctx)}
+
+	if err := req.Validate(); err != nil {
+		logger.Errorw("msg", "invalid request", "error", err)
+		return biz.UserWatchParams{}, errors.BadRequest("INVALID_REQUEST", "Invalid request").
+			WithMetadata(md)
+	}
+
+	params := biz.UserWatchParams{ResourceVersion: req.ResourceVersion}
+	if err := auth.IsAdmin(ctx); err != nil {
+		username := auth.Username(ctx)
+		if username == "" {
+			logger.Errorw("msg", "insufficient permissions", "error", err)
+			return biz.UserWatchParams{}, errors.Forbidden("INSUFFICIENT_PERMISSIONS", "Insufficient permissions").
+				WithMetadata(md)
+		}
+		params.Username = username
+	}
+	return params, nil
+}
+
+// Stream the changes of users to `send` until the context is done or the session of the
+// watcher ends.
+func (s *UserService) watchUsers(ctx context.Context, params biz.UserWatchParams, send func(*userv1.UserWatchEvent) error) error {
+	logger := s.log.WithContext(ctx)
+	md := map[string]string{"traceId": tracingx.GetTraceID(ctx)}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	go func() {
+		ticker := time.NewTicker(watchSessionInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := auth.VerifySession(ctx); err != nil {
+					logger.Infow("msg", "watcher session ended", "error", err)
+					cancel(errWatchSessionEnded)
+					return
+				}
+			}
+		}
+	}()
+
+	err := s.uc.WatchUsers(ctx, params, func(event *biz.Event) error {
+		return send(s.toUserWatchEvent(event))
+	})
+	if cause := context.Cause(ctx); errors.Is(cause, errWatchSessionEnded) {
+		return errWatchSessionEnded.WithMetadata(md)
+	}
+	// The client going away ends the watch
+	if err != nil && ctx.Err() == nil {
+		logger.Errorw("msg", "failed to watch users", "error", err)
+		return errors.InternalServer("WATCH_USERS_FAILED", "Failed to watch users").
+			WithMetadata(md)
+	}
+	return nil
+}
+
+// Convert biz event to user watch event.
+func (s *UserService) toUserWatchEvent(e *biz.Event) *userv1.UserWatchEvent {
+	if e == nil {
+		return nil
+	}
+
+	changes := make([]*userv1.UserFieldChange, 0, len(e.Changes))
+	for _, change := range e.Changes {
+		changes = append(changes, &userv1.UserFieldChange{
+			Field:    change.Field,
+			OldValue: change.OldValue,
+			NewValue: change.NewValue,
+		})
+	}
+	event := &userv1.UserWatchEvent{
+		ResourceVersion: e.Version,
+		User:            s.toUserPublic(e.User),
+		Changes:         changes,
+		Actor:           e.Actor,
+		OccurredAt:      timestamppb.New(e.OccurredAt),
+	}
+	switch e.Type {
+	case biz.EventTypeUserCreated, biz.EventTypeUserUndeleted:
+		// An undeleted user reappears to watchers
+		event.Type = userv1.UserWatchEventType_USER_WATCH_EVENT_TYPE_CREATED
+	case biz.EventTypeUserUpdated:
+		event.Type = userv1.UserWatchEventType_USER_WATCH_EVENT_TYPE_UPDATED
+	case biz.EventTypeUserDeleted:
+		event.Type = userv1.UserWatchEventType_USER_WATCH_EVENT_TYPE_DELETED
+	}
+	return event
+}
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+	userv1 "usermanage/gen/proto/api/user/v1"
+	"usermanage/gen/proto/conf"
+	"usermanage/internal/biz"
+	"usermanage/internal/data"
+	"usermanage/internal/data/migrations"
+	"usermanage/internal/pkg/auth"
+	"usermanage/internal/pkg/broker"
+	"usermanage/internal/pkg/db"
+	"usermanage/internal/pkg/jwt"
+	"usermanage/internal/pkg/migrate"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// watchTest is a user service over an in-memory database, with the relay numbering its events.
+type watchTest struct {
+	svc   *UserService
+	uc    *biz.UserUseCase
+	relay *data.OutboxRelay
+}
+
+// Create a user use case over an in-memory database.
+func newTestUserUseCase(t *testing.T) (*biz.UserUseCase, *db.Database) {
+	t.Helper()
+
+	database, err := db.NewDatabase(&conf.Data{
+		Database: &conf.Data_Database{
+			Driver: conf.DatabaseDriver_DATABASE_DRIVER_SQLITE,
+			Name:   "test",
+			Dsn:    ":memory:",
+		},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if sqlDB, err := database.DB.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	ms, err := migrations.Load(database.Dialector.Name())
+	require.NoError(t, err)
+	_, err = migrate.New(database.DB, ms).Up(context.Background(), 0)
+	require.NoError(t, err)
+
+	uc := biz.NewUserUseCase(
+		data.NewTransaction(database),
+		data.NewUserRepo(database, nil, log.DefaultLogger),
+		data.NewMemoryTokenRepo(&conf.Data{}),
+		data.NewEventRepo(database, log.DefaultLogger),
+	)
+	return uc, database
+}
+
+func newWatchTest(t *testing.T) *watchTest {
+	t.Helper()
+
+	interval := watchSessionInterval
+	watchSessionInterval = 10 * time.Millisecond
+	t.Cleanup(func() { watchSessionInterval = interval })
+
+	uc, database := newTestUserUseCase(t)
+	return &watchTest{
+		svc:   NewUserService(uc, log.DefaultLogger),
+		uc:    uc,
+		relay: data.NewOutboxRelay(&conf.Data{}, database, broker.NewMemory(), log.DefaultLogger),
+	}
+}
+
+// Create a user and number its event.
+func (w *watchTest) createUser(t *testing.T, username string) {
+	t.Helper()
+	ctx := context.Background()
+	_, err := w.uc.CreateUser(ctx, biz.UserCreateParams{
+		Username: username,
+		Role:     int32(biz.UserRoleUser),
+		Status:   int32(biz.UserStatusNormal),
+		Creator:  "admin",
+	})
+	require.NoError(t, err)
+	_, err = w.relay.RelayOnce(ctx)
+	require.NoError(t, err)
+}
+
+// Return the context of an admin whose session ends once `ended` is set.
+func adminSession(ctx context.Context, ended *atomic.Bool) context.Context {
+	claims := &jwt.Claims{Username: "admin"}
+	claims.Role(int32(biz.UserRoleAdmin))
+	return auth.WithSession(jwt.WithContext(ctx, claims), func(context.Context) error {
+		if ended.Load() {
+			return errors.New("token revoked")
+		}
+		return nil
+	})
+}
+
+// watchStream is the server side of a WatchUsers stream, sending the events to a channel.
+type watchStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	events chan *userv1.UserWatchEvent
+}
+
+func (s *watchStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *watchStream) Send(event *userv1.UserWatchEvent) error {
+	s.events <- event
+	return nil
+}
+
+func TestUserService_WatchUsers(t *testing.T) {
+	w := newWatchTest(t)
+	w.createUser(t, "foo")
+
+	var ended atomic.Bool
+	stream := &watchStream{
+		ctx:    adminSession(context.Background(), &ended),
+		events: make(chan *userv1.UserWatchEvent, 10),
+	}
+	done := make(chan error, 1)
+	go func() { done <- w.svc.WatchUsers(&userv1.UserWatchRequest{ResourceVersion: 1}, stream) }()
+
+	w.createUser(t, "bar")
+	select {
+	case event := <-stream.events:
+		assert.Equal(t, uint64(2), event.ResourceVersion)
+		assert.Equal(t, userv1.UserWatchEventType_USER_WATCH_EVENT_TYPE_CREATED, event.Type)
+		assert.Equal(t, "bar", event.User.Username)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event streamed")
+	}
+
+	ended.Store(true)
+	select {
+	case err := <-done:
+		assert.Equal(t, "SESSION_ENDED", kerrors.Reason(err))
+		assert.EqualValues(t, http.StatusUnauthorized, kerrors.Code(err))
+	case <-time.After(5 * time.Second):
+		t.Fatal("the stream outlived its session")
+	}
+}
+
+func TestUserService_WatchUsersSSE(t *testing.T) {
+	w := newWatchTest(t)
+	w.createUser(t, "foo")
+
+	var ended atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		ctx := adminSession(r.Context(), &ended)
+		_ = w.svc.watchUsersSSE(ctx, &userv1.UserWatchRequest{ResourceVersion: 1}, rw)
+	}))
+	t.Cleanup(server.Close)
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	lines := make(chan string)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+	}()
+	// Return the lines of the next message, nil once the stream is closed
+	next := func() []string {
+		t.Helper()
+		var message []string
+		for {
+			select {
+			case line, ok := <-lines:
+				if !ok || line == "" {
+					return message
+				}
+				message = append(message, line)
+			case <-time.After(5 * time.Second):
+				t.Fatal("no message streamed")
+			}
+		}
+	}
+
+	assert.Equal(t, []string{": watching"}, next())
+	w.createUser(t, "bar")
+	event := next()
+	require.Len(t, event, 3)
+	assert.Equal(t, "id: 2", event[0])
+	assert.Equal(t, "event: USER_WATCH_EVENT_TYPE_CREATED", event[1])
+	assert.True(t, strings.HasPrefix(event[2], "data: "))
+	assert.Contains(t, event[2], `"username":"bar"`)
+
+	ended.Store(true)
+	assert.Equal(t, []string{"event: session_ended", "data: {}"}, next())
+	assert.Nil(t, next(), "the stream is closed")
+}
@@ -0,0 +1,307 @@
+package service
+
+import (
+	"context"
+	commonv1 "usermanage/gen/proto/api/common/v1"
+	webhookv1 "usermanage/gen/proto/api/webhook/v1"
+	"usermanage/internal/biz"
+	"usermanage/internal/pkg/auth"
+	"usermanage/internal/pkg/constants"
+	"usermanage/internal/pkg/tracingx"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type WebhookService struct {
+	webhookv1.UnimplementedWebhookServiceServer
+	uc  *biz.WebhookUseCase
+	log *log.Helper
+}
+
+// NewWebhookService creates a new webhook service.
+func NewWebhookService(uc *biz.WebhookUseCase, logger log.Logger) *WebhookService {
+	return &WebhookService{uc: uc, log: log.NewHelper(logger)}
+}
+
+// ListWebhooks lists webhooks.
+func (s *WebhookService) ListWebhooks(ctx context.Context, req *webhookv1.WebhookListRequest) (*webhookv1.WebhookListResponse, error) {
+	logger := s.log.WithContext(ctx)
+	md := map[string]string{"traceId": tracingx.GetTraceID(ctx)}
+
+	if err := s.validateAdminAndRequest(ctx, req); err != nil {
+		return nil, err
+	}
+
+	page := func() int32 {
+		if req.Page == 0 {
+			return constants.DefaultPage
+		}
+		return req.Page
+	}()
+	pageSize := func() int32 {
+		if req.PageSize == 0 {
+			return constants.DefaultPageSize
+		}
+		return req.PageSize
+	}()
+	params := biz.WebhookListParams{
+		Page:     page,
+		PageSize: pageSize,
+	}
+	logger.Info("list webhooks")
+	result, err := s.uc.ListWebhooks(ctx, params)
+	if err != nil {
+		logger.Errorw("msg", "failed to list webhooks", "error", err)
+		err = errors.InternalServer("LIST_WEBHOOKS_FAILED", "Failed to list webhooks").
+			WithMetadata(md)
+		return nil, err
+	}
+
+	pagination := commonv1.PageResponse{
+		Page:       page,
+		PageSize:   pageSize,
+		TotalCount: result.TotalCount,
+	}
+	data := make([]*webhookv1.Webhook, 0, len(result.Webhooks))
+	for _, hook := range result.Webhooks {
+		data = append(data, s.toWebhook(hook))
+	}
+	return &webhookv1.WebhookListResponse{Data: data, Pagination: &pagination}, nil
+}
+
+// GetWebhook gets a webhook by ID.
+func (s *WebhookService) GetWebhook(ctx context.Context, req *webhookv1.WebhookRequest) (*webhookv1.WebhookResponse, error) {
+	logger := s.log.WithContext(ctx)
+	md := map[string]string{"traceId": tracingx.GetTraceID(ctx)}
+
+	if err := s.validateAdminAndRequest(ctx, req); err != nil {
+		return nil, err
+	}
+
+	logger.Infow("msg", "get webhook", "webhook.id", req.Id)
+	hook, err := s.uc.GetWebhook(ctx, req.Id)
+	if err != nil {
+		logger.Errorw("msg", "failed to get webhook", "error", err)
+		err = errors.InternalServer("GET_WEBHOOK_FAILED", "Failed to get webhook").
+			WithMetadata(md)
+		return nil, err
+	}
+	return &webhookv1.WebhookResponse{Data: s.toWebhook(hook)}, nil
+}
+
+// CreateWebhook registers a webhook.
+func (s *WebhookService) CreateWebhook(ctx context.Context, req *webhookv1.WebhookCreateRequest) (*webhookv1.WebhookResponse, error) {
+	logger := s.log.WithContext(ctx)
+	md := map[string]string{"traceId": tracingx.GetTraceID(ctx)}
+
+	if err := s.validateAdminAndRequest(ctx, req); err != nil {
+		return nil, err
+	}
+
+	params := biz.WebhookCreateParams{
+		URL:         req.Url,
+		EventTypes:  req.EventTypes,
+		Secret:      req.Secret,
+		Description: req.Description,
+		Creator:     auth.Username(ctx),
+	}
+	logger.Infow("msg", "create webhook", "url", req.Url, "event_types", req.EventTypes)
+	hook, err := s.uc.CreateWebhook(ctx, params)
+	if err != nil {
+		logger.Errorw("msg", "failed to create webhook", "error", err)
+		err = errors.InternalServer("CREATE_WEBHOOK_FAILED", "Failed to create webhook").
+			WithMetadata(md)
+		return nil, err
+	}
+	logger.Infow("msg", "successfully create webhook", "webhook.id", hook.ID)
+
+	// The secret is only handed out once, at creation
+	data := s.toWebhook(hook)
+	data.Secret = hook.Secret
+	return &webhookv1.WebhookResponse{Data: data}, nil
+}
+
+// DeleteWebhook deletes a webhook by ID.
+func (s *WebhookService) DeleteWebhook(ctx context.Context, req *webhookv1.WebhookDeleteRequest) (*emptypb.Empty, error) {
+	logger := s.log.WithContext(ctx)
+	md := map[string]string{"traceId": tracingx.GetTraceID(ctx)}
+
+	if err := s.validateAdminAndRequest(ctx, req); err != nil {
+		return nil, err
+	}
+
+	logger.Infow("msg", "delete webhook", "webhook.id", req.Id)
+	if err := s.uc.DeleteWebhook(ctx, req.Id); err != nil {
+		logger.Errorw("msg", "failed to delete webhook", "error", err)
+		err = errors.InternalServer("DELETE_WEBHOOK_FAILED", "Failed to delete webhook").
+			WithMetadata(md)
+		return nil, err
+	}
+	logger.Infow("msg", "successfully delete webhook", "webhook.id", req.Id)
+	return &emptypb.Empty{}, nil
+}
+
+// ListWebhookDeliveries lists the deliveries of a webhook, newest first.
+func (s *WebhookService) ListWebhookDeliveries(ctx context.Context, req *webhookv1.WebhookDeliveryListRequest) (*webhookv1.WebhookDeliveryListResponse, error) {
+	logger := s.log.WithContext(ctx)
+	md := map[string]string{"traceId": tracingx.GetTraceID(ctx)}
+
+	if err := s.validateAdminAndRequest(ctx, req); err != nil {
+		return nil, err
+	}
+
+	page := func() int32 {
+		if req.Page == 0 {
+			return constants.DefaultPage
+		}
+		return req.Page
+	}()
+	pageSize := func() int32 {
+		if req.PageSize == 0 {
+			return constants.DefaultPageSize
+		}
+		return req.PageSize
+	}()
+	params := biz.WebhookDeliveryListParams{
+		WebhookID: req.WebhookId,
+		Status:    biz.WebhookDeliveryStatus(req.Status),
+		Page:      page,
+		PageSize:  pageSize,
+	}
+	logger.Infow("msg", "list webhook deliveries", "webhook.id", req.WebhookId, "status", req.Status)
+	result, err := s.uc.ListWebhookDeliveries(ctx, params)
+	if err != nil {
+		logger.Errorw("msg", "failed to list webhook deliveries", "error", err)
+		err = errors.InternalServer("LIST_WEBHOOK_DELIVERIES_FAILED", "Failed to list webhook deliveries").
+			WithMetadata(md)
+		return nil, err
+	}
+
+	pagination := commonv1.PageResponse{
+		Page:       page,
+		PageSize:   pageSize,
+		TotalCount: result.TotalCount,
+	}
+	data := make([]*webhookv1.WebhookDelivery, 0, len(result.Deliveries))
+	for _, delivery := range result.Deliveries {
+		data = append(data, s.toWebhookDelivery(delivery))
+	}
+	return &webhookv1.WebhookDeliveryListResponse{Data: data, Pagination: &pagination}, nil
+}
+
+// GetWebhookDelivery gets a delivery by ID along with its attempts.
+func (s *WebhookService) GetWebhookDelivery(ctx context.Context, req *webhookv1.WebhookDeliveryRequest) (*webhookv1.WebhookDeliveryResponse, error) {
+	logger := s.log.WithContext(ctx)
+	md := map[string]string{"traceId": tracingx.GetTraceID(ctx)}
+
+	if err := s.validateAdminAndRequest(ctx, req); err != nil {
+		return nil, err
+	}
+
+	logger.Infow("msg", "get webhook delivery", "delivery.id", req.Id)
+	delivery, err := s.uc.GetWebhookDelivery(ctx, req.Id)
+	if err != nil {
+		logger.Errorw("msg", "failed to get webhook delivery", "error", err)
+		err = errors.InternalServer("GET_WEBHOOK_DELIVERY_FAILED", "Failed to get webhook delivery").
+			WithMetadata(md)
+		return nil, err
+	}
+	return &webhookv1.WebhookDeliveryResponse{Data: s.toWebhookDelivery(delivery)}, nil
+}
+
+// RedeliverWebhookDelivery schedules a delivery to be sent again right away.
+func (s *WebhookService) RedeliverWebhookDelivery(ctx context.Context, req *webhookv1.WebhookDeliveryRequest) (*webhookv1.WebhookDeliveryResponse, error) {
+	logger := s.log.WithContext(ctx)
+	md := map[string]string{"traceId": tracingx.GetTraceID(ctx)}
+
+	if err := s.validateAdminAndRequest(ctx, req); err != nil {
+		return nil, err
+	}
+
+	logger.Infow("msg", "redeliver webhook delivery", "delivery.id", req.Id)
+	delivery, err := s.uc.RedeliverWebhookDelivery(ctx, req.Id)
+	if err != nil {
+		logger.Errorw("msg", "failed to redeliver webhook delivery", "error", err)
+		err = errors.InternalServer("REDELIVER_WEBHOOK_DELIVERY_FAILED", "Failed to redeliver webhook delivery").
+			WithMetadata(md)
+		return nil, err
+	}
+	logger.Infow("msg", "successfully redeliver webhook delivery", "delivery.id", req.Id)
+	return &webhookv1.WebhookDeliveryResponse{Data: s.toWebhookDelivery(delivery)}, nil
+}
+
+// Validate the current user is an admin and the request is valid.
+func (s *WebhookService) validateAdminAndRequest(ctx context.Context, req interface{ Validate() error }) error {
+	md := map[string]string{"traceId": tracingx.GetTraceID(ctx)}
+	logger := s.log.WithContext(ctx)
+
+	if err := auth.IsAdmin(ctx); err != nil {
+		logger.Errorw("msg", "insufficient permissions", "error", err)
+		return errors.Forbidden("INSUFFICIENT_PERMISSIONS", "Insufficient permissions").
+			WithMetadata(md)
+	}
+
+	if err := req.Validate(); err != nil {
+		logger.Errorw("msg", "invalid request", "error", err)
+		return errors.BadRequest("INVALID_REQUEST", "Invalid request").
+			WithMetadata(md)
+	}
+
+	return nil
+}
+
+// Convert biz webhook to webhook, without its secret.
+func (s *WebhookService) toWebhook(w *biz.Webhook) *webhookv1.Webhook {
+	if w == nil {
+		return nil
+	}
+
+	return &webhookv1.Webhook{
+		Id:          w.ID,
+		Url:         w.URL,
+		EventTypes:  w.EventTypes,
+		Description: w.Description,
+		Active:      w.Active,
+		Creator:     w.Creator,
+		CreatedAt:   timestamppb.New(w.CreatedAt),
+		UpdatedAt:   timestamppb.New(w.UpdatedAt),
+	}
+}
+
+// Convert biz webhook delivery to webhook delivery.
+func (s *WebhookService) toWebhookDelivery(d *biz.WebhookDelivery) *webhookv1.WebhookDelivery {
+	if d == nil {
+		return nil
+	}
+
+	attemptLog := make([]*webhookv1.WebhookDeliveryAttempt, 0, len(d.AttemptLog))
+	for _, attempt := range d.AttemptLog {
+		attemptLog = append(attemptLog, &webhookv1.WebhookDeliveryAttempt{
+			Attempt:    attempt.Attempt,
+			StatusCode: attempt.StatusCode,
+			Error:      attempt.Error,
+			DurationMs: attempt.Duration.Milliseconds(),
+			CreatedAt:  timestamppb.New(attempt.CreatedAt),
+		})
+	}
+	delivery := &webhookv1.WebhookDelivery{
+		Id:             d.ID,
+		WebhookId:      d.WebhookID,
+		EventId:        d.EventID,
+		EventType:      d.EventType,
+		Status:         webhookv1.WebhookDeliveryStatus(d.Status),
+		Attempts:       d.Attempts,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		NextAttemptAt:  timestamppb.New(d.NextAttemptAt),
+		CreatedAt:      timestamppb.New(d.CreatedAt),
+		AttemptLog:     attemptLog,
+	}
+	if d.DeliveredAt != nil {
+		delivery.DeliveredAt = timestamppb.New(*d.DeliveredAt)
+	}
+	return delivery
+}
@@ -3,4 +3,5 @@ package service
 import "github.com/google/wire"
 
 // ProviderSet is service providers.
-var ProviderSet = wire.NewSet(NewHealthService, NewUserService, NewAuthService)
+var ProviderSet = wire.NewSet(NewHealthService, NewUserService, NewAuthService, NewWebhookService, NewScimService, NewAuthOptions,
+	NewOAuthOptions, NewOAuthClientService, NewOidcService, NewFederationOptions, NewFederationService)